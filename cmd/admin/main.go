@@ -0,0 +1,54 @@
+// Command admin grants or revokes the admin role from the command line.
+// It is the only way to create the first admin; afterwards admins can use the API.
+//
+//	go run ./cmd/admin promote <username>
+//	go run ./cmd/admin demote <username>
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/database"
+	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: admin promote|demote <username>")
+		os.Exit(2)
+	}
+
+	var role string
+	switch os.Args[1] {
+	case "promote":
+		role = entity.AccountRoleAdmin
+	case "demote":
+		role = entity.AccountRoleUser
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		os.Exit(2)
+	}
+
+	db := database.NewDB(config.NewConfig())
+	accountRepository := repo.NewAccountRepository(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	acc, err := accountRepository.GetByUsername(ctx, os.Args[2])
+	if err != nil {
+		log.Fatalf("❌ account %q not found: %v", os.Args[2], err)
+	}
+
+	if err := accountRepository.UpdateRole(ctx, acc.ID, role, time.Now()); err != nil {
+		log.Fatalf("❌ failed to update role: %v", err)
+	}
+
+	log.Printf("✅ %s is now %s; existing access tokens must be refreshed", acc.Username, role)
+}
@@ -1,13 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	accountUC "github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/buildinfo"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/dav"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/tracing"
 	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/handlers"
 	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/middlewares"
 	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/routes"
@@ -15,10 +25,10 @@ import (
 
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/database"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/database/migrations"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/joho/godotenv"
 )
 
 type Application struct {
@@ -29,21 +39,41 @@ type Application struct {
 }
 
 func main() {
-	_ = godotenv.Load()
-
+	// Configuration is loaded and validated before anything else starts
 	cfg := config.NewConfig()
+
+	// Tracing is set up before the database so every query can be traced
+	shutdownTracing, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("❌ failed to initialize tracing: %v", err)
+	}
+
 	db := database.NewDB(cfg)
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		log.Fatalf("❌ failed to install database tracing: %v", err)
+	}
+	checkSchema(db, cfg.MigrateOnStart)
 	dbConnector := database.NewDBConnector(db)
 
 	app := fiber.New(fiber.Config{
 		AppName: "smart-task-ai",
+		// Leaves room for the multipart overhead around a maximum-size attachment
+		BodyLimit: 26 * 1024 * 1024,
+		// CalDAV clients use the WebDAV methods
+		RequestMethods: append(append([]string{}, fiber.DefaultMethods...), dav.RequestMethods...),
 	})
 
-	zapLogger := logger.NewZapLogger()
+	zapLogger := logger.NewZapLogger(cfg.AppEnv, cfg.LogLevel)
 
 	// Panic recovery middleware (should be first)
 	app.Use(middlewares.RecoverMiddleware(zapLogger))
 
+	// Request ID middleware, before anything that logs
+	app.Use(middlewares.RequestIDMiddleware())
+
+	// Tracing middleware
+	app.Use(middlewares.TracingMiddleware())
+
 	// Request timeout middleware
 	app.Use(middlewares.TimeoutMiddleware(middlewares.TimeoutConfig{
 		Timeout:      30 * time.Second,
@@ -53,35 +83,90 @@ func main() {
 	// Logger middleware
 	app.Use(middlewares.FiberLoggerMiddleware(zapLogger))
 
-	// CORS middleware
-	allowOrigins := os.Getenv("CORS_ALLOW_ORIGINS")
-	if allowOrigins == "" {
-		allowOrigins = "http://localhost:3000"
-	}
+	// Metrics middleware
+	app.Use(middlewares.MetricsMiddleware())
 
+	// CORS middleware
 	app.Use(cors.New(cors.Config{
-		AllowOrigins:     allowOrigins,
+		// Calendar apps are not browsers, and CalDAV answers OPTIONS itself
+		Next: func(c *fiber.Ctx) bool {
+			return strings.HasPrefix(c.Path(), accountUC.CalDAVPathPrefix) || strings.HasPrefix(c.Path(), "/.well-known/caldav")
+		},
+		AllowOrigins:     cfg.CORSAllowOrigins,
 		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Request-ID, traceparent, tracestate",
 		AllowCredentials: true,
+		ExposeHeaders:    "X-Request-ID, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After",
 	}))
 
-	// Health check route (public, no auth required)
-	healthHandler := handlers.NewHealthHandler(dbConnector)
-	app.Get("/health", healthHandler.Health)
+	// Liveness and readiness probes (public, no auth required)
+	healthHandler := routes.RegisterHealthRoutes(app, cfg, dbConnector, zapLogger)
 
-	// Application routes
-	routes.RegisterPublicRoutes(app, db, zapLogger)
-	routes.RegisterPrivateRoutes(app, db, zapLogger)
+	// Prometheus metrics, protected by METRICS_TOKEN when it is set
+	if err := metrics.RegisterDBStats(dbConnector.GetStats); err != nil {
+		zapLogger.Warn("Failed to register database pool metrics", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	metricsHandler := handlers.NewMetricsHandler(cfg.MetricsToken)
+	app.Get("/metrics", metricsHandler.Metrics)
 
-	addr := ":8080"
-	if port := os.Getenv("PORT"); port != "" {
-		addr = ":" + port
+	// Application routes
+	realtimeHub := routes.NewRealtimeHub(cfg, db, zapLogger)
+	routes.RegisterPublicRoutes(app, cfg, db, zapLogger)
+	routes.RegisterPrivateRoutes(app, cfg, db, realtimeHub, zapLogger)
+	routes.RegisterDAVRoutes(app, cfg, db, zapLogger)
+
+	// Background workers; every instance may run them
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	if cfg.ReminderWorkerEnabled {
+		worker := routes.NewReminderWorker(cfg, db, zapLogger)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			worker.Run(workerCtx)
+		}()
+	}
+	if cfg.OutboxRelayEnabled {
+		relay := routes.NewEventRelay(cfg, db, zapLogger)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			relay.Run(workerCtx)
+		}()
 	}
+	if cfg.WebhookWorkerEnabled {
+		worker := routes.NewWebhookWorker(cfg, db, zapLogger)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			worker.Run(workerCtx)
+		}()
+	}
+	// Project event streams are fed by every instance over LISTEN/NOTIFY
+	listener := database.NewListener(database.DSN(cfg), zapLogger, realtime.ChannelEvents, realtime.ChannelPresence)
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		listener.Run(workerCtx, realtimeHub.Resync, realtimeHub.HandleNotification)
+	}()
+	go func() {
+		defer workers.Done()
+		realtimeHub.Run(workerCtx)
+	}()
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+
+	addr := cfg.ListenAddr()
 
 	// Graceful shutdown
 	go func() {
-		log.Printf("🚀 server running on %s", addr)
+		info := buildinfo.Get()
+		log.Printf("🚀 server %s (%s) running on %s", info.Version, info.Commit, addr)
 		if err := app.Listen(addr); err != nil {
 			log.Fatalf("❌ server error: %v", err)
 		}
@@ -94,9 +179,67 @@ func main() {
 
 	log.Println("🛑 Shutting down server...")
 
+	// Fail readiness first and give load balancers time to stop sending traffic
+	healthHandler.SetShuttingDown()
+	if cfg.ShutdownDrainDelay > 0 {
+		time.Sleep(cfg.ShutdownDrainDelay)
+	}
+
+	// Event streams stay open until closed, and the server waits for them
+	realtimeHub.Close()
 	if err := app.Shutdown(); err != nil {
 		log.Fatalf("❌ Server forced to shutdown: %v", err)
 	}
 
+	// Let the workers finish what they claimed, so it is not delivered twice
+	stopWorkers()
+	select {
+	case <-workersDone:
+	case <-time.After(30 * time.Second):
+		log.Println("⚠️ background workers did not stop in time")
+	}
+
+	// Flush the spans of the last requests
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("⚠️ failed to flush traces: %v", err)
+	}
+
 	log.Println("✅ Server exited gracefully")
 }
+
+// checkSchema refuses to start against a schema this binary was not built for.
+// MIGRATE_ON_START=true applies pending migrations first, which is handy locally.
+func checkSchema(db *gorm.DB, migrateOnStart bool) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("❌ failed to get underlying sql.DB: %v", err)
+	}
+
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		log.Fatalf("❌ failed to load migrations: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if migrateOnStart {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("❌ failed to apply migrations: %v", err)
+		}
+		for _, m := range applied {
+			log.Printf("✅ applied migration %04d_%s", m.Version, m.Name)
+		}
+	}
+
+	if err := migrator.Check(ctx); err != nil {
+		if errors.Is(err, migrations.ErrPendingMigrations) {
+			log.Fatalf("❌ %v; run `migrate up` first", err)
+		}
+		log.Fatalf("❌ refusing to start: %v", err)
+	}
+	log.Printf("✅ Database schema at version %d", migrator.Latest())
+}
@@ -0,0 +1,99 @@
+// Command migrate applies the SQL migrations embedded in the binary.
+//
+//	go run ./cmd/migrate up
+//	go run ./cmd/migrate down [steps]   (default 1)
+//	go run ./cmd/migrate status
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/database"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/database/migrations"
+)
+
+func main() {
+	if len(os.Args) < 2 || len(os.Args) > 3 {
+		usage()
+	}
+
+	steps := 1
+	if len(os.Args) == 3 {
+		if os.Args[1] != "down" {
+			usage()
+		}
+		n, err := strconv.Atoi(os.Args[2])
+		if err != nil || n < 1 {
+			fmt.Fprintf(os.Stderr, "invalid number of steps %q\n", os.Args[2])
+			os.Exit(2)
+		}
+		steps = n
+	}
+
+	db := database.NewDB(config.NewConfig())
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("❌ failed to get underlying sql.DB: %v", err)
+	}
+
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		log.Fatalf("❌ failed to load migrations: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("✅ applied %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		if len(applied) == 0 {
+			log.Println("✅ schema is up to date")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			log.Printf("✅ reverted %04d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		if len(reverted) == 0 {
+			log.Println("✅ nothing to revert")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			switch {
+			case s.Unknown:
+				state = "unknown, applied " + s.AppliedAt.Format(time.RFC3339)
+			case s.AppliedAt != nil:
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up | down [steps] | status")
+	os.Exit(2)
+}
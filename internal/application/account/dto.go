@@ -1,6 +1,10 @@
 package account
 
-import "github.com/FrostBitzX/smart-task-ai/internal/application/common"
+import (
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
+)
 
 type CreateAccountRequest struct {
 	Username        string `json:"username" validate:"required,min=3,max=20"`
@@ -10,8 +14,11 @@ type CreateAccountRequest struct {
 }
 
 type ListAccountsRequest struct {
-	Limit  *int `query:"limit" validate:"omitempty,min=1,max=100"`
-	Offset *int `query:"offset" validate:"omitempty,min=0"`
+	Limit  *int   `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset *int   `query:"offset" validate:"omitempty,min=0"`
+	Query  string `query:"q" validate:"omitempty,max=100"`
+	State  string `query:"state" validate:"omitempty,oneof=active inactive suspended"`
+	Role   string `query:"role" validate:"omitempty,oneof=user admin"`
 }
 
 type CreateAccountResponse struct {
@@ -19,10 +26,12 @@ type CreateAccountResponse struct {
 }
 
 type AccountDTO struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Status   string `json:"status"`
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	Status        string `json:"status"`
+	Role          string `json:"role"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 type ListAccountsResponse struct {
@@ -31,10 +40,116 @@ type ListAccountsResponse struct {
 }
 
 type LoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token            string    `json:"token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int64     `json:"expires_in"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	SessionID        string    `json:"session_id"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	UserAgent    string `json:"-"`
+	IPAddress    string `json:"-"`
+}
+
+type SessionDTO struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type ListSessionsResponse struct {
+	Items []SessionDTO `json:"items"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+type CreateAppPasswordRequest struct {
+	// Name tells the devices apart, e.g. "iPhone calendar"
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type AppPasswordDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// CreateAppPasswordResponse carries the password, which is only returned once,
+// and what a CalDAV client needs to connect
+type CreateAppPasswordResponse struct {
+	AppPasswordDTO
+	Password  string `json:"password"`
+	Username  string `json:"username"`
+	CalDAVURL string `json:"caldav_url"`
+}
+
+type ListAppPasswordsResponse struct {
+	Items []AppPasswordDTO `json:"items"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token           string `json:"token" validate:"required"`
+	Password        string `json:"password" validate:"required,min=4"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,min=4,eqfield=Password"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=4"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,min=4,eqfield=NewPassword"`
+}
+
+type ChangeEmailRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	Email           string `json:"email" validate:"required,email"`
+}
+
+type ChangeUsernameRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	Username        string `json:"username" validate:"required,min=3,max=20"`
+}
+
+type DeactivateAccountRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+}
+
+type AccountSupportViewResponse struct {
+	Account        AccountDTO   `json:"account"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	LastActiveAt   *time.Time   `json:"last_active_at,omitempty"`
+	ActiveSessions []SessionDTO `json:"active_sessions"`
+}
+
+type SetAccountRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
 }
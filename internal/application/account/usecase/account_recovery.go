@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+)
+
+type ForgotPasswordUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewForgotPasswordUseCase(svc *service.AccountService, l logger.Logger) *ForgotPasswordUseCase {
+	return &ForgotPasswordUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+// Execute always succeeds for a valid request, so the response never reveals whether the email is registered
+func (uc *ForgotPasswordUseCase) Execute(ctx context.Context, req *account.ForgotPasswordRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	if err := uc.accountService.RequestPasswordReset(ctx, req.Email); err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to send password reset email", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return nil
+}
+
+type ResetPasswordUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewResetPasswordUseCase(svc *service.AccountService, l logger.Logger) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, req *account.ResetPasswordRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	return uc.accountService.ResetPassword(ctx, req)
+}
+
+type VerifyEmailUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewVerifyEmailUseCase(svc *service.AccountService, l logger.Logger) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *VerifyEmailUseCase) Execute(ctx context.Context, req *account.VerifyEmailRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	return uc.accountService.VerifyEmail(ctx, req.Token)
+}
+
+type ResendVerificationUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewResendVerificationUseCase(svc *service.AccountService, l logger.Logger) *ResendVerificationUseCase {
+	return &ResendVerificationUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+// Execute behaves like ForgotPasswordUseCase: errors are logged, not returned
+func (uc *ResendVerificationUseCase) Execute(ctx context.Context, req *account.ResendVerificationRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	if err := uc.accountService.ResendVerificationEmail(ctx, req.Email); err != nil {
+		uc.logger.ErrorContext(ctx, "Failed to send verification email", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	return nil
+}
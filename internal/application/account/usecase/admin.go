@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+type GetAccountSupportViewUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewGetAccountSupportViewUseCase(svc *service.AccountService, l logger.Logger) *GetAccountSupportViewUseCase {
+	return &GetAccountSupportViewUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *GetAccountSupportViewUseCase) Execute(ctx context.Context, accountID string) (*account.AccountSupportViewResponse, error) {
+	accID, err := utils.ParseID(accountID, entity.AccountIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	view, err := uc.accountService.GetSupportView(ctx, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastActiveAt *time.Time
+	sessions := make([]account.SessionDTO, len(view.ActiveSessions))
+	for i, s := range view.ActiveSessions {
+		sessions[i] = account.SessionDTO{
+			ID:         utils.ShortUUIDWithPrefix(s.ID, entity.SessionIDPrefix),
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		}
+		if lastActiveAt == nil || s.LastUsedAt.After(*lastActiveAt) {
+			lastActiveAt = &view.ActiveSessions[i].LastUsedAt
+		}
+	}
+
+	return &account.AccountSupportViewResponse{
+		Account:        *toAccountDTO(view.Account),
+		CreatedAt:      view.Account.CreatedAt,
+		UpdatedAt:      view.Account.UpdatedAt,
+		LastActiveAt:   lastActiveAt,
+		ActiveSessions: sessions,
+	}, nil
+}
+
+type SuspendAccountUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewSuspendAccountUseCase(svc *service.AccountService, l logger.Logger) *SuspendAccountUseCase {
+	return &SuspendAccountUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *SuspendAccountUseCase) Execute(ctx context.Context, adminID, accountID string) (*account.AccountDTO, error) {
+	adminUUID, accID, err := parseAdminTarget(adminID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := uc.accountService.SuspendAccount(ctx, adminUUID, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Account suspended by admin", map[string]interface{}{
+		"admin_id":   adminID,
+		"account_id": acc.ID.String(),
+	})
+
+	return toAccountDTO(acc), nil
+}
+
+type UnsuspendAccountUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewUnsuspendAccountUseCase(svc *service.AccountService, l logger.Logger) *UnsuspendAccountUseCase {
+	return &UnsuspendAccountUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *UnsuspendAccountUseCase) Execute(ctx context.Context, adminID, accountID string) (*account.AccountDTO, error) {
+	_, accID, err := parseAdminTarget(adminID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := uc.accountService.UnsuspendAccount(ctx, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Account unsuspended by admin", map[string]interface{}{
+		"admin_id":   adminID,
+		"account_id": acc.ID.String(),
+	})
+
+	return toAccountDTO(acc), nil
+}
+
+type SetAccountRoleUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewSetAccountRoleUseCase(svc *service.AccountService, l logger.Logger) *SetAccountRoleUseCase {
+	return &SetAccountRoleUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *SetAccountRoleUseCase) Execute(ctx context.Context, adminID, accountID string, req *account.SetAccountRoleRequest) (*account.AccountDTO, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	adminUUID, accID, err := parseAdminTarget(adminID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := uc.accountService.SetAccountRole(ctx, adminUUID, accID, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Account role changed by admin", map[string]interface{}{
+		"admin_id":   adminID,
+		"account_id": acc.ID.String(),
+		"role":       acc.Role,
+	})
+
+	return toAccountDTO(acc), nil
+}
+
+// parseAdminTarget parses the acting admin (raw UUID from the token) and the target account ID
+func parseAdminTarget(adminID, accountID string) (uuid.UUID, uuid.UUID, error) {
+	adminUUID, err := uuid.Parse(adminID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	accID, err := utils.ParseID(accountID, entity.AccountIDPrefix)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	return adminUUID, accID, nil
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+// CalDAVPathPrefix is where the CalDAV server is mounted; calendar apps discover the rest
+const CalDAVPathPrefix = "/dav"
+
+type CreateAppPasswordUseCase struct {
+	appPasswordService *service.AppPasswordService
+	publicURL          string
+	logger             logger.Logger
+}
+
+// NewCreateAppPasswordUseCase builds the CalDAV server URL under publicURL, the address calendar apps reach the API at
+func NewCreateAppPasswordUseCase(svc *service.AppPasswordService, publicURL string, l logger.Logger) *CreateAppPasswordUseCase {
+	return &CreateAppPasswordUseCase{
+		appPasswordService: svc,
+		publicURL:          strings.TrimRight(publicURL, "/"),
+		logger:             l,
+	}
+}
+
+func (u *CreateAppPasswordUseCase) Execute(ctx context.Context, accountID string, req *account.CreateAppPasswordRequest) (*account.CreateAppPasswordResponse, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	creds, err := u.appPasswordService.CreateAppPassword(ctx, accID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.InfoContext(ctx, "App password created", map[string]interface{}{
+		"app_password_id": creds.AppPassword.ID.String(),
+		"account_id":      accountID,
+	})
+
+	return &account.CreateAppPasswordResponse{
+		AppPasswordDTO: toAppPasswordDTO(creds.AppPassword),
+		Password:       creds.Password,
+		Username:       creds.Username,
+		CalDAVURL:      u.publicURL + CalDAVPathPrefix + "/",
+	}, nil
+}
+
+type ListAppPasswordsUseCase struct {
+	appPasswordService *service.AppPasswordService
+	logger             logger.Logger
+}
+
+func NewListAppPasswordsUseCase(svc *service.AppPasswordService, l logger.Logger) *ListAppPasswordsUseCase {
+	return &ListAppPasswordsUseCase{
+		appPasswordService: svc,
+		logger:             l,
+	}
+}
+
+func (u *ListAppPasswordsUseCase) Execute(ctx context.Context, accountID string) (*account.ListAppPasswordsResponse, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	appPasswords, err := u.appPasswordService.ListAppPasswords(ctx, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]account.AppPasswordDTO, len(appPasswords))
+	for i, p := range appPasswords {
+		items[i] = toAppPasswordDTO(p)
+	}
+
+	return &account.ListAppPasswordsResponse{Items: items}, nil
+}
+
+type RevokeAppPasswordUseCase struct {
+	appPasswordService *service.AppPasswordService
+	logger             logger.Logger
+}
+
+func NewRevokeAppPasswordUseCase(svc *service.AppPasswordService, l logger.Logger) *RevokeAppPasswordUseCase {
+	return &RevokeAppPasswordUseCase{
+		appPasswordService: svc,
+		logger:             l,
+	}
+}
+
+// Execute revokes one app password; appPasswordID accepts both prefixed and raw IDs
+func (u *RevokeAppPasswordUseCase) Execute(ctx context.Context, accountID, appPasswordID string) error {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	id, err := utils.ParseID(appPasswordID, entity.AppPasswordIDPrefix)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid app password ID format", "INVALID_APP_PASSWORD_ID", err)
+	}
+
+	if err := u.appPasswordService.RevokeAppPassword(ctx, accID, id); err != nil {
+		return err
+	}
+
+	u.logger.InfoContext(ctx, "App password revoked", map[string]interface{}{
+		"app_password_id": id.String(),
+		"account_id":      accountID,
+	})
+	return nil
+}
+
+func toAppPasswordDTO(p *entity.AppPassword) account.AppPasswordDTO {
+	return account.AppPasswordDTO{
+		ID:         utils.ShortUUIDWithPrefix(p.ID, entity.AppPasswordIDPrefix),
+		Name:       p.Name,
+		CreatedAt:  p.CreatedAt,
+		LastUsedAt: p.LastUsedAt,
+	}
+}
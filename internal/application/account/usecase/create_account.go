@@ -34,6 +34,14 @@ func (uc *CreateAccountUseCase) Execute(ctx context.Context, req *account.Create
 		return nil, err
 	}
 
+	// The account is usable without verification for now, so a failed email must not fail the signup
+	if err := uc.accountService.SendVerificationEmail(ctx, acc); err != nil {
+		uc.logger.WarnContext(ctx, "Failed to send verification email", map[string]interface{}{
+			"account_id": acc.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+
 	// Convert UUID to string with prefix
 	accountID := utils.ShortUUIDWithPrefix(acc.ID, entity.AccountIDPrefix)
 
@@ -2,13 +2,13 @@ package usecase
 
 import (
 	"context"
+	"strings"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
 	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
-	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
-	"github.com/FrostBitzX/smart-task-ai/internal/utils"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 )
 
@@ -32,21 +32,22 @@ func (uc *ListAccountUseCase) Execute(ctx context.Context, req *account.ListAcco
 	// Set pagination
 	limit, offset := common.ValidatePagination(req.Limit, req.Offset)
 
+	filter := accounts.AccountFilter{
+		Query: strings.TrimSpace(req.Query),
+		State: req.State,
+		Role:  req.Role,
+	}
+
 	// Get accounts from service
-	accounts, total, err := uc.accountService.ListAccounts(ctx, limit, offset)
+	items, total, err := uc.accountService.ListAccounts(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
 	// Convert entities to DTOs
-	accountDTOs := make([]account.AccountDTO, len(accounts))
-	for i, acc := range accounts {
-		accountDTOs[i] = account.AccountDTO{
-			ID:       utils.ShortUUIDWithPrefix(acc.ID, entity.AccountIDPrefix),
-			Username: acc.Username,
-			Email:    acc.Email,
-			Status:   acc.State,
-		}
+	accountDTOs := make([]account.AccountDTO, len(items))
+	for i, acc := range items {
+		accountDTOs[i] = *toAccountDTO(acc)
 	}
 
 	// Calculate pagination info
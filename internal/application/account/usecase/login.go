@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 )
 
@@ -26,15 +29,22 @@ func (u *LoginUseCase) Execute(ctx context.Context, req *account.LoginRequest) (
 		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
 	}
 
-	token, err := u.accountService.Login(ctx, req)
+	tokens, err := u.accountService.Login(ctx, req)
 
 	if err != nil {
 		return nil, err
 	}
 
-	res := &account.LoginResponse{
-		Token: token,
-	}
+	return toLoginResponse(tokens), nil
+}
 
-	return res, nil
+func toLoginResponse(tokens *accounts.TokenPair) *account.LoginResponse {
+	return &account.LoginResponse{
+		Token:            tokens.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(accounts.AccessTokenTTL.Seconds()),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+		SessionID:        utils.ShortUUIDWithPrefix(tokens.SessionID, entity.SessionIDPrefix),
+	}
 }
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+type GetCurrentAccountUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewGetCurrentAccountUseCase(svc *service.AccountService, l logger.Logger) *GetCurrentAccountUseCase {
+	return &GetCurrentAccountUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *GetCurrentAccountUseCase) Execute(ctx context.Context, accountID string) (*account.AccountDTO, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	acc, err := uc.accountService.GetAccount(ctx, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountDTO(acc), nil
+}
+
+type ChangePasswordUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewChangePasswordUseCase(svc *service.AccountService, l logger.Logger) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *ChangePasswordUseCase) Execute(ctx context.Context, accountID, sessionID string, req *account.ChangePasswordRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	sessID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid session ID format", "INVALID_SESSION_ID", err)
+	}
+
+	return uc.accountService.ChangePassword(ctx, accID, sessID, req)
+}
+
+type ChangeEmailUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewChangeEmailUseCase(svc *service.AccountService, l logger.Logger) *ChangeEmailUseCase {
+	return &ChangeEmailUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *ChangeEmailUseCase) Execute(ctx context.Context, accountID string, req *account.ChangeEmailRequest) (*account.AccountDTO, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	acc, err := uc.accountService.ChangeEmail(ctx, accID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The change is saved already; a failed email can be retried through the resend endpoint
+	if err := uc.accountService.SendVerificationEmail(ctx, acc); err != nil {
+		uc.logger.WarnContext(ctx, "Failed to send verification email", map[string]interface{}{
+			"account_id": acc.ID.String(),
+			"error":      err.Error(),
+		})
+	}
+
+	return toAccountDTO(acc), nil
+}
+
+type ChangeUsernameUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewChangeUsernameUseCase(svc *service.AccountService, l logger.Logger) *ChangeUsernameUseCase {
+	return &ChangeUsernameUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *ChangeUsernameUseCase) Execute(ctx context.Context, accountID string, req *account.ChangeUsernameRequest) (*account.AccountDTO, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	acc, err := uc.accountService.ChangeUsername(ctx, accID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountDTO(acc), nil
+}
+
+type DeactivateAccountUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewDeactivateAccountUseCase(svc *service.AccountService, l logger.Logger) *DeactivateAccountUseCase {
+	return &DeactivateAccountUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *DeactivateAccountUseCase) Execute(ctx context.Context, accountID string, req *account.DeactivateAccountRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	return uc.accountService.DeactivateAccount(ctx, accID, req)
+}
+
+type ReactivateAccountUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewReactivateAccountUseCase(svc *service.AccountService, l logger.Logger) *ReactivateAccountUseCase {
+	return &ReactivateAccountUseCase{
+		accountService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *ReactivateAccountUseCase) Execute(ctx context.Context, req *account.LoginRequest) (*account.LoginResponse, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	tokens, err := uc.accountService.ReactivateAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return toLoginResponse(tokens), nil
+}
+
+func toAccountDTO(acc *entity.Account) *account.AccountDTO {
+	return &account.AccountDTO{
+		ID:            utils.ShortUUIDWithPrefix(acc.ID, entity.AccountIDPrefix),
+		Username:      acc.Username,
+		Email:         acc.Email,
+		Status:        acc.State,
+		Role:          acc.Role,
+		EmailVerified: acc.IsEmailVerified(),
+	}
+}
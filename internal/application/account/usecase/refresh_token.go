@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+)
+
+type RefreshTokenUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewRefreshTokenUseCase(accSvc *service.AccountService, l logger.Logger) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		accountService: accSvc,
+		logger:         l,
+	}
+}
+
+func (u *RefreshTokenUseCase) Execute(ctx context.Context, req *account.RefreshTokenRequest) (*account.LoginResponse, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	tokens, err := u.accountService.RefreshSession(ctx, req)
+	if err != nil {
+		if appErr, ok := apperror.IsAppError(err); ok && appErr.Code == "REFRESH_TOKEN_REUSED" {
+			u.logger.WarnContext(ctx, "Refresh token reuse detected, session revoked", map[string]interface{}{
+				"ip": req.IPAddress,
+			})
+		}
+		return nil, err
+	}
+
+	return toLoginResponse(tokens), nil
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+type ListSessionsUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewListSessionsUseCase(accSvc *service.AccountService, l logger.Logger) *ListSessionsUseCase {
+	return &ListSessionsUseCase{
+		accountService: accSvc,
+		logger:         l,
+	}
+}
+
+func (u *ListSessionsUseCase) Execute(ctx context.Context, accountID, currentSessionID string) (*account.ListSessionsResponse, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	sessions, err := u.accountService.ListSessions(ctx, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]account.SessionDTO, len(sessions))
+	for i, s := range sessions {
+		items[i] = account.SessionDTO{
+			ID:         utils.ShortUUIDWithPrefix(s.ID, entity.SessionIDPrefix),
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			Current:    s.ID.String() == currentSessionID,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		}
+	}
+
+	return &account.ListSessionsResponse{Items: items}, nil
+}
+
+type RevokeSessionUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewRevokeSessionUseCase(accSvc *service.AccountService, l logger.Logger) *RevokeSessionUseCase {
+	return &RevokeSessionUseCase{
+		accountService: accSvc,
+		logger:         l,
+	}
+}
+
+// Execute revokes one session of the account; sessionID accepts both prefixed and raw IDs
+func (u *RevokeSessionUseCase) Execute(ctx context.Context, accountID, sessionID string) (*account.RevokeSessionsResponse, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	sessID, err := utils.ParseID(sessionID, entity.SessionIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid session ID format", "INVALID_SESSION_ID", err)
+	}
+
+	if err := u.accountService.RevokeSession(ctx, accID, sessID); err != nil {
+		return nil, err
+	}
+
+	return &account.RevokeSessionsResponse{Revoked: 1}, nil
+}
+
+type RevokeAllSessionsUseCase struct {
+	accountService *service.AccountService
+	logger         logger.Logger
+}
+
+func NewRevokeAllSessionsUseCase(accSvc *service.AccountService, l logger.Logger) *RevokeAllSessionsUseCase {
+	return &RevokeAllSessionsUseCase{
+		accountService: accSvc,
+		logger:         l,
+	}
+}
+
+func (u *RevokeAllSessionsUseCase) Execute(ctx context.Context, accountID string) (*account.RevokeSessionsResponse, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	count, err := u.accountService.RevokeAllSessions(ctx, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account.RevokeSessionsResponse{Revoked: count}, nil
+}
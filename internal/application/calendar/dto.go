@@ -0,0 +1,51 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+)
+
+// Feed scopes
+const (
+	FeedScopeAccount = "account"
+	FeedScopeProject = "project"
+)
+
+type CreateFeedRequest struct {
+	// ProjectID limits the feed to one project; empty covers every project of the account
+	ProjectID string `json:"project_id"`
+}
+
+// FeedResponse describes a calendar feed. URL and WebcalURL are only returned
+// when the feed is created, since only a hash of the secret token is stored.
+type FeedResponse struct {
+	ID        string    `json:"id"`
+	Scope     string    `json:"scope"`
+	ProjectID string    `json:"project_id,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	WebcalURL string    `json:"webcal_url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListFeedsResponse struct {
+	Items []FeedResponse `json:"items"`
+}
+
+// ImportTasksResponse reports what an .ics import created, or would create in a dry run
+type ImportTasksResponse struct {
+	DryRun         bool                  `json:"dry_run"`
+	CreatedCount   int                   `json:"created_count"`
+	DuplicateCount int                   `json:"duplicate_count"`
+	Events         []ImportedEventResult `json:"events"`
+}
+
+// ImportedEventResult covers every VEVENT sharing one UID. Recurring events may
+// map to several tasks, one per weekday and per run between exceptions.
+type ImportedEventResult struct {
+	UID       string                    `json:"uid"`
+	Summary   string                    `json:"summary"`
+	Duplicate bool                      `json:"duplicate"`
+	Tasks     []task.CreateTaskResponse `json:"tasks"`
+	Warnings  []string                  `json:"warnings"`
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/calendar"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/service"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+// FeedPathPrefix is where the public feeds are served; the token and ".ics" follow it
+const FeedPathPrefix = "/calendar/"
+
+type CreateFeedUseCase struct {
+	calendarService *service.CalendarService
+	publicURL       string
+	logger          logger.Logger
+}
+
+// NewCreateFeedUseCase builds subscription URLs under publicURL, the address calendar apps reach the API at
+func NewCreateFeedUseCase(svc *service.CalendarService, publicURL string, l logger.Logger) *CreateFeedUseCase {
+	return &CreateFeedUseCase{
+		calendarService: svc,
+		publicURL:       strings.TrimRight(publicURL, "/"),
+		logger:          l,
+	}
+}
+
+func (uc *CreateFeedUseCase) Execute(ctx context.Context, accountID string, req *calendar.CreateFeedRequest) (*calendar.FeedResponse, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	var projectID *uuid.UUID
+	if req.ProjectID != "" {
+		parsed, err := utils.ParseID(req.ProjectID, projectEntity.ProjectIDPrefix)
+		if err != nil {
+			return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
+		}
+		projectID = &parsed
+	}
+
+	feed, token, err := uc.calendarService.CreateFeed(ctx, accID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Calendar feed created", map[string]interface{}{
+		"feed_id":    feed.ID.String(),
+		"account_id": accountID,
+	})
+
+	res := toFeedResponse(feed)
+	res.URL = uc.publicURL + FeedPathPrefix + token + ".ics"
+	// webcal:// makes browsers hand the URL to the default calendar app
+	res.WebcalURL = "webcal://" + strings.TrimPrefix(strings.TrimPrefix(res.URL, "https://"), "http://")
+	return &res, nil
+}
+
+type ListFeedsUseCase struct {
+	calendarService *service.CalendarService
+	logger          logger.Logger
+}
+
+func NewListFeedsUseCase(svc *service.CalendarService, l logger.Logger) *ListFeedsUseCase {
+	return &ListFeedsUseCase{
+		calendarService: svc,
+		logger:          l,
+	}
+}
+
+func (uc *ListFeedsUseCase) Execute(ctx context.Context, accountID string) (*calendar.ListFeedsResponse, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	feeds, err := uc.calendarService.ListFeeds(ctx, accID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]calendar.FeedResponse, 0, len(feeds))
+	for _, f := range feeds {
+		items = append(items, toFeedResponse(f))
+	}
+
+	return &calendar.ListFeedsResponse{Items: items}, nil
+}
+
+type RevokeFeedUseCase struct {
+	calendarService *service.CalendarService
+	logger          logger.Logger
+}
+
+func NewRevokeFeedUseCase(svc *service.CalendarService, l logger.Logger) *RevokeFeedUseCase {
+	return &RevokeFeedUseCase{
+		calendarService: svc,
+		logger:          l,
+	}
+}
+
+// Execute revokes one feed of the account; feedID accepts both prefixed and raw IDs
+func (uc *RevokeFeedUseCase) Execute(ctx context.Context, accountID, feedID string) error {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	parsedFeedID, err := utils.ParseID(feedID, entity.FeedIDPrefix)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid calendar feed ID format", "INVALID_CALENDAR_FEED_ID", err)
+	}
+
+	return uc.calendarService.RevokeFeed(ctx, accID, parsedFeedID)
+}
+
+type GetFeedUseCase struct {
+	calendarService *service.CalendarService
+	logger          logger.Logger
+}
+
+func NewGetFeedUseCase(svc *service.CalendarService, l logger.Logger) *GetFeedUseCase {
+	return &GetFeedUseCase{
+		calendarService: svc,
+		logger:          l,
+	}
+}
+
+// Execute returns the iCalendar document of the feed the secret token belongs to
+func (uc *GetFeedUseCase) Execute(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, apperror.NewNotFoundError("calendar feed not found", "CALENDAR_FEED_NOT_FOUND", nil)
+	}
+
+	return uc.calendarService.RenderFeed(ctx, token)
+}
+
+func toFeedResponse(f *entity.Feed) calendar.FeedResponse {
+	res := calendar.FeedResponse{
+		ID:        utils.ShortUUIDWithPrefix(f.ID, entity.FeedIDPrefix),
+		Scope:     calendar.FeedScopeAccount,
+		CreatedAt: f.CreatedAt,
+	}
+	if f.ProjectID != nil {
+		res.Scope = calendar.FeedScopeProject
+		res.ProjectID = utils.ShortUUIDWithPrefix(*f.ProjectID, projectEntity.ProjectIDPrefix)
+	}
+	return res
+}
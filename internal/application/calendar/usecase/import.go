@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/calendar"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/service"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+type ImportTasksInput struct {
+	AccountID string
+	ProjectID string
+	File      io.Reader
+	// TimeZone is an IANA name applied to floating times; empty means the
+	// time zone of the account, which is also the one tasks are shown in
+	TimeZone string
+	DryRun   bool
+}
+
+type ImportTasksUseCase struct {
+	calendarService *service.CalendarService
+	profileService  *profileSvc.ProfileService
+	logger          logger.Logger
+}
+
+func NewImportTasksUseCase(svc *service.CalendarService, ps *profileSvc.ProfileService, l logger.Logger) *ImportTasksUseCase {
+	return &ImportTasksUseCase{
+		calendarService: svc,
+		profileService:  ps,
+		logger:          l,
+	}
+}
+
+func (uc *ImportTasksUseCase) Execute(ctx context.Context, input *ImportTasksInput) (*calendar.ImportTasksResponse, error) {
+	accID, err := uuid.Parse(input.AccountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	projectID, err := utils.ParseID(input.ProjectID, projectEntity.ProjectIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
+	}
+
+	loc, err := uc.profileService.GetLocation(ctx, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if input.TimeZone != "" {
+		if loc, err = time.LoadLocation(input.TimeZone); err != nil {
+			return nil, apperror.NewBadRequestError("invalid time zone", "INVALID_TIMEZONE", err)
+		}
+	}
+	opts := service.ImportOptions{DryRun: input.DryRun, Location: loc}
+
+	results, err := uc.calendarService.ImportTasks(ctx, accID, projectID, input.File, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &calendar.ImportTasksResponse{
+		DryRun: input.DryRun,
+		Events: make([]calendar.ImportedEventResult, 0, len(results)),
+	}
+	for _, r := range results {
+		event := calendar.ImportedEventResult{
+			UID:       r.UID,
+			Summary:   r.Summary,
+			Duplicate: r.Duplicate,
+			Tasks:     make([]task.CreateTaskResponse, 0, len(r.Tasks)),
+			Warnings:  r.Warnings,
+		}
+		if event.Warnings == nil {
+			event.Warnings = []string{}
+		}
+		for _, t := range r.Tasks {
+			tr := toTaskResponse(t, loc)
+			if input.DryRun {
+				// Planned tasks are not saved, so their IDs mean nothing
+				tr.ID = ""
+			}
+			event.Tasks = append(event.Tasks, tr)
+		}
+		if r.Duplicate {
+			res.DuplicateCount++
+		}
+		res.CreatedCount += len(event.Tasks)
+		res.Events = append(res.Events, event)
+	}
+
+	if !input.DryRun {
+		metrics.TasksCreated.WithLabelValues(task.TaskSourceICSImport).Add(float64(res.CreatedCount))
+		uc.logger.InfoContext(ctx, "Tasks imported from calendar", map[string]interface{}{
+			"project_id":      input.ProjectID,
+			"account_id":      input.AccountID,
+			"created_count":   res.CreatedCount,
+			"duplicate_count": res.DuplicateCount,
+		})
+	}
+
+	return res, nil
+}
+
+func toTaskResponse(t *taskEntity.Task, loc *time.Location) task.CreateTaskResponse {
+	times := task.FormatTaskTimes(t, loc)
+	return task.CreateTaskResponse{
+		ID:             utils.ShortUUIDWithPrefix(t.ID, taskEntity.TaskIDPrefix),
+		Status:         t.Status,
+		Name:           t.Name,
+		Description:    t.Description,
+		Priority:       t.Priority,
+		StartDateTime:  times.Start,
+		EndDateTime:    times.End,
+		AllDay:         t.AllDay,
+		Location:       t.Location,
+		RecurringDays:  t.RecurringDays,
+		RecurringUntil: times.RecurringUntil,
+	}
+}
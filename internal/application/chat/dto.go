@@ -1,5 +1,7 @@
 package chat
 
+import "github.com/FrostBitzX/smart-task-ai/internal/application/task"
+
 // SendMessageRequestDTO represents the request to send a message to the AI assistant
 type SendMessageRequestDTO struct {
 	ProjectID      string       `json:"project_id,omitempty"` // Set from URL parameter
@@ -30,4 +32,6 @@ type TaskDTO struct {
 	Location       *string `json:"location,omitempty"`
 	RecurringDays  *int    `json:"recurring_days,omitempty"`
 	RecurringUntil *string `json:"recurring_until,omitempty"`
+
+	Conflicts []task.ScheduleConflict `json:"conflicts,omitempty"` // Scheduled tasks the suggestion overlaps
 }
@@ -2,12 +2,16 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/chat"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
 	chatSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/chats/service"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
 	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/groq"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
 	"github.com/FrostBitzX/smart-task-ai/internal/utils"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 	"github.com/google/uuid"
@@ -15,15 +19,17 @@ import (
 
 // SendMessageUseCase handles sending messages to the AI assistant
 type SendMessageUseCase struct {
-	chatService chatSvc.ChatService
-	logger      logger.Logger
+	chatService    chatSvc.ChatService
+	profileService *profileSvc.ProfileService
+	logger         logger.Logger
 }
 
 // NewSendMessageUseCase creates a new SendMessageUseCase
-func NewSendMessageUseCase(cs chatSvc.ChatService, l logger.Logger) *SendMessageUseCase {
+func NewSendMessageUseCase(cs chatSvc.ChatService, ps *profileSvc.ProfileService, l logger.Logger) *SendMessageUseCase {
 	return &SendMessageUseCase{
-		chatService: cs,
-		logger:      l,
+		chatService:    cs,
+		profileService: ps,
+		logger:         l,
 	}
 }
 
@@ -38,11 +44,17 @@ func (uc *SendMessageUseCase) Execute(ctx context.Context, accountID string, req
 	if err != nil {
 		return nil, err
 	}
+	metrics.AITaskSuggestions.Add(float64(len(resp.Tasks)))
+
+	loc, err := uc.profileService.GetLocation(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
 
 	return &chat.SendMessageResponseDTO{
 		Type:    resp.Type,
 		Message: resp.Message,
-		Tasks:   mapTasksToDTO(resp.Tasks),
+		Tasks:   mapTasksToDTO(resp.Tasks, loc),
 	}, nil
 }
 
@@ -86,8 +98,8 @@ func convertSessionHistory(history []chat.MessageDTO) []groq.ChatMessage {
 	return messages
 }
 
-// mapTasksToDTO converts domain tasks to DTOs
-func mapTasksToDTO(tasks []chatSvc.TaskFromAI) []chat.TaskDTO {
+// mapTasksToDTO converts domain tasks to DTOs, with conflict times in loc
+func mapTasksToDTO(tasks []chatSvc.TaskFromAI, loc *time.Location) []chat.TaskDTO {
 	if tasks == nil {
 		return nil
 	}
@@ -98,6 +110,7 @@ func mapTasksToDTO(tasks []chatSvc.TaskFromAI) []chat.TaskDTO {
 			Name:        t.Name,
 			Description: t.Description,
 			Priority:    t.Priority,
+			Conflicts:   task.NewScheduleConflicts(t.Conflicts, loc),
 		}
 		if t.StartDateTime != "" {
 			dtos[i].StartDatetime = &t.StartDateTime
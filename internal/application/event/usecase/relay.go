@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+)
+
+// purgeInterval is how often dispatched events past their retention are deleted
+const purgeInterval = time.Hour
+
+// purgeBatchSize is how many events one delete removes
+const purgeBatchSize = 1000
+
+// RelayConfig tunes the outbox relay
+type RelayConfig struct {
+	// Interval is how often due events are looked for
+	Interval time.Duration
+	// BatchSize is how many events are claimed at once
+	BatchSize int
+	// Lease is how long a claim lasts; it must cover dispatching a whole batch
+	Lease time.Duration
+	// Retention is how long dispatched events are kept
+	Retention time.Duration
+}
+
+// RelayWorker dispatches the events of the outbox in the background. Every
+// instance of the API runs one; the claims in RelayService.DispatchDue keep
+// them from dispatching the same event at once.
+type RelayWorker struct {
+	relayService *service.RelayService
+	config       RelayConfig
+	logger       logger.Logger
+}
+
+func NewRelayWorker(svc *service.RelayService, cfg RelayConfig, l logger.Logger) *RelayWorker {
+	return &RelayWorker{
+		relayService: svc,
+		config:       cfg,
+		logger:       l,
+	}
+}
+
+// Run dispatches events until ctx is cancelled. A batch in progress is
+// finished first, so no claim is left to expire and no handler is cut off.
+func (w *RelayWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for {
+		w.dispatchDue(ctx)
+
+		if time.Since(lastPurge) >= purgeInterval && ctx.Err() == nil {
+			w.purge(ctx)
+			lastPurge = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchDue claims batches until the due events run out
+func (w *RelayWorker) dispatchDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		batchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), w.config.Lease)
+		results, err := w.relayService.DispatchDue(batchCtx, time.Now(), w.config.BatchSize, w.config.Lease)
+		cancel()
+		if err != nil {
+			w.logger.Error("Failed to dispatch outbox events", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		for _, r := range results {
+			metrics.OutboxEventsDispatched.WithLabelValues(r.Type, r.Outcome).Inc()
+			if r.Err != nil {
+				w.logger.Warn("Failed to handle outbox event", map[string]interface{}{
+					"event_id": r.EventID.String(),
+					"type":     r.Type,
+					"outcome":  r.Outcome,
+					"error":    r.Err.Error(),
+				})
+			}
+		}
+
+		if len(results) < w.config.BatchSize {
+			return
+		}
+	}
+}
+
+// purge deletes the events dispatched longer than the retention ago
+func (w *RelayWorker) purge(ctx context.Context) {
+	before := time.Now().Add(-w.config.Retention)
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := w.relayService.PurgeDispatched(ctx, before, purgeBatchSize)
+		if err != nil {
+			w.logger.Error("Failed to purge outbox events", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		total += deleted
+		if deleted < purgeBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		w.logger.Info("Purged dispatched outbox events", map[string]interface{}{
+			"deleted": total,
+		})
+	}
+}
@@ -3,11 +3,11 @@ package profile
 import "time"
 
 type CreateProfileRequest struct {
-	AccountID  string  `json:"account_id"`
-	FirstName  string  `json:"first_name" validate:"min=0,max=20"`
-	LastName   string  `json:"last_name" validate:"min=0,max=20"`
-	Nickname   string  `json:"nickname" validate:"min=0,max=20"`
-	AvatarPath *string `json:"avatar_path,omitempty"`
+	AccountID string `json:"account_id"`
+	FirstName string `json:"first_name" validate:"min=0,max=20"`
+	LastName  string `json:"last_name" validate:"min=0,max=20"`
+	Nickname  string `json:"nickname" validate:"min=0,max=20"`
+	Timezone  string `json:"timezone" validate:"omitempty,timezone"`
 }
 
 type CreateProfileResponse struct {
@@ -20,29 +20,36 @@ type GetProfileByAccountIDRequest struct {
 }
 
 type GetProfileByAccountIDResponse struct {
-	AccountID  string    `json:"account_id"`
-	FirstName  string    `json:"first_name" validate:"min=0,max=20"`
-	LastName   string    `json:"last_name" validate:"min=0,max=20"`
-	Nickname   string    `json:"nickname" validate:"min=0,max=20"`
-	AvatarPath *string   `json:"avatar_path,omitempty"`
-	State      string    `json:"state"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	AccountID  string            `json:"account_id"`
+	FirstName  string            `json:"first_name" validate:"min=0,max=20"`
+	LastName   string            `json:"last_name" validate:"min=0,max=20"`
+	Nickname   string            `json:"nickname" validate:"min=0,max=20"`
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty"`
+	Timezone   string            `json:"timezone"`
+	State      string            `json:"state"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
 }
 
 type UpdateProfileRequest struct {
-	AccountID  string  `json:"account_id"`
-	FirstName  string  `json:"first_name" validate:"min=0,max=20"`
-	LastName   string  `json:"last_name" validate:"min=0,max=20"`
-	Nickname   string  `json:"nickname" validate:"min=0,max=20"`
-	AvatarPath *string `json:"avatar_path,omitempty"`
+	AccountID string `json:"account_id"`
+	FirstName string `json:"first_name" validate:"min=0,max=20"`
+	LastName  string `json:"last_name" validate:"min=0,max=20"`
+	Nickname  string `json:"nickname" validate:"min=0,max=20"`
+	// Timezone keeps the current zone when empty
+	Timezone string `json:"timezone" validate:"omitempty,timezone"`
 }
 
 type UpdateProfileResponse struct {
-	AccountID  string  `json:"account_id"`
-	ProfileID  string  `json:"profile_id"`
-	FirstName  string  `json:"first_name" validate:"min=0,max=20"`
-	LastName   string  `json:"last_name" validate:"min=0,max=20"`
-	Nickname   string  `json:"nickname" validate:"min=0,max=20"`
-	AvatarPath *string `json:"avatar_path,omitempty"`
+	AccountID  string            `json:"account_id"`
+	ProfileID  string            `json:"profile_id"`
+	FirstName  string            `json:"first_name" validate:"min=0,max=20"`
+	LastName   string            `json:"last_name" validate:"min=0,max=20"`
+	Nickname   string            `json:"nickname" validate:"min=0,max=20"`
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty"`
+	Timezone   string            `json:"timezone"`
+}
+
+type AvatarResponse struct {
+	AvatarURLs map[string]string `json:"avatar_urls"`
 }
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/profile"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+)
+
+type UploadAvatarUseCase struct {
+	profileService *service.ProfileService
+	logger         logger.Logger
+}
+
+func NewUploadAvatarUseCase(svc *service.ProfileService, l logger.Logger) *UploadAvatarUseCase {
+	return &UploadAvatarUseCase{
+		profileService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *UploadAvatarUseCase) Execute(ctx context.Context, accountID string, file io.Reader) (*profile.AvatarResponse, error) {
+	if file == nil {
+		return nil, apperror.NewBadRequestError("avatar file is required", "MISSING_AVATAR", nil)
+	}
+
+	prof, err := uc.profileService.UploadAvatar(ctx, accountID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	urls, err := uc.profileService.AvatarURLs(ctx, prof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile.AvatarResponse{AvatarURLs: urls}, nil
+}
+
+type DeleteAvatarUseCase struct {
+	profileService *service.ProfileService
+	logger         logger.Logger
+}
+
+func NewDeleteAvatarUseCase(svc *service.ProfileService, l logger.Logger) *DeleteAvatarUseCase {
+	return &DeleteAvatarUseCase{
+		profileService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *DeleteAvatarUseCase) Execute(ctx context.Context, accountID string) error {
+	_, err := uc.profileService.DeleteAvatar(ctx, accountID)
+	return err
+}
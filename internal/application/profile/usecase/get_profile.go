@@ -33,6 +33,11 @@ func (uc *GetProfileUseCase) Execute(ctx context.Context, req *profile.GetProfil
 		return nil, err
 	}
 
+	avatarURLs, err := uc.profileService.AvatarURLs(ctx, prof)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert UUID to string with prefix
 	accountID := utils.ShortUUIDWithPrefix(prof.AccountID, accountEntity.AccountIDPrefix)
 
@@ -41,7 +46,8 @@ func (uc *GetProfileUseCase) Execute(ctx context.Context, req *profile.GetProfil
 		FirstName:  prof.FirstName,
 		LastName:   prof.LastName,
 		Nickname:   prof.Nickname,
-		AvatarPath: prof.AvatarPath,
+		AvatarURLs: avatarURLs,
+		Timezone:   prof.Timezone,
 		State:      prof.State,
 		CreatedAt:  prof.CreatedAt,
 		UpdatedAt:  prof.UpdatedAt,
@@ -34,6 +34,11 @@ func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req *profile.Update
 		return nil, err
 	}
 
+	avatarURLs, err := uc.profileService.AvatarURLs(ctx, prof)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert UUID to string with prefix
 	accountID := utils.ShortUUIDWithPrefix(prof.AccountID, accountEntity.AccountIDPrefix)
 	profileID := utils.ShortUUIDWithPrefix(prof.ID, entity.ProfileIDPrefix)
@@ -44,7 +49,8 @@ func (uc *UpdateProfileUseCase) Execute(ctx context.Context, req *profile.Update
 		FirstName:  prof.FirstName,
 		LastName:   prof.LastName,
 		Nickname:   prof.Nickname,
-		AvatarPath: prof.AvatarPath,
+		AvatarURLs: avatarURLs,
+		Timezone:   prof.Timezone,
 	}
 	return res, nil
 }
@@ -53,3 +53,38 @@ type UpdateProjectResponse struct {
 type DeleteProjectResponse struct {
 	ProjectID string `json:"project_id"`
 }
+
+type ProjectConfigSchemaResponse struct {
+	ProjectID string          `json:"project_id"`
+	Version   int             `json:"version"`
+	Schema    json.RawMessage `json:"schema"`
+}
+
+type GetProjectStatsRequest struct {
+	ProjectID    string `query:"-"`
+	From         string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To           string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	DueSoonHours *int   `query:"due_soon_hours" validate:"omitempty,min=1,max=720"`
+}
+
+type BurndownPointResponse struct {
+	Date      string `json:"date"`
+	Scope     int64  `json:"scope"`
+	Completed int64  `json:"completed"`
+	Remaining int64  `json:"remaining"`
+}
+
+type ProjectStatsResponse struct {
+	ProjectID      string                  `json:"project_id"`
+	From           string                  `json:"from"`
+	To             string                  `json:"to"`
+	Total          int64                   `json:"total"`
+	Completed      int64                   `json:"completed"`
+	Open           int64                   `json:"open"`
+	CompletionRate float64                 `json:"completion_rate"`
+	Overdue        int64                   `json:"overdue"`
+	DueSoon        int64                   `json:"due_soon"`
+	ByStatus       map[string]int64        `json:"by_status"`
+	ByPriority     map[string]int64        `json:"by_priority"`
+	Burndown       []BurndownPointResponse `json:"burndown"`
+}
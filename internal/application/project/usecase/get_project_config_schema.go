@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/project"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+)
+
+type GetProjectConfigSchemaUseCase struct {
+	projectService *service.ProjectService
+	logger         logger.Logger
+}
+
+func NewGetProjectConfigSchemaUseCase(svc *service.ProjectService, l logger.Logger) *GetProjectConfigSchemaUseCase {
+	return &GetProjectConfigSchemaUseCase{
+		projectService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *GetProjectConfigSchemaUseCase) Execute(ctx context.Context, id string) (*project.ProjectConfigSchemaResponse, error) {
+	projectID, err := utils.ParseID(id, entity.ProjectIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
+	}
+
+	proj, err := uc.projectService.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &project.ProjectConfigSchemaResponse{
+		ProjectID: utils.ShortUUIDWithPrefix(proj.ID, entity.ProjectIDPrefix),
+		Version:   projects.CurrentConfigVersion,
+		Schema:    projects.ConfigSchema(),
+	}, nil
+}
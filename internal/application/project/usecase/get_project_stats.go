@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/project"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+)
+
+const (
+	statsDateLayout      = "2006-01-02"
+	defaultStatsDays     = 14
+	defaultDueSoonWindow = 48 * time.Hour
+)
+
+type GetProjectStatsUseCase struct {
+	projectService *service.ProjectService
+	logger         logger.Logger
+}
+
+func NewGetProjectStatsUseCase(svc *service.ProjectService, l logger.Logger) *GetProjectStatsUseCase {
+	return &GetProjectStatsUseCase{
+		projectService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *GetProjectStatsUseCase) Execute(ctx context.Context, req *project.GetProjectStatsRequest) (*project.ProjectStatsResponse, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request", "INVALID_REQUEST", nil)
+	}
+
+	projectID, err := utils.ParseID(req.ProjectID, entity.ProjectIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
+	}
+
+	// Default to the last two weeks, ending today
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today
+	if req.To != "" {
+		if to, err = time.Parse(statsDateLayout, req.To); err != nil {
+			return nil, apperror.NewBadRequestError("invalid to date format", "INVALID_DATE_FORMAT", err)
+		}
+	}
+	from := to.AddDate(0, 0, -(defaultStatsDays - 1))
+	if req.From != "" {
+		if from, err = time.Parse(statsDateLayout, req.From); err != nil {
+			return nil, apperror.NewBadRequestError("invalid from date format", "INVALID_DATE_FORMAT", err)
+		}
+	}
+
+	dueSoon := defaultDueSoonWindow
+	if req.DueSoonHours != nil {
+		dueSoon = time.Duration(*req.DueSoonHours) * time.Hour
+	}
+
+	stats, err := uc.projectService.GetProjectStats(ctx, projectID, from, to, dueSoon)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &project.ProjectStatsResponse{
+		ProjectID:  utils.ShortUUIDWithPrefix(projectID, entity.ProjectIDPrefix),
+		From:       from.Format(statsDateLayout),
+		To:         to.Format(statsDateLayout),
+		Total:      stats.Tasks.Total,
+		Completed:  stats.Tasks.Completed,
+		Open:       stats.Tasks.Total - stats.Tasks.Completed,
+		Overdue:    stats.Tasks.Overdue,
+		DueSoon:    stats.Tasks.DueSoon,
+		ByStatus:   groupCountsToMap(stats.Tasks.ByStatus),
+		ByPriority: groupCountsToMap(stats.Tasks.ByPriority),
+		Burndown:   make([]project.BurndownPointResponse, len(stats.Burndown)),
+	}
+
+	if stats.Tasks.Total > 0 {
+		rate := float64(stats.Tasks.Completed) / float64(stats.Tasks.Total)
+		res.CompletionRate = math.Round(rate*10000) / 10000
+	}
+
+	for i, p := range stats.Burndown {
+		res.Burndown[i] = project.BurndownPointResponse{
+			Date:      p.Date.Format(statsDateLayout),
+			Scope:     p.Scope,
+			Completed: p.Completed,
+			Remaining: p.Remaining(),
+		}
+	}
+
+	return res, nil
+}
+
+func groupCountsToMap(counts []tasks.GroupCount) map[string]int64 {
+	m := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		m[c.Key] = c.Count
+	}
+	return m
+}
@@ -0,0 +1,12 @@
+package realtime
+
+import "time"
+
+// StreamTicketResponse carries a ticket that opens the event stream of a
+// project without the Authorization header, and the URL to open it at
+type StreamTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// URL is the path of the stream with the ticket, relative to the API
+	URL string `json:"url"`
+}
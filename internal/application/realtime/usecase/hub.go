@@ -0,0 +1,477 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// streamBuffer is how far a stream may fall behind its client. A stream that
+// falls further is closed, and the client resumes from its cursor.
+const streamBuffer = 64
+
+// notificationTimeout bounds loading what one notification refers to
+const notificationTimeout = 5 * time.Second
+
+// sessionCheckInterval is how often the sessions of open streams are checked,
+// so a revoked session or suspended account does not keep receiving events
+const sessionCheckInterval = time.Minute
+
+// SessionValidator rejects the claims of a revoked session or inactive account
+type SessionValidator func(ctx context.Context, claims *accounts.AccessClaims) error
+
+// Stream is one client following the events of a project
+type Stream struct {
+	viewer    *entity.Viewer
+	claims    *accounts.AccessClaims
+	messages  chan realtime.Message
+	done      chan struct{}
+	closeOnce sync.Once
+	// final is set before done is closed when the client must authenticate again
+	final *realtime.Message
+
+	// Guarded by Hub.mu. Until the stream has started, live messages wait in
+	// pending so they follow the replay.
+	started  bool
+	pending  []realtime.Message
+	replayed map[string]struct{}
+}
+
+// Messages are the messages to send to the client, in order
+func (s *Stream) Messages() <-chan realtime.Message {
+	return s.messages
+}
+
+// Done is closed when the stream ends: the client fell behind, the instance
+// lost the notifications or it is shutting down
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Final is the message to end the stream with once Done is closed, or nil
+func (s *Stream) Final() *realtime.Message {
+	return s.final
+}
+
+// ExpiresAt is when the access token the stream was opened with expires; the
+// stream must end then. It is zero when the token does not expire.
+func (s *Stream) ExpiresAt() time.Time {
+	if s.claims.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return s.claims.ExpiresAt.Time
+}
+
+func (s *Stream) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// Hub fans the notifications of every instance out to the streams open on
+// this one. Events reach it from the outbox trigger, after they are committed
+// and in commit order, which the outbox keeps equal to seq order; presence
+// reaches it whenever a viewer joins or leaves.
+type Hub struct {
+	realtimeService *service.RealtimeService
+	validateSession SessionValidator
+	logger          logger.Logger
+
+	mu       sync.Mutex
+	projects map[uuid.UUID]map[*Stream]struct{}
+	closed   bool
+}
+
+func NewHub(svc *service.RealtimeService, validateSession SessionValidator, l logger.Logger) *Hub {
+	return &Hub{
+		realtimeService: svc,
+		validateSession: validateSession,
+		logger:          l,
+		projects:        map[uuid.UUID]map[*Stream]struct{}{},
+	}
+}
+
+// Subscribe opens a stream of the project for the account of claims, which
+// authenticated the request. cursor is the ID of the last message the client
+// received, empty on a first connection.
+func (h *Hub) Subscribe(ctx context.Context, claims *accounts.AccessClaims, projectID, cursor string) (*Stream, error) {
+	accID, err := uuid.Parse(claims.AccountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+	parsedProjectID, err := utils.ParseID(projectID, projectEntity.ProjectIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
+	}
+	var after *int64
+	if cursor != "" {
+		seq, err := realtime.ParseCursor(cursor)
+		if err != nil {
+			return nil, apperror.NewBadRequestError("invalid cursor", "INVALID_CURSOR", err)
+		}
+		after = &seq
+	}
+
+	if err := h.realtimeService.Authorize(ctx, accID, parsedProjectID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stream := &Stream{
+		viewer: &entity.Viewer{
+			ConnectionID: uuid.New(),
+			ProjectID:    parsedProjectID,
+			AccountID:    accID,
+			Username:     claims.Username,
+			ConnectedAt:  now,
+			SeenAt:       now,
+		},
+		claims: claims,
+		// Room for a whole replay on top of the live messages
+		messages: make(chan realtime.Message, realtime.MaxReplay+streamBuffer),
+		done:     make(chan struct{}),
+		replayed: map[string]struct{}{},
+	}
+
+	// The stream is added before the replay is read, so an event committed in
+	// between arrives live if not replayed, and is skipped if it is both
+	if !h.add(stream) {
+		return nil, apperror.NewAppError("SERVER_SHUTTING_DOWN", "server is shutting down", http.StatusServiceUnavailable, nil)
+	}
+
+	start, err := h.realtimeService.Start(ctx, parsedProjectID, after)
+	if err == nil {
+		err = h.realtimeService.Join(ctx, stream.viewer)
+	}
+	if err != nil {
+		h.remove(stream)
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range start.Replayed {
+		stream.replayed[id] = struct{}{}
+	}
+	stream.started = true
+	for _, msg := range start.Messages {
+		h.push(stream, msg)
+	}
+	for _, msg := range stream.pending {
+		h.send(stream, msg)
+	}
+	stream.pending = nil
+
+	return stream, nil
+}
+
+// Unsubscribe closes the stream and unlists its viewer
+func (h *Hub) Unsubscribe(stream *Stream) {
+	h.remove(stream)
+
+	ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+	defer cancel()
+	if err := h.realtimeService.Leave(ctx, stream.viewer); err != nil {
+		h.logger.Warn("Failed to remove project viewer", map[string]interface{}{
+			"connection_id": stream.viewer.ConnectionID.String(),
+			"error":         err.Error(),
+		})
+	}
+}
+
+// HandleNotification passes a notification of the database listener to the
+// streams of its project
+func (h *Hub) HandleNotification(channel, payload string) {
+	switch channel {
+	case realtime.ChannelEvents:
+		projectID, seq, err := realtime.ParseEventNotification(payload)
+		if err != nil {
+			h.logger.Warn("Invalid event notification", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		if !h.watching(projectID) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+		msg, err := h.realtimeService.EventMessage(ctx, seq)
+		if err != nil {
+			// The streams would miss the event; they resume from their cursors instead
+			h.logger.Error("Failed to load streamed event", map[string]interface{}{
+				"seq":   seq,
+				"error": err.Error(),
+			})
+			h.closeProject(projectID)
+			return
+		}
+		if msg != nil {
+			h.broadcast(projectID, *msg)
+		}
+
+	case realtime.ChannelPresence:
+		projectID, err := uuid.Parse(payload)
+		if err != nil {
+			h.logger.Warn("Invalid presence notification", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		if !h.watching(projectID) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+		msg, err := h.realtimeService.Presence(ctx, projectID, time.Now())
+		if err != nil {
+			h.logger.Warn("Failed to load project viewers", map[string]interface{}{
+				"project_id": projectID.String(),
+				"error":      err.Error(),
+			})
+			return
+		}
+		h.broadcast(projectID, *msg)
+	}
+}
+
+// Resync closes every stream after the listener reconnected, as the
+// notifications sent meanwhile are lost. The clients resume from their cursors.
+func (h *Hub) Resync() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	count := 0
+	for _, streams := range h.projects {
+		for stream := range streams {
+			h.removeLocked(stream)
+			count++
+		}
+	}
+	h.logger.Info("Closed event streams to resync", map[string]interface{}{
+		"streams": count,
+	})
+}
+
+// Close ends every stream and refuses new ones. It is called before the server
+// shuts down, which waits for the open responses.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, streams := range h.projects {
+		for stream := range streams {
+			h.removeLocked(stream)
+		}
+	}
+}
+
+// Run keeps the viewers of this instance listed and unlists the ones of
+// instances that are gone, and closes the streams of sessions that can no
+// longer be used, until ctx is cancelled
+func (h *Hub) Run(ctx context.Context) {
+	ticker := time.NewTicker(realtime.HeartbeatInterval)
+	defer ticker.Stop()
+	sessionTicker := time.NewTicker(sessionCheckInterval)
+	defer sessionTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sessionTicker.C:
+			h.checkSessions(ctx)
+			continue
+		case <-ticker.C:
+		}
+
+		tickCtx, cancel := context.WithTimeout(ctx, realtime.HeartbeatInterval)
+		now := time.Now()
+		if viewers := h.viewers(); len(viewers) > 0 {
+			if err := h.realtimeService.Touch(tickCtx, viewers, now); err != nil {
+				h.logger.Error("Failed to touch project viewers", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+		if err := h.realtimeService.SweepStale(tickCtx, now); err != nil {
+			h.logger.Error("Failed to sweep stale project viewers", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		cancel()
+	}
+}
+
+// checkSessions closes the streams whose session was revoked, or whose account
+// was deactivated or suspended, since they were opened. A session that cannot
+// be checked keeps its streams.
+func (h *Hub) checkSessions(ctx context.Context) {
+	if h.validateSession == nil {
+		return
+	}
+
+	for sessionID, claims := range h.sessions() {
+		checkCtx, cancel := context.WithTimeout(ctx, notificationTimeout)
+		err := h.validateSession(checkCtx, claims)
+		cancel()
+		if err == nil {
+			continue
+		}
+		if appErr, ok := apperror.IsAppError(err); !ok || appErr.Status != http.StatusUnauthorized {
+			h.logger.Warn("Failed to check the session of event streams", map[string]interface{}{
+				"session_id": sessionID,
+				"error":      err.Error(),
+			})
+			continue
+		}
+		h.closeSession(sessionID, realtime.ClosedMessage(realtime.ClosedSessionRevoked))
+	}
+}
+
+// sessions returns the claims of one stream per session with open streams
+func (h *Hub) sessions() map[string]*accounts.AccessClaims {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sessions := map[string]*accounts.AccessClaims{}
+	for _, streams := range h.projects {
+		for stream := range streams {
+			sessions[stream.claims.SessionID] = stream.claims
+		}
+	}
+	return sessions
+}
+
+// closeSession ends the streams of the session with final
+func (h *Hub) closeSession(sessionID string, final realtime.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, streams := range h.projects {
+		for stream := range streams {
+			if stream.claims.SessionID == sessionID {
+				stream.final = &final
+				h.removeLocked(stream)
+			}
+		}
+	}
+}
+
+func (h *Hub) add(stream *Stream) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return false
+	}
+	streams, ok := h.projects[stream.viewer.ProjectID]
+	if !ok {
+		streams = map[*Stream]struct{}{}
+		h.projects[stream.viewer.ProjectID] = streams
+	}
+	streams[stream] = struct{}{}
+	metrics.RealtimeStreams.Inc()
+	return true
+}
+
+func (h *Hub) remove(stream *Stream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(stream)
+}
+
+func (h *Hub) removeLocked(stream *Stream) {
+	stream.close()
+
+	streams := h.projects[stream.viewer.ProjectID]
+	if _, ok := streams[stream]; !ok {
+		return
+	}
+	delete(streams, stream)
+	if len(streams) == 0 {
+		delete(h.projects, stream.viewer.ProjectID)
+	}
+	metrics.RealtimeStreams.Dec()
+}
+
+func (h *Hub) closeProject(projectID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for stream := range h.projects[projectID] {
+		h.removeLocked(stream)
+	}
+}
+
+func (h *Hub) watching(projectID uuid.UUID) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.projects[projectID]) > 0
+}
+
+func (h *Hub) broadcast(projectID uuid.UUID, msg realtime.Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for stream := range h.projects[projectID] {
+		h.send(stream, msg)
+	}
+}
+
+// send queues msg on the stream, closing the stream when its client fell too
+// far behind. h.mu must be held.
+func (h *Hub) send(stream *Stream, msg realtime.Message) {
+	if !stream.started {
+		if len(stream.pending) >= streamBuffer {
+			h.removeLocked(stream)
+			return
+		}
+		stream.pending = append(stream.pending, msg)
+		return
+	}
+	if _, ok := stream.replayed[msg.ID]; ok {
+		// Sent with the replay already
+		return
+	}
+	h.push(stream, msg)
+}
+
+func (h *Hub) push(stream *Stream, msg realtime.Message) {
+	select {
+	case stream.messages <- msg:
+	default:
+		h.logger.Warn("Closed event stream that fell behind", map[string]interface{}{
+			"connection_id": stream.viewer.ConnectionID.String(),
+		})
+		h.removeLocked(stream)
+	}
+}
+
+// viewers copies the viewers of the open streams
+func (h *Hub) viewers() []*entity.Viewer {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var list []*entity.Viewer
+	for _, streams := range h.projects {
+		for stream := range streams {
+			if stream.started {
+				list = append(list, lo.ToPtr(*stream.viewer))
+			}
+		}
+	}
+	return list
+}
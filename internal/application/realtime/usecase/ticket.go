@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/realtime"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+// StreamTicketQuery is the query parameter a stream ticket is sent in
+const StreamTicketQuery = "ticket"
+
+// StreamPath is the path of the event stream of a project
+func StreamPath(projectID uuid.UUID) string {
+	return "/api/projects/" + utils.ShortUUIDWithPrefix(projectID, projectEntity.ProjectIDPrefix) + "/events"
+}
+
+type CreateStreamTicketUseCase struct {
+	realtimeService *service.RealtimeService
+	secret          string
+	logger          logger.Logger
+}
+
+func NewCreateStreamTicketUseCase(svc *service.RealtimeService, secret string, l logger.Logger) *CreateStreamTicketUseCase {
+	return &CreateStreamTicketUseCase{
+		realtimeService: svc,
+		secret:          secret,
+		logger:          l,
+	}
+}
+
+// Execute issues a ticket for the event stream of the project on behalf of the
+// access token with claims. The stream it opens ends when that token expires.
+func (u *CreateStreamTicketUseCase) Execute(ctx context.Context, claims *accounts.AccessClaims, projectID string) (*realtime.StreamTicketResponse, error) {
+	accID, err := uuid.Parse(claims.AccountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+	parsedProjectID, err := utils.ParseID(projectID, projectEntity.ProjectIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
+	}
+
+	if err := u.realtimeService.Authorize(ctx, accID, parsedProjectID); err != nil {
+		return nil, err
+	}
+
+	path := StreamPath(parsedProjectID)
+	ticket, expiresAt, err := accounts.SignStreamTicket(u.secret, claims, path, time.Now())
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to sign stream ticket", "SIGN_TICKET_ERROR", err)
+	}
+
+	return &realtime.StreamTicketResponse{
+		Ticket:    ticket,
+		ExpiresAt: expiresAt,
+		URL:       path + "?" + url.Values{StreamTicketQuery: {ticket}}.Encode(),
+	}, nil
+}
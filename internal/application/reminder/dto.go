@@ -0,0 +1,59 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
+)
+
+type CreateReminderRequest struct {
+	// OffsetMinutes is how long before each occurrence of the task to remind
+	OffsetMinutes *int   `json:"offset_minutes" validate:"required,min=0,max=43200"`
+	Channel       string `json:"channel" validate:"required,oneof=in_app email webhook"`
+	// WebhookURL is required for the webhook channel and ignored otherwise
+	WebhookURL string `json:"webhook_url" validate:"omitempty,max=2048"`
+}
+
+// ReminderResponse describes a reminder; its times are in the zone of the account.
+// WebhookSecret is only returned when a webhook reminder is created.
+type ReminderResponse struct {
+	ID            string  `json:"id"`
+	TaskID        string  `json:"task_id"`
+	OffsetMinutes int     `json:"offset_minutes"`
+	Channel       string  `json:"channel"`
+	WebhookURL    *string `json:"webhook_url,omitempty"`
+	WebhookSecret *string `json:"webhook_secret,omitempty"`
+	Status        string  `json:"status"`
+	// RemindAt and OccurrenceStart are omitted while the task has no upcoming occurrence
+	RemindAt        *time.Time `json:"remind_at,omitempty"`
+	OccurrenceStart *time.Time `json:"occurrence_start,omitempty"`
+	LastSentAt      *time.Time `json:"last_sent_at,omitempty"`
+	LastError       *string    `json:"last_error,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type ListRemindersResponse struct {
+	Items []ReminderResponse `json:"items"`
+}
+
+type ListNotificationsRequest struct {
+	Limit  *int `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset *int `query:"offset" validate:"omitempty,min=0"`
+	Unread bool `query:"unread"`
+}
+
+type NotificationResponse struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"task_id,omitempty"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type ListNotificationsResponse struct {
+	Items       []NotificationResponse `json:"items"`
+	UnreadCount int                    `json:"unread_count"`
+	Pagination  common.Pagination      `json:"pagination"`
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/reminder"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/service"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+type ListNotificationsUseCase struct {
+	notificationService *service.NotificationService
+	logger              logger.Logger
+}
+
+func NewListNotificationsUseCase(svc *service.NotificationService, l logger.Logger) *ListNotificationsUseCase {
+	return &ListNotificationsUseCase{
+		notificationService: svc,
+		logger:              l,
+	}
+}
+
+func (uc *ListNotificationsUseCase) Execute(ctx context.Context, accountID string, req *reminder.ListNotificationsRequest) (*reminder.ListNotificationsResponse, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	limit, offset := common.ValidatePagination(req.Limit, req.Offset)
+
+	list, total, unread, err := uc.notificationService.ListNotifications(ctx, accID, req.Unread, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]reminder.NotificationResponse, 0, len(list))
+	for _, n := range list {
+		items = append(items, toNotificationResponse(n))
+	}
+
+	return &reminder.ListNotificationsResponse{
+		Items:       items,
+		UnreadCount: unread,
+		Pagination: common.Pagination{
+			Total:   total,
+			Limit:   limit,
+			Offset:  offset,
+			HasMore: common.CalculateHasMore(offset, limit, total),
+		},
+	}, nil
+}
+
+type MarkNotificationReadUseCase struct {
+	notificationService *service.NotificationService
+	logger              logger.Logger
+}
+
+func NewMarkNotificationReadUseCase(svc *service.NotificationService, l logger.Logger) *MarkNotificationReadUseCase {
+	return &MarkNotificationReadUseCase{
+		notificationService: svc,
+		logger:              l,
+	}
+}
+
+func (uc *MarkNotificationReadUseCase) Execute(ctx context.Context, accountID, notificationID string) error {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	parsedID, err := utils.ParseID(notificationID, entity.NotificationIDPrefix)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid notification ID format", "INVALID_NOTIFICATION_ID", err)
+	}
+
+	return uc.notificationService.MarkNotificationRead(ctx, accID, parsedID)
+}
+
+type MarkAllNotificationsReadUseCase struct {
+	notificationService *service.NotificationService
+	logger              logger.Logger
+}
+
+func NewMarkAllNotificationsReadUseCase(svc *service.NotificationService, l logger.Logger) *MarkAllNotificationsReadUseCase {
+	return &MarkAllNotificationsReadUseCase{
+		notificationService: svc,
+		logger:              l,
+	}
+}
+
+func (uc *MarkAllNotificationsReadUseCase) Execute(ctx context.Context, accountID string) error {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	return uc.notificationService.MarkAllNotificationsRead(ctx, accID)
+}
+
+func toNotificationResponse(n *entity.Notification) reminder.NotificationResponse {
+	res := reminder.NotificationResponse{
+		ID:        utils.ShortUUIDWithPrefix(n.ID, entity.NotificationIDPrefix),
+		Title:     n.Title,
+		Body:      n.Body,
+		Read:      n.IsRead(),
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.TaskID != nil {
+		res.TaskID = utils.ShortUUIDWithPrefix(*n.TaskID, taskEntity.TaskIDPrefix)
+	}
+	return res
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/reminder"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/service"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type CreateReminderUseCase struct {
+	reminderService *service.ReminderService
+	profileService  *profileSvc.ProfileService
+	logger          logger.Logger
+}
+
+func NewCreateReminderUseCase(svc *service.ReminderService, ps *profileSvc.ProfileService, l logger.Logger) *CreateReminderUseCase {
+	return &CreateReminderUseCase{
+		reminderService: svc,
+		profileService:  ps,
+		logger:          l,
+	}
+}
+
+func (uc *CreateReminderUseCase) Execute(ctx context.Context, accountID, taskID string, req *reminder.CreateReminderRequest) (*reminder.ReminderResponse, error) {
+	if req == nil || req.OffsetMinutes == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, parsedTaskID, err := parseTaskIDs(accountID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.reminderService.CreateReminder(ctx, accID, parsedTaskID, *req.OffsetMinutes, req.Channel, req.WebhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Reminder created", map[string]interface{}{
+		"reminder_id": created.ID.String(),
+		"task_id":     taskID,
+		"channel":     created.Channel,
+	})
+
+	loc, err := uc.profileService.GetLocation(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toReminderResponse(created, loc)
+	// The secret is only shown once
+	res.WebhookSecret = created.WebhookSecret
+	return &res, nil
+}
+
+type ListRemindersUseCase struct {
+	reminderService *service.ReminderService
+	profileService  *profileSvc.ProfileService
+	logger          logger.Logger
+}
+
+func NewListRemindersUseCase(svc *service.ReminderService, ps *profileSvc.ProfileService, l logger.Logger) *ListRemindersUseCase {
+	return &ListRemindersUseCase{
+		reminderService: svc,
+		profileService:  ps,
+		logger:          l,
+	}
+}
+
+func (uc *ListRemindersUseCase) Execute(ctx context.Context, accountID, taskID string) (*reminder.ListRemindersResponse, error) {
+	accID, parsedTaskID, err := parseTaskIDs(accountID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := uc.reminderService.ListReminders(ctx, accID, parsedTaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := uc.profileService.GetLocation(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]reminder.ReminderResponse, 0, len(list))
+	for _, r := range list {
+		items = append(items, toReminderResponse(r, loc))
+	}
+	return &reminder.ListRemindersResponse{Items: items}, nil
+}
+
+type DeleteReminderUseCase struct {
+	reminderService *service.ReminderService
+	logger          logger.Logger
+}
+
+func NewDeleteReminderUseCase(svc *service.ReminderService, l logger.Logger) *DeleteReminderUseCase {
+	return &DeleteReminderUseCase{
+		reminderService: svc,
+		logger:          l,
+	}
+}
+
+func (uc *DeleteReminderUseCase) Execute(ctx context.Context, accountID, taskID, reminderID string) error {
+	accID, parsedTaskID, err := parseTaskIDs(accountID, taskID)
+	if err != nil {
+		return err
+	}
+
+	parsedReminderID, err := utils.ParseID(reminderID, entity.ReminderIDPrefix)
+	if err != nil {
+		return apperror.NewBadRequestError("invalid reminder ID format", "INVALID_REMINDER_ID", err)
+	}
+
+	return uc.reminderService.DeleteReminder(ctx, accID, parsedTaskID, parsedReminderID)
+}
+
+func parseTaskIDs(accountID, taskID string) (uuid.UUID, uuid.UUID, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	parsedTaskID, err := utils.ParseID(taskID, taskEntity.TaskIDPrefix)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid task ID format", "INVALID_TASK_ID", err)
+	}
+
+	return accID, parsedTaskID, nil
+}
+
+func toReminderResponse(r *entity.Reminder, loc *time.Location) reminder.ReminderResponse {
+	in := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		return lo.ToPtr(t.In(loc))
+	}
+
+	return reminder.ReminderResponse{
+		ID:              utils.ShortUUIDWithPrefix(r.ID, entity.ReminderIDPrefix),
+		TaskID:          utils.ShortUUIDWithPrefix(r.TaskID, taskEntity.TaskIDPrefix),
+		OffsetMinutes:   r.OffsetMinutes,
+		Channel:         r.Channel,
+		WebhookURL:      r.WebhookURL,
+		Status:          r.Status,
+		RemindAt:        in(r.RemindAt),
+		OccurrenceStart: in(r.OccurrenceStart),
+		LastSentAt:      in(r.SentAt),
+		LastError:       r.LastError,
+		CreatedAt:       r.CreatedAt.In(loc),
+	}
+}
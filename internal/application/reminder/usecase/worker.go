@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+)
+
+// WorkerConfig tunes the reminder worker
+type WorkerConfig struct {
+	// Interval is how often due reminders are looked for
+	Interval time.Duration
+	// BatchSize is how many reminders are claimed at once
+	BatchSize int
+	// Lease is how long a claim lasts; it must cover delivering a whole batch
+	Lease time.Duration
+}
+
+// ReminderWorker delivers due reminders in the background. Every instance of
+// the API runs one; the claims in ReminderService.DispatchDue keep them from
+// delivering the same reminder.
+type ReminderWorker struct {
+	reminderService *service.ReminderService
+	config          WorkerConfig
+	logger          logger.Logger
+}
+
+func NewReminderWorker(svc *service.ReminderService, cfg WorkerConfig, l logger.Logger) *ReminderWorker {
+	return &ReminderWorker{
+		reminderService: svc,
+		config:          cfg,
+		logger:          l,
+	}
+}
+
+// Run delivers reminders until ctx is cancelled. A batch in progress is
+// finished first, so no claim is left to expire.
+func (w *ReminderWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.dispatchDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchDue claims batches until the due reminders run out
+func (w *ReminderWorker) dispatchDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		batchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), w.config.Lease)
+		results, err := w.reminderService.DispatchDue(batchCtx, time.Now(), w.config.BatchSize, w.config.Lease)
+		cancel()
+		if err != nil {
+			w.logger.Error("Failed to dispatch reminders", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		for _, r := range results {
+			metrics.RemindersDispatched.WithLabelValues(r.Channel, r.Outcome).Inc()
+			if r.Err != nil {
+				w.logger.Warn("Failed to deliver reminder", map[string]interface{}{
+					"reminder_id": r.ReminderID.String(),
+					"channel":     r.Channel,
+					"outcome":     r.Outcome,
+					"error":       r.Err.Error(),
+				})
+			}
+		}
+
+		if len(results) < w.config.BatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package search
+
+import (
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
+)
+
+type SearchRequest struct {
+	Q      string `query:"q" validate:"required"`
+	Type   string `query:"type" validate:"omitempty,oneof=task project"`
+	Limit  *int   `query:"limit" validate:"omitempty,min=1,max=50"`
+	Offset *int   `query:"offset" validate:"omitempty,min=0"`
+}
+
+// SearchResultResponse is a task or project matching the query. Highlights
+// holds the fields that matched as HTML: the text is escaped and the matches
+// are wrapped in <mark>. name is always there; a long description is cut down
+// to a snippet around the first match.
+type SearchResultResponse struct {
+	Type        string            `json:"type"`
+	ID          string            `json:"id"`
+	ProjectID   string            `json:"project_id"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description,omitempty"`
+	Location    *string           `json:"location,omitempty"`
+	Status      *string           `json:"status,omitempty"`
+	Rank        float64           `json:"rank"`
+	Highlights  map[string]string `json:"highlights"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type SearchResponse struct {
+	Items      []SearchResultResponse `json:"items"`
+	Pagination common.Pagination      `json:"pagination"`
+}
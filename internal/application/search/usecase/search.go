@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/search"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	searchDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/search"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/search/service"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+type SearchUseCase struct {
+	searchService *service.SearchService
+	logger        logger.Logger
+}
+
+func NewSearchUseCase(svc *service.SearchService, l logger.Logger) *SearchUseCase {
+	return &SearchUseCase{
+		searchService: svc,
+		logger:        l,
+	}
+}
+
+func (uc *SearchUseCase) Execute(ctx context.Context, accountID string, req *search.SearchRequest) (*search.SearchResponse, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	var kinds []string
+	if req.Type != "" {
+		kinds = []string{req.Type}
+	}
+	limit, offset := common.ValidatePagination(req.Limit, req.Offset)
+
+	matches, total, err := uc.searchService.Search(ctx, accID, req.Q, kinds, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]search.SearchResultResponse, 0, len(matches))
+	for _, m := range matches {
+		items = append(items, toSearchResultResponse(m))
+	}
+
+	return &search.SearchResponse{
+		Items: items,
+		Pagination: common.Pagination{
+			Total:   total,
+			Limit:   limit,
+			Offset:  offset,
+			HasMore: common.CalculateHasMore(offset, limit, total),
+		},
+	}, nil
+}
+
+func toSearchResultResponse(m *service.Match) search.SearchResultResponse {
+	id := utils.ShortUUIDWithPrefix(m.ID, projectEntity.ProjectIDPrefix)
+	if m.Kind == searchDomain.KindTask {
+		id = utils.ShortUUIDWithPrefix(m.ID, taskEntity.TaskIDPrefix)
+	}
+
+	return search.SearchResultResponse{
+		Type:        m.Kind,
+		ID:          id,
+		ProjectID:   utils.ShortUUIDWithPrefix(m.ProjectID, projectEntity.ProjectIDPrefix),
+		Name:        m.Name,
+		Description: m.Description,
+		Location:    m.Location,
+		Status:      m.Status,
+		Rank:        m.Rank,
+		Highlights:  m.Highlights,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
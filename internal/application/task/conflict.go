@@ -0,0 +1,49 @@
+package task
+
+import (
+	"time"
+
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+)
+
+// NewScheduleConflicts converts conflicts for responses and error details,
+// with times in loc
+func NewScheduleConflicts(conflicts []tasks.Conflict, loc *time.Location) []ScheduleConflict {
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	result := make([]ScheduleConflict, len(conflicts))
+	for i, c := range conflicts {
+		result[i] = ScheduleConflict{
+			TaskID:          utils.ShortUUIDWithPrefix(c.TaskID, entity.TaskIDPrefix),
+			ProjectID:       utils.ShortUUIDWithPrefix(c.ProjectID, projectEntity.ProjectIDPrefix),
+			Name:            c.Name,
+			Start:           c.Start.In(loc),
+			End:             c.End.In(loc),
+			OccurrenceStart: c.OccurrenceStart.In(loc),
+		}
+	}
+	return result
+}
+
+// ConflictErrorIn renders the conflicts listed by a SCHEDULE_CONFLICT error in loc;
+// other errors are returned unchanged
+func ConflictErrorIn(err error, loc *time.Location) error {
+	appErr, ok := apperror.IsAppError(err)
+	if !ok {
+		return err
+	}
+	if conflicts, ok := appErr.Details.([]ScheduleConflict); ok {
+		for i := range conflicts {
+			conflicts[i].Start = conflicts[i].Start.In(loc)
+			conflicts[i].End = conflicts[i].End.In(loc)
+			conflicts[i].OccurrenceStart = conflicts[i].OccurrenceStart.In(loc)
+		}
+	}
+	return err
+}
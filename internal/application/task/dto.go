@@ -6,15 +6,26 @@ import (
 	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
 )
 
+// Task sources, used to measure how many AI suggestions are accepted. The
+// source of a created task is whatever the client sends, so the measure is
+// only as honest as the clients.
+const (
+	TaskSourceManual       = "manual"
+	TaskSourceAISuggestion = "ai_suggestion"
+	TaskSourceICSImport    = "ics_import"
+)
+
 type CreateTaskRequest struct {
 	Name           string  `json:"name" validate:"required"`
 	Description    *string `json:"description"`
 	Priority       string  `json:"priority" validate:"required"`
 	StartDateTime  *string `json:"start_datetime"`
 	EndDateTime    *string `json:"end_datetime"`
+	AllDay         bool    `json:"all_day"`
 	Location       *string `json:"location"`
 	RecurringDays  *int    `json:"recurring_days"`
 	RecurringUntil *string `json:"recurring_until"`
+	Source         string  `json:"source" validate:"omitempty,oneof=manual ai_suggestion"`
 }
 
 type CreateTaskResponse struct {
@@ -25,9 +36,24 @@ type CreateTaskResponse struct {
 	Priority       string  `json:"priority"`
 	StartDateTime  *string `json:"start_datetime,omitempty"`
 	EndDateTime    *string `json:"end_datetime,omitempty"`
+	AllDay         bool    `json:"all_day"`
 	Location       *string `json:"location,omitempty"`
 	RecurringDays  *int    `json:"recurring_days,omitempty"`
 	RecurringUntil *string `json:"recurring_until,omitempty"`
+	// Conflicts lists the tasks of the account this one overlaps
+	Conflicts []ScheduleConflict `json:"conflicts,omitempty"`
+}
+
+// ScheduleConflict is another task occupying the same time
+type ScheduleConflict struct {
+	TaskID    string    `json:"task_id"`
+	ProjectID string    `json:"project_id"`
+	Name      string    `json:"name"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	// OccurrenceStart is the occurrence of the saved task that overlaps, which
+	// differs from its start for recurring tasks
+	OccurrenceStart time.Time `json:"occurrence_start"`
 }
 
 type GetTaskByIDResponse struct {
@@ -38,6 +64,7 @@ type GetTaskByIDResponse struct {
 	Priority       string    `json:"priority"`
 	StartDateTime  *string   `json:"start_datetime,omitempty"`
 	EndDateTime    *string   `json:"end_datetime,omitempty"`
+	AllDay         bool      `json:"all_day"`
 	Location       *string   `json:"location,omitempty"`
 	RecurringDays  *int      `json:"recurring_days,omitempty"`
 	RecurringUntil *string   `json:"recurring_until,omitempty"`
@@ -57,6 +84,7 @@ type UpdateTaskRequest struct {
 	Priority       string  `json:"priority"`
 	StartDateTime  *string `json:"start_datetime"`
 	EndDateTime    *string `json:"end_datetime"`
+	AllDay         *bool   `json:"all_day"`
 	Location       *string `json:"location"`
 	RecurringDays  *int    `json:"recurring_days"`
 	RecurringUntil *string `json:"recurring_until"`
@@ -70,7 +98,25 @@ type UpdateTaskResponse struct {
 	Priority       string  `json:"priority"`
 	StartDateTime  *string `json:"start_datetime,omitempty"`
 	EndDateTime    *string `json:"end_datetime,omitempty"`
+	AllDay         bool    `json:"all_day"`
 	Location       *string `json:"location,omitempty"`
 	RecurringDays  *int    `json:"recurring_days,omitempty"`
 	RecurringUntil *string `json:"recurring_until,omitempty"`
+	// Conflicts lists the tasks of the account this one overlaps
+	Conflicts []ScheduleConflict `json:"conflicts,omitempty"`
+}
+
+type AttachmentResponse struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	UploadedBy  string    `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ListAttachmentsResponse struct {
+	Items []AttachmentResponse `json:"items"`
 }
@@ -0,0 +1,44 @@
+package task
+
+import (
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/samber/lo"
+)
+
+// TaskTimes are the times of a task as written in responses
+type TaskTimes struct {
+	Start          *string
+	End            *string
+	RecurringUntil *string
+}
+
+// FormatTaskTimes renders the times of t as RFC 3339 in loc. All-day tasks are
+// dates, the same in every zone, and end on their last day.
+func FormatTaskTimes(t *entity.Task, loc *time.Location) TaskTimes {
+	format := func(v *time.Time) *string {
+		if v == nil {
+			return nil
+		}
+		var s string
+		if t.AllDay {
+			s = v.UTC().Format(tasks.DateLayout)
+		} else {
+			s = v.In(loc).Format(time.RFC3339)
+		}
+		return &s
+	}
+
+	end := t.EndDateTime
+	if t.AllDay && end != nil {
+		end = lo.ToPtr(end.AddDate(0, 0, -1))
+	}
+
+	return TaskTimes{
+		Start:          format(t.StartDateTime),
+		End:            format(end),
+		RecurringUntil: format(t.RecurringUntil),
+	}
+}
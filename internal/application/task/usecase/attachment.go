@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+	accountEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+// UploadAttachmentInput carries a file received by the HTTP layer
+type UploadAttachmentInput struct {
+	TaskID    string
+	AccountID string
+	FileName  string
+	Size      int64
+	File      io.Reader
+}
+
+type UploadAttachmentUseCase struct {
+	attachmentService *service.AttachmentService
+	logger            logger.Logger
+}
+
+func NewUploadAttachmentUseCase(svc *service.AttachmentService, l logger.Logger) *UploadAttachmentUseCase {
+	return &UploadAttachmentUseCase{
+		attachmentService: svc,
+		logger:            l,
+	}
+}
+
+func (uc *UploadAttachmentUseCase) Execute(ctx context.Context, in *UploadAttachmentInput) (*task.AttachmentResponse, error) {
+	parsedTaskID, err := utils.ParseID(in.TaskID, entity.TaskIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid task ID format", "INVALID_TASK_ID", err)
+	}
+
+	uploaderID, err := uuid.Parse(in.AccountID)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("invalid account", "INVALID_ACCOUNT_ID", nil)
+	}
+
+	attachment, err := uc.attachmentService.UploadAttachment(ctx, parsedTaskID, uploaderID, in.FileName, in.Size, in.File)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toAttachmentResponse(attachment)
+	return &res, nil
+}
+
+type ListAttachmentsUseCase struct {
+	attachmentService *service.AttachmentService
+	logger            logger.Logger
+}
+
+func NewListAttachmentsUseCase(svc *service.AttachmentService, l logger.Logger) *ListAttachmentsUseCase {
+	return &ListAttachmentsUseCase{
+		attachmentService: svc,
+		logger:            l,
+	}
+}
+
+func (uc *ListAttachmentsUseCase) Execute(ctx context.Context, taskID string) (*task.ListAttachmentsResponse, error) {
+	parsedTaskID, err := utils.ParseID(taskID, entity.TaskIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid task ID format", "INVALID_TASK_ID", err)
+	}
+
+	attachments, err := uc.attachmentService.ListAttachments(ctx, parsedTaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]task.AttachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		items = append(items, toAttachmentResponse(a))
+	}
+
+	return &task.ListAttachmentsResponse{Items: items}, nil
+}
+
+type DownloadAttachmentUseCase struct {
+	attachmentService *service.AttachmentService
+	logger            logger.Logger
+}
+
+func NewDownloadAttachmentUseCase(svc *service.AttachmentService, l logger.Logger) *DownloadAttachmentUseCase {
+	return &DownloadAttachmentUseCase{
+		attachmentService: svc,
+		logger:            l,
+	}
+}
+
+// Execute returns the attachment metadata and its content; the caller must close the reader
+func (uc *DownloadAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID string) (*task.AttachmentResponse, io.ReadCloser, error) {
+	parsedTaskID, parsedAttachmentID, err := parseAttachmentIDs(taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, body, err := uc.attachmentService.OpenAttachment(ctx, parsedTaskID, parsedAttachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := toAttachmentResponse(attachment)
+	return &res, body, nil
+}
+
+type DeleteAttachmentUseCase struct {
+	attachmentService *service.AttachmentService
+	logger            logger.Logger
+}
+
+func NewDeleteAttachmentUseCase(svc *service.AttachmentService, l logger.Logger) *DeleteAttachmentUseCase {
+	return &DeleteAttachmentUseCase{
+		attachmentService: svc,
+		logger:            l,
+	}
+}
+
+func (uc *DeleteAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID string) error {
+	parsedTaskID, parsedAttachmentID, err := parseAttachmentIDs(taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.attachmentService.DeleteAttachment(ctx, parsedTaskID, parsedAttachmentID)
+	var orphaned *service.OrphanedObjectsError
+	if errors.As(err, &orphaned) {
+		uc.logger.WarnContext(ctx, "Failed to delete attachment object", map[string]interface{}{
+			"keys":  orphaned.Keys,
+			"error": orphaned.Err.Error(),
+		})
+		return nil
+	}
+
+	return err
+}
+
+func parseAttachmentIDs(taskID, attachmentID string) (uuid.UUID, uuid.UUID, error) {
+	parsedTaskID, err := utils.ParseID(taskID, entity.TaskIDPrefix)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid task ID format", "INVALID_TASK_ID", err)
+	}
+
+	parsedAttachmentID, err := utils.ParseID(attachmentID, entity.AttachmentIDPrefix)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid attachment ID format", "INVALID_ATTACHMENT_ID", err)
+	}
+
+	return parsedTaskID, parsedAttachmentID, nil
+}
+
+func toAttachmentResponse(a *entity.Attachment) task.AttachmentResponse {
+	return task.AttachmentResponse{
+		ID:          utils.ShortUUIDWithPrefix(a.ID, entity.AttachmentIDPrefix),
+		TaskID:      utils.ShortUUIDWithPrefix(a.TaskID, entity.TaskIDPrefix),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		UploadedBy:  utils.ShortUUIDWithPrefix(a.UploadedBy, accountEntity.AccountIDPrefix),
+		CreatedAt:   a.CreatedAt,
+	}
+}
@@ -4,27 +4,32 @@ import (
 	"context"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
 	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
 	"github.com/FrostBitzX/smart-task-ai/internal/utils"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 )
 
 type CreateTaskUseCase struct {
-	taskService *service.TaskService
-	logger      logger.Logger
+	taskService    *service.TaskService
+	profileService *profileSvc.ProfileService
+	logger         logger.Logger
 }
 
-func NewCreateTaskUseCase(svc *service.TaskService, l logger.Logger) *CreateTaskUseCase {
+func NewCreateTaskUseCase(svc *service.TaskService, ps *profileSvc.ProfileService, l logger.Logger) *CreateTaskUseCase {
 	return &CreateTaskUseCase{
-		taskService: svc,
-		logger:      l,
+		taskService:    svc,
+		profileService: ps,
+		logger:         l,
 	}
 }
 
-func (uc *CreateTaskUseCase) Execute(ctx context.Context, projectID string, req *task.CreateTaskRequest) (*task.CreateTaskResponse, error) {
+// Execute renders the times of the task in the time zone of accountID
+func (uc *CreateTaskUseCase) Execute(ctx context.Context, accountID, projectID string, req *task.CreateTaskRequest) (*task.CreateTaskResponse, error) {
 	if req == nil {
 		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
 	}
@@ -34,25 +39,40 @@ func (uc *CreateTaskUseCase) Execute(ctx context.Context, projectID string, req
 		return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
 	}
 
-	tsk, err := uc.taskService.CreateTask(ctx, parsedProjectID, req)
+	loc, err := uc.profileService.GetLocation(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 
+	tsk, conflicts, err := uc.taskService.CreateTask(ctx, parsedProjectID, req)
+	if err != nil {
+		return nil, task.ConflictErrorIn(err, loc)
+	}
+
+	source := task.TaskSourceManual
+	if req.Source == task.TaskSourceAISuggestion {
+		source = task.TaskSourceAISuggestion
+		metrics.AITaskSuggestionsAccepted.Inc()
+	}
+	metrics.TasksCreated.WithLabelValues(source).Inc()
+
 	// Convert UUID to string with prefix
 	taskID := utils.ShortUUIDWithPrefix(tsk.ID, entity.TaskIDPrefix)
 
+	times := task.FormatTaskTimes(tsk, loc)
 	res := &task.CreateTaskResponse{
 		ID:             taskID,
 		Status:         tsk.Status,
 		Name:           tsk.Name,
 		Description:    tsk.Description,
 		Priority:       tsk.Priority,
-		StartDateTime:  tsk.StartDateTime,
-		EndDateTime:    tsk.EndDateTime,
+		StartDateTime:  times.Start,
+		EndDateTime:    times.End,
+		AllDay:         tsk.AllDay,
 		Location:       tsk.Location,
 		RecurringDays:  tsk.RecurringDays,
-		RecurringUntil: tsk.RecurringUntil,
+		RecurringUntil: times.RecurringUntil,
+		Conflicts:      task.NewScheduleConflicts(conflicts, loc),
 	}
 	return res, nil
 }
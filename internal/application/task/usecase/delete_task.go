@@ -22,6 +22,8 @@ func NewDeleteTaskUseCase(s *service.TaskService, l logger.Logger) *DeleteTaskUs
 	}
 }
 
+// Execute deletes the task; its attachments and reminders are removed by the
+// outbox handlers of task.deleted once the deletion commits
 func (uc *DeleteTaskUseCase) Execute(ctx context.Context, taskID string) (string, error) {
 	parsedTaskID, err := utils.ParseID(taskID, entity.TaskIDPrefix)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
@@ -12,18 +13,21 @@ import (
 )
 
 type GetTaskByIDUseCase struct {
-	taskService *service.TaskService
-	logger      logger.Logger
+	taskService    *service.TaskService
+	profileService *profileSvc.ProfileService
+	logger         logger.Logger
 }
 
-func NewGetTaskByIDUseCase(svc *service.TaskService, l logger.Logger) *GetTaskByIDUseCase {
+func NewGetTaskByIDUseCase(svc *service.TaskService, ps *profileSvc.ProfileService, l logger.Logger) *GetTaskByIDUseCase {
 	return &GetTaskByIDUseCase{
-		taskService: svc,
-		logger:      l,
+		taskService:    svc,
+		profileService: ps,
+		logger:         l,
 	}
 }
 
-func (uc *GetTaskByIDUseCase) Execute(ctx context.Context, taskID string) (*task.GetTaskByIDResponse, error) {
+// Execute renders the times of the task in the time zone of accountID
+func (uc *GetTaskByIDUseCase) Execute(ctx context.Context, accountID, taskID string) (*task.GetTaskByIDResponse, error) {
 	parsedTaskID, err := utils.ParseID(taskID, entity.TaskIDPrefix)
 	if err != nil {
 		return nil, apperror.NewBadRequestError("invalid task ID format", "INVALID_TASK_ID", err)
@@ -34,17 +38,24 @@ func (uc *GetTaskByIDUseCase) Execute(ctx context.Context, taskID string) (*task
 		return nil, err
 	}
 
+	loc, err := uc.profileService.GetLocation(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	times := task.FormatTaskTimes(tsk, loc)
 	res := &task.GetTaskByIDResponse{
 		ID:             utils.ShortUUIDWithPrefix(tsk.ID, entity.TaskIDPrefix),
 		Status:         tsk.Status,
 		Name:           tsk.Name,
 		Description:    tsk.Description,
 		Priority:       tsk.Priority,
-		StartDateTime:  tsk.StartDateTime,
-		EndDateTime:    tsk.EndDateTime,
+		StartDateTime:  times.Start,
+		EndDateTime:    times.End,
+		AllDay:         tsk.AllDay,
 		Location:       tsk.Location,
 		RecurringDays:  tsk.RecurringDays,
-		RecurringUntil: tsk.RecurringUntil,
+		RecurringUntil: times.RecurringUntil,
 		CreatedAt:      tsk.CreatedAt,
 		UpdatedAt:      tsk.UpdatedAt,
 	}
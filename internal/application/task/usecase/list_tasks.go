@@ -5,6 +5,7 @@ import (
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
 	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
 	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
@@ -14,18 +15,21 @@ import (
 )
 
 type ListTasksByProjectUseCase struct {
-	taskService *service.TaskService
-	logger      logger.Logger
+	taskService    *service.TaskService
+	profileService *profileSvc.ProfileService
+	logger         logger.Logger
 }
 
-func NewListTasksByProjectUseCase(svc *service.TaskService, l logger.Logger) *ListTasksByProjectUseCase {
+func NewListTasksByProjectUseCase(svc *service.TaskService, ps *profileSvc.ProfileService, l logger.Logger) *ListTasksByProjectUseCase {
 	return &ListTasksByProjectUseCase{
-		taskService: svc,
-		logger:      l,
+		taskService:    svc,
+		profileService: ps,
+		logger:         l,
 	}
 }
 
-func (uc *ListTasksByProjectUseCase) Execute(ctx context.Context, projectID string) (*task.ListTasksByProjectResponse, error) {
+// Execute renders the times of the tasks in the time zone of accountID
+func (uc *ListTasksByProjectUseCase) Execute(ctx context.Context, accountID, projectID string) (*task.ListTasksByProjectResponse, error) {
 	parsedProjectID, err := utils.ParseID(projectID, entity.ProjectIDPrefix)
 	if err != nil {
 		return nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
@@ -36,19 +40,26 @@ func (uc *ListTasksByProjectUseCase) Execute(ctx context.Context, projectID stri
 		return nil, err
 	}
 
+	loc, err := uc.profileService.GetLocation(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
 	items := make([]task.GetTaskByIDResponse, 0, len(tsks))
 	for _, t := range tsks {
+		times := task.FormatTaskTimes(t, loc)
 		items = append(items, task.GetTaskByIDResponse{
 			ID:             utils.ShortUUIDWithPrefix(t.ID, taskEntity.TaskIDPrefix),
 			Status:         t.Status,
 			Name:           t.Name,
 			Description:    t.Description,
 			Priority:       t.Priority,
-			StartDateTime:  t.StartDateTime,
-			EndDateTime:    t.EndDateTime,
+			StartDateTime:  times.Start,
+			EndDateTime:    times.End,
+			AllDay:         t.AllDay,
 			Location:       t.Location,
 			RecurringDays:  t.RecurringDays,
-			RecurringUntil: t.RecurringUntil,
+			RecurringUntil: times.RecurringUntil,
 			CreatedAt:      t.CreatedAt,
 			UpdatedAt:      t.UpdatedAt,
 		})
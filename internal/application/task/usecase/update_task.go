@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
@@ -12,18 +13,22 @@ import (
 )
 
 type UpdateTaskUseCase struct {
-	taskService *service.TaskService
-	logger      logger.Logger
+	taskService    *service.TaskService
+	profileService *profileSvc.ProfileService
+	logger         logger.Logger
 }
 
-func NewUpdateTaskUseCase(s *service.TaskService, l logger.Logger) *UpdateTaskUseCase {
+func NewUpdateTaskUseCase(s *service.TaskService, ps *profileSvc.ProfileService, l logger.Logger) *UpdateTaskUseCase {
 	return &UpdateTaskUseCase{
-		taskService: s,
-		logger:      l,
+		taskService:    s,
+		profileService: ps,
+		logger:         l,
 	}
 }
 
-func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID string, req *task.UpdateTaskRequest) (*task.UpdateTaskResponse, error) {
+// Execute renders the times of the task in the time zone of accountID. The
+// reminders of the task are rescheduled by the outbox handler of task.updated.
+func (uc *UpdateTaskUseCase) Execute(ctx context.Context, accountID, taskID string, req *task.UpdateTaskRequest) (*task.UpdateTaskResponse, error) {
 	if req == nil {
 		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
 	}
@@ -33,24 +38,32 @@ func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID string, req *ta
 		return nil, apperror.NewBadRequestError("invalid task ID format", "INVALID_TASK_ID", err)
 	}
 
-	result, err := uc.taskService.UpdateTask(ctx, parsedTaskID, req)
+	loc, err := uc.profileService.GetLocation(ctx, accountID)
 	if err != nil {
 		return nil, err
 	}
 
+	result, conflicts, err := uc.taskService.UpdateTask(ctx, parsedTaskID, req)
+	if err != nil {
+		return nil, task.ConflictErrorIn(err, loc)
+	}
+
 	// Convert UUID to string with prefix
 	taskIDRes := utils.ShortUUIDWithPrefix(result.ID, entity.TaskIDPrefix)
 
+	times := task.FormatTaskTimes(result, loc)
 	return &task.UpdateTaskResponse{
 		ID:             taskIDRes,
 		Status:         result.Status,
 		Name:           result.Name,
 		Description:    result.Description,
 		Priority:       result.Priority,
-		StartDateTime:  result.StartDateTime,
-		EndDateTime:    result.EndDateTime,
+		StartDateTime:  times.Start,
+		EndDateTime:    times.End,
+		AllDay:         result.AllDay,
 		Location:       result.Location,
 		RecurringDays:  result.RecurringDays,
-		RecurringUntil: result.RecurringUntil,
+		RecurringUntil: times.RecurringUntil,
+		Conflicts:      task.NewScheduleConflicts(conflicts, loc),
 	}, nil
 }
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
+)
+
+type CreateWebhookRequest struct {
+	URL         string  `json:"url" validate:"required,max=2048"`
+	Description *string `json:"description" validate:"omitempty,max=255"`
+	// Events lists the event types to deliver, or "*" for every type
+	Events []string `json:"events" validate:"required,min=1,max=20"`
+}
+
+// UpdateWebhookRequest changes only the fields sent
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url" validate:"omitempty,max=2048"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	Events      []string `json:"events" validate:"omitempty,min=1,max=20"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookResponse describes a webhook. Secret is only returned when the
+// webhook is created.
+type WebhookResponse struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	URL         string    `json:"url"`
+	Description *string   `json:"description,omitempty"`
+	Events      []string  `json:"events"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ListWebhooksResponse struct {
+	Items []WebhookResponse `json:"items"`
+}
+
+type ListDeliveriesRequest struct {
+	Limit  *int `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset *int `query:"offset" validate:"omitempty,min=0"`
+}
+
+// DeliveryResponse is an entry of the delivery log; the response fields are
+// those of the last attempt
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	ResponseBody   *string         `json:"response_body,omitempty"`
+	DurationMs     *int            `json:"duration_ms,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	RedeliveryOf   string          `json:"redelivery_of,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type ListDeliveriesResponse struct {
+	Items      []DeliveryResponse `json:"items"`
+	Pagination common.Pagination  `json:"pagination"`
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/common"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/webhook"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+type CreateWebhookUseCase struct {
+	webhookService *service.WebhookService
+	logger         logger.Logger
+}
+
+func NewCreateWebhookUseCase(svc *service.WebhookService, l logger.Logger) *CreateWebhookUseCase {
+	return &CreateWebhookUseCase{
+		webhookService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *CreateWebhookUseCase) Execute(ctx context.Context, accountID, projectID string, req *webhook.CreateWebhookRequest) (*webhook.WebhookResponse, error) {
+	accID, parsedProjectID, err := parseProjectIDs(accountID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.webhookService.CreateSubscription(ctx, accID, parsedProjectID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Webhook created", map[string]interface{}{
+		"webhook_id": created.ID.String(),
+		"project_id": projectID,
+	})
+
+	res := toWebhookResponse(created)
+	// The secret is only shown once
+	res.Secret = created.Secret
+	return &res, nil
+}
+
+type ListWebhooksUseCase struct {
+	webhookService *service.WebhookService
+	logger         logger.Logger
+}
+
+func NewListWebhooksUseCase(svc *service.WebhookService, l logger.Logger) *ListWebhooksUseCase {
+	return &ListWebhooksUseCase{
+		webhookService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *ListWebhooksUseCase) Execute(ctx context.Context, accountID, projectID string) (*webhook.ListWebhooksResponse, error) {
+	accID, parsedProjectID, err := parseProjectIDs(accountID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := uc.webhookService.ListSubscriptions(ctx, accID, parsedProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]webhook.WebhookResponse, 0, len(list))
+	for _, s := range list {
+		items = append(items, toWebhookResponse(s))
+	}
+	return &webhook.ListWebhooksResponse{Items: items}, nil
+}
+
+type GetWebhookUseCase struct {
+	webhookService *service.WebhookService
+	logger         logger.Logger
+}
+
+func NewGetWebhookUseCase(svc *service.WebhookService, l logger.Logger) *GetWebhookUseCase {
+	return &GetWebhookUseCase{
+		webhookService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *GetWebhookUseCase) Execute(ctx context.Context, accountID, projectID, webhookID string) (*webhook.WebhookResponse, error) {
+	accID, parsedProjectID, parsedWebhookID, err := parseWebhookIDs(accountID, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := uc.webhookService.GetSubscription(ctx, accID, parsedProjectID, parsedWebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toWebhookResponse(subscription)
+	return &res, nil
+}
+
+type UpdateWebhookUseCase struct {
+	webhookService *service.WebhookService
+	logger         logger.Logger
+}
+
+func NewUpdateWebhookUseCase(svc *service.WebhookService, l logger.Logger) *UpdateWebhookUseCase {
+	return &UpdateWebhookUseCase{
+		webhookService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *UpdateWebhookUseCase) Execute(ctx context.Context, accountID, projectID, webhookID string, req *webhook.UpdateWebhookRequest) (*webhook.WebhookResponse, error) {
+	accID, parsedProjectID, parsedWebhookID, err := parseWebhookIDs(accountID, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.webhookService.UpdateSubscription(ctx, accID, parsedProjectID, parsedWebhookID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := toWebhookResponse(updated)
+	return &res, nil
+}
+
+type DeleteWebhookUseCase struct {
+	webhookService *service.WebhookService
+	logger         logger.Logger
+}
+
+func NewDeleteWebhookUseCase(svc *service.WebhookService, l logger.Logger) *DeleteWebhookUseCase {
+	return &DeleteWebhookUseCase{
+		webhookService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *DeleteWebhookUseCase) Execute(ctx context.Context, accountID, projectID, webhookID string) error {
+	accID, parsedProjectID, parsedWebhookID, err := parseWebhookIDs(accountID, projectID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return uc.webhookService.DeleteSubscription(ctx, accID, parsedProjectID, parsedWebhookID)
+}
+
+type ListDeliveriesUseCase struct {
+	webhookService *service.WebhookService
+	logger         logger.Logger
+}
+
+func NewListDeliveriesUseCase(svc *service.WebhookService, l logger.Logger) *ListDeliveriesUseCase {
+	return &ListDeliveriesUseCase{
+		webhookService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *ListDeliveriesUseCase) Execute(ctx context.Context, accountID, projectID, webhookID string, req *webhook.ListDeliveriesRequest) (*webhook.ListDeliveriesResponse, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	accID, parsedProjectID, parsedWebhookID, err := parseWebhookIDs(accountID, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := common.ValidatePagination(req.Limit, req.Offset)
+
+	list, total, err := uc.webhookService.ListDeliveries(ctx, accID, parsedProjectID, parsedWebhookID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]webhook.DeliveryResponse, 0, len(list))
+	for _, d := range list {
+		items = append(items, toDeliveryResponse(d))
+	}
+
+	return &webhook.ListDeliveriesResponse{
+		Items: items,
+		Pagination: common.Pagination{
+			Total:   total,
+			Limit:   limit,
+			Offset:  offset,
+			HasMore: common.CalculateHasMore(offset, limit, total),
+		},
+	}, nil
+}
+
+type RedeliverUseCase struct {
+	webhookService *service.WebhookService
+	logger         logger.Logger
+}
+
+func NewRedeliverUseCase(svc *service.WebhookService, l logger.Logger) *RedeliverUseCase {
+	return &RedeliverUseCase{
+		webhookService: svc,
+		logger:         l,
+	}
+}
+
+func (uc *RedeliverUseCase) Execute(ctx context.Context, accountID, projectID, webhookID, deliveryID string) (*webhook.DeliveryResponse, error) {
+	accID, parsedProjectID, parsedWebhookID, err := parseWebhookIDs(accountID, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedDeliveryID, err := utils.ParseID(deliveryID, entity.DeliveryIDPrefix)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid delivery ID format", "INVALID_DELIVERY_ID", err)
+	}
+
+	delivery, err := uc.webhookService.Redeliver(ctx, accID, parsedProjectID, parsedWebhookID, parsedDeliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "Webhook delivery queued again", map[string]interface{}{
+		"webhook_id":  parsedWebhookID.String(),
+		"delivery_id": delivery.ID.String(),
+		"original_id": parsedDeliveryID.String(),
+	})
+
+	res := toDeliveryResponse(delivery)
+	return &res, nil
+}
+
+func parseProjectIDs(accountID, projectID string) (uuid.UUID, uuid.UUID, error) {
+	accID, err := uuid.Parse(accountID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
+	}
+
+	parsedProjectID, err := utils.ParseID(projectID, projectEntity.ProjectIDPrefix)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid project ID format", "INVALID_PROJECT_ID", err)
+	}
+
+	return accID, parsedProjectID, nil
+}
+
+func parseWebhookIDs(accountID, projectID, webhookID string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	accID, parsedProjectID, err := parseProjectIDs(accountID, projectID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	parsedWebhookID, err := utils.ParseID(webhookID, entity.SubscriptionIDPrefix)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, apperror.NewBadRequestError("invalid webhook ID format", "INVALID_WEBHOOK_ID", err)
+	}
+
+	return accID, parsedProjectID, parsedWebhookID, nil
+}
+
+func toWebhookResponse(s *entity.Subscription) webhook.WebhookResponse {
+	return webhook.WebhookResponse{
+		ID:          utils.ShortUUIDWithPrefix(s.ID, entity.SubscriptionIDPrefix),
+		ProjectID:   utils.ShortUUIDWithPrefix(s.ProjectID, projectEntity.ProjectIDPrefix),
+		URL:         s.URL,
+		Description: s.Description,
+		Events:      s.Events,
+		Active:      s.Active,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func toDeliveryResponse(d *entity.Delivery) webhook.DeliveryResponse {
+	res := webhook.DeliveryResponse{
+		ID:             utils.ShortUUIDWithPrefix(d.ID, entity.DeliveryIDPrefix),
+		WebhookID:      utils.ShortUUIDWithPrefix(d.SubscriptionID, entity.SubscriptionIDPrefix),
+		EventID:        utils.ShortUUIDWithPrefix(d.EventID, webhooks.EventIDPrefix),
+		Event:          d.Event,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		DurationMs:     d.DurationMs,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		Payload:        json.RawMessage(d.Payload),
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.RedeliveryOf != nil {
+		res.RedeliveryOf = utils.ShortUUIDWithPrefix(*d.RedeliveryOf, entity.DeliveryIDPrefix)
+	}
+	return res
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+)
+
+// WorkerConfig tunes the webhook worker
+type WorkerConfig struct {
+	// Interval is how often due deliveries are looked for
+	Interval time.Duration
+	// BatchSize is how many deliveries are claimed at once
+	BatchSize int
+	// Lease is how long a claim lasts; it must cover posting a whole batch
+	Lease time.Duration
+}
+
+// WebhookWorker posts due webhook deliveries in the background. Every instance
+// of the API runs one; the claims in WebhookService.DispatchDue keep them from
+// posting the same delivery.
+type WebhookWorker struct {
+	webhookService *service.WebhookService
+	config         WorkerConfig
+	logger         logger.Logger
+}
+
+func NewWebhookWorker(svc *service.WebhookService, cfg WorkerConfig, l logger.Logger) *WebhookWorker {
+	return &WebhookWorker{
+		webhookService: svc,
+		config:         cfg,
+		logger:         l,
+	}
+}
+
+// Run posts deliveries until ctx is cancelled. A batch in progress is
+// finished first, so no claim is left to expire.
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.dispatchDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchDue claims batches until the due deliveries run out
+func (w *WebhookWorker) dispatchDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		batchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), w.config.Lease)
+		results, err := w.webhookService.DispatchDue(batchCtx, time.Now(), w.config.BatchSize, w.config.Lease)
+		cancel()
+		if err != nil {
+			w.logger.Error("Failed to dispatch webhook deliveries", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		for _, r := range results {
+			metrics.WebhookDeliveries.WithLabelValues(r.Event, r.Outcome).Inc()
+			if r.Err != nil {
+				w.logger.Warn("Failed to deliver webhook", map[string]interface{}{
+					"delivery_id": r.DeliveryID.String(),
+					"event":       r.Event,
+					"outcome":     r.Outcome,
+					"error":       r.Err.Error(),
+				})
+			}
+		}
+
+		if len(results) < w.config.BatchSize {
+			return
+		}
+	}
+}
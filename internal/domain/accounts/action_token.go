@@ -0,0 +1,77 @@
+package accounts
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+
+	PasswordResetTokenTTL     = time.Hour
+	EmailVerificationTokenTTL = 48 * time.Hour
+)
+
+// ActionClaims are the claims of a single-use token sent by email.
+// The token ID (jti) references the account_tokens row that makes it single-use.
+type ActionClaims struct {
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// SignActionToken signs a token for purpose with a key derived from secret, so
+// action tokens can never be accepted as access tokens and vice versa
+func SignActionToken(secret, purpose string, tokenID, accountID uuid.UUID, now, expiresAt time.Time) (string, error) {
+	if secret == "" {
+		return "", errors.New("jwt secret is empty")
+	}
+
+	claims := ActionClaims{
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    TokenIssuer,
+			Subject:   accountID.String(),
+			ID:        tokenID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(actionKey(secret, purpose))
+}
+
+// ParseActionToken verifies the signature, expiry and purpose of an action token
+func ParseActionToken(tokenStr, secret, purpose string) (*ActionClaims, error) {
+	if secret == "" {
+		return nil, errors.New("jwt secret is empty")
+	}
+
+	claims := &ActionClaims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		return actionKey(secret, purpose), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(TokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.Purpose != purpose {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+func actionKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("action-token:" + purpose))
+	return mac.Sum(nil)
+}
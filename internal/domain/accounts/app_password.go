@@ -0,0 +1,10 @@
+package accounts
+
+import "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+
+// AppPasswordCredentials is returned once, when an app password is created
+type AppPasswordCredentials struct {
+	AppPassword *entity.AppPassword
+	Username    string
+	Password    string
+}
@@ -11,6 +11,14 @@ type State struct {
 	Inactive string
 }
 
+// AccountFilter narrows the account listing; empty fields match every account
+type AccountFilter struct {
+	// Query matches a substring of the username or email, case-insensitively
+	Query string
+	State string
+	Role  string
+}
+
 // Account represents the account data exposed via the HTTP API.
 // It is mapped from the domain/entity Account model.
 type Account struct {
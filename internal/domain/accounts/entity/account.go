@@ -8,17 +8,51 @@ import (
 
 const AccountIDPrefix = "acc"
 
+const (
+	AccountStateActive   = "active"
+	AccountStateInactive = "inactive"
+	// AccountStateSuspended is set by an admin and, unlike inactive, cannot be undone by the account owner
+	AccountStateSuspended = "suspended"
+)
+
+const (
+	AccountRoleUser  = "user"
+	AccountRoleAdmin = "admin"
+)
+
 type Account struct {
-	ID        uuid.UUID  `gorm:"type:char(36);primaryKey"`
-	NodeID    *uuid.UUID `gorm:"type:char(36)"`
-	Username  string     `gorm:"type:varchar(100);unique;not null"`
-	Email     string     `gorm:"type:varchar(255);unique;not null"`
-	Password  string     `gorm:"type:varchar(255);not null"`
-	State     string     `gorm:"type:enum('active','inactive');not null;default:'active'"`
-	CreatedAt time.Time  `gorm:"not null"`
-	UpdatedAt time.Time  `gorm:"not null"`
+	ID              uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	NodeID          *uuid.UUID `gorm:"type:char(36)"`
+	Username        string     `gorm:"type:varchar(100);unique;not null"`
+	Email           string     `gorm:"type:varchar(255);unique;not null"`
+	Password        string     `gorm:"type:varchar(255);not null"`
+	State           string     `gorm:"type:varchar(16);not null;default:'active'"`
+	Role            string     `gorm:"type:varchar(16);not null;default:'user'"`
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at"`
+	CreatedAt       time.Time  `gorm:"not null"`
+	UpdatedAt       time.Time  `gorm:"not null"`
 }
 
 func (Account) TableName() string {
 	return "accounts"
 }
+
+// IsEmailVerified reports whether the account has proven it owns its email
+func (a *Account) IsEmailVerified() bool {
+	return a.EmailVerifiedAt != nil
+}
+
+// IsActive reports whether the account may log in and use its tokens
+func (a *Account) IsActive() bool {
+	return a.State == AccountStateActive
+}
+
+// IsSuspended reports whether an admin has deactivated the account
+func (a *Account) IsSuspended() bool {
+	return a.State == AccountStateSuspended
+}
+
+// IsAdmin reports whether the account may use the admin API
+func (a *Account) IsAdmin() bool {
+	return a.Role == AccountRoleAdmin
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountToken records an issued password reset or email verification token.
+// The signed token itself is never stored; its ID is enough to make it single-use.
+type AccountToken struct {
+	ID        uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	AccountID uuid.UUID  `gorm:"type:char(36);index;not null"`
+	Purpose   string     `gorm:"type:varchar(32);not null"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"not null"`
+}
+
+func (AccountToken) TableName() string {
+	return "account_tokens"
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const AppPasswordIDPrefix = "apw"
+
+// AppPassword lets a device that cannot log in interactively, such as a
+// calendar app, use the account with HTTP Basic authentication. Only the
+// SHA-256 hash of the generated password is stored.
+type AppPassword struct {
+	ID           uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	AccountID    uuid.UUID  `gorm:"column:account_id;type:char(36);index;not null"`
+	Name         string     `gorm:"column:name;type:varchar(100);not null"`
+	PasswordHash string     `gorm:"column:password_hash;type:char(64);uniqueIndex;not null"`
+	LastUsedAt   *time.Time `gorm:"column:last_used_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;not null"`
+	RevokedAt    *time.Time `gorm:"column:revoked_at"`
+}
+
+func (AppPassword) TableName() string {
+	return "app_passwords"
+}
+
+// IsActive reports whether the app password can still be used
+func (p *AppPassword) IsActive() bool {
+	return p.RevokedAt == nil
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const SessionIDPrefix = "sess"
+
+// Session is a refresh-token backed login of an account on a single device.
+// Only SHA-256 hashes of refresh tokens are stored.
+type Session struct {
+	ID                uuid.UUID  `gorm:"type:char(36);primaryKey"`
+	AccountID         uuid.UUID  `gorm:"type:char(36);index;not null"`
+	RefreshTokenHash  string     `gorm:"type:char(64);uniqueIndex;not null"`
+	PreviousTokenHash *string    `gorm:"type:char(64);index"`
+	UserAgent         string     `gorm:"type:varchar(255)"`
+	IPAddress         string     `gorm:"type:varchar(45)"`
+	ExpiresAt         time.Time  `gorm:"not null"`
+	LastUsedAt        time.Time  `gorm:"not null"`
+	RevokedAt         *time.Time `gorm:"index"`
+	CreatedAt         time.Time  `gorm:"not null"`
+	UpdatedAt         time.Time  `gorm:"not null"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// IsActive reports whether the session can still be used at the given time
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
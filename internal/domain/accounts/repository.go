@@ -3,13 +3,54 @@ package accounts
 
 import (
 	"context"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/google/uuid"
 )
 
 type AccountRepository interface {
 	CreateAccount(ctx context.Context, acc *entity.Account) error
 	ExistsAccount(ctx context.Context, username, email string) (bool, error)
 	GetByUsername(ctx context.Context, username string) (*entity.Account, error)
-	ListAccounts(ctx context.Context, limit, offset int) ([]*entity.Account, int, error)
+	GetByID(ctx context.Context, accountID uuid.UUID) (*entity.Account, error)
+	GetByEmail(ctx context.Context, email string) (*entity.Account, error)
+	// ExistsUsername and ExistsEmail ignore the account with excludeID, so an account does not conflict with itself
+	ExistsUsername(ctx context.Context, username string, excludeID uuid.UUID) (bool, error)
+	ExistsEmail(ctx context.Context, email string, excludeID uuid.UUID) (bool, error)
+	UpdateAccount(ctx context.Context, acc *entity.Account) error
+	UpdateState(ctx context.Context, accountID uuid.UUID, state string, at time.Time) error
+	UpdateRole(ctx context.Context, accountID uuid.UUID, role string, at time.Time) error
+	UpdatePassword(ctx context.Context, accountID uuid.UUID, passwordHash string, at time.Time) error
+	MarkEmailVerified(ctx context.Context, accountID uuid.UUID, at time.Time) error
+	ListAccounts(ctx context.Context, filter AccountFilter, limit, offset int) ([]*entity.Account, int, error)
+}
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *entity.Session) error
+	GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error)
+	// FindSessionByTokenHash matches either the current or the previous refresh token hash
+	FindSessionByTokenHash(ctx context.Context, tokenHash string) (*entity.Session, error)
+	// RotateSession swaps the refresh token only if oldHash is still current; it reports whether a row was updated
+	RotateSession(ctx context.Context, sessionID uuid.UUID, oldHash, newHash string, expiresAt, usedAt time.Time) (bool, error)
+	ListActiveSessions(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*entity.Session, error)
+	RevokeSession(ctx context.Context, accountID, sessionID uuid.UUID, at time.Time) (bool, error)
+	RevokeAllSessions(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error)
+	RevokeOtherSessions(ctx context.Context, accountID, keepSessionID uuid.UUID, at time.Time) (int64, error)
+}
+
+type AccountTokenRepository interface {
+	CreateAccountToken(ctx context.Context, token *entity.AccountToken) error
+	// ConsumeAccountToken marks an unused, unexpired token as used; it reports whether the token was consumed
+	ConsumeAccountToken(ctx context.Context, tokenID uuid.UUID, purpose string, now time.Time) (bool, error)
+	// InvalidateAccountTokens marks every unused token of the account for purpose as used
+	InvalidateAccountTokens(ctx context.Context, accountID uuid.UUID, purpose string, at time.Time) error
+}
+
+type AppPasswordRepository interface {
+	CreateAppPassword(ctx context.Context, appPassword *entity.AppPassword) error
+	GetAppPasswordByHash(ctx context.Context, passwordHash string) (*entity.AppPassword, error)
+	ListActiveAppPasswords(ctx context.Context, accountID uuid.UUID) ([]*entity.AppPassword, error)
+	RevokeAppPassword(ctx context.Context, accountID, appPasswordID uuid.UUID, at time.Time) (bool, error)
+	TouchAppPassword(ctx context.Context, appPasswordID uuid.UUID, at time.Time) error
 }
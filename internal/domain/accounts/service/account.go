@@ -2,12 +2,11 @@ package service
 
 import (
 	"context"
-	"os"
 	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/mailer"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
@@ -15,12 +14,39 @@ import (
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
 )
 
+// AccountSettings is the configuration used by AccountService
+type AccountSettings struct {
+	// JWTSecret signs access tokens and the hashes of account tokens
+	JWTSecret string
+	// FrontendURL prefixes the links sent by email
+	FrontendURL string
+	// EmailVerificationGracePeriod is how long unverified accounts may log in after signing up;
+	// nil means they are not limited and 0 requires verification before the first login
+	EmailVerificationGracePeriod *time.Duration
+}
+
 type AccountService struct {
-	repo accounts.AccountRepository
+	repo        accounts.AccountRepository
+	sessionRepo accounts.SessionRepository
+	tokenRepo   accounts.AccountTokenRepository
+	mailer      mailer.Mailer
+	settings    AccountSettings
 }
 
-func NewAccountService(repo accounts.AccountRepository) *AccountService {
-	return &AccountService{repo: repo}
+func NewAccountService(
+	repo accounts.AccountRepository,
+	sessionRepo accounts.SessionRepository,
+	tokenRepo accounts.AccountTokenRepository,
+	m mailer.Mailer,
+	settings AccountSettings,
+) *AccountService {
+	return &AccountService{
+		repo:        repo,
+		sessionRepo: sessionRepo,
+		tokenRepo:   tokenRepo,
+		mailer:      m,
+		settings:    settings,
+	}
 }
 
 func (s *AccountService) CreateAccount(ctx context.Context, req *account.CreateAccountRequest) (*entity.Account, error) {
@@ -54,7 +80,7 @@ func (s *AccountService) CreateAccount(ctx context.Context, req *account.CreateA
 		Username:  req.Username,
 		Email:     req.Email,
 		Password:  string(hashedPassword),
-		State:     "active",
+		State:     entity.AccountStateActive,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -67,51 +93,40 @@ func (s *AccountService) CreateAccount(ctx context.Context, req *account.CreateA
 	return acc, nil
 }
 
-func (s *AccountService) ListAccounts(ctx context.Context, limit, offset int) ([]*entity.Account, int, error) {
-	accounts, total, err := s.repo.ListAccounts(ctx, limit, offset)
+func (s *AccountService) ListAccounts(ctx context.Context, filter accounts.AccountFilter, limit, offset int) ([]*entity.Account, int, error) {
+	accounts, total, err := s.repo.ListAccounts(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, 0, apperror.NewInternalServerError("failed to list accounts", "LIST_ACCOUNTS_ERROR", err)
 	}
 	return accounts, total, nil
 }
 
-func (s *AccountService) Login(ctx context.Context, req *account.LoginRequest) (string, error) {
+// Login verifies the credentials and starts a new session for the client
+func (s *AccountService) Login(ctx context.Context, req *account.LoginRequest) (*accounts.TokenPair, error) {
 	acc, err := s.repo.GetByUsername(ctx, req.Username)
 	if err != nil {
-		return "", apperror.NewBadRequestError("user does not exist", "LOGIN_ERROR", nil)
+		return nil, apperror.NewBadRequestError("user does not exist", "LOGIN_ERROR", nil)
 	}
 
 	// compare password
 	if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password)); err != nil {
-		return "", apperror.NewBadRequestError("invalid username or password", "LOGIN_ERROR", nil)
+		return nil, apperror.NewBadRequestError("invalid username or password", "LOGIN_ERROR", nil)
 	}
 
-	claims := jwt.MapClaims{
-		"AccountId": acc.ID,
-		"Email":     acc.Email,
-		"Username":  acc.Username,
-		"Exp":       time.Now().Add(time.Hour * 72).Unix(),
+	// Checked after the password so the state of an account is not disclosed to guessers
+	if acc.IsSuspended() {
+		return nil, apperror.NewForbiddenError("account has been suspended", "ACCOUNT_SUSPENDED", nil)
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return "", apperror.NewInternalServerError(
-			"JWT_SECRET environment variable not set",
-			"JWT_SECRET_MISSING",
-			nil,
-		)
+	if !acc.IsActive() {
+		return nil, apperror.NewForbiddenError("account is deactivated, reactivate it to log in", "ACCOUNT_INACTIVE", nil)
 	}
 
-	t, err := token.SignedString([]byte(jwtSecret))
-	if err != nil {
-		return "", apperror.NewInternalServerError(
-			"failed to sign jwt",
-			"JWT_SIGN_ERROR",
-			err,
-		)
+	if err := checkEmailVerification(acc, time.Now(), s.settings.EmailVerificationGracePeriod); err != nil {
+		return nil, err
 	}
 
-	return t, nil
+	return s.startSession(ctx, acc, accounts.ClientInfo{
+		UserAgent: req.UserAgent,
+		IPAddress: req.IPAddress,
+	})
 }
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+)
+
+func (s *AccountService) GetAccount(ctx context.Context, accountID uuid.UUID) (*entity.Account, error) {
+	acc, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("account not found", "ACCOUNT_NOT_FOUND", nil)
+		}
+		return nil, apperror.NewInternalServerError("failed to get account", "GET_ACCOUNT_ERROR", err)
+	}
+
+	return acc, nil
+}
+
+// ChangePassword sets a new password and signs out every session except the current one
+func (s *AccountService) ChangePassword(ctx context.Context, accountID, currentSessionID uuid.UUID, req *account.ChangePasswordRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	if req.NewPassword != req.ConfirmPassword {
+		return apperror.NewBadRequestError("password and confirm password does not match", "PASSWORD_DOES_NOT_MATCH_ERROR", nil)
+	}
+
+	acc, err := s.authenticate(ctx, accountID, req.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return apperror.NewInternalServerError("failed to hash password", "HASH_PASSWORD_ERROR", err)
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdatePassword(ctx, acc.ID, string(hashedPassword), now); err != nil {
+		return apperror.NewInternalServerError("failed to update password", "UPDATE_PASSWORD_ERROR", err)
+	}
+
+	if _, err := s.sessionRepo.RevokeOtherSessions(ctx, acc.ID, currentSessionID, now); err != nil {
+		return apperror.NewInternalServerError("failed to revoke sessions", "REVOKE_SESSIONS_ERROR", err)
+	}
+
+	return nil
+}
+
+// ChangeEmail replaces the email and marks it unverified until the new address is confirmed.
+// Pending reset links sent to the old address stop working.
+func (s *AccountService) ChangeEmail(ctx context.Context, accountID uuid.UUID, req *account.ChangeEmailRequest) (*entity.Account, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	acc, err := s.authenticate(ctx, accountID, req.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(acc.Email, req.Email) {
+		return acc, nil
+	}
+
+	exists, err := s.repo.ExistsEmail(ctx, req.Email, acc.ID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to check account existence", "CHECK_ACCOUNT_EXISTS_ERROR", err)
+	}
+	if exists {
+		return nil, apperror.NewConflictError("email already exists", "EMAIL_EXISTS", nil)
+	}
+
+	now := time.Now()
+	acc.Email = req.Email
+	acc.EmailVerifiedAt = nil
+	acc.UpdatedAt = now
+	if err := s.repo.UpdateAccount(ctx, acc); err != nil {
+		return nil, apperror.NewInternalServerError("failed to update account", "UPDATE_ACCOUNT_ERROR", err)
+	}
+
+	if err := s.tokenRepo.InvalidateAccountTokens(ctx, acc.ID, accounts.TokenPurposePasswordReset, now); err != nil {
+		return nil, apperror.NewInternalServerError("failed to invalidate tokens", "INVALIDATE_TOKENS_ERROR", err)
+	}
+
+	return acc, nil
+}
+
+func (s *AccountService) ChangeUsername(ctx context.Context, accountID uuid.UUID, req *account.ChangeUsernameRequest) (*entity.Account, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	acc, err := s.authenticate(ctx, accountID, req.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.Username == req.Username {
+		return acc, nil
+	}
+
+	exists, err := s.repo.ExistsUsername(ctx, req.Username, acc.ID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to check account existence", "CHECK_ACCOUNT_EXISTS_ERROR", err)
+	}
+	if exists {
+		return nil, apperror.NewConflictError("username already exists", "USERNAME_EXISTS", nil)
+	}
+
+	acc.Username = req.Username
+	acc.UpdatedAt = time.Now()
+	if err := s.repo.UpdateAccount(ctx, acc); err != nil {
+		return nil, apperror.NewInternalServerError("failed to update account", "UPDATE_ACCOUNT_ERROR", err)
+	}
+
+	return acc, nil
+}
+
+// DeactivateAccount marks the account inactive and signs it out everywhere.
+// The data is kept so the account can be reactivated by logging in through ReactivateAccount.
+func (s *AccountService) DeactivateAccount(ctx context.Context, accountID uuid.UUID, req *account.DeactivateAccountRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	acc, err := s.authenticate(ctx, accountID, req.CurrentPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdateState(ctx, acc.ID, entity.AccountStateInactive, now); err != nil {
+		return apperror.NewInternalServerError("failed to deactivate account", "DEACTIVATE_ACCOUNT_ERROR", err)
+	}
+
+	if _, err := s.sessionRepo.RevokeAllSessions(ctx, acc.ID, now); err != nil {
+		return apperror.NewInternalServerError("failed to revoke sessions", "REVOKE_SESSIONS_ERROR", err)
+	}
+
+	return nil
+}
+
+// ReactivateAccount verifies the credentials of a deactivated account, activates it and logs it in
+func (s *AccountService) ReactivateAccount(ctx context.Context, req *account.LoginRequest) (*accounts.TokenPair, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	acc, err := s.repo.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid username or password", "LOGIN_ERROR", nil)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password)); err != nil {
+		return nil, apperror.NewBadRequestError("invalid username or password", "LOGIN_ERROR", nil)
+	}
+
+	if acc.IsSuspended() {
+		return nil, apperror.NewForbiddenError("account has been suspended", "ACCOUNT_SUSPENDED", nil)
+	}
+
+	now := time.Now()
+	if !acc.IsActive() {
+		if err := s.repo.UpdateState(ctx, acc.ID, entity.AccountStateActive, now); err != nil {
+			return nil, apperror.NewInternalServerError("failed to reactivate account", "REACTIVATE_ACCOUNT_ERROR", err)
+		}
+		acc.State = entity.AccountStateActive
+	}
+
+	if err := checkEmailVerification(acc, now, s.settings.EmailVerificationGracePeriod); err != nil {
+		return nil, err
+	}
+
+	return s.startSession(ctx, acc, accounts.ClientInfo{
+		UserAgent: req.UserAgent,
+		IPAddress: req.IPAddress,
+	})
+}
+
+// authenticate loads the account and checks its current password before a sensitive change
+func (s *AccountService) authenticate(ctx context.Context, accountID uuid.UUID, password string) (*entity.Account, error) {
+	acc, err := s.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !acc.IsActive() {
+		return nil, apperror.NewForbiddenError("account is deactivated", "ACCOUNT_INACTIVE", nil)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(password)); err != nil {
+		return nil, apperror.NewBadRequestError("current password is incorrect", "INVALID_CURRENT_PASSWORD", nil)
+	}
+
+	return acc, nil
+}
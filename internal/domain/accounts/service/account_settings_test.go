@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAccountService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("oldpass"), bcrypt.DefaultCost)
+	accountID := uuid.New()
+	sessionID := uuid.New()
+	newAccount := func(state string) *entity.Account {
+		return &entity.Account{ID: accountID, Password: string(hashedPassword), State: state}
+	}
+
+	tests := []struct {
+		name          string
+		request       *account.ChangePasswordRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name:    "success - keeps only the current session",
+			request: &account.ChangePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass", ConfirmPassword: "newpass"},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(ctx, accountID).Return(newAccount(entity.AccountStateActive), nil).Times(1)
+				mockRepo.EXPECT().
+					UpdatePassword(ctx, accountID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, hash string, _ time.Time) error {
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("newpass")))
+						return nil
+					}).
+					Times(1)
+				mockSessionRepo.EXPECT().RevokeOtherSessions(ctx, accountID, sessionID, gomock.Any()).Return(int64(1), nil).Times(1)
+			},
+		},
+		{
+			name:    "error - wrong current password",
+			request: &account.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "newpass", ConfirmPassword: "newpass"},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(ctx, accountID).Return(newAccount(entity.AccountStateActive), nil).Times(1)
+			},
+			expectedError: "current password is incorrect",
+		},
+		{
+			name:    "error - inactive account",
+			request: &account.ChangePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass", ConfirmPassword: "newpass"},
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(ctx, accountID).Return(newAccount(entity.AccountStateInactive), nil).Times(1)
+			},
+			expectedError: "account is deactivated",
+		},
+		{
+			name:          "error - passwords do not match",
+			request:       &account.ChangePasswordRequest{CurrentPassword: "oldpass", NewPassword: "newpass", ConfirmPassword: "other"},
+			setupMock:     func() {},
+			expectedError: "password and confirm password does not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := svc.ChangePassword(ctx, accountID, sessionID, tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAccountService_ChangeEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockTokenRepo := mocks.NewMockAccountTokenRepository(ctrl)
+	svc := NewAccountService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockTokenRepo, nil, testSettings)
+	ctx := context.Background()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
+	accountID := uuid.New()
+	verifiedAt := time.Now().Add(-time.Hour)
+	newAccount := func() *entity.Account {
+		return &entity.Account{
+			ID:              accountID,
+			Email:           "old@example.com",
+			Password:        string(hashedPassword),
+			State:           entity.AccountStateActive,
+			EmailVerifiedAt: &verifiedAt,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		email         string
+		setupMock     func()
+		expectedError string
+		expectedEmail string
+	}{
+		{
+			name:  "success - new email is unverified",
+			email: "new@example.com",
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(ctx, accountID).Return(newAccount(), nil).Times(1)
+				mockRepo.EXPECT().ExistsEmail(ctx, "new@example.com", accountID).Return(false, nil).Times(1)
+				mockRepo.EXPECT().
+					UpdateAccount(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, acc *entity.Account) error {
+						assert.Equal(t, "new@example.com", acc.Email)
+						assert.Nil(t, acc.EmailVerifiedAt)
+						return nil
+					}).
+					Times(1)
+				mockTokenRepo.EXPECT().
+					InvalidateAccountTokens(ctx, accountID, accounts.TokenPurposePasswordReset, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			expectedEmail: "new@example.com",
+		},
+		{
+			name:  "success - same email is a no-op",
+			email: "OLD@example.com",
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(ctx, accountID).Return(newAccount(), nil).Times(1)
+			},
+			expectedEmail: "old@example.com",
+		},
+		{
+			name:  "error - email taken",
+			email: "taken@example.com",
+			setupMock: func() {
+				mockRepo.EXPECT().GetByID(ctx, accountID).Return(newAccount(), nil).Times(1)
+				mockRepo.EXPECT().ExistsEmail(ctx, "taken@example.com", accountID).Return(true, nil).Times(1)
+			},
+			expectedError: "email already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			acc, err := svc.ChangeEmail(ctx, accountID, &account.ChangeEmailRequest{CurrentPassword: "secret", Email: tt.email})
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEmail, acc.Email)
+		})
+	}
+}
+
+func TestAccountService_DeactivateAndReactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
+	acc := &entity.Account{ID: uuid.New(), Username: "testuser", Password: string(hashedPassword), State: entity.AccountStateActive}
+
+	// Deactivation revokes every session
+	mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(acc, nil).Times(1)
+	mockRepo.EXPECT().
+		UpdateState(ctx, acc.ID, entity.AccountStateInactive, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, state string, _ time.Time) error {
+			acc.State = state
+			return nil
+		}).
+		Times(1)
+	mockSessionRepo.EXPECT().RevokeAllSessions(ctx, acc.ID, gomock.Any()).Return(int64(2), nil).Times(1)
+	require.NoError(t, svc.DeactivateAccount(ctx, acc.ID, &account.DeactivateAccountRequest{CurrentPassword: "secret"}))
+
+	// Login is refused while inactive
+	mockRepo.EXPECT().GetByUsername(ctx, "testuser").Return(acc, nil).Times(1)
+	_, err := svc.Login(ctx, &account.LoginRequest{Username: "testuser", Password: "secret"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "account is deactivated")
+
+	// Wrong credentials never reactivate
+	mockRepo.EXPECT().GetByUsername(ctx, "testuser").Return(acc, nil).Times(1)
+	_, err = svc.ReactivateAccount(ctx, &account.LoginRequest{Username: "testuser", Password: "wrong"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid username or password")
+
+	// Reactivation flips the state back and starts a session
+	mockRepo.EXPECT().GetByUsername(ctx, "testuser").Return(acc, nil).Times(1)
+	mockRepo.EXPECT().UpdateState(ctx, acc.ID, entity.AccountStateActive, gomock.Any()).Return(nil).Times(1)
+	mockSessionRepo.EXPECT().CreateSession(ctx, gomock.Any()).Return(nil).Times(1)
+	tokens, err := svc.ReactivateAccount(ctx, &account.LoginRequest{Username: "testuser", Password: "secret"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.Equal(t, entity.AccountStateActive, acc.State)
+}
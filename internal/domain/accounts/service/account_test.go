@@ -3,10 +3,11 @@ package service
 import (
 	"context"
 	"errors"
-	"os"
 	"testing"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
@@ -17,12 +18,16 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// testSettings holds the settings the API normally derives from config
+var testSettings = AccountSettings{JWTSecret: "testsecret", FrontendURL: "http://localhost:3000"}
+
 func TestAccountService_CreateAccount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAccountRepository(ctrl)
-	svc := NewAccountService(mockRepo)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -196,12 +201,11 @@ func TestAccountService_Login(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAccountRepository(ctrl)
-	svc := NewAccountService(mockRepo)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
 	ctx := context.Background()
 
 	// Set JWT secret for tests
-	os.Setenv("JWT_SECRET", "testsecret")
-	defer os.Unsetenv("JWT_SECRET")
 
 	// Pre-hash password for test accounts
 	validPassword := "password123"
@@ -218,8 +222,9 @@ func TestAccountService_Login(t *testing.T) {
 		{
 			name: "success - valid credentials",
 			request: &account.LoginRequest{
-				Username: "testuser",
-				Password: validPassword,
+				Username:  "testuser",
+				Password:  validPassword,
+				UserAgent: "test-agent",
 			},
 			setupMock: func() {
 				acc := &entity.Account{
@@ -227,15 +232,47 @@ func TestAccountService_Login(t *testing.T) {
 					Username: "testuser",
 					Email:    "test@example.com",
 					Password: string(hashedPassword),
+					State:    entity.AccountStateActive,
 				}
 				mockRepo.EXPECT().
 					GetByUsername(ctx, "testuser").
 					Return(acc, nil).
 					Times(1)
+				mockSessionRepo.EXPECT().
+					CreateSession(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, session *entity.Session) error {
+						assert.Equal(t, acc.ID, session.AccountID)
+						assert.Equal(t, "test-agent", session.UserAgent)
+						assert.Len(t, session.RefreshTokenHash, 64)
+						return nil
+					}).
+					Times(1)
 			},
 			expectedError: "",
 			expectToken:   true,
 		},
+		{
+			name: "error - inactive account",
+			request: &account.LoginRequest{
+				Username: "testuser",
+				Password: validPassword,
+			},
+			setupMock: func() {
+				acc := &entity.Account{
+					ID:       uuid.New(),
+					Username: "testuser",
+					Email:    "test@example.com",
+					Password: string(hashedPassword),
+					State:    entity.AccountStateInactive,
+				}
+				mockRepo.EXPECT().
+					GetByUsername(ctx, "testuser").
+					Return(acc, nil).
+					Times(1)
+			},
+			expectedError: "account is deactivated",
+			expectToken:   false,
+		},
 		{
 			name: "error - user not found",
 			request: &account.LoginRequest{
@@ -263,6 +300,7 @@ func TestAccountService_Login(t *testing.T) {
 					Username: "testuser",
 					Email:    "test@example.com",
 					Password: string(hashedPassword), // Correct hashed password
+					State:    entity.AccountStateActive,
 				}
 				mockRepo.EXPECT().
 					GetByUsername(ctx, "testuser").
@@ -284,6 +322,7 @@ func TestAccountService_Login(t *testing.T) {
 					Username: "testuser",
 					Email:    "test@example.com",
 					Password: "plaintext_not_hashed", // Invalid - not a bcrypt hash
+					State:    entity.AccountStateActive,
 				}
 				mockRepo.EXPECT().
 					GetByUsername(ctx, "testuser").
@@ -305,6 +344,7 @@ func TestAccountService_Login(t *testing.T) {
 					Username: "testuser",
 					Email:    "test@example.com",
 					Password: string(hashedPassword),
+					State:    entity.AccountStateActive,
 				}
 				mockRepo.EXPECT().
 					GetByUsername(ctx, "testuser").
@@ -330,12 +370,18 @@ func TestAccountService_Login(t *testing.T) {
 			}
 
 			if tt.expectToken {
-				assert.NotEmpty(t, token)
-				// Verify token has 3 parts (header.payload.signature)
-				parts := len(token)
-				assert.Greater(t, parts, 0)
+				require.NotNil(t, token)
+				assert.NotEmpty(t, token.RefreshToken)
+
+				// Access token must carry standard registered claims that are actually enforced
+				claims, err := accounts.ParseAccessToken(token.AccessToken, "testsecret")
+				require.NoError(t, err)
+				assert.Equal(t, "testuser", claims.Username)
+				assert.Equal(t, token.SessionID.String(), claims.SessionID)
+				require.NotNil(t, claims.ExpiresAt)
+				assert.WithinDuration(t, time.Now().Add(accounts.AccessTokenTTL), claims.ExpiresAt.Time, time.Minute)
 			} else {
-				assert.Empty(t, token)
+				assert.Nil(t, token)
 			}
 		})
 	}
@@ -346,12 +392,10 @@ func TestAccountService_Login_MissingJWTSecret(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAccountRepository(ctrl)
-	svc := NewAccountService(mockRepo)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, AccountSettings{})
 	ctx := context.Background()
 
-	// Ensure JWT_SECRET is not set
-	os.Unsetenv("JWT_SECRET")
-
 	validPassword := "password123"
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(validPassword), bcrypt.DefaultCost)
 
@@ -360,6 +404,7 @@ func TestAccountService_Login_MissingJWTSecret(t *testing.T) {
 		Username: "testuser",
 		Email:    "test@example.com",
 		Password: string(hashedPassword),
+		State:    entity.AccountStateActive,
 	}
 
 	mockRepo.EXPECT().
@@ -375,8 +420,8 @@ func TestAccountService_Login_MissingJWTSecret(t *testing.T) {
 	token, err := svc.Login(ctx, req)
 
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "JWT_SECRET")
-	assert.Empty(t, token)
+	assert.Contains(t, err.Error(), "JWT secret")
+	assert.Nil(t, token)
 }
 
 func TestAccountService_ListAccounts(t *testing.T) {
@@ -384,11 +429,13 @@ func TestAccountService_ListAccounts(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAccountRepository(ctrl)
-	svc := NewAccountService(mockRepo)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
 	ctx := context.Background()
 
 	tests := []struct {
 		name          string
+		filter        accounts.AccountFilter
 		limit         int
 		offset        int
 		setupMock     func()
@@ -401,26 +448,41 @@ func TestAccountService_ListAccounts(t *testing.T) {
 			limit:  10,
 			offset: 0,
 			setupMock: func() {
-				accounts := []*entity.Account{
+				accs := []*entity.Account{
 					{ID: uuid.New(), Username: "user1", Email: "user1@example.com"},
 					{ID: uuid.New(), Username: "user2", Email: "user2@example.com"},
 				}
 				mockRepo.EXPECT().
-					ListAccounts(ctx, 10, 0).
-					Return(accounts, 2, nil).
+					ListAccounts(ctx, accounts.AccountFilter{}, 10, 0).
+					Return(accs, 2, nil).
 					Times(1)
 			},
 			expectedCount: 2,
 			expectedTotal: 2,
 			expectedError: "",
 		},
+		{
+			name:   "success - passes search filter to repository",
+			filter: accounts.AccountFilter{Query: "user1", State: entity.AccountStateActive, Role: entity.AccountRoleUser},
+			limit:  10,
+			offset: 0,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					ListAccounts(ctx, accounts.AccountFilter{Query: "user1", State: entity.AccountStateActive, Role: entity.AccountRoleUser}, 10, 0).
+					Return([]*entity.Account{{ID: uuid.New(), Username: "user1", Email: "user1@example.com"}}, 1, nil).
+					Times(1)
+			},
+			expectedCount: 1,
+			expectedTotal: 1,
+			expectedError: "",
+		},
 		{
 			name:   "success - empty list",
 			limit:  10,
 			offset: 0,
 			setupMock: func() {
 				mockRepo.EXPECT().
-					ListAccounts(ctx, 10, 0).
+					ListAccounts(ctx, accounts.AccountFilter{}, 10, 0).
 					Return([]*entity.Account{}, 0, nil).
 					Times(1)
 			},
@@ -434,7 +496,7 @@ func TestAccountService_ListAccounts(t *testing.T) {
 			offset: 0,
 			setupMock: func() {
 				mockRepo.EXPECT().
-					ListAccounts(ctx, 10, 0).
+					ListAccounts(ctx, accounts.AccountFilter{}, 10, 0).
 					Return(nil, 0, errors.New("database error")).
 					Times(1)
 			},
@@ -448,14 +510,14 @@ func TestAccountService_ListAccounts(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			accounts, total, err := svc.ListAccounts(ctx, tt.limit, tt.offset)
+			result, total, err := svc.ListAccounts(ctx, tt.filter, tt.limit, tt.offset)
 
 			if tt.expectedError != "" {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
 			} else {
 				require.NoError(t, err)
-				assert.Len(t, accounts, tt.expectedCount)
+				assert.Len(t, result, tt.expectedCount)
 				assert.Equal(t, tt.expectedTotal, total)
 			}
 		})
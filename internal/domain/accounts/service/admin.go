@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+)
+
+// SupportView is the read-only picture of an account shown to admins.
+// It never exposes credentials or tokens and cannot be used to act as the account.
+type SupportView struct {
+	Account        *entity.Account
+	ActiveSessions []*entity.Session
+}
+
+// GetSupportView returns an account together with its active sessions
+func (s *AccountService) GetSupportView(ctx context.Context, accountID uuid.UUID) (*SupportView, error) {
+	acc, err := s.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.ListSessions(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SupportView{
+		Account:        acc,
+		ActiveSessions: sessions,
+	}, nil
+}
+
+// SuspendAccount deactivates an account on behalf of an admin and signs it out everywhere.
+// Admins cannot suspend themselves or other admins; demote them first.
+func (s *AccountService) SuspendAccount(ctx context.Context, adminID, accountID uuid.UUID) (*entity.Account, error) {
+	if adminID == accountID {
+		return nil, apperror.NewForbiddenError("admins cannot suspend their own account", "CANNOT_SUSPEND_SELF", nil)
+	}
+
+	acc, err := s.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.IsAdmin() {
+		return nil, apperror.NewForbiddenError("admin accounts must be demoted before they can be suspended", "CANNOT_SUSPEND_ADMIN", nil)
+	}
+
+	if acc.IsSuspended() {
+		return acc, nil
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdateState(ctx, acc.ID, entity.AccountStateSuspended, now); err != nil {
+		return nil, apperror.NewInternalServerError("failed to suspend account", "SUSPEND_ACCOUNT_ERROR", err)
+	}
+
+	if _, err := s.sessionRepo.RevokeAllSessions(ctx, acc.ID, now); err != nil {
+		return nil, apperror.NewInternalServerError("failed to revoke sessions", "REVOKE_SESSIONS_ERROR", err)
+	}
+
+	acc.State = entity.AccountStateSuspended
+	acc.UpdatedAt = now
+	return acc, nil
+}
+
+// UnsuspendAccount lifts a suspension; the account owner can log in again afterwards
+func (s *AccountService) UnsuspendAccount(ctx context.Context, accountID uuid.UUID) (*entity.Account, error) {
+	acc, err := s.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !acc.IsSuspended() {
+		return nil, apperror.NewConflictError("account is not suspended", "ACCOUNT_NOT_SUSPENDED", nil)
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdateState(ctx, acc.ID, entity.AccountStateActive, now); err != nil {
+		return nil, apperror.NewInternalServerError("failed to unsuspend account", "UNSUSPEND_ACCOUNT_ERROR", err)
+	}
+
+	acc.State = entity.AccountStateActive
+	acc.UpdatedAt = now
+	return acc, nil
+}
+
+// SetAccountRole promotes or demotes an account. Existing tokens of the account
+// stop working until they are refreshed, see ValidateSession.
+func (s *AccountService) SetAccountRole(ctx context.Context, adminID, accountID uuid.UUID, role string) (*entity.Account, error) {
+	if role != entity.AccountRoleUser && role != entity.AccountRoleAdmin {
+		return nil, apperror.NewBadRequestError("role must be user or admin", "INVALID_ROLE", nil)
+	}
+
+	// Keeps the last admin from locking everyone out by demoting themselves
+	if adminID == accountID {
+		return nil, apperror.NewForbiddenError("admins cannot change their own role", "CANNOT_CHANGE_OWN_ROLE", nil)
+	}
+
+	acc, err := s.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.Role == role {
+		return acc, nil
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdateRole(ctx, acc.ID, role, now); err != nil {
+		return nil, apperror.NewInternalServerError("failed to update role", "UPDATE_ROLE_ERROR", err)
+	}
+
+	acc.Role = role
+	acc.UpdatedAt = now
+	return acc, nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountService_SuspendAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+
+	adminID := uuid.New()
+	accountID := uuid.New()
+
+	tests := []struct {
+		name          string
+		accountID     uuid.UUID
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name:      "success - suspends account and revokes sessions",
+			accountID: accountID,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(&entity.Account{ID: accountID, State: entity.AccountStateActive, Role: entity.AccountRoleUser}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					UpdateState(ctx, accountID, entity.AccountStateSuspended, gomock.Any()).
+					Return(nil).
+					Times(1)
+				mockSessionRepo.EXPECT().
+					RevokeAllSessions(ctx, accountID, gomock.Any()).
+					Return(int64(2), nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "error - cannot suspend self",
+			accountID:     adminID,
+			setupMock:     func() {},
+			expectedError: "admins cannot suspend their own account",
+		},
+		{
+			name:      "error - cannot suspend another admin",
+			accountID: accountID,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(&entity.Account{ID: accountID, State: entity.AccountStateActive, Role: entity.AccountRoleAdmin}, nil).
+					Times(1)
+			},
+			expectedError: "admin accounts must be demoted",
+		},
+		{
+			name:      "error - account not found",
+			accountID: accountID,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(nil, errors.New("record not found")).
+					Times(1)
+			},
+			expectedError: "failed to get account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			acc, err := svc.SuspendAccount(ctx, adminID, tt.accountID)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, acc)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entity.AccountStateSuspended, acc.State)
+			assert.False(t, acc.IsActive())
+		})
+	}
+}
+
+func TestAccountService_UnsuspendAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	svc := NewAccountService(mockRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+	accountID := uuid.New()
+
+	tests := []struct {
+		name          string
+		state         string
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name:  "success - reactivates suspended account",
+			state: entity.AccountStateSuspended,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					UpdateState(ctx, accountID, entity.AccountStateActive, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "error - account is not suspended",
+			state:         entity.AccountStateInactive,
+			setupMock:     func() {},
+			expectedError: "account is not suspended",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().
+				GetByID(ctx, accountID).
+				Return(&entity.Account{ID: accountID, State: tt.state}, nil).
+				Times(1)
+			tt.setupMock()
+
+			acc, err := svc.UnsuspendAccount(ctx, accountID)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, acc.IsActive())
+		})
+	}
+}
+
+func TestAccountService_SetAccountRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	svc := NewAccountService(mockRepo, mocks.NewMockSessionRepository(ctrl), mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+
+	adminID := uuid.New()
+	accountID := uuid.New()
+
+	tests := []struct {
+		name          string
+		accountID     uuid.UUID
+		role          string
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name:      "success - promotes account",
+			accountID: accountID,
+			role:      entity.AccountRoleAdmin,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(&entity.Account{ID: accountID, Role: entity.AccountRoleUser}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					UpdateRole(ctx, accountID, entity.AccountRoleAdmin, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:      "success - unchanged role is a no-op",
+			accountID: accountID,
+			role:      entity.AccountRoleUser,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(&entity.Account{ID: accountID, Role: entity.AccountRoleUser}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:          "error - invalid role",
+			accountID:     accountID,
+			role:          "owner",
+			setupMock:     func() {},
+			expectedError: "role must be user or admin",
+		},
+		{
+			name:          "error - cannot change own role",
+			accountID:     adminID,
+			role:          entity.AccountRoleUser,
+			setupMock:     func() {},
+			expectedError: "admins cannot change their own role",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			acc, err := svc.SetAccountRole(ctx, adminID, tt.accountID, tt.role)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.role, acc.Role)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+)
+
+const (
+	// MaxAppPasswords bounds the active app passwords of one account
+	MaxAppPasswords = 25
+
+	maxAppPasswordNameLength = 100
+
+	// appPasswordGroups of appPasswordGroupLength letters give about 94 bits of entropy
+	appPasswordGroups      = 5
+	appPasswordGroupLength = 4
+	appPasswordAlphabet    = "abcdefghijklmnopqrstuvwxyz"
+
+	// appPasswordTouchInterval limits how often last_used_at is written, since
+	// calendar apps send several requests on every sync
+	appPasswordTouchInterval = 5 * time.Minute
+)
+
+// AppPasswordService manages the app passwords CalDAV clients log in with
+type AppPasswordService struct {
+	repo            accounts.AccountRepository
+	appPasswordRepo accounts.AppPasswordRepository
+}
+
+func NewAppPasswordService(repo accounts.AccountRepository, appPasswordRepo accounts.AppPasswordRepository) *AppPasswordService {
+	return &AppPasswordService{
+		repo:            repo,
+		appPasswordRepo: appPasswordRepo,
+	}
+}
+
+// CreateAppPassword generates a password for one device. The password cannot
+// be retrieved again.
+func (s *AppPasswordService) CreateAppPassword(ctx context.Context, accountID uuid.UUID, name string) (*accounts.AppPasswordCredentials, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperror.NewBadRequestError("name is required", "INVALID_REQUEST", nil)
+	}
+	if utf8.RuneCountInString(name) > maxAppPasswordNameLength {
+		return nil, apperror.NewBadRequestError("name must be at most 100 characters", "INVALID_REQUEST", nil)
+	}
+
+	acc, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("account not found", "ACCOUNT_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to get account", "GET_ACCOUNT_ERROR", err)
+	}
+
+	active, err := s.appPasswordRepo.ListActiveAppPasswords(ctx, accountID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list app passwords", "LIST_APP_PASSWORDS_ERROR", err)
+	}
+	if len(active) >= MaxAppPasswords {
+		return nil, apperror.NewConflictError("too many app passwords, revoke one first", "APP_PASSWORD_LIMIT_REACHED", nil)
+	}
+
+	password, err := newAppPassword()
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to generate app password", "APP_PASSWORD_ERROR", err)
+	}
+
+	appPassword := &entity.AppPassword{
+		ID:           uuid.New(),
+		AccountID:    accountID,
+		Name:         name,
+		PasswordHash: hashAppPassword(password),
+		CreatedAt:    time.Now(),
+	}
+	if err := s.appPasswordRepo.CreateAppPassword(ctx, appPassword); err != nil {
+		return nil, apperror.NewInternalServerError("failed to create app password", "CREATE_APP_PASSWORD_ERROR", err)
+	}
+
+	return &accounts.AppPasswordCredentials{
+		AppPassword: appPassword,
+		Username:    acc.Username,
+		Password:    password,
+	}, nil
+}
+
+func (s *AppPasswordService) ListAppPasswords(ctx context.Context, accountID uuid.UUID) ([]*entity.AppPassword, error) {
+	appPasswords, err := s.appPasswordRepo.ListActiveAppPasswords(ctx, accountID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list app passwords", "LIST_APP_PASSWORDS_ERROR", err)
+	}
+
+	return appPasswords, nil
+}
+
+func (s *AppPasswordService) RevokeAppPassword(ctx context.Context, accountID, appPasswordID uuid.UUID) error {
+	revoked, err := s.appPasswordRepo.RevokeAppPassword(ctx, accountID, appPasswordID, time.Now())
+	if err != nil {
+		return apperror.NewInternalServerError("failed to revoke app password", "REVOKE_APP_PASSWORD_ERROR", err)
+	}
+	if !revoked {
+		return apperror.NewNotFoundError("app password not found", "APP_PASSWORD_NOT_FOUND", nil)
+	}
+
+	return nil
+}
+
+// Authenticate checks HTTP Basic credentials, where the user is the username
+// or email of the account and the password one of its app passwords
+func (s *AppPasswordService) Authenticate(ctx context.Context, user, password string) (*entity.Account, error) {
+	invalid := apperror.NewUnauthorizedError("invalid username or app password", "INVALID_APP_PASSWORD", nil)
+	if user == "" || password == "" {
+		return nil, invalid
+	}
+
+	appPassword, err := s.appPasswordRepo.GetAppPasswordByHash(ctx, hashAppPassword(password))
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, invalid
+		}
+		return nil, apperror.NewInternalServerError("failed to get app password", "GET_APP_PASSWORD_ERROR", err)
+	}
+	if !appPassword.IsActive() {
+		return nil, invalid
+	}
+
+	acc, err := s.repo.GetByID(ctx, appPassword.AccountID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, invalid
+		}
+		return nil, apperror.NewInternalServerError("failed to get account", "GET_ACCOUNT_ERROR", err)
+	}
+	if acc.Username != user && !strings.EqualFold(acc.Email, user) {
+		return nil, invalid
+	}
+	if !acc.IsActive() {
+		return nil, apperror.NewUnauthorizedError("account is deactivated", "ACCOUNT_INACTIVE", nil)
+	}
+
+	now := time.Now()
+	if appPassword.LastUsedAt == nil || now.Sub(*appPassword.LastUsedAt) >= appPasswordTouchInterval {
+		if err := s.appPasswordRepo.TouchAppPassword(ctx, appPassword.ID, now); err != nil {
+			return nil, apperror.NewInternalServerError("failed to update app password", "UPDATE_APP_PASSWORD_ERROR", err)
+		}
+	}
+
+	return acc, nil
+}
+
+// newAppPassword returns lowercase letter groups such as "abcd-efgh-ijkl-mnop-qrst",
+// which are easy to type on a phone
+func newAppPassword() (string, error) {
+	b := make([]byte, appPasswordGroups*appPasswordGroupLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for i, v := range b {
+		if i > 0 && i%appPasswordGroupLength == 0 {
+			sb.WriteByte('-')
+		}
+		// 256 is not a multiple of 26; the bias is too small to matter at this length
+		sb.WriteByte(appPasswordAlphabet[int(v)%len(appPasswordAlphabet)])
+	}
+	return sb.String(), nil
+}
+
+// hashAppPassword ignores separators and case, since users often retype the password
+func hashAppPassword(password string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(password))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAppPasswordService_CreateAppPassword(t *testing.T) {
+	ctx := context.Background()
+	acc := &entity.Account{ID: uuid.New(), Username: "testuser", Email: "test@example.com", State: entity.AccountStateActive}
+
+	tests := []struct {
+		name          string
+		input         string
+		setupMock     func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository)
+		expectedError string
+	}{
+		{
+			name:  "success",
+			input: "  iPhone calendar ",
+			setupMock: func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(acc, nil)
+				mockAppPasswordRepo.EXPECT().ListActiveAppPasswords(ctx, acc.ID).Return(nil, nil)
+				mockAppPasswordRepo.EXPECT().CreateAppPassword(ctx, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:          "error - empty name",
+			input:         "  ",
+			setupMock:     func(*mocks.MockAccountRepository, *mocks.MockAppPasswordRepository) {},
+			expectedError: "name is required",
+		},
+		{
+			name:          "error - name too long",
+			input:         strings.Repeat("ป", 101),
+			setupMock:     func(*mocks.MockAccountRepository, *mocks.MockAppPasswordRepository) {},
+			expectedError: "at most 100 characters",
+		},
+		{
+			name:  "error - limit reached",
+			input: "Laptop",
+			setupMock: func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(acc, nil)
+				mockAppPasswordRepo.EXPECT().ListActiveAppPasswords(ctx, acc.ID).Return(make([]*entity.AppPassword, MaxAppPasswords), nil)
+			},
+			expectedError: "too many app passwords",
+		},
+		{
+			name:  "error - account not found",
+			input: "Laptop",
+			setupMock: func(mockRepo *mocks.MockAccountRepository, _ *mocks.MockAppPasswordRepository) {
+				mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(nil, apperror.ErrRecordNotFound)
+			},
+			expectedError: "account not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mocks.NewMockAccountRepository(ctrl)
+			mockAppPasswordRepo := mocks.NewMockAppPasswordRepository(ctrl)
+			svc := NewAppPasswordService(mockRepo, mockAppPasswordRepo)
+			tt.setupMock(mockRepo, mockAppPasswordRepo)
+
+			creds, err := svc.CreateAppPassword(ctx, acc.ID, tt.input)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "iPhone calendar", creds.AppPassword.Name)
+			assert.Equal(t, "testuser", creds.Username)
+			assert.Regexp(t, regexp.MustCompile(`^[a-z]{4}(-[a-z]{4}){4}$`), creds.Password)
+			assert.Equal(t, hashAppPassword(creds.Password), creds.AppPassword.PasswordHash)
+			assert.NotContains(t, creds.AppPassword.PasswordHash, creds.Password)
+		})
+	}
+}
+
+func TestAppPasswordService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	password := "abcd-efgh-ijkl-mnop-qrst"
+	acc := &entity.Account{ID: uuid.New(), Username: "testuser", Email: "Test@Example.com", State: entity.AccountStateActive}
+	recentlyUsed := time.Now().Add(-time.Minute)
+
+	newAppPassword := func() *entity.AppPassword {
+		return &entity.AppPassword{ID: uuid.New(), AccountID: acc.ID, PasswordHash: hashAppPassword(password)}
+	}
+
+	tests := []struct {
+		name          string
+		user          string
+		password      string
+		setupMock     func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository)
+		expectedError string
+	}{
+		{
+			name:     "success - username, first use is recorded",
+			user:     "testuser",
+			password: password,
+			setupMock: func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				appPassword := newAppPassword()
+				mockAppPasswordRepo.EXPECT().GetAppPasswordByHash(ctx, hashAppPassword(password)).Return(appPassword, nil)
+				mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(acc, nil)
+				mockAppPasswordRepo.EXPECT().TouchAppPassword(ctx, appPassword.ID, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "success - email in any case, password retyped without dashes",
+			user:     "test@example.com",
+			password: "ABCDEFGHIJKLMNOPQRST",
+			setupMock: func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				appPassword := newAppPassword()
+				appPassword.LastUsedAt = &recentlyUsed
+				mockAppPasswordRepo.EXPECT().GetAppPasswordByHash(ctx, hashAppPassword(password)).Return(appPassword, nil)
+				mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(acc, nil)
+			},
+		},
+		{
+			name:     "error - unknown password",
+			user:     "testuser",
+			password: "wrong",
+			setupMock: func(_ *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				mockAppPasswordRepo.EXPECT().GetAppPasswordByHash(ctx, hashAppPassword("wrong")).Return(nil, apperror.ErrRecordNotFound)
+			},
+			expectedError: "invalid username or app password",
+		},
+		{
+			name:     "error - revoked password",
+			user:     "testuser",
+			password: password,
+			setupMock: func(_ *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				appPassword := newAppPassword()
+				appPassword.RevokedAt = &recentlyUsed
+				mockAppPasswordRepo.EXPECT().GetAppPasswordByHash(ctx, hashAppPassword(password)).Return(appPassword, nil)
+			},
+			expectedError: "invalid username or app password",
+		},
+		{
+			name:     "error - password of another account",
+			user:     "someoneelse",
+			password: password,
+			setupMock: func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				mockAppPasswordRepo.EXPECT().GetAppPasswordByHash(ctx, hashAppPassword(password)).Return(newAppPassword(), nil)
+				mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(acc, nil)
+			},
+			expectedError: "invalid username or app password",
+		},
+		{
+			name:     "error - deactivated account",
+			user:     "testuser",
+			password: password,
+			setupMock: func(mockRepo *mocks.MockAccountRepository, mockAppPasswordRepo *mocks.MockAppPasswordRepository) {
+				mockAppPasswordRepo.EXPECT().GetAppPasswordByHash(ctx, hashAppPassword(password)).Return(newAppPassword(), nil)
+				mockRepo.EXPECT().GetByID(ctx, acc.ID).Return(&entity.Account{ID: acc.ID, Username: "testuser", State: entity.AccountStateInactive}, nil)
+			},
+			expectedError: "account is deactivated",
+		},
+		{
+			name:          "error - no credentials",
+			setupMock:     func(*mocks.MockAccountRepository, *mocks.MockAppPasswordRepository) {},
+			expectedError: "invalid username or app password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mocks.NewMockAccountRepository(ctrl)
+			mockAppPasswordRepo := mocks.NewMockAppPasswordRepository(ctrl)
+			svc := NewAppPasswordService(mockRepo, mockAppPasswordRepo)
+			tt.setupMock(mockRepo, mockAppPasswordRepo)
+
+			got, err := svc.Authenticate(ctx, tt.user, tt.password)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, acc.ID, got.ID)
+		})
+	}
+}
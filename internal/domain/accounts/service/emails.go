@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/mailer"
+)
+
+const defaultFrontendURL = "http://localhost:3000"
+
+func passwordResetEmail(frontendURL string, acc *entity.Account, token string) *mailer.Message {
+	link := frontendLink(frontendURL, "/reset-password", token)
+	return &mailer.Message{
+		To:      []string{acc.Email},
+		Subject: "Reset your Smart Task AI password",
+		Text: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to choose a new password. It expires in 1 hour and can only be used once.\n\n%s\n\nIf you did not ask for a password reset you can ignore this email.\n",
+			acc.Username, link,
+		),
+		HTML: fmt.Sprintf(
+			"<p>Hi %s,</p><p>Use the link below to choose a new password. It expires in 1 hour and can only be used once.</p><p><a href=\"%s\">Reset password</a></p><p>If you did not ask for a password reset you can ignore this email.</p>",
+			html.EscapeString(acc.Username), html.EscapeString(link),
+		),
+	}
+}
+
+func verificationEmail(frontendURL string, acc *entity.Account, token string) *mailer.Message {
+	link := frontendLink(frontendURL, "/verify-email", token)
+	return &mailer.Message{
+		To:      []string{acc.Email},
+		Subject: "Verify your Smart Task AI email address",
+		Text: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below. It expires in 48 hours.\n\n%s\n",
+			acc.Username, link,
+		),
+		HTML: fmt.Sprintf(
+			"<p>Hi %s,</p><p>Please confirm your email address by opening the link below. It expires in 48 hours.</p><p><a href=\"%s\">Verify email</a></p>",
+			html.EscapeString(acc.Username), html.EscapeString(link),
+		),
+	}
+}
+
+func frontendLink(base, path, token string) string {
+	if base == "" {
+		base = defaultFrontendURL
+	}
+	return strings.TrimRight(base, "/") + path + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/mailer"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+)
+
+// RequestPasswordReset emails a reset link to the account with the given email.
+// Unknown emails are ignored so the endpoint cannot be used to discover accounts.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	acc, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil
+		}
+		return apperror.NewInternalServerError("failed to get account", "GET_ACCOUNT_ERROR", err)
+	}
+
+	token, err := s.issueActionToken(ctx, acc, accounts.TokenPurposePasswordReset, accounts.PasswordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(ctx, passwordResetEmail(s.settings.FrontendURL, acc, token))
+}
+
+// ResetPassword sets a new password using a reset token and signs the account out everywhere
+func (s *AccountService) ResetPassword(ctx context.Context, req *account.ResetPasswordRequest) error {
+	if req == nil {
+		return apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	if req.Password != req.ConfirmPassword {
+		return apperror.NewBadRequestError("password and confirm password does not match", "PASSWORD_DOES_NOT_MATCH_ERROR", nil)
+	}
+
+	now := time.Now()
+	accountID, err := s.consumeActionToken(ctx, req.Token, accounts.TokenPurposePasswordReset, now)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return apperror.NewInternalServerError("failed to hash password", "HASH_PASSWORD_ERROR", err)
+	}
+
+	if err := s.repo.UpdatePassword(ctx, accountID, string(hashedPassword), now); err != nil {
+		return apperror.NewInternalServerError("failed to update password", "UPDATE_PASSWORD_ERROR", err)
+	}
+
+	// Following the emailed link also proves the account owns the address
+	if err := s.repo.MarkEmailVerified(ctx, accountID, now); err != nil {
+		return apperror.NewInternalServerError("failed to verify email", "VERIFY_EMAIL_ERROR", err)
+	}
+
+	if err := s.tokenRepo.InvalidateAccountTokens(ctx, accountID, accounts.TokenPurposePasswordReset, now); err != nil {
+		return apperror.NewInternalServerError("failed to invalidate tokens", "INVALIDATE_TOKENS_ERROR", err)
+	}
+
+	if _, err := s.sessionRepo.RevokeAllSessions(ctx, accountID, now); err != nil {
+		return apperror.NewInternalServerError("failed to revoke sessions", "REVOKE_SESSIONS_ERROR", err)
+	}
+
+	return nil
+}
+
+// SendVerificationEmail emails a verification link unless the account is already verified
+func (s *AccountService) SendVerificationEmail(ctx context.Context, acc *entity.Account) error {
+	if acc.IsEmailVerified() {
+		return nil
+	}
+
+	token, err := s.issueActionToken(ctx, acc, accounts.TokenPurposeEmailVerification, accounts.EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(ctx, verificationEmail(s.settings.FrontendURL, acc, token))
+}
+
+// ResendVerificationEmail looks the account up by email and sends a new verification link.
+// Like RequestPasswordReset it does not reveal whether the email exists.
+func (s *AccountService) ResendVerificationEmail(ctx context.Context, email string) error {
+	acc, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil
+		}
+		return apperror.NewInternalServerError("failed to get account", "GET_ACCOUNT_ERROR", err)
+	}
+
+	return s.SendVerificationEmail(ctx, acc)
+}
+
+// VerifyEmail marks the email of the token's account as verified
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) error {
+	now := time.Now()
+	accountID, err := s.consumeActionToken(ctx, token, accounts.TokenPurposeEmailVerification, now)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, accountID, now); err != nil {
+		return apperror.NewInternalServerError("failed to verify email", "VERIFY_EMAIL_ERROR", err)
+	}
+
+	if err := s.tokenRepo.InvalidateAccountTokens(ctx, accountID, accounts.TokenPurposeEmailVerification, now); err != nil {
+		return apperror.NewInternalServerError("failed to invalidate tokens", "INVALIDATE_TOKENS_ERROR", err)
+	}
+
+	return nil
+}
+
+// issueActionToken voids earlier tokens for the same purpose, so only the latest email works
+func (s *AccountService) issueActionToken(ctx context.Context, acc *entity.Account, purpose string, ttl time.Duration) (string, error) {
+	secret, err := s.jwtSecret()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	record := &entity.AccountToken{
+		ID:        uuid.New(),
+		AccountID: acc.ID,
+		Purpose:   purpose,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+
+	token, err := accounts.SignActionToken(secret, purpose, record.ID, acc.ID, now, record.ExpiresAt)
+	if err != nil {
+		return "", apperror.NewInternalServerError("failed to sign token", "SIGN_TOKEN_ERROR", err)
+	}
+
+	if err := s.tokenRepo.InvalidateAccountTokens(ctx, acc.ID, purpose, now); err != nil {
+		return "", apperror.NewInternalServerError("failed to invalidate tokens", "INVALIDATE_TOKENS_ERROR", err)
+	}
+
+	if err := s.tokenRepo.CreateAccountToken(ctx, record); err != nil {
+		return "", apperror.NewInternalServerError("failed to create token", "CREATE_TOKEN_ERROR", err)
+	}
+
+	return token, nil
+}
+
+// consumeActionToken verifies the token and marks it used, returning the account it was issued to
+func (s *AccountService) consumeActionToken(ctx context.Context, token, purpose string, now time.Time) (uuid.UUID, error) {
+	code := "INVALID_RESET_TOKEN"
+	if purpose == accounts.TokenPurposeEmailVerification {
+		code = "INVALID_VERIFICATION_TOKEN"
+	}
+	invalid := apperror.NewBadRequestError("token is invalid or has expired", code, nil)
+
+	secret, err := s.jwtSecret()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	claims, err := accounts.ParseActionToken(token, secret, purpose)
+	if err != nil {
+		return uuid.Nil, invalid
+	}
+
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return uuid.Nil, invalid
+	}
+	accountID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, invalid
+	}
+
+	consumed, err := s.tokenRepo.ConsumeAccountToken(ctx, tokenID, purpose, now)
+	if err != nil {
+		return uuid.Nil, apperror.NewInternalServerError("failed to consume token", "CONSUME_TOKEN_ERROR", err)
+	}
+	if !consumed {
+		return uuid.Nil, invalid
+	}
+
+	return accountID, nil
+}
+
+func (s *AccountService) sendEmail(ctx context.Context, msg *mailer.Message) error {
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return apperror.NewInternalServerError("failed to send email", "SEND_EMAIL_ERROR", err)
+	}
+	return nil
+}
+
+// checkEmailVerification applies the email verification grace period to unverified accounts.
+// nil means unverified accounts are not limited, 0 requires verification before the first login,
+// and any other duration lets new accounts log in for that long after signing up.
+func checkEmailVerification(acc *entity.Account, now time.Time, grace *time.Duration) error {
+	if acc.IsEmailVerified() || grace == nil {
+		return nil
+	}
+
+	if now.Before(acc.CreatedAt.Add(*grace)) {
+		return nil
+	}
+
+	return apperror.NewForbiddenError("email address has not been verified", "EMAIL_NOT_VERIFIED", nil)
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/mailer"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type fakeMailer struct {
+	sent []*mailer.Message
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, msg *mailer.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// tokenFromMessage extracts the token query parameter from the link in the email body
+func tokenFromMessage(t *testing.T, msg *mailer.Message) string {
+	for _, field := range strings.Fields(msg.Text) {
+		if u, err := url.Parse(field); err == nil && u.Query().Get("token") != "" {
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("no token link in email %q", msg.Text)
+	return ""
+}
+
+func TestAccountService_RequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockTokenRepo := mocks.NewMockAccountTokenRepository(ctrl)
+	ctx := context.Background()
+
+	acc := &entity.Account{ID: uuid.New(), Username: "testuser", Email: "test@example.com"}
+
+	tests := []struct {
+		name          string
+		email         string
+		mailErr       error
+		setupMock     func()
+		expectedError string
+		expectSent    int
+	}{
+		{
+			name:  "success - sends reset link",
+			email: "test@example.com",
+			setupMock: func() {
+				mockRepo.EXPECT().GetByEmail(ctx, "test@example.com").Return(acc, nil).Times(1)
+				mockTokenRepo.EXPECT().
+					InvalidateAccountTokens(ctx, acc.ID, accounts.TokenPurposePasswordReset, gomock.Any()).
+					Return(nil).
+					Times(1)
+				mockTokenRepo.EXPECT().
+					CreateAccountToken(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, token *entity.AccountToken) error {
+						assert.Equal(t, acc.ID, token.AccountID)
+						assert.Equal(t, accounts.TokenPurposePasswordReset, token.Purpose)
+						assert.WithinDuration(t, time.Now().Add(accounts.PasswordResetTokenTTL), token.ExpiresAt, time.Minute)
+						return nil
+					}).
+					Times(1)
+			},
+			expectSent: 1,
+		},
+		{
+			name:  "success - unknown email is ignored",
+			email: "nobody@example.com",
+			setupMock: func() {
+				mockRepo.EXPECT().GetByEmail(ctx, "nobody@example.com").Return(nil, apperror.ErrRecordNotFound).Times(1)
+			},
+		},
+		{
+			name:    "error - mailer failure",
+			email:   "test@example.com",
+			mailErr: errors.New("connection refused"),
+			setupMock: func() {
+				mockRepo.EXPECT().GetByEmail(ctx, "test@example.com").Return(acc, nil).Times(1)
+				mockTokenRepo.EXPECT().InvalidateAccountTokens(ctx, acc.ID, accounts.TokenPurposePasswordReset, gomock.Any()).Return(nil).Times(1)
+				mockTokenRepo.EXPECT().CreateAccountToken(ctx, gomock.Any()).Return(nil).Times(1)
+			},
+			expectedError: "failed to send email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &fakeMailer{err: tt.mailErr}
+			svc := NewAccountService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockTokenRepo, m, testSettings)
+			tt.setupMock()
+
+			err := svc.RequestPasswordReset(ctx, tt.email)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, m.sent, tt.expectSent)
+			if tt.expectSent > 0 {
+				assert.Equal(t, []string{acc.Email}, m.sent[0].To)
+				claims, err := accounts.ParseActionToken(tokenFromMessage(t, m.sent[0]), "testsecret", accounts.TokenPurposePasswordReset)
+				require.NoError(t, err)
+				assert.Equal(t, acc.ID.String(), claims.Subject)
+			}
+		})
+	}
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	mockTokenRepo := mocks.NewMockAccountTokenRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mockTokenRepo, &fakeMailer{}, testSettings)
+	ctx := context.Background()
+
+	accountID := uuid.New()
+	tokenID := uuid.New()
+	now := time.Now()
+
+	sign := func(purpose string, expiresAt time.Time) string {
+		token, err := accounts.SignActionToken("testsecret", purpose, tokenID, accountID, now, expiresAt)
+		require.NoError(t, err)
+		return token
+	}
+	validToken := sign(accounts.TokenPurposePasswordReset, now.Add(time.Hour))
+
+	tests := []struct {
+		name          string
+		request       *account.ResetPasswordRequest
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name:    "success - updates password and revokes sessions",
+			request: &account.ResetPasswordRequest{Token: validToken, Password: "newpass", ConfirmPassword: "newpass"},
+			setupMock: func() {
+				mockTokenRepo.EXPECT().
+					ConsumeAccountToken(ctx, tokenID, accounts.TokenPurposePasswordReset, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					UpdatePassword(ctx, accountID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, hash string, _ time.Time) error {
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte("newpass")))
+						return nil
+					}).
+					Times(1)
+				mockRepo.EXPECT().MarkEmailVerified(ctx, accountID, gomock.Any()).Return(nil).Times(1)
+				mockTokenRepo.EXPECT().
+					InvalidateAccountTokens(ctx, accountID, accounts.TokenPurposePasswordReset, gomock.Any()).
+					Return(nil).
+					Times(1)
+				mockSessionRepo.EXPECT().RevokeAllSessions(ctx, accountID, gomock.Any()).Return(int64(2), nil).Times(1)
+			},
+		},
+		{
+			name:    "error - token already used",
+			request: &account.ResetPasswordRequest{Token: validToken, Password: "newpass", ConfirmPassword: "newpass"},
+			setupMock: func() {
+				mockTokenRepo.EXPECT().
+					ConsumeAccountToken(ctx, tokenID, accounts.TokenPurposePasswordReset, gomock.Any()).
+					Return(false, nil).
+					Times(1)
+			},
+			expectedError: "token is invalid or has expired",
+		},
+		{
+			name:          "error - expired token",
+			request:       &account.ResetPasswordRequest{Token: sign(accounts.TokenPurposePasswordReset, now.Add(-time.Minute)), Password: "newpass", ConfirmPassword: "newpass"},
+			setupMock:     func() {},
+			expectedError: "token is invalid or has expired",
+		},
+		{
+			name:          "error - verification token cannot reset password",
+			request:       &account.ResetPasswordRequest{Token: sign(accounts.TokenPurposeEmailVerification, now.Add(time.Hour)), Password: "newpass", ConfirmPassword: "newpass"},
+			setupMock:     func() {},
+			expectedError: "token is invalid or has expired",
+		},
+		{
+			name:          "error - passwords do not match",
+			request:       &account.ResetPasswordRequest{Token: validToken, Password: "newpass", ConfirmPassword: "other"},
+			setupMock:     func() {},
+			expectedError: "password and confirm password does not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := svc.ResetPassword(ctx, tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockTokenRepo := mocks.NewMockAccountTokenRepository(ctrl)
+	m := &fakeMailer{}
+	svc := NewAccountService(mockRepo, mocks.NewMockSessionRepository(ctrl), mockTokenRepo, m, testSettings)
+	ctx := context.Background()
+
+	acc := &entity.Account{ID: uuid.New(), Username: "testuser", Email: "test@example.com"}
+
+	mockTokenRepo.EXPECT().
+		InvalidateAccountTokens(ctx, acc.ID, accounts.TokenPurposeEmailVerification, gomock.Any()).
+		Return(nil).
+		Times(2)
+	mockTokenRepo.EXPECT().CreateAccountToken(ctx, gomock.Any()).Return(nil).Times(1)
+	require.NoError(t, svc.SendVerificationEmail(ctx, acc))
+	require.Len(t, m.sent, 1)
+
+	mockTokenRepo.EXPECT().
+		ConsumeAccountToken(ctx, gomock.Any(), accounts.TokenPurposeEmailVerification, gomock.Any()).
+		Return(true, nil).
+		Times(1)
+	mockRepo.EXPECT().MarkEmailVerified(ctx, acc.ID, gomock.Any()).Return(nil).Times(1)
+	require.NoError(t, svc.VerifyEmail(ctx, tokenFromMessage(t, m.sent[0])))
+
+	// verified accounts are not emailed again
+	verifiedAt := time.Now()
+	acc.EmailVerifiedAt = &verifiedAt
+	require.NoError(t, svc.SendVerificationEmail(ctx, acc))
+	assert.Len(t, m.sent, 1)
+
+	err := svc.VerifyEmail(ctx, "not-a-token")
+	require.Error(t, err)
+	appErr, ok := apperror.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, "INVALID_VERIFICATION_TOKEN", appErr.Code)
+}
+
+func TestCheckEmailVerification(t *testing.T) {
+	now := time.Now()
+	verifiedAt := now.Add(-time.Hour)
+	immediately := time.Duration(0)
+	threeDays := 72 * time.Hour
+
+	tests := []struct {
+		name          string
+		gracePeriod   *time.Duration
+		account       *entity.Account
+		expectedError string
+	}{
+		{
+			name:    "unlimited when not configured",
+			account: &entity.Account{CreatedAt: now.Add(-365 * 24 * time.Hour)},
+		},
+		{
+			name:        "verified account always allowed",
+			gracePeriod: &immediately,
+			account:     &entity.Account{CreatedAt: now.Add(-time.Hour), EmailVerifiedAt: &verifiedAt},
+		},
+		{
+			name:        "unverified account within grace period",
+			gracePeriod: &threeDays,
+			account:     &entity.Account{CreatedAt: now.Add(-time.Hour)},
+		},
+		{
+			name:          "unverified account after grace period",
+			gracePeriod:   &threeDays,
+			account:       &entity.Account{CreatedAt: now.Add(-73 * time.Hour)},
+			expectedError: "email address has not been verified",
+		},
+		{
+			name:          "verification required immediately",
+			gracePeriod:   &immediately,
+			account:       &entity.Account{CreatedAt: now},
+			expectedError: "email address has not been verified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEmailVerification(tt.account, now, tt.gracePeriod)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
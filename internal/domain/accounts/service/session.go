@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+)
+
+// RefreshSession exchanges a refresh token for a new token pair and rotates the refresh token.
+// Presenting an already rotated token revokes the whole session, since it was most likely stolen.
+func (s *AccountService) RefreshSession(ctx context.Context, req *account.RefreshTokenRequest) (*accounts.TokenPair, error) {
+	if req == nil || req.RefreshToken == "" {
+		return nil, apperror.NewBadRequestError("refresh token is required", "INVALID_REQUEST", nil)
+	}
+
+	now := time.Now()
+	presentedHash := hashRefreshToken(req.RefreshToken)
+
+	session, err := s.sessionRepo.FindSessionByTokenHash(ctx, presentedHash)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewUnauthorizedError("invalid refresh token", "INVALID_REFRESH_TOKEN", nil)
+		}
+		return nil, apperror.NewInternalServerError("failed to get session", "GET_SESSION_ERROR", err)
+	}
+
+	if session.RefreshTokenHash != presentedHash {
+		if _, err := s.sessionRepo.RevokeSession(ctx, session.AccountID, session.ID, now); err != nil {
+			return nil, apperror.NewInternalServerError("failed to revoke session", "REVOKE_SESSION_ERROR", err)
+		}
+		return nil, apperror.NewUnauthorizedError("refresh token has already been used", "REFRESH_TOKEN_REUSED", nil)
+	}
+
+	if !session.IsActive(now) {
+		return nil, apperror.NewUnauthorizedError("session has expired or was revoked", "SESSION_EXPIRED", nil)
+	}
+
+	acc, err := s.repo.GetByID(ctx, session.AccountID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewUnauthorizedError("account not found", "ACCOUNT_NOT_FOUND", nil)
+		}
+		return nil, apperror.NewInternalServerError("failed to get account", "GET_ACCOUNT_ERROR", err)
+	}
+
+	if !acc.IsActive() {
+		return nil, apperror.NewUnauthorizedError("account is deactivated", "ACCOUNT_INACTIVE", nil)
+	}
+
+	if err := checkEmailVerification(acc, now, s.settings.EmailVerificationGracePeriod); err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to generate refresh token", "REFRESH_TOKEN_ERROR", err)
+	}
+
+	refreshExpiresAt := now.Add(accounts.RefreshTokenTTL)
+	rotated, err := s.sessionRepo.RotateSession(ctx, session.ID, presentedHash, hashRefreshToken(refreshToken), refreshExpiresAt, now)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to rotate session", "ROTATE_SESSION_ERROR", err)
+	}
+	if !rotated {
+		// Another request rotated the same token first
+		return nil, apperror.NewUnauthorizedError("refresh token has already been used", "REFRESH_TOKEN_REUSED", nil)
+	}
+
+	accessToken, accessExpiresAt, err := s.signAccessToken(acc, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accounts.TokenPair{
+		SessionID:        session.ID,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// ValidateSession is used by the auth middleware to reject access tokens of revoked sessions and inactive accounts
+func (s *AccountService) ValidateSession(ctx context.Context, claims *accounts.AccessClaims) error {
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return apperror.NewUnauthorizedError("invalid session", "INVALID_SESSION", nil)
+	}
+
+	session, err := s.sessionRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewUnauthorizedError("invalid session", "INVALID_SESSION", nil)
+		}
+		return apperror.NewInternalServerError("failed to get session", "GET_SESSION_ERROR", err)
+	}
+
+	if session.AccountID.String() != claims.AccountID || !session.IsActive(time.Now()) {
+		return apperror.NewUnauthorizedError("session has expired or was revoked", "SESSION_EXPIRED", nil)
+	}
+
+	acc, err := s.repo.GetByID(ctx, session.AccountID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewUnauthorizedError("account not found", "ACCOUNT_NOT_FOUND", nil)
+		}
+		return apperror.NewInternalServerError("failed to get account", "GET_ACCOUNT_ERROR", err)
+	}
+
+	if !acc.IsActive() {
+		return apperror.NewUnauthorizedError("account is deactivated", "ACCOUNT_INACTIVE", nil)
+	}
+
+	// Tokens issued before a role change must be refreshed so a demoted admin loses access immediately
+	if (claims.Role == entity.AccountRoleAdmin) != acc.IsAdmin() {
+		return apperror.NewUnauthorizedError("account role has changed, refresh the token", "ROLE_CHANGED", nil)
+	}
+
+	return nil
+}
+
+func (s *AccountService) ListSessions(ctx context.Context, accountID uuid.UUID) ([]*entity.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveSessions(ctx, accountID, time.Now())
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list sessions", "LIST_SESSIONS_ERROR", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends a single session of the account, e.g. on logout
+func (s *AccountService) RevokeSession(ctx context.Context, accountID, sessionID uuid.UUID) error {
+	revoked, err := s.sessionRepo.RevokeSession(ctx, accountID, sessionID, time.Now())
+	if err != nil {
+		return apperror.NewInternalServerError("failed to revoke session", "REVOKE_SESSION_ERROR", err)
+	}
+	if !revoked {
+		return apperror.NewNotFoundError("session not found", "SESSION_NOT_FOUND", nil)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions signs the account out everywhere and returns the number of revoked sessions
+func (s *AccountService) RevokeAllSessions(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	count, err := s.sessionRepo.RevokeAllSessions(ctx, accountID, time.Now())
+	if err != nil {
+		return 0, apperror.NewInternalServerError("failed to revoke sessions", "REVOKE_SESSIONS_ERROR", err)
+	}
+
+	return count, nil
+}
+
+func (s *AccountService) startSession(ctx context.Context, acc *entity.Account, client accounts.ClientInfo) (*accounts.TokenPair, error) {
+	now := time.Now()
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to generate refresh token", "REFRESH_TOKEN_ERROR", err)
+	}
+
+	session := &entity.Session{
+		ID:               uuid.New(),
+		AccountID:        acc.ID,
+		RefreshTokenHash: hashRefreshToken(refreshToken),
+		UserAgent:        truncate(client.UserAgent, 255),
+		IPAddress:        truncate(client.IPAddress, 45),
+		ExpiresAt:        now.Add(accounts.RefreshTokenTTL),
+		LastUsedAt:       now,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	// Sign first so a missing secret does not leave an orphaned session behind
+	accessToken, accessExpiresAt, err := s.signAccessToken(acc, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.CreateSession(ctx, session); err != nil {
+		return nil, apperror.NewInternalServerError("failed to create session", "CREATE_SESSION_ERROR", err)
+	}
+
+	return &accounts.TokenPair{
+		SessionID:        session.ID,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+func (s *AccountService) signAccessToken(acc *entity.Account, sessionID uuid.UUID, now time.Time) (string, time.Time, error) {
+	jwtSecret, err := s.jwtSecret()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := now.Add(accounts.AccessTokenTTL)
+	claims := accounts.AccessClaims{
+		AccountID: acc.ID.String(),
+		Email:     acc.Email,
+		Username:  acc.Username,
+		SessionID: sessionID.String(),
+		Role:      acc.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    accounts.TokenIssuer,
+			Subject:   acc.ID.String(),
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	t, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(jwtSecret))
+	if err != nil {
+		return "", time.Time{}, apperror.NewInternalServerError(
+			"failed to sign jwt",
+			"JWT_SIGN_ERROR",
+			err,
+		)
+	}
+
+	return t, expiresAt, nil
+}
+
+func (s *AccountService) jwtSecret() (string, error) {
+	if s.settings.JWTSecret == "" {
+		return "", apperror.NewInternalServerError(
+			"JWT secret is not configured",
+			"JWT_SECRET_MISSING",
+			nil,
+		)
+	}
+	return s.settings.JWTSecret, nil
+}
+
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max]
+	}
+	return s
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountService_RefreshSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+
+	refreshToken := "current-refresh-token"
+	currentHash := hashRefreshToken(refreshToken)
+	previousHash := hashRefreshToken("old-refresh-token")
+	acc := &entity.Account{ID: uuid.New(), Username: "testuser", Email: "test@example.com", State: entity.AccountStateActive}
+	revokedAt := time.Now().Add(-time.Minute)
+
+	newSession := func() *entity.Session {
+		return &entity.Session{
+			ID:                uuid.New(),
+			AccountID:         acc.ID,
+			RefreshTokenHash:  currentHash,
+			PreviousTokenHash: &previousHash,
+			ExpiresAt:         time.Now().Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		setupMock     func()
+		expectedError string
+		expectedCode  string
+	}{
+		{
+			name:  "success - rotates refresh token",
+			token: refreshToken,
+			setupMock: func() {
+				session := newSession()
+				mockSessionRepo.EXPECT().
+					FindSessionByTokenHash(ctx, currentHash).
+					Return(session, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					GetByID(ctx, acc.ID).
+					Return(acc, nil).
+					Times(1)
+				mockSessionRepo.EXPECT().
+					RotateSession(ctx, session.ID, currentHash, gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _, newHash string, _, _ time.Time) (bool, error) {
+						assert.NotEqual(t, currentHash, newHash)
+						return true, nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:  "error - unknown refresh token",
+			token: "unknown",
+			setupMock: func() {
+				mockSessionRepo.EXPECT().
+					FindSessionByTokenHash(ctx, hashRefreshToken("unknown")).
+					Return(nil, apperror.ErrRecordNotFound).
+					Times(1)
+			},
+			expectedError: "invalid refresh token",
+			expectedCode:  "INVALID_REFRESH_TOKEN",
+		},
+		{
+			name:  "error - reused refresh token revokes session",
+			token: "old-refresh-token",
+			setupMock: func() {
+				session := newSession()
+				mockSessionRepo.EXPECT().
+					FindSessionByTokenHash(ctx, previousHash).
+					Return(session, nil).
+					Times(1)
+				mockSessionRepo.EXPECT().
+					RevokeSession(ctx, acc.ID, session.ID, gomock.Any()).
+					Return(true, nil).
+					Times(1)
+			},
+			expectedError: "refresh token has already been used",
+			expectedCode:  "REFRESH_TOKEN_REUSED",
+		},
+		{
+			name:  "error - revoked session",
+			token: refreshToken,
+			setupMock: func() {
+				session := newSession()
+				session.RevokedAt = &revokedAt
+				mockSessionRepo.EXPECT().
+					FindSessionByTokenHash(ctx, currentHash).
+					Return(session, nil).
+					Times(1)
+			},
+			expectedError: "session has expired or was revoked",
+			expectedCode:  "SESSION_EXPIRED",
+		},
+		{
+			name:  "error - concurrent rotation wins",
+			token: refreshToken,
+			setupMock: func() {
+				session := newSession()
+				mockSessionRepo.EXPECT().
+					FindSessionByTokenHash(ctx, currentHash).
+					Return(session, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					GetByID(ctx, acc.ID).
+					Return(acc, nil).
+					Times(1)
+				mockSessionRepo.EXPECT().
+					RotateSession(ctx, session.ID, currentHash, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(false, nil).
+					Times(1)
+			},
+			expectedError: "refresh token has already been used",
+			expectedCode:  "REFRESH_TOKEN_REUSED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			res, err := svc.RefreshSession(ctx, &account.RefreshTokenRequest{RefreshToken: tt.token})
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				appErr, ok := apperror.IsAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, appErr.Code)
+				assert.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEqual(t, refreshToken, res.RefreshToken)
+			claims, err := accounts.ParseAccessToken(res.AccessToken, "testsecret")
+			require.NoError(t, err)
+			assert.Equal(t, acc.ID.String(), claims.AccountID)
+		})
+	}
+}
+
+func TestAccountService_ValidateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+
+	accountID := uuid.New()
+	sessionID := uuid.New()
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		claims        *accounts.AccessClaims
+		setupMock     func()
+		expectedError string
+	}{
+		{
+			name:   "success - active session",
+			claims: &accounts.AccessClaims{AccountID: accountID.String(), SessionID: sessionID.String()},
+			setupMock: func() {
+				mockSessionRepo.EXPECT().
+					GetSessionByID(ctx, sessionID).
+					Return(&entity.Session{ID: sessionID, AccountID: accountID, ExpiresAt: time.Now().Add(time.Hour)}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(&entity.Account{ID: accountID, State: entity.AccountStateActive}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:   "error - inactive account",
+			claims: &accounts.AccessClaims{AccountID: accountID.String(), SessionID: sessionID.String()},
+			setupMock: func() {
+				mockSessionRepo.EXPECT().
+					GetSessionByID(ctx, sessionID).
+					Return(&entity.Session{ID: sessionID, AccountID: accountID, ExpiresAt: time.Now().Add(time.Hour)}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(&entity.Account{ID: accountID, State: entity.AccountStateInactive}, nil).
+					Times(1)
+			},
+			expectedError: "account is deactivated",
+		},
+		{
+			name:   "error - admin token after demotion",
+			claims: &accounts.AccessClaims{AccountID: accountID.String(), SessionID: sessionID.String(), Role: entity.AccountRoleAdmin},
+			setupMock: func() {
+				mockSessionRepo.EXPECT().
+					GetSessionByID(ctx, sessionID).
+					Return(&entity.Session{ID: sessionID, AccountID: accountID, ExpiresAt: time.Now().Add(time.Hour)}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					GetByID(ctx, accountID).
+					Return(&entity.Account{ID: accountID, State: entity.AccountStateActive, Role: entity.AccountRoleUser}, nil).
+					Times(1)
+			},
+			expectedError: "account role has changed",
+		},
+		{
+			name:          "error - token without session",
+			claims:        &accounts.AccessClaims{AccountID: accountID.String()},
+			setupMock:     func() {},
+			expectedError: "invalid session",
+		},
+		{
+			name:   "error - revoked session",
+			claims: &accounts.AccessClaims{AccountID: accountID.String(), SessionID: sessionID.String()},
+			setupMock: func() {
+				mockSessionRepo.EXPECT().
+					GetSessionByID(ctx, sessionID).
+					Return(&entity.Session{ID: sessionID, AccountID: accountID, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil).
+					Times(1)
+			},
+			expectedError: "session has expired or was revoked",
+		},
+		{
+			name:   "error - session of another account",
+			claims: &accounts.AccessClaims{AccountID: uuid.NewString(), SessionID: sessionID.String()},
+			setupMock: func() {
+				mockSessionRepo.EXPECT().
+					GetSessionByID(ctx, sessionID).
+					Return(&entity.Session{ID: sessionID, AccountID: accountID, ExpiresAt: time.Now().Add(time.Hour)}, nil).
+					Times(1)
+			},
+			expectedError: "session has expired or was revoked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			err := svc.ValidateSession(ctx, tt.claims)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAccountService_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAccountRepository(ctrl)
+	mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+	svc := NewAccountService(mockRepo, mockSessionRepo, mocks.NewMockAccountTokenRepository(ctrl), nil, testSettings)
+	ctx := context.Background()
+
+	accountID := uuid.New()
+	sessionID := uuid.New()
+
+	mockSessionRepo.EXPECT().
+		RevokeSession(ctx, accountID, sessionID, gomock.Any()).
+		Return(true, nil).
+		Times(1)
+	require.NoError(t, svc.RevokeSession(ctx, accountID, sessionID))
+
+	mockSessionRepo.EXPECT().
+		RevokeSession(ctx, accountID, sessionID, gomock.Any()).
+		Return(false, nil).
+		Times(1)
+	err := svc.RevokeSession(ctx, accountID, sessionID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "session not found")
+
+	mockSessionRepo.EXPECT().
+		RevokeAllSessions(ctx, accountID, gomock.Any()).
+		Return(int64(3), nil).
+		Times(1)
+	count, err := svc.RevokeAllSessions(ctx, accountID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
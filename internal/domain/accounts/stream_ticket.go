@@ -0,0 +1,111 @@
+package accounts
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// StreamTicketTTL is how long a stream ticket can be used to open its stream
+const StreamTicketTTL = time.Minute
+
+// StreamTicketClaims are the claims of a stream ticket: the access token it was
+// issued with, for the one stream path in aud. EventSource cannot set the
+// Authorization header, so browsers open streams with a ticket in the URL.
+type StreamTicketClaims struct {
+	AccountID string `json:"AccountId"`
+	Email     string `json:"Email"`
+	Username  string `json:"Username"`
+	SessionID string `json:"sid"`
+	Role      string `json:"role,omitempty"`
+	// AccessExpiresAt is when the access token expires; a stream opened with the ticket ends then
+	AccessExpiresAt *jwt.NumericDate `json:"access_exp"`
+	jwt.RegisteredClaims
+}
+
+// SignStreamTicket signs a ticket for the stream at path on behalf of access.
+// It expires after StreamTicketTTL, or with the access token if that is sooner.
+func SignStreamTicket(secret string, access *AccessClaims, path string, now time.Time) (string, time.Time, error) {
+	if secret == "" {
+		return "", time.Time{}, errors.New("jwt secret is empty")
+	}
+	if access.ExpiresAt == nil {
+		return "", time.Time{}, errors.New("access token has no expiry")
+	}
+
+	expiresAt := now.Add(StreamTicketTTL)
+	if access.ExpiresAt.Before(expiresAt) {
+		expiresAt = access.ExpiresAt.Time
+	}
+
+	claims := StreamTicketClaims{
+		AccountID:       access.AccountID,
+		Email:           access.Email,
+		Username:        access.Username,
+		SessionID:       access.SessionID,
+		Role:            access.Role,
+		AccessExpiresAt: access.ExpiresAt,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    TokenIssuer,
+			Subject:   access.AccountID,
+			Audience:  jwt.ClaimStrings{path},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	ticket, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(streamTicketKey(secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return ticket, expiresAt, nil
+}
+
+// ParseStreamTicket verifies a ticket for the stream at path and returns the
+// claims of the access token it was issued with
+func ParseStreamTicket(tokenStr, secret, path string) (*AccessClaims, error) {
+	if secret == "" {
+		return nil, errors.New("jwt secret is empty")
+	}
+
+	claims := &StreamTicketClaims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		return streamTicketKey(secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(TokenIssuer),
+		jwt.WithAudience(path),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.AccessExpiresAt == nil {
+		return nil, errors.New("invalid token")
+	}
+
+	return &AccessClaims{
+		AccountID: claims.AccountID,
+		Email:     claims.Email,
+		Username:  claims.Username,
+		SessionID: claims.SessionID,
+		Role:      claims.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    claims.Issuer,
+			Subject:   claims.Subject,
+			IssuedAt:  claims.IssuedAt,
+			ExpiresAt: claims.AccessExpiresAt,
+		},
+	}, nil
+}
+
+// streamTicketKey keeps tickets and access tokens from being accepted as one another
+func streamTicketKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("stream-ticket"))
+	return mac.Sum(nil)
+}
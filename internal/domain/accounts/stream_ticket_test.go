@@ -0,0 +1,83 @@
+package accounts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamTicket(t *testing.T) {
+	const (
+		secret = "test-secret"
+		path   = "/api/projects/prj_1/events"
+	)
+	now := time.Now().Truncate(time.Second)
+	access := &AccessClaims{
+		AccountID: "0b0a5d1e-4a3c-4f5e-9d3b-2f1c6e7a8b9c",
+		Username:  "johndoe",
+		SessionID: "5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a8b",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    TokenIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+		},
+	}
+
+	t.Run("stands for the access token on its path", func(t *testing.T) {
+		ticket, expiresAt, err := SignStreamTicket(secret, access, path, now)
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(StreamTicketTTL), expiresAt)
+
+		claims, err := ParseStreamTicket(ticket, secret, path)
+		require.NoError(t, err)
+		assert.Equal(t, access.AccountID, claims.AccountID)
+		assert.Equal(t, access.Username, claims.Username)
+		assert.Equal(t, access.SessionID, claims.SessionID)
+		// Streams end with the access token, not the ticket
+		assert.Equal(t, access.ExpiresAt.Time, claims.ExpiresAt.Time)
+	})
+
+	t.Run("expires with an access token about to expire", func(t *testing.T) {
+		soon := *access
+		soon.ExpiresAt = jwt.NewNumericDate(now.Add(10 * time.Second))
+
+		_, expiresAt, err := SignStreamTicket(secret, &soon, path, now)
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(10*time.Second), expiresAt)
+	})
+
+	t.Run("rejects another path", func(t *testing.T) {
+		ticket, _, err := SignStreamTicket(secret, access, path, now)
+		require.NoError(t, err)
+
+		_, err = ParseStreamTicket(ticket, secret, "/api/projects/prj_2/events")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an expired ticket", func(t *testing.T) {
+		ticket, _, err := SignStreamTicket(secret, access, path, now.Add(-2*StreamTicketTTL))
+		require.NoError(t, err)
+
+		_, err = ParseStreamTicket(ticket, secret, path)
+		assert.Error(t, err)
+	})
+
+	t.Run("is not an access token", func(t *testing.T) {
+		ticket, _, err := SignStreamTicket(secret, access, path, now)
+		require.NoError(t, err)
+
+		_, err = ParseAccessToken(ticket, secret)
+		assert.Error(t, err)
+	})
+
+	t.Run("access token is not a ticket", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, access).SignedString([]byte(secret))
+		require.NoError(t, err)
+
+		_, err = ParseStreamTicket(token, secret, path)
+		assert.Error(t, err)
+	})
+}
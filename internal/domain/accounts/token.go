@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// TokenIssuer is the iss claim of every access token
+	TokenIssuer = "smart-task-ai"
+
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// AccessClaims are the claims carried by an access token.
+// The custom claim names are kept for clients that already read them.
+type AccessClaims struct {
+	AccountID string `json:"AccountId"`
+	Email     string `json:"Email"`
+	Username  string `json:"Username"`
+	SessionID string `json:"sid"`
+	Role      string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// TokenPair is returned on login and on every refresh
+type TokenPair struct {
+	SessionID        uuid.UUID
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// ClientInfo describes the device a session was created from
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// ParseAccessToken verifies the signature and registered claims (exp, nbf, iat, iss) of an access token
+func ParseAccessToken(tokenStr, secret string) (*AccessClaims, error) {
+	if secret == "" {
+		return nil, errors.New("jwt secret is empty")
+	}
+
+	claims := &AccessClaims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(TokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const FeedIDPrefix = "cal"
+
+// Feed is a secret iCalendar subscription URL of an account. A feed with a
+// ProjectID covers that project only, otherwise every project of the account.
+// Only the SHA-256 hash of the URL token is stored.
+type Feed struct {
+	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	AccountID uuid.UUID  `gorm:"column:account_id;type:char(36);index;not null"`
+	ProjectID *uuid.UUID `gorm:"column:project_id;type:char(36);index"`
+	TokenHash string     `gorm:"column:token_hash;type:char(64);uniqueIndex;not null"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+}
+
+func (Feed) TableName() string {
+	return "calendar_feeds"
+}
+
+// IsActive reports whether the feed URL can still be used
+func (f *Feed) IsActive() bool {
+	return f.RevokedAt == nil
+}
@@ -0,0 +1,182 @@
+package calendars
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// ProductID identifies the generator of the feeds (PRODID)
+	ProductID = "-//Smart Task AI//Calendar Feed//EN"
+
+	// RefreshInterval is how often subscribed calendar apps are asked to poll, as an RFC 5545 duration
+	RefreshInterval = "PT1H"
+
+	// maxLineOctets is the RFC 5545 limit of a content line, excluding CRLF
+	maxLineOctets = 75
+
+	icalDateTime = "20060102T150405Z"
+)
+
+// Calendar is an iCalendar (RFC 5545) object published as a feed
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Event is a VEVENT. Times are written in UTC; a zero End gives a zero-length event.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	// AllDay events are written as the UTC dates of Start, End and Until
+	AllDay bool
+	// IntervalDays repeats the event every n days when positive
+	IntervalDays int
+	// Until bounds the recurrence, inclusive; zero repeats forever
+	Until time.Time
+	// Priority is 1 (highest) to 9 (lowest), 0 leaves it undefined
+	Priority     int
+	Created      time.Time
+	LastModified time.Time
+}
+
+// RRule returns the recurrence rule of the event, or "" when it does not repeat
+func (e Event) RRule() string {
+	if e.IntervalDays <= 0 {
+		return ""
+	}
+	rule := "FREQ=DAILY"
+	if e.IntervalDays > 1 {
+		rule += fmt.Sprintf(";INTERVAL=%d", e.IntervalDays)
+	}
+	if !e.Until.IsZero() {
+		// UNTIL must have the value type of DTSTART
+		rule += ";UNTIL=" + e.formatTime(e.Until)
+	}
+	return rule
+}
+
+// Encode renders the calendar with CRLF line endings and folded long lines
+func (c *Calendar) Encode() []byte {
+	w := &contentWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", ProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	// REFRESH-INTERVAL is the standard (RFC 7986), X-PUBLISHED-TTL is read by Outlook
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", RefreshInterval)
+	w.line("X-PUBLISHED-TTL", RefreshInterval)
+
+	for _, e := range c.Events {
+		w.event(e)
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// EncodeObject renders one event as a CalDAV calendar object resource, which
+// must not carry METHOD or the feed properties of a subscribed calendar
+func EncodeObject(e Event) []byte {
+	w := &contentWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", ProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.event(e)
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+type contentWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *contentWriter) event(e Event) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", escapeText(e.UID))
+	w.line("DTSTAMP", formatDateTime(e.LastModified))
+	dateParam := ""
+	if e.AllDay {
+		dateParam = ";VALUE=DATE"
+	}
+	w.line("DTSTART"+dateParam, e.formatTime(e.Start))
+	if !e.End.IsZero() {
+		w.line("DTEND"+dateParam, e.formatTime(e.End))
+	}
+	if rule := e.RRule(); rule != "" {
+		w.line("RRULE", rule)
+	}
+	w.line("SUMMARY", escapeText(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", escapeText(e.Description))
+	}
+	if e.Location != "" {
+		w.line("LOCATION", escapeText(e.Location))
+	}
+	if e.Priority > 0 {
+		w.line("PRIORITY", fmt.Sprintf("%d", e.Priority))
+	}
+	if !e.Created.IsZero() {
+		w.line("CREATED", formatDateTime(e.Created))
+	}
+	w.line("LAST-MODIFIED", formatDateTime(e.LastModified))
+	w.line("END", "VEVENT")
+}
+
+// line writes name:value, folding it into lines of at most 75 octets
+// without splitting a UTF-8 sequence
+func (w *contentWriter) line(name, value string) {
+	s := name + ":" + value
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escapeText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// formatTime writes t as a DATE for all-day events and a DATE-TIME otherwise
+func (e Event) formatTime(t time.Time) string {
+	if e.AllDay {
+		return t.UTC().Format(icalDate)
+	}
+	return formatDateTime(t)
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(icalDateTime)
+}
@@ -0,0 +1,521 @@
+package calendars
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// Calendars name IANA zones; the runtime image ships without a zone database
+	_ "time/tzdata"
+)
+
+const (
+	// MaxImportBytes is the largest .ics file that can be imported
+	MaxImportBytes = 2 << 20
+
+	// MaxImportEvents bounds the VEVENTs read from one file
+	MaxImportEvents = 2000
+
+	// MaxImportTasks bounds the tasks one import may create
+	MaxImportTasks = 5000
+
+	icalLocalDateTime = "20060102T150405"
+	icalDate          = "20060102"
+)
+
+// ErrInvalidCalendar is returned when the input is not an iCalendar document
+var ErrInvalidCalendar = errors.New("not a valid iCalendar file")
+
+// ParsedEvent is a VEVENT read from an imported file, with times resolved to their zone
+type ParsedEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	// End is zero when the event has neither DTEND nor DURATION
+	End    time.Time
+	AllDay bool
+	// RRule is nil for single events
+	RRule   *RecurrenceRule
+	ExDates []time.Time
+	// RecurrenceID is set on an event that replaces one occurrence of a series
+	RecurrenceID time.Time
+	Cancelled    bool
+	// Priority is the RFC 5545 value, 1 (highest) to 9, 0 when undefined
+	Priority int
+}
+
+// RecurrenceRule is the part of an RRULE that tasks can represent
+type RecurrenceRule struct {
+	Freq     string
+	Interval int
+	Until    time.Time
+	Count    int
+	ByDay    []time.Weekday
+	// WeekStart decides which week an occurrence of a multi-week rule falls in
+	WeekStart time.Weekday
+	// Unsupported lists rule parts that were ignored, e.g. BYMONTHDAY
+	Unsupported []string
+}
+
+// Parse reads the VEVENTs of an iCalendar document. Floating times, and zones
+// that cannot be resolved, use defaultLoc.
+func Parse(r io.Reader, defaultLoc *time.Location) ([]ParsedEvent, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0].String(), "BEGIN:VCALENDAR") {
+		return nil, ErrInvalidCalendar
+	}
+
+	root, err := buildComponents(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := &zoneResolver{defaultLoc: defaultLoc, offsets: make(map[string]*time.Location)}
+	if tz := root.first("X-WR-TIMEZONE"); tz != nil {
+		if loc, err := time.LoadLocation(tz.Value); err == nil {
+			zones.defaultLoc = loc
+		}
+	}
+	for _, c := range root.children {
+		if c.name == "VTIMEZONE" {
+			zones.addVTimezone(c)
+		}
+	}
+
+	var events []ParsedEvent
+	for _, c := range root.children {
+		if c.name != "VEVENT" {
+			continue
+		}
+		if len(events) == MaxImportEvents {
+			return nil, fmt.Errorf("calendar has more than %d events", MaxImportEvents)
+		}
+		event, err := parseEvent(c, zones)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func parseEvent(c *component, zones *zoneResolver) (ParsedEvent, error) {
+	event := ParsedEvent{
+		UID:         c.text("UID"),
+		Summary:     c.text("SUMMARY"),
+		Description: c.text("DESCRIPTION"),
+		Location:    c.text("LOCATION"),
+		Cancelled:   strings.EqualFold(c.text("STATUS"), "CANCELLED"),
+	}
+	if event.UID == "" {
+		return event, fmt.Errorf("event %q has no UID", event.Summary)
+	}
+	if p, err := strconv.Atoi(c.text("PRIORITY")); err == nil && p >= 0 && p <= 9 {
+		event.Priority = p
+	}
+
+	dtstart := c.first("DTSTART")
+	if dtstart == nil {
+		return event, fmt.Errorf("event %s has no DTSTART", event.UID)
+	}
+	start, allDay, err := zones.parseTime(dtstart)
+	if err != nil {
+		return event, fmt.Errorf("event %s: invalid DTSTART: %w", event.UID, err)
+	}
+	event.Start, event.AllDay = start, allDay
+
+	if dtend := c.first("DTEND"); dtend != nil {
+		if event.End, _, err = zones.parseTime(dtend); err != nil {
+			return event, fmt.Errorf("event %s: invalid DTEND: %w", event.UID, err)
+		}
+	} else if dur := c.first("DURATION"); dur != nil {
+		d, err := parseDuration(dur.Value)
+		if err != nil {
+			return event, fmt.Errorf("event %s: invalid DURATION: %w", event.UID, err)
+		}
+		event.End = event.Start.Add(d)
+	} else if allDay {
+		// An all-day event without an end lasts the whole day
+		event.End = event.Start.AddDate(0, 0, 1)
+	}
+
+	if rrule := c.first("RRULE"); rrule != nil {
+		rule, err := parseRRule(rrule.Value, event.Start.Location())
+		if err != nil {
+			return event, fmt.Errorf("event %s: invalid RRULE: %w", event.UID, err)
+		}
+		event.RRule = rule
+	}
+
+	for _, exdate := range c.all("EXDATE") {
+		for _, v := range strings.Split(exdate.Value, ",") {
+			t, _, err := zones.parseTime(&contentLine{Name: exdate.Name, Params: exdate.Params, Value: v})
+			if err != nil {
+				return event, fmt.Errorf("event %s: invalid EXDATE: %w", event.UID, err)
+			}
+			event.ExDates = append(event.ExDates, t)
+		}
+	}
+
+	if rid := c.first("RECURRENCE-ID"); rid != nil {
+		if event.RecurrenceID, _, err = zones.parseTime(rid); err != nil {
+			return event, fmt.Errorf("event %s: invalid RECURRENCE-ID: %w", event.UID, err)
+		}
+	}
+
+	return event, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func parseRRule(value string, loc *time.Location) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1, WeekStart: time.Monday}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", val)
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, allDay, err := parseTimeValue(val, loc)
+			if err != nil {
+				return nil, err
+			}
+			if allDay {
+				// A date bound includes the whole day
+				t = t.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			rule.Until = t
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				wd, ok := weekdays[strings.ToUpper(d)]
+				if !ok {
+					// Ordinal days such as 2MO only make sense for monthly rules
+					rule.Unsupported = append(rule.Unsupported, "BYDAY="+val)
+					rule.ByDay = nil
+					break
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "WKST":
+			wd, ok := weekdays[strings.ToUpper(val)]
+			if !ok {
+				return nil, fmt.Errorf("invalid WKST %q", val)
+			}
+			rule.WeekStart = wd
+		default:
+			rule.Unsupported = append(rule.Unsupported, strings.ToUpper(key))
+		}
+	}
+	if rule.Freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	return rule, nil
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses an RFC 5545 DURATION such as PT1H30M or P1D
+func parseDuration(s string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(strings.ToUpper(s))
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+2])
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseTimeValue parses a DATE or DATE-TIME value; UTC values end with Z
+func parseTimeValue(v string, loc *time.Location) (time.Time, bool, error) {
+	switch {
+	case len(v) == len(icalDate):
+		t, err := time.ParseInLocation(icalDate, v, loc)
+		return t, true, err
+	case strings.HasSuffix(v, "Z"):
+		t, err := time.Parse(icalDateTime, v)
+		return t, false, err
+	default:
+		t, err := time.ParseInLocation(icalLocalDateTime, v, loc)
+		return t, false, err
+	}
+}
+
+// zoneResolver maps TZID parameters to locations
+type zoneResolver struct {
+	defaultLoc *time.Location
+	// offsets holds fixed zones built from VTIMEZONE components with unknown names
+	offsets map[string]*time.Location
+}
+
+func (z *zoneResolver) parseTime(l *contentLine) (time.Time, bool, error) {
+	return parseTimeValue(l.Value, z.location(l.Params["TZID"]))
+}
+
+func (z *zoneResolver) location(tzid string) *time.Location {
+	if tzid == "" {
+		return z.defaultLoc
+	}
+	if loc, err := time.LoadLocation(tzid); err == nil {
+		return loc
+	}
+	// Some producers prefix the IANA name, e.g. /mozilla.org/20050126_1/Europe/Berlin
+	if parts := strings.Split(strings.Trim(tzid, "/"), "/"); len(parts) >= 2 {
+		if loc, err := time.LoadLocation(strings.Join(parts[len(parts)-2:], "/")); err == nil {
+			return loc
+		}
+	}
+	if loc, ok := z.offsets[tzid]; ok {
+		return loc
+	}
+	return z.defaultLoc
+}
+
+// addVTimezone registers the standard offset of a zone, e.g. Outlook's
+// "SE Asia Standard Time", whose name is not in the zone database
+func (z *zoneResolver) addVTimezone(c *component) {
+	tzid := c.text("TZID")
+	if tzid == "" {
+		return
+	}
+	for _, sub := range c.children {
+		if sub.name != "STANDARD" {
+			continue
+		}
+		if offset, ok := parseUTCOffset(sub.text("TZOFFSETTO")); ok {
+			z.offsets[tzid] = time.FixedZone(tzid, offset)
+		}
+		return
+	}
+}
+
+// parseUTCOffset parses +HHMM or -HHMMSS into seconds east of UTC
+func parseUTCOffset(s string) (int, bool) {
+	if len(s) != 5 && len(s) != 7 || (s[0] != '+' && s[0] != '-') {
+		return 0, false
+	}
+	h, err1 := strconv.Atoi(s[1:3])
+	m, err2 := strconv.Atoi(s[3:5])
+	sec := 0
+	var err3 error
+	if len(s) == 7 {
+		sec, err3 = strconv.Atoi(s[5:7])
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, false
+	}
+	offset := h*3600 + m*60 + sec
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, true
+}
+
+// contentLine is one unfolded "NAME;PARAM=value:VALUE" line
+type contentLine struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+func (l *contentLine) String() string {
+	return l.Name + ":" + l.Value
+}
+
+type component struct {
+	name     string
+	props    []*contentLine
+	children []*component
+}
+
+func (c *component) first(name string) *contentLine {
+	for _, p := range c.props {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (c *component) all(name string) []*contentLine {
+	var lines []*contentLine
+	for _, p := range c.props {
+		if p.Name == name {
+			lines = append(lines, p)
+		}
+	}
+	return lines
+}
+
+// text returns the unescaped TEXT value of a property, or ""
+func (c *component) text(name string) string {
+	if p := c.first(name); p != nil {
+		return unescapeText(p.Value)
+	}
+	return ""
+}
+
+func buildComponents(lines []*contentLine) (*component, error) {
+	var stack []*component
+	var root *component
+	for _, l := range lines {
+		switch l.Name {
+		case "BEGIN":
+			c := &component{name: strings.ToUpper(l.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, c)
+			} else if root == nil {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != strings.ToUpper(l.Value) {
+				return nil, fmt.Errorf("%w: unexpected END:%s", ErrInvalidCalendar, l.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: property %s outside a component", ErrInvalidCalendar, l.Name)
+			}
+			c := stack[len(stack)-1]
+			c.props = append(c.props, l)
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrInvalidCalendar, stack[len(stack)-1].name)
+	}
+	return root, nil
+}
+
+// unfoldLines joins folded lines and splits each into name, parameters and value
+func unfoldLines(r io.Reader) ([]*contentLine, error) {
+	scanner := bufio.NewScanner(io.LimitReader(r, MaxImportBytes+1))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxImportBytes+1)
+
+	var raw []string
+	total := 0
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		total += len(line) + 1
+		if total > MaxImportBytes {
+			return nil, fmt.Errorf("calendar is larger than %d MB", MaxImportBytes>>20)
+		}
+		if len(raw) == 0 {
+			// Drop a UTF-8 byte order mark
+			line = strings.TrimPrefix(line, "\uFEFF")
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(raw) > 0 {
+			raw[len(raw)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		raw = append(raw, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	lines := make([]*contentLine, 0, len(raw))
+	for _, s := range raw {
+		l, err := parseContentLine(s)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+func parseContentLine(s string) (*contentLine, error) {
+	// The value starts at the first colon outside a quoted parameter value
+	colon := -1
+	inQuotes := false
+	for i := 0; i < len(s) && colon < 0; i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ':':
+			if !inQuotes {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return nil, fmt.Errorf("%w: malformed line %q", ErrInvalidCalendar, s)
+	}
+
+	l := &contentLine{Value: s[colon+1:], Params: make(map[string]string)}
+	head := splitParams(s[:colon])
+	l.Name = strings.ToUpper(head[0])
+	for _, p := range head[1:] {
+		key, val, _ := strings.Cut(p, "=")
+		l.Params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return l, nil
+}
+
+// splitParams splits "NAME;A=1;B="x;y"" at semicolons outside quotes
+func splitParams(s string) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';':
+			if !inQuotes {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
@@ -0,0 +1,153 @@
+package calendars
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ics(lines ...string) string {
+	return strings.Join(append(append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...), "END:VCALENDAR"), "\r\n") + "\r\n"
+}
+
+func TestParse(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("event with zone, folding and escaping", func(t *testing.T) {
+		events, err := Parse(strings.NewReader(ics(
+			"BEGIN:VEVENT",
+			"UID:lecture-1@example.com",
+			"SUMMARY:Algorithms\\, Lecture",
+			"DESCRIPTION:Room change\\nsee no",
+			" tes",
+			"DTSTART;TZID=Europe/Berlin:20260302T100000",
+			"DURATION:PT1H30M",
+			"PRIORITY:1",
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20260331",
+			"EXDATE;TZID=Europe/Berlin:20260304T100000,20260309T100000",
+			"END:VEVENT",
+		)), time.UTC)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		e := events[0]
+		assert.Equal(t, "lecture-1@example.com", e.UID)
+		assert.Equal(t, "Algorithms, Lecture", e.Summary)
+		assert.Equal(t, "Room change\nsee notes", e.Description)
+		assert.Equal(t, 1, e.Priority)
+		assert.True(t, e.Start.Equal(time.Date(2026, 3, 2, 10, 0, 0, 0, berlin)))
+		assert.True(t, e.End.Equal(time.Date(2026, 3, 2, 11, 30, 0, 0, berlin)))
+		require.NotNil(t, e.RRule)
+		assert.Equal(t, "WEEKLY", e.RRule.Freq)
+		assert.Equal(t, []time.Weekday{time.Monday, time.Wednesday}, e.RRule.ByDay)
+		assert.True(t, e.RRule.Until.Equal(time.Date(2026, 3, 31, 23, 59, 59, 0, berlin)))
+		assert.Len(t, e.ExDates, 2)
+	})
+
+	t.Run("floating and all-day times use the calendar zone", func(t *testing.T) {
+		events, err := Parse(strings.NewReader(ics(
+			"X-WR-TIMEZONE:Asia/Bangkok",
+			"BEGIN:VEVENT",
+			"UID:a",
+			"DTSTART;VALUE=DATE:20260310",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:b",
+			"DTSTART:20260310T090000",
+			"DTEND:20260310T100000Z",
+			"END:VEVENT",
+		)), time.UTC)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		assert.True(t, events[0].AllDay)
+		assert.True(t, events[0].Start.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, bangkok)))
+		assert.True(t, events[0].End.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, bangkok)))
+		assert.True(t, events[1].Start.Equal(time.Date(2026, 3, 10, 9, 0, 0, 0, bangkok)))
+		assert.True(t, events[1].End.Equal(time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("unknown zone names fall back to the VTIMEZONE offset", func(t *testing.T) {
+		events, err := Parse(strings.NewReader(ics(
+			"BEGIN:VTIMEZONE",
+			"TZID:SE Asia Standard Time",
+			"BEGIN:STANDARD",
+			"DTSTART:16010101T000000",
+			"TZOFFSETFROM:+0700",
+			"TZOFFSETTO:+0700",
+			"END:STANDARD",
+			"END:VTIMEZONE",
+			"BEGIN:VEVENT",
+			"UID:c",
+			"DTSTART;TZID=\"SE Asia Standard Time\":20260310T090000",
+			"END:VEVENT",
+		)), time.UTC)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.True(t, events[0].Start.Equal(time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("unsupported rule parts are recorded", func(t *testing.T) {
+		events, err := Parse(strings.NewReader(ics(
+			"BEGIN:VEVENT",
+			"UID:d",
+			"DTSTART:20260310T090000Z",
+			"RRULE:FREQ=MONTHLY;BYDAY=2TU",
+			"END:VEVENT",
+		)), time.UTC)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, []string{"BYDAY=2TU"}, events[0].RRule.Unsupported)
+	})
+
+	errorTests := []struct {
+		name  string
+		input string
+	}{
+		{name: "not a calendar", input: "hello"},
+		{name: "missing UID", input: ics("BEGIN:VEVENT", "DTSTART:20260310T090000Z", "END:VEVENT")},
+		{name: "missing DTSTART", input: ics("BEGIN:VEVENT", "UID:e", "END:VEVENT")},
+		{name: "invalid DTSTART", input: ics("BEGIN:VEVENT", "UID:e", "DTSTART:tomorrow", "END:VEVENT")},
+		{name: "unterminated component", input: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n"},
+	}
+	for _, tt := range errorTests {
+		t.Run("error - "+tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input), time.UTC)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{input: "PT1H30M", expected: 90 * time.Minute},
+		{input: "P1D", expected: 24 * time.Hour},
+		{input: "P1W", expected: 7 * 24 * time.Hour},
+		{input: "-PT15M", expected: -15 * time.Minute},
+		{input: "P", wantErr: true},
+		{input: "PT", wantErr: true},
+		{input: "1H", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := parseDuration(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
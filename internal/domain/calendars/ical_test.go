@@ -0,0 +1,104 @@
+package calendars
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_RRule(t *testing.T) {
+	until := time.Date(2026, 3, 1, 9, 0, 0, 0, time.FixedZone("ICT", 7*3600))
+
+	tests := []struct {
+		name     string
+		event    Event
+		expected string
+	}{
+		{name: "not recurring", event: Event{}, expected: ""},
+		{name: "daily", event: Event{IntervalDays: 1}, expected: "FREQ=DAILY"},
+		{name: "weekly until", event: Event{IntervalDays: 7, Until: until}, expected: "FREQ=DAILY;INTERVAL=7;UNTIL=20260301T020000Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.event.RRule())
+		})
+	}
+}
+
+func TestCalendar_Encode(t *testing.T) {
+	start := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	cal := &Calendar{
+		Name: "Work, personal",
+		Events: []Event{{
+			UID:          "task-1@smart-task-ai",
+			Summary:      "Standup; daily",
+			Description:  "Line one\nLine two",
+			Location:     "Room 1",
+			Start:        start,
+			End:          start.Add(15 * time.Minute),
+			IntervalDays: 1,
+			Priority:     1,
+			LastModified: start,
+		}},
+	}
+
+	out := string(cal.Encode())
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, out, "X-WR-CALNAME:Work\\, personal\r\n")
+	assert.Contains(t, out, "DTSTART:20260105T100000Z\r\n")
+	assert.Contains(t, out, "DTEND:20260105T101500Z\r\n")
+	assert.Contains(t, out, "RRULE:FREQ=DAILY\r\n")
+	assert.Contains(t, out, "SUMMARY:Standup\\; daily\r\n")
+	assert.Contains(t, out, "DESCRIPTION:Line one\\nLine two\r\n")
+	assert.Contains(t, out, "LOCATION:Room 1\r\n")
+	assert.Contains(t, out, "PRIORITY:1\r\n")
+	assert.NotContains(t, out, "CREATED:")
+}
+
+func TestCalendar_Encode_FoldsLongLines(t *testing.T) {
+	cal := &Calendar{Events: []Event{{
+		UID:     "task-1",
+		Summary: strings.Repeat("ประชุม", 20),
+	}}}
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(cal.Encode()), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+		assert.True(t, utf8.ValidString(line), line)
+	}
+}
+
+func TestEncodeObject(t *testing.T) {
+	start := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	out := string(EncodeObject(Event{UID: "task-1@smart-task-ai", Summary: "Standup", Start: start, LastModified: start}))
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Equal(t, 1, strings.Count(out, "BEGIN:VEVENT"))
+	assert.Contains(t, out, "UID:task-1@smart-task-ai\r\n")
+	assert.NotContains(t, out, "METHOD:")
+	assert.NotContains(t, out, "X-WR-")
+}
+
+func TestEncodeObject_AllDay(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	out := string(EncodeObject(Event{
+		UID:          "task-2@smart-task-ai",
+		Summary:      "Offsite",
+		Start:        start,
+		End:          start.AddDate(0, 0, 2),
+		AllDay:       true,
+		IntervalDays: 7,
+		Until:        start.AddDate(0, 0, 28),
+		LastModified: start,
+	}))
+
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20260105\r\n")
+	assert.Contains(t, out, "DTEND;VALUE=DATE:20260107\r\n")
+	assert.Contains(t, out, "RRULE:FREQ=DAILY;INTERVAL=7;UNTIL=20260202\r\n")
+}
@@ -0,0 +1,310 @@
+package calendars
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxDraftsPerEvent bounds how many tasks the exceptions of one series may split it into
+	maxDraftsPerEvent = 100
+
+	// maxTaskNameLength matches the tasks.name column
+	maxTaskNameLength = 255
+
+	untitledEventName = "(no title)"
+)
+
+// TaskDraft is a task planned from an imported event. Tasks repeat every
+// IntervalDays, so a rule such as "every Monday and Wednesday" becomes one
+// draft per weekday, and exceptions split a series into several drafts.
+type TaskDraft struct {
+	Name        string
+	Description string
+	Location    string
+	Priority    string
+	Start       time.Time
+	// End is zero for events without a duration
+	End time.Time
+	// AllDay drafts start and end at midnight in the zone of Start
+	AllDay       bool
+	IntervalDays int
+	// Until is the start of the last occurrence; zero repeats forever
+	Until time.Time
+}
+
+// ImportedEvent holds the drafts planned for every VEVENT sharing one UID
+type ImportedEvent struct {
+	UID      string
+	Summary  string
+	Drafts   []TaskDraft
+	Warnings []string
+}
+
+// PlanImport maps parsed events to task drafts. Events that replace one
+// occurrence of a series (RECURRENCE-ID) are imported on their own and
+// remove that occurrence from the series, like EXDATE does.
+func PlanImport(events []ParsedEvent) []ImportedEvent {
+	var order []string
+	byUID := make(map[string][]ParsedEvent)
+	for _, e := range events {
+		if _, ok := byUID[e.UID]; !ok {
+			order = append(order, e.UID)
+		}
+		byUID[e.UID] = append(byUID[e.UID], e)
+	}
+
+	planned := make([]ImportedEvent, 0, len(order))
+	for _, uid := range order {
+		planned = append(planned, planEvent(byUID[uid]))
+	}
+	return planned
+}
+
+func planEvent(events []ParsedEvent) ImportedEvent {
+	var master *ParsedEvent
+	var overrides []ParsedEvent
+	for i := range events {
+		if events[i].RecurrenceID.IsZero() && master == nil {
+			master = &events[i]
+		} else {
+			overrides = append(overrides, events[i])
+		}
+	}
+
+	result := ImportedEvent{UID: events[0].UID, Summary: events[0].Summary}
+	if master != nil {
+		result.Summary = master.Summary
+		exceptions := append([]time.Time(nil), master.ExDates...)
+		for _, o := range overrides {
+			exceptions = append(exceptions, o.RecurrenceID)
+		}
+
+		if master.Cancelled {
+			result.Warnings = append(result.Warnings, "event is cancelled")
+		} else {
+			drafts, warnings := planSeries(master, exceptions)
+			result.Drafts = append(result.Drafts, drafts...)
+			result.Warnings = append(result.Warnings, warnings...)
+		}
+	}
+
+	for i := range overrides {
+		if !overrides[i].Cancelled {
+			result.Drafts = append(result.Drafts, newDraft(&overrides[i], overrides[i].Start))
+		}
+	}
+
+	sort.SliceStable(result.Drafts, func(i, j int) bool { return result.Drafts[i].Start.Before(result.Drafts[j].Start) })
+	if len(result.Drafts) > maxDraftsPerEvent {
+		result.Drafts = result.Drafts[:maxDraftsPerEvent]
+		result.Warnings = append(result.Warnings, fmt.Sprintf("only the first %d tasks of the series are imported", maxDraftsPerEvent))
+	}
+	return result
+}
+
+// planSeries expands the RRULE of e into one draft per weekday and per run of
+// occurrences between exceptions
+func planSeries(e *ParsedEvent, exceptions []time.Time) ([]TaskDraft, []string) {
+	rule := e.RRule
+	if rule == nil {
+		return []TaskDraft{newDraft(e, e.Start)}, nil
+	}
+
+	starts, step, ok := seriesStarts(e.Start, rule)
+	if !ok {
+		desc := "FREQ=" + rule.Freq
+		if len(rule.Unsupported) > 0 {
+			desc = strings.Join(rule.Unsupported, ", ")
+		}
+		return []TaskDraft{newDraft(e, e.Start)}, []string{
+			fmt.Sprintf("recurrence %s cannot be represented, only the first occurrence is imported", desc),
+		}
+	}
+
+	until := rule.Until
+	if rule.Count > 0 {
+		if last := countBound(starts, step, rule.Count); until.IsZero() || last.Before(until) {
+			until = last
+		}
+	}
+
+	var drafts []TaskDraft
+	for _, s := range starts {
+		if !until.IsZero() && s.After(until) {
+			continue
+		}
+		// The index of the last occurrence, -1 when the series never ends
+		last := -1
+		if !until.IsZero() {
+			last = daysBetween(s, until) / step
+			if occurrence(s, step, last).After(until) {
+				last--
+			}
+		}
+
+		for _, run := range splitRuns(exceptionIndexes(s, step, last, exceptions), last) {
+			d := newDraft(e, occurrence(s, step, run[0]))
+			if run[1] != run[0] {
+				d.IntervalDays = step
+				if run[1] >= 0 {
+					d.Until = occurrence(s, step, run[1])
+				}
+			}
+			drafts = append(drafts, d)
+		}
+	}
+	return drafts, nil
+}
+
+// seriesStarts returns the first occurrence of every weekday the rule repeats on,
+// and the number of days between occurrences of each
+func seriesStarts(start time.Time, rule *RecurrenceRule) ([]time.Time, int, bool) {
+	if len(rule.Unsupported) > 0 {
+		return nil, 0, false
+	}
+
+	switch rule.Freq {
+	case "DAILY":
+		if len(rule.ByDay) == 0 {
+			return []time.Time{start}, rule.Interval, true
+		}
+		if rule.Interval != 1 {
+			return nil, 0, false
+		}
+		// Every day restricted to some weekdays is a weekly rule
+	case "WEEKLY":
+	default:
+		return nil, 0, false
+	}
+
+	days := rule.ByDay
+	if len(days) == 0 {
+		days = []time.Weekday{start.Weekday()}
+	}
+	step := 7 * rule.Interval
+	weekStart := start.AddDate(0, 0, -int((start.Weekday()-rule.WeekStart+7)%7))
+
+	seen := make(map[time.Weekday]bool)
+	var starts []time.Time
+	for _, wd := range days {
+		if seen[wd] {
+			continue
+		}
+		seen[wd] = true
+		s := weekStart.AddDate(0, 0, int((wd-rule.WeekStart+7)%7))
+		if s.Before(start) {
+			s = s.AddDate(0, 0, step)
+		}
+		starts = append(starts, s)
+	}
+	return starts, step, true
+}
+
+// countBound returns the start of the count-th occurrence across all series
+func countBound(starts []time.Time, step, count int) time.Time {
+	var all []time.Time
+	for _, s := range starts {
+		for k := 0; k < count; k++ {
+			all = append(all, occurrence(s, step, k))
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Before(all[j]) })
+	return all[count-1]
+}
+
+// exceptionIndexes returns the sorted indexes of the occurrences that are excluded
+func exceptionIndexes(s time.Time, step, last int, exceptions []time.Time) []int {
+	seen := make(map[int]bool)
+	var indexes []int
+	for _, ex := range exceptions {
+		days := daysBetween(s, ex)
+		if days < 0 || days%step != 0 {
+			continue
+		}
+		k := days / step
+		if (last >= 0 && k > last) || seen[k] || !occurrence(s, step, k).Equal(ex) {
+			continue
+		}
+		seen[k] = true
+		indexes = append(indexes, k)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// splitRuns returns the [first, last] occurrence indexes between exceptions;
+// a last index of -1 marks a run that never ends
+func splitRuns(exceptions []int, last int) [][2]int {
+	var runs [][2]int
+	first := 0
+	for _, k := range exceptions {
+		if k > first {
+			runs = append(runs, [2]int{first, k - 1})
+		}
+		first = k + 1
+	}
+	if last < 0 || first <= last {
+		runs = append(runs, [2]int{first, last})
+	}
+	return runs
+}
+
+// occurrence returns the k-th occurrence, keeping the wall clock time across DST changes
+func occurrence(s time.Time, step, k int) time.Time {
+	return s.AddDate(0, 0, k*step)
+}
+
+// daysBetween counts calendar days from a to b in the zone of a
+func daysBetween(a, b time.Time) int {
+	b = b.In(a.Location())
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
+
+func newDraft(e *ParsedEvent, start time.Time) TaskDraft {
+	d := TaskDraft{
+		Name:        taskName(e.Summary),
+		Description: e.Description,
+		Location:    e.Location,
+		Priority:    TaskPriority(e.Priority),
+		Start:       start,
+		AllDay:      e.AllDay,
+	}
+	switch {
+	case e.End.IsZero() || !e.End.After(e.Start):
+	case e.AllDay:
+		// Days rather than hours, which differ across a DST change
+		d.End = start.AddDate(0, 0, daysBetween(e.Start, e.End))
+	default:
+		d.End = start.Add(e.End.Sub(e.Start))
+	}
+	return d
+}
+
+// TaskPriority maps an RFC 5545 priority to the low, medium and high task priorities
+func TaskPriority(p int) string {
+	switch {
+	case p >= 1 && p <= 4:
+		return "high"
+	case p >= 6:
+		return "low"
+	default:
+		return "medium"
+	}
+}
+
+func taskName(summary string) string {
+	name := strings.TrimSpace(strings.ReplaceAll(summary, "\n", " "))
+	if name == "" {
+		return untitledEventName
+	}
+	if utf8.RuneCountInString(name) > maxTaskNameLength {
+		name = string([]rune(name)[:maxTaskNameLength])
+	}
+	return name
+}
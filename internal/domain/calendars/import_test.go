@@ -0,0 +1,165 @@
+package calendars
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type draftView struct {
+	Start        string
+	IntervalDays int
+	Until        string
+}
+
+func viewDrafts(drafts []TaskDraft) []draftView {
+	views := make([]draftView, 0, len(drafts))
+	for _, d := range drafts {
+		v := draftView{Start: d.Start.Format("2006-01-02 15:04"), IntervalDays: d.IntervalDays}
+		if !d.Until.IsZero() {
+			v.Until = d.Until.Format("2006-01-02")
+		}
+		views = append(views, v)
+	}
+	return views
+}
+
+func TestPlanImport(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, berlin) }
+
+	tests := []struct {
+		name             string
+		events           []ParsedEvent
+		expected         []draftView
+		expectedWarnings []string
+	}{
+		{
+			name:     "single event",
+			events:   []ParsedEvent{{UID: "a", Start: at(2, 10), End: at(2, 11)}},
+			expected: []draftView{{Start: "2026-03-02 10:00"}},
+		},
+		{
+			name: "daily with count",
+			events: []ParsedEvent{{
+				UID: "a", Start: at(2, 10),
+				RRule: &RecurrenceRule{Freq: "DAILY", Interval: 1, Count: 3, WeekStart: time.Monday},
+			}},
+			expected: []draftView{{Start: "2026-03-02 10:00", IntervalDays: 1, Until: "2026-03-04"}},
+		},
+		{
+			name: "weekly on two days with exceptions",
+			events: []ParsedEvent{{
+				UID: "a", Start: at(2, 10),
+				RRule: &RecurrenceRule{
+					Freq: "WEEKLY", Interval: 1, WeekStart: time.Monday,
+					ByDay: []time.Weekday{time.Monday, time.Wednesday},
+					Until: time.Date(2026, 3, 31, 23, 59, 59, 0, berlin),
+				},
+				ExDates: []time.Time{at(4, 10), at(9, 10)},
+			}},
+			expected: []draftView{
+				{Start: "2026-03-02 10:00"},
+				{Start: "2026-03-11 10:00", IntervalDays: 7, Until: "2026-03-25"},
+				{Start: "2026-03-16 10:00", IntervalDays: 7, Until: "2026-03-30"},
+			},
+		},
+		{
+			name: "biweekly keeps its time across the DST change",
+			events: []ParsedEvent{{
+				UID: "a", Start: at(23, 10),
+				RRule: &RecurrenceRule{Freq: "WEEKLY", Interval: 2, WeekStart: time.Monday},
+			}},
+			expected: []draftView{{Start: "2026-03-23 10:00", IntervalDays: 14}},
+		},
+		{
+			name: "moved occurrence",
+			events: []ParsedEvent{
+				{UID: "a", Start: at(2, 10), RRule: &RecurrenceRule{Freq: "DAILY", Interval: 1, Count: 3, WeekStart: time.Monday}},
+				{UID: "a", Start: at(3, 15), RecurrenceID: at(3, 10)},
+			},
+			expected: []draftView{
+				{Start: "2026-03-02 10:00"},
+				{Start: "2026-03-03 15:00"},
+				{Start: "2026-03-04 10:00"},
+			},
+		},
+		{
+			name: "cancelled occurrence",
+			events: []ParsedEvent{
+				{UID: "a", Start: at(2, 10), RRule: &RecurrenceRule{Freq: "DAILY", Interval: 1, Count: 3, WeekStart: time.Monday}},
+				{UID: "a", Start: at(3, 10), RecurrenceID: at(3, 10), Cancelled: true},
+			},
+			expected: []draftView{
+				{Start: "2026-03-02 10:00"},
+				{Start: "2026-03-04 10:00"},
+			},
+		},
+		{
+			name:             "cancelled event",
+			events:           []ParsedEvent{{UID: "a", Start: at(2, 10), Cancelled: true}},
+			expected:         []draftView{},
+			expectedWarnings: []string{"event is cancelled"},
+		},
+		{
+			name: "monthly falls back to the first occurrence",
+			events: []ParsedEvent{{
+				UID: "a", Start: at(2, 10),
+				RRule: &RecurrenceRule{Freq: "MONTHLY", Interval: 1, WeekStart: time.Monday},
+			}},
+			expected:         []draftView{{Start: "2026-03-02 10:00"}},
+			expectedWarnings: []string{"recurrence FREQ=MONTHLY cannot be represented, only the first occurrence is imported"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planned := PlanImport(tt.events)
+			require.Len(t, planned, 1)
+			assert.Equal(t, tt.expected, viewDrafts(planned[0].Drafts))
+			assert.Equal(t, tt.expectedWarnings, planned[0].Warnings)
+		})
+	}
+}
+
+func TestPlanImport_GroupsByUID(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	planned := PlanImport([]ParsedEvent{
+		{UID: "b", Summary: "Second", Start: start},
+		{UID: "a", Summary: "First", Start: start},
+		{UID: "b", Summary: "Second moved", Start: start.Add(time.Hour), RecurrenceID: start},
+	})
+
+	require.Len(t, planned, 2)
+	assert.Equal(t, "b", planned[0].UID)
+	assert.Equal(t, "Second", planned[0].Summary)
+	assert.Len(t, planned[0].Drafts, 2)
+	assert.Equal(t, "a", planned[1].UID)
+}
+
+func TestNewDraft(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	d := newDraft(&ParsedEvent{
+		Summary:  "  " + strings.Repeat("é", 300) + "  ",
+		Start:    start,
+		End:      start.Add(90 * time.Minute),
+		Priority: 9,
+	}, start.AddDate(0, 0, 7))
+
+	assert.Equal(t, maxTaskNameLength, len([]rune(d.Name)))
+	assert.Equal(t, "low", d.Priority)
+	assert.Equal(t, start.AddDate(0, 0, 7).Add(90*time.Minute), d.End)
+	assert.Equal(t, untitledEventName, newDraft(&ParsedEvent{Start: start}, start).Name)
+}
+
+func TestTaskPriority(t *testing.T) {
+	assert.Equal(t, "medium", TaskPriority(0))
+	assert.Equal(t, "high", TaskPriority(1))
+	assert.Equal(t, "high", TaskPriority(4))
+	assert.Equal(t, "medium", TaskPriority(5))
+	assert.Equal(t, "low", TaskPriority(9))
+}
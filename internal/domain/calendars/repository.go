@@ -0,0 +1,18 @@
+//go:generate go run go.uber.org/mock/mockgen -source=$GOFILE -destination=../../mocks/calendar_repository.go -package=mocks
+package calendars
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/entity"
+	"github.com/google/uuid"
+)
+
+type FeedRepository interface {
+	CreateFeed(ctx context.Context, feed *entity.Feed) error
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (*entity.Feed, error)
+	ListActiveFeeds(ctx context.Context, accountID uuid.UUID) ([]*entity.Feed, error)
+	// RevokeFeed marks an active feed of the account as revoked; it reports whether a row was updated
+	RevokeFeed(ctx context.Context, accountID, feedID uuid.UUID, at time.Time) (bool, error)
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// objectExtension ends the resource name of every calendar object
+const objectExtension = ".ics"
+
+// CalendarObject is a task served as a CalDAV calendar object resource
+type CalendarObject struct {
+	// Name is the resource name within the project collection
+	Name string
+	Task *taskEntity.Task
+	Data []byte
+	ETag string
+}
+
+// ObjectPreconditions are the If-Match and If-None-Match headers of a write.
+// Each holds an ETag, "*" or "" when the header was not sent.
+type ObjectPreconditions struct {
+	IfMatch     string
+	IfNoneMatch string
+}
+
+// ListCalendarProjects returns the projects served as calendar collections
+func (s *CalendarService) ListCalendarProjects(ctx context.Context, accountID uuid.UUID) ([]*projectEntity.Project, error) {
+	projects, err := s.projectRepo.ListAllProjectsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list projects", "LIST_PROJECTS_ERROR", err)
+	}
+
+	return projects, nil
+}
+
+func (s *CalendarService) GetCalendarProject(ctx context.Context, accountID, projectID uuid.UUID) (*projectEntity.Project, error) {
+	return s.getOwnedProject(ctx, accountID, projectID)
+}
+
+// ListCalendarObjects returns the scheduled tasks of the project; tasks without
+// a start or end time have no place in a calendar and are left out
+func (s *CalendarService) ListCalendarObjects(ctx context.Context, accountID, projectID uuid.UUID) ([]CalendarObject, error) {
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, err
+	}
+
+	taskList, err := s.taskRepo.ListTasksByProject(ctx, projectID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list tasks", "LIST_TASKS_ERROR", err)
+	}
+
+	objects := make([]CalendarObject, 0, len(taskList))
+	for _, t := range taskList {
+		if object, ok := newCalendarObject(t); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+func (s *CalendarService) GetCalendarObject(ctx context.Context, accountID, projectID uuid.UUID, name string) (*CalendarObject, error) {
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, err
+	}
+
+	t, err := s.findObjectTask(ctx, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := newCalendarObject(t)
+	if !ok {
+		return nil, calendarObjectNotFound()
+	}
+	return &object, nil
+}
+
+// PutCalendarObject creates or updates the task stored under name from an
+// iCalendar object holding one event. The event must map to a single task, so
+// recurrences with exceptions or rules other than every n days or weeks are
+// rejected. It reports whether the task was created.
+func (s *CalendarService) PutCalendarObject(ctx context.Context, accountID, projectID uuid.UUID, name string, r io.Reader, pre ObjectPreconditions) (*CalendarObject, bool, error) {
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, false, err
+	}
+	if !strings.HasSuffix(name, objectExtension) || len(name) > 255 {
+		return nil, false, apperror.NewBadRequestError("calendar object names must end with .ics", "INVALID_CALENDAR_OBJECT_NAME", nil)
+	}
+
+	uid, draft, err := parseCalendarObject(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := s.findObjectTask(ctx, projectID, name)
+	if err != nil && !isNotFound(err) {
+		return nil, false, err
+	}
+
+	if existing == nil {
+		if pre.IfMatch != "" {
+			return nil, false, preconditionFailed()
+		}
+		return s.createObjectTask(ctx, projectID, name, uid, draft)
+	}
+
+	if pre.IfNoneMatch == "*" {
+		return nil, false, preconditionFailed()
+	}
+	if pre.IfMatch != "" && pre.IfMatch != "*" {
+		current, ok := newCalendarObject(existing)
+		if !ok || current.ETag != pre.IfMatch {
+			return nil, false, preconditionFailed()
+		}
+	}
+
+	object, err := s.updateObjectTask(ctx, existing, draft)
+	return object, false, err
+}
+
+// DeleteCalendarObject deletes the task stored under name and returns it
+func (s *CalendarService) DeleteCalendarObject(ctx context.Context, accountID, projectID uuid.UUID, name string, pre ObjectPreconditions) (*taskEntity.Task, error) {
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, err
+	}
+
+	t, err := s.findObjectTask(ctx, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	if pre.IfMatch != "" && pre.IfMatch != "*" {
+		current, ok := newCalendarObject(t)
+		if !ok || current.ETag != pre.IfMatch {
+			return nil, preconditionFailed()
+		}
+	}
+
+	if err := s.taskService.DeleteTask(ctx, t.ID); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *CalendarService) createObjectTask(ctx context.Context, projectID uuid.UUID, name, uid string, draft calendars.TaskDraft) (*CalendarObject, bool, error) {
+	// A collection must not hold two objects with the same UID (RFC 4791 section 5.3.2.1)
+	existingUIDs, err := s.taskRepo.ListExternalUIDs(ctx, projectID, []string{uid})
+	if err != nil {
+		return nil, false, apperror.NewInternalServerError("failed to check calendar object UID", "CHECK_CALENDAR_OBJECT_UID_ERROR", err)
+	}
+	if len(existingUIDs) > 0 {
+		return nil, false, apperror.NewConflictError("an event with this UID already exists in the calendar", "CALENDAR_OBJECT_UID_CONFLICT", nil)
+	}
+
+	t := taskFromDraft(projectID, uid, draft, time.Now())
+	t.CalDAVName = lo.ToPtr(name)
+	// A client has no way to show warnings, only conflicts the project blocks fail the request
+	if _, err := s.taskService.AddTask(ctx, t); err != nil {
+		return nil, false, err
+	}
+
+	object, _ := newCalendarObject(t)
+	return &object, true, nil
+}
+
+func (s *CalendarService) updateObjectTask(ctx context.Context, t *taskEntity.Task, draft calendars.TaskDraft) (*CalendarObject, error) {
+	updated := taskFromDraft(t.ProjectID, "", draft, time.Now())
+
+	// The same rule as TaskService.UpdateTask: started tasks keep their start time
+	if t.Status != "todo" && !sameTaskTime(t.StartDateTime, updated.StartDateTime) {
+		return nil, apperror.NewForbiddenError("cannot move a task that is not todo", "INVALID_REQUEST", nil)
+	}
+
+	previous := *t
+	t.Name = updated.Name
+	t.Description = updated.Description
+	t.Location = updated.Location
+	t.Priority = updated.Priority
+	t.StartDateTime = updated.StartDateTime
+	t.EndDateTime = updated.EndDateTime
+	t.AllDay = updated.AllDay
+	t.RecurringDays = updated.RecurringDays
+	t.RecurringUntil = updated.RecurringUntil
+	t.UpdatedAt = updated.UpdatedAt
+	if _, err := s.taskService.ReplaceTask(ctx, &previous, t); err != nil {
+		return nil, err
+	}
+
+	object, _ := newCalendarObject(t)
+	return &object, nil
+}
+
+// findObjectTask resolves a resource name: tasks created by a CalDAV client keep
+// the name it chose, every other task is served as "<task ID>.ics"
+func (s *CalendarService) findObjectTask(ctx context.Context, projectID uuid.UUID, name string) (*taskEntity.Task, error) {
+	t, err := s.taskRepo.GetTaskByCalDAVName(ctx, projectID, name)
+	if err == nil {
+		return t, nil
+	}
+	if !errors.Is(err, apperror.ErrRecordNotFound) {
+		return nil, apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
+	}
+
+	id := strings.TrimSuffix(name, objectExtension)
+	if id == name || !utils.HasIDPrefix(id, taskEntity.TaskIDPrefix) {
+		return nil, calendarObjectNotFound()
+	}
+	taskID, err := utils.ParseID(id, taskEntity.TaskIDPrefix)
+	if err != nil {
+		return nil, calendarObjectNotFound()
+	}
+
+	t, err = s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, calendarObjectNotFound()
+		}
+		return nil, apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
+	}
+	if t.ProjectID != projectID || t.CalDAVName != nil {
+		return nil, calendarObjectNotFound()
+	}
+	return t, nil
+}
+
+// parseCalendarObject returns the UID of the single event in the object and the task it maps to
+func parseCalendarObject(r io.Reader) (string, calendars.TaskDraft, error) {
+	events, err := calendars.Parse(r, time.UTC)
+	if err != nil {
+		return "", calendars.TaskDraft{}, apperror.NewBadRequestError("invalid calendar object: "+err.Error(), "INVALID_CALENDAR_OBJECT", nil)
+	}
+
+	planned := calendars.PlanImport(events)
+	if len(planned) != 1 {
+		return "", calendars.TaskDraft{}, apperror.NewBadRequestError("calendar object must hold exactly one event", "INVALID_CALENDAR_OBJECT", nil)
+	}
+
+	event := planned[0]
+	if len(event.Drafts) != 1 || len(event.Warnings) > 0 {
+		return "", calendars.TaskDraft{}, apperror.NewForbiddenError(
+			"tasks only repeat every n days without exceptions, this event cannot be stored",
+			"UNSUPPORTED_CALENDAR_OBJECT",
+			event.Warnings,
+		)
+	}
+	return event.UID, event.Drafts[0], nil
+}
+
+func sameTaskTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func newCalendarObject(t *taskEntity.Task) (CalendarObject, bool) {
+	event, ok := EventFromTask(t)
+	if !ok {
+		return CalendarObject{}, false
+	}
+
+	name := utils.ShortUUIDWithPrefix(t.ID, taskEntity.TaskIDPrefix) + objectExtension
+	if t.CalDAVName != nil {
+		name = *t.CalDAVName
+	}
+
+	data := calendars.EncodeObject(event)
+	// The data includes LAST-MODIFIED, so the ETag changes whenever the task does
+	sum := sha256.Sum256(data)
+	return CalendarObject{
+		Name: name,
+		Task: t,
+		Data: data,
+		ETag: hex.EncodeToString(sum[:16]),
+	}, true
+}
+
+func calendarObjectNotFound() error {
+	return apperror.NewNotFoundError("calendar object not found", "CALENDAR_OBJECT_NOT_FOUND", nil)
+}
+
+func preconditionFailed() error {
+	return apperror.NewAppError("PRECONDITION_FAILED", "calendar object has changed", http.StatusPreconditionFailed, nil)
+}
+
+func isNotFound(err error) bool {
+	appErr, ok := apperror.IsAppError(err)
+	return ok && appErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func calendarObjectData(lines ...string) string {
+	return strings.Join(append(append([]string{"BEGIN:VCALENDAR", "BEGIN:VEVENT"}, lines...), "END:VEVENT", "END:VCALENDAR"), "\r\n")
+}
+
+func TestCalendarService_ListCalendarObjects(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+
+	svc, _, projectRepo, taskRepo, _ := newTestCalendarService(t)
+	scheduled := &taskEntity.Task{ID: uuid.New(), ProjectID: projectID, Name: "Review", StartDateTime: timePtr("2026-01-05T10:00:00+07:00")}
+	synced := &taskEntity.Task{ID: uuid.New(), ProjectID: projectID, Name: "Lecture", StartDateTime: timePtr("2026-01-06T10:00:00Z"), CalDAVName: lo.ToPtr("lecture.ics"), ExternalUID: lo.ToPtr("lecture@example.com")}
+	projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+	taskRepo.EXPECT().ListTasksByProject(ctx, projectID).Return([]*taskEntity.Task{scheduled, {ID: uuid.New(), ProjectID: projectID}, synced}, nil)
+
+	objects, err := svc.ListCalendarObjects(ctx, accountID, projectID)
+
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, utils.ShortUUIDWithPrefix(scheduled.ID, taskEntity.TaskIDPrefix)+".ics", objects[0].Name)
+	assert.Contains(t, string(objects[0].Data), "UID:"+scheduled.ID.String()+"@"+eventUIDDomain)
+	assert.Equal(t, "lecture.ics", objects[1].Name)
+	assert.Contains(t, string(objects[1].Data), "UID:lecture@example.com")
+	assert.Len(t, objects[0].ETag, 32)
+	assert.NotEqual(t, objects[0].ETag, objects[1].ETag)
+}
+
+func TestCalendarService_PutCalendarObject(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+	ownProject := &projectEntity.Project{ID: projectID, AccountID: accountID}
+	blockingProject := &projectEntity.Project{ID: projectID, AccountID: accountID, Config: json.RawMessage(`{"version":1,"scheduling":{"conflicts":"block"}}`)}
+
+	event := calendarObjectData(
+		"UID:lecture@example.com",
+		"SUMMARY:Lecture",
+		"DTSTART:20260302T020000Z",
+		"DTEND:20260302T033000Z",
+		"RRULE:FREQ=WEEKLY",
+	)
+	existingTask := func() *taskEntity.Task {
+		return &taskEntity.Task{
+			ID:            uuid.New(),
+			ProjectID:     projectID,
+			Name:          "Old name",
+			Status:        "todo",
+			StartDateTime: timePtr("2026-03-02T09:00:00+07:00"),
+			EndDateTime:   timePtr("2026-03-02T10:00:00+07:00"),
+			CalDAVName:    lo.ToPtr("lecture.ics"),
+			ExternalUID:   lo.ToPtr("lecture@example.com"),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		input          string
+		project        *projectEntity.Project
+		pre            func(existing *taskEntity.Task) ObjectPreconditions
+		setupMock      func(taskRepo *mocks.MockTaskRepository, existing *taskEntity.Task)
+		events         []string
+		expectedStatus int
+		expectedCode   string
+		validate       func(t *testing.T, object *CalendarObject, created bool)
+	}{
+		{
+			name:  "success - creates a task under the client name",
+			input: event,
+			setupMock: func(taskRepo *mocks.MockTaskRepository, _ *taskEntity.Task) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(nil, apperror.ErrRecordNotFound)
+				taskRepo.EXPECT().ListExternalUIDs(ctx, projectID, []string{"lecture@example.com"}).Return(nil, nil)
+				taskRepo.EXPECT().CreateTask(ctx, gomock.Any()).Return(nil)
+			},
+			events: []string{events.TaskCreated},
+			validate: func(t *testing.T, object *CalendarObject, created bool) {
+				assert.True(t, created)
+				assert.Equal(t, "lecture.ics", object.Name)
+				assert.Equal(t, "Lecture", object.Task.Name)
+				assert.Equal(t, "todo", object.Task.Status)
+				assert.Equal(t, 7, lo.FromPtr(object.Task.RecurringDays))
+				assert.Equal(t, "lecture@example.com", lo.FromPtr(object.Task.ExternalUID))
+				assert.Equal(t, "lecture.ics", lo.FromPtr(object.Task.CalDAVName))
+			},
+		},
+		{
+			name:  "success - updates the task",
+			input: event,
+			pre: func(existing *taskEntity.Task) ObjectPreconditions {
+				current, _ := newCalendarObject(existing)
+				return ObjectPreconditions{IfMatch: current.ETag}
+			},
+			setupMock: func(taskRepo *mocks.MockTaskRepository, existing *taskEntity.Task) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(existing, nil)
+				taskRepo.EXPECT().UpdateTask(ctx, existing).Return(nil)
+			},
+			events: []string{events.TaskUpdated},
+			validate: func(t *testing.T, object *CalendarObject, created bool) {
+				assert.False(t, created)
+				assert.Equal(t, "Lecture", object.Task.Name)
+				assert.Equal(t, "2026-03-02T02:00:00Z", object.Task.StartDateTime.UTC().Format(time.RFC3339))
+				assert.Equal(t, "2026-03-02T03:30:00Z", object.Task.EndDateTime.UTC().Format(time.RFC3339))
+			},
+		},
+		{
+			name:    "error - overlaps a task the project blocks",
+			input:   event,
+			project: blockingProject,
+			setupMock: func(taskRepo *mocks.MockTaskRepository, _ *taskEntity.Task) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(nil, apperror.ErrRecordNotFound)
+				taskRepo.EXPECT().ListExternalUIDs(ctx, projectID, []string{"lecture@example.com"}).Return(nil, nil)
+				taskRepo.EXPECT().ListTasksByAccount(ctx, accountID).Return([]*taskEntity.Task{{
+					ID:            uuid.New(),
+					ProjectID:     projectID,
+					Name:          "Standup",
+					Status:        "todo",
+					StartDateTime: timePtr("2026-03-09T02:30:00Z"),
+					EndDateTime:   timePtr("2026-03-09T03:00:00Z"),
+					RecurringDays: lo.ToPtr(7),
+				}}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "SCHEDULE_CONFLICT",
+		},
+		{
+			name:  "error - stale If-Match",
+			input: event,
+			pre: func(*taskEntity.Task) ObjectPreconditions {
+				return ObjectPreconditions{IfMatch: "stale"}
+			},
+			setupMock: func(taskRepo *mocks.MockTaskRepository, existing *taskEntity.Task) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(existing, nil)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   "PRECONDITION_FAILED",
+		},
+		{
+			name:  "error - If-None-Match on an existing object",
+			input: event,
+			pre: func(*taskEntity.Task) ObjectPreconditions {
+				return ObjectPreconditions{IfNoneMatch: "*"}
+			},
+			setupMock: func(taskRepo *mocks.MockTaskRepository, existing *taskEntity.Task) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(existing, nil)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   "PRECONDITION_FAILED",
+		},
+		{
+			name:  "error - If-Match on a missing object",
+			input: event,
+			pre: func(*taskEntity.Task) ObjectPreconditions {
+				return ObjectPreconditions{IfMatch: "*"}
+			},
+			setupMock: func(taskRepo *mocks.MockTaskRepository, _ *taskEntity.Task) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(nil, apperror.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   "PRECONDITION_FAILED",
+		},
+		{
+			name:  "error - UID already in the calendar",
+			input: event,
+			setupMock: func(taskRepo *mocks.MockTaskRepository, _ *taskEntity.Task) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(nil, apperror.ErrRecordNotFound)
+				taskRepo.EXPECT().ListExternalUIDs(ctx, projectID, []string{"lecture@example.com"}).Return([]string{"lecture@example.com"}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "CALENDAR_OBJECT_UID_CONFLICT",
+		},
+		{
+			name: "error - recurrence a task cannot hold",
+			input: calendarObjectData(
+				"UID:lecture@example.com",
+				"SUMMARY:Lecture",
+				"DTSTART:20260302T020000Z",
+				"RRULE:FREQ=MONTHLY",
+			),
+			setupMock:      func(*mocks.MockTaskRepository, *taskEntity.Task) {},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "UNSUPPORTED_CALENDAR_OBJECT",
+		},
+		{
+			name:  "error - moving a started task",
+			input: strings.Replace(event, "DTSTART:20260302T020000Z", "DTSTART:20260302T030000Z", 1),
+			setupMock: func(taskRepo *mocks.MockTaskRepository, existing *taskEntity.Task) {
+				existing.Status = "in_progress"
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "lecture.ics").Return(existing, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "INVALID_REQUEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, projectRepo, taskRepo, outbox := newTestCalendarService(t)
+			existing := existingTask()
+			project := ownProject
+			if tt.project != nil {
+				project = tt.project
+			}
+			projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(project, nil).AnyTimes()
+			tt.setupMock(taskRepo, existing)
+			taskRepo.EXPECT().ListTasksByAccount(ctx, accountID).Return(nil, nil).AnyTimes()
+			expectEvents(outbox, tt.events...)
+
+			var pre ObjectPreconditions
+			if tt.pre != nil {
+				pre = tt.pre(existing)
+			}
+			object, created, err := svc.PutCalendarObject(ctx, accountID, projectID, "lecture.ics", strings.NewReader(tt.input), pre)
+
+			if tt.expectedCode != "" {
+				require.Error(t, err)
+				appErr, ok := apperror.IsAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedStatus, appErr.Status)
+				assert.Equal(t, tt.expectedCode, appErr.Code)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.validate(t, object, created)
+		})
+	}
+}
+
+func TestCalendarService_DeleteCalendarObject(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+	ownProject := &projectEntity.Project{ID: projectID, AccountID: accountID}
+
+	task := &taskEntity.Task{ID: uuid.New(), ProjectID: projectID, StartDateTime: timePtr("2026-01-05T10:00:00Z"), UpdatedAt: time.Now()}
+	name := utils.ShortUUIDWithPrefix(task.ID, taskEntity.TaskIDPrefix) + ".ics"
+	current, _ := newCalendarObject(task)
+
+	tests := []struct {
+		name          string
+		objectName    string
+		pre           ObjectPreconditions
+		setupMock     func(taskRepo *mocks.MockTaskRepository)
+		events        []string
+		expectedError string
+	}{
+		{
+			name:       "success - task served under its ID",
+			objectName: name,
+			pre:        ObjectPreconditions{IfMatch: current.ETag},
+			setupMock: func(taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, name).Return(nil, apperror.ErrRecordNotFound)
+				taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil).Times(2)
+				taskRepo.EXPECT().DeleteTask(ctx, task.ID).Return(nil)
+			},
+			events: []string{events.TaskDeleted},
+		},
+		{
+			name:       "error - stale If-Match",
+			objectName: name,
+			pre:        ObjectPreconditions{IfMatch: "stale"},
+			setupMock: func(taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, name).Return(nil, apperror.ErrRecordNotFound)
+				taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+			},
+			expectedError: "calendar object has changed",
+		},
+		{
+			name:       "error - task of another project",
+			objectName: name,
+			setupMock: func(taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, name).Return(nil, apperror.ErrRecordNotFound)
+				taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(&taskEntity.Task{ID: task.ID, ProjectID: uuid.New()}, nil)
+			},
+			expectedError: "calendar object not found",
+		},
+		{
+			name:       "error - unknown name",
+			objectName: "missing.ics",
+			setupMock: func(taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByCalDAVName(ctx, projectID, "missing.ics").Return(nil, apperror.ErrRecordNotFound)
+			},
+			expectedError: "calendar object not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, projectRepo, taskRepo, outbox := newTestCalendarService(t)
+			projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(ownProject, nil)
+			tt.setupMock(taskRepo)
+			expectEvents(outbox, tt.events...)
+
+			deleted, err := svc.DeleteCalendarObject(ctx, accountID, projectID, tt.objectName, tt.pre)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, task.ID, deleted.ID)
+		})
+	}
+}
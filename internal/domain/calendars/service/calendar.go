@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	taskSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// AccountFeedName is the calendar name of feeds that cover every project
+const AccountFeedName = "Smart Task AI"
+
+// eventUIDDomain makes task UIDs globally unique, as RFC 5545 requires
+const eventUIDDomain = "smart-task-ai"
+
+// ImportOptions controls how an .ics file is imported
+type ImportOptions struct {
+	// DryRun plans the tasks without saving them
+	DryRun bool
+	// Location is used for floating times and zones the file does not define
+	Location *time.Location
+}
+
+// ImportedEventResult is the outcome of one imported event
+type ImportedEventResult struct {
+	calendars.ImportedEvent
+	// Duplicate is set when tasks with the UID already exist; nothing is created then
+	Duplicate bool
+	// Tasks are the tasks created, or that would be created in a dry run
+	Tasks []*taskEntity.Task
+}
+
+type CalendarService struct {
+	feedRepo    calendars.FeedRepository
+	projectRepo projects.ProjectRepository
+	taskRepo    tasks.TaskRepository
+	taskService *taskSvc.TaskService
+}
+
+// NewCalendarService creates the service; tasks are read from taskRepo, and
+// written through taskService so that their events are recorded
+func NewCalendarService(feedRepo calendars.FeedRepository, projectRepo projects.ProjectRepository, taskRepo tasks.TaskRepository, taskService *taskSvc.TaskService) *CalendarService {
+	return &CalendarService{
+		feedRepo:    feedRepo,
+		projectRepo: projectRepo,
+		taskRepo:    taskRepo,
+		taskService: taskService,
+	}
+}
+
+// CreateFeed creates a feed of one project, or of every project when projectID is nil.
+// The returned token is part of the subscription URL and cannot be retrieved again.
+func (s *CalendarService) CreateFeed(ctx context.Context, accountID uuid.UUID, projectID *uuid.UUID) (*entity.Feed, string, error) {
+	if projectID != nil {
+		if _, err := s.getOwnedProject(ctx, accountID, *projectID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, "", apperror.NewInternalServerError("failed to generate feed token", "FEED_TOKEN_ERROR", err)
+	}
+
+	feed := &entity.Feed{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		ProjectID: projectID,
+		TokenHash: hashFeedToken(token),
+		CreatedAt: time.Now(),
+	}
+	if err := s.feedRepo.CreateFeed(ctx, feed); err != nil {
+		return nil, "", apperror.NewInternalServerError("failed to create calendar feed", "CREATE_CALENDAR_FEED_ERROR", err)
+	}
+
+	return feed, token, nil
+}
+
+func (s *CalendarService) ListFeeds(ctx context.Context, accountID uuid.UUID) ([]*entity.Feed, error) {
+	feeds, err := s.feedRepo.ListActiveFeeds(ctx, accountID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list calendar feeds", "LIST_CALENDAR_FEEDS_ERROR", err)
+	}
+
+	return feeds, nil
+}
+
+// RevokeFeed disables the subscription URL of a feed; calendar apps stop receiving updates
+func (s *CalendarService) RevokeFeed(ctx context.Context, accountID, feedID uuid.UUID) error {
+	revoked, err := s.feedRepo.RevokeFeed(ctx, accountID, feedID, time.Now())
+	if err != nil {
+		return apperror.NewInternalServerError("failed to revoke calendar feed", "REVOKE_CALENDAR_FEED_ERROR", err)
+	}
+	if !revoked {
+		return apperror.NewNotFoundError("calendar feed not found", "CALENDAR_FEED_NOT_FOUND", nil)
+	}
+
+	return nil
+}
+
+// RenderFeed builds the iCalendar document of the feed the token belongs to
+func (s *CalendarService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.feedRepo.GetFeedByTokenHash(ctx, hashFeedToken(token))
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("calendar feed not found", "CALENDAR_FEED_NOT_FOUND", nil)
+		}
+		return nil, apperror.NewInternalServerError("failed to get calendar feed", "GET_CALENDAR_FEED_ERROR", err)
+	}
+	if !feed.IsActive() {
+		return nil, apperror.NewNotFoundError("calendar feed not found", "CALENDAR_FEED_NOT_FOUND", nil)
+	}
+
+	cal := &calendars.Calendar{Name: AccountFeedName}
+	var taskList []*taskEntity.Task
+	if feed.ProjectID != nil {
+		proj, err := s.getOwnedProject(ctx, feed.AccountID, *feed.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		cal.Name = proj.Name
+
+		taskList, err = s.taskRepo.ListTasksByProject(ctx, proj.ID)
+		if err != nil {
+			return nil, apperror.NewInternalServerError("failed to list tasks", "LIST_TASKS_ERROR", err)
+		}
+	} else {
+		taskList, err = s.taskRepo.ListTasksByAccount(ctx, feed.AccountID)
+		if err != nil {
+			return nil, apperror.NewInternalServerError("failed to list tasks", "LIST_TASKS_ERROR", err)
+		}
+	}
+
+	for _, t := range taskList {
+		if event, ok := EventFromTask(t); ok {
+			cal.Events = append(cal.Events, event)
+		}
+	}
+
+	return cal.Encode(), nil
+}
+
+// ImportTasks creates tasks in the project from the events of an iCalendar file.
+// Events whose UID was imported into the project before are reported as duplicates.
+func (s *CalendarService) ImportTasks(ctx context.Context, accountID, projectID uuid.UUID, r io.Reader, opts ImportOptions) ([]ImportedEventResult, error) {
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, err
+	}
+
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	events, err := calendars.Parse(r, loc)
+	if err != nil {
+		return nil, apperror.NewBadRequestError("invalid calendar file: "+err.Error(), "INVALID_CALENDAR_FILE", nil)
+	}
+
+	planned := calendars.PlanImport(events)
+	uids := make([]string, 0, len(planned))
+	for _, p := range planned {
+		uids = append(uids, p.UID)
+	}
+
+	var results []ImportedEventResult
+	plan := func(existing []string) ([]*taskEntity.Task, error) {
+		duplicates := lo.SliceToMap(existing, func(uid string) (string, bool) { return uid, true })
+
+		now := time.Now()
+		results = make([]ImportedEventResult, 0, len(planned))
+		var newTasks []*taskEntity.Task
+		for _, p := range planned {
+			result := ImportedEventResult{ImportedEvent: p, Duplicate: duplicates[p.UID]}
+			if !result.Duplicate {
+				for _, d := range p.Drafts {
+					result.Tasks = append(result.Tasks, taskFromDraft(projectID, p.UID, d, now))
+				}
+				newTasks = append(newTasks, result.Tasks...)
+			}
+			results = append(results, result)
+		}
+
+		if len(newTasks) > calendars.MaxImportTasks {
+			return nil, apperror.NewBadRequestError(
+				fmt.Sprintf("calendar would create %d tasks, at most %d can be imported at once", len(newTasks), calendars.MaxImportTasks),
+				"TOO_MANY_IMPORTED_TASKS",
+				nil,
+			)
+		}
+		return newTasks, nil
+	}
+
+	if opts.DryRun {
+		existing, err := s.taskRepo.ListExternalUIDs(ctx, projectID, uids)
+		if err != nil {
+			return nil, apperror.NewInternalServerError("failed to check imported tasks", "CHECK_IMPORTED_TASKS_ERROR", err)
+		}
+		if _, err := plan(existing); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
+	// The duplicates are checked again under the lock of the project
+	if err := s.taskService.ImportTasks(ctx, projectID, uids, plan); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func taskFromDraft(projectID uuid.UUID, uid string, d calendars.TaskDraft, now time.Time) *taskEntity.Task {
+	// All-day tasks keep the dates of the event, whatever zone it was read in
+	toTaskTime := func(t time.Time) *time.Time { return lo.ToPtr(t) }
+	if d.AllDay {
+		toTaskTime = func(t time.Time) *time.Time { return lo.ToPtr(tasks.StartOfDate(t)) }
+	}
+
+	t := &taskEntity.Task{
+		ID:            uuid.New(),
+		ProjectID:     projectID,
+		Name:          d.Name,
+		Priority:      d.Priority,
+		StartDateTime: toTaskTime(d.Start),
+		AllDay:        d.AllDay,
+		Status:        "todo",
+		ExternalUID:   lo.ToPtr(uid),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if !d.End.IsZero() {
+		t.EndDateTime = toTaskTime(d.End)
+	}
+	if d.Description != "" {
+		t.Description = lo.ToPtr(d.Description)
+	}
+	if d.Location != "" {
+		t.Location = lo.ToPtr(d.Location)
+	}
+	if d.IntervalDays > 0 {
+		t.RecurringDays = lo.ToPtr(d.IntervalDays)
+		if !d.Until.IsZero() {
+			t.RecurringUntil = toTaskTime(d.Until)
+		}
+	}
+	return t
+}
+
+// EventFromTask maps a task to a VEVENT. Tasks without a valid start or end time
+// are not scheduled and are skipped; a task with only one of them becomes a
+// zero-length event at that time.
+func EventFromTask(t *taskEntity.Task) (calendars.Event, bool) {
+	start, hasStart := lo.FromPtr(t.StartDateTime), t.StartDateTime != nil
+	end, hasEnd := lo.FromPtr(t.EndDateTime), t.EndDateTime != nil
+	switch {
+	case !hasStart && !hasEnd:
+		return calendars.Event{}, false
+	case !hasStart:
+		start, end = end, time.Time{}
+	case !hasEnd || !end.After(start):
+		end = time.Time{}
+	}
+
+	event := calendars.Event{
+		UID:          eventUID(t),
+		Summary:      t.Name,
+		Description:  lo.FromPtr(t.Description),
+		Location:     lo.FromPtr(t.Location),
+		Start:        start,
+		End:          end,
+		AllDay:       t.AllDay,
+		IntervalDays: lo.FromPtr(t.RecurringDays),
+		Priority:     icalPriority(t.Priority),
+		Created:      t.CreatedAt,
+		LastModified: t.UpdatedAt,
+	}
+	if event.IntervalDays > 0 && t.RecurringUntil != nil {
+		event.Until = *t.RecurringUntil
+	}
+
+	return event, true
+}
+
+// eventUID keeps the UID a CalDAV client created the task with, since it
+// matches the event back to its own copy by UID
+func eventUID(t *taskEntity.Task) string {
+	if t.CalDAVName != nil && t.ExternalUID != nil {
+		return *t.ExternalUID
+	}
+	return t.ID.String() + "@" + eventUIDDomain
+}
+
+// getOwnedProject hides projects of other accounts behind the same not found error
+func (s *CalendarService) getOwnedProject(ctx context.Context, accountID, projectID uuid.UUID) (*projectEntity.Project, error) {
+	proj, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to get project", "GET_PROJECT_ERROR", err)
+	}
+	if proj.AccountID != accountID {
+		return nil, apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", nil)
+	}
+
+	return proj, nil
+}
+
+// icalPriority maps task priorities to the RFC 5545 high (1), medium (5) and low (9) values
+func icalPriority(priority string) int {
+	switch strings.ToLower(priority) {
+	case "high", "urgent":
+		return 1
+	case "medium":
+		return 5
+	case "low":
+		return 9
+	default:
+		return 0
+	}
+}
+
+func newFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
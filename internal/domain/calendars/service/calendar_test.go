@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	taskSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestCalendarService(t *testing.T) (*CalendarService, *mocks.MockFeedRepository, *mocks.MockProjectRepository, *mocks.MockTaskRepository, *mocks.MockOutbox) {
+	ctrl := gomock.NewController(t)
+	feedRepo := mocks.NewMockFeedRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	outbox := mocks.NewMockOutbox(ctrl)
+	taskService := taskSvc.NewTaskService(taskRepo, projectRepo, outbox)
+	return NewCalendarService(feedRepo, projectRepo, taskRepo, taskService), feedRepo, projectRepo, taskRepo, outbox
+}
+
+// expectEvents runs the transactions of the service and expects it to record
+// one event of each of eventTypes
+func expectEvents(outbox *mocks.MockOutbox, eventTypes ...string) {
+	outbox.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	for _, eventType := range eventTypes {
+		outbox.EXPECT().Record(gomock.Any(), gomock.Cond(func(e any) bool {
+			return e.(events.Event).Type == eventType
+		})).Return(nil)
+	}
+}
+
+func timePtr(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestCalendarService_CreateFeed(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name          string
+		projectID     *uuid.UUID
+		setupMock     func(feedRepo *mocks.MockFeedRepository, projectRepo *mocks.MockProjectRepository)
+		expectedError string
+	}{
+		{
+			name: "success - account feed",
+			setupMock: func(feedRepo *mocks.MockFeedRepository, _ *mocks.MockProjectRepository) {
+				feedRepo.EXPECT().CreateFeed(ctx, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "success - project feed",
+			projectID: &projectID,
+			setupMock: func(feedRepo *mocks.MockFeedRepository, projectRepo *mocks.MockProjectRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+				feedRepo.EXPECT().CreateFeed(ctx, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "error - project of another account",
+			projectID: &projectID,
+			setupMock: func(_ *mocks.MockFeedRepository, projectRepo *mocks.MockProjectRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: uuid.New()}, nil)
+			},
+			expectedError: "project not found",
+		},
+		{
+			name:      "error - project not found",
+			projectID: &projectID,
+			setupMock: func(_ *mocks.MockFeedRepository, projectRepo *mocks.MockProjectRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(nil, apperror.ErrRecordNotFound)
+			},
+			expectedError: "project not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, feedRepo, projectRepo, _, _ := newTestCalendarService(t)
+			tt.setupMock(feedRepo, projectRepo)
+
+			feed, token, err := svc.CreateFeed(ctx, accountID, tt.projectID)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, token)
+			assert.Equal(t, hashFeedToken(token), feed.TokenHash)
+			assert.NotEqual(t, token, feed.TokenHash)
+			assert.Equal(t, tt.projectID, feed.ProjectID)
+		})
+	}
+}
+
+func TestCalendarService_RenderFeed(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+	revokedAt := time.Now()
+	now := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	scheduled := &taskEntity.Task{
+		ID:            uuid.New(),
+		ProjectID:     projectID,
+		Name:          "Review",
+		StartDateTime: timePtr("2026-01-05T10:00:00+07:00"),
+		EndDateTime:   timePtr("2026-01-05T11:00:00+07:00"),
+		UpdatedAt:     now,
+	}
+	unscheduled := &taskEntity.Task{ID: uuid.New(), ProjectID: projectID, Name: "Someday", UpdatedAt: now}
+
+	tests := []struct {
+		name          string
+		setupMock     func(feedRepo *mocks.MockFeedRepository, projectRepo *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository)
+		expectedError string
+		validate      func(t *testing.T, out string)
+	}{
+		{
+			name: "success - project feed",
+			setupMock: func(feedRepo *mocks.MockFeedRepository, projectRepo *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository) {
+				feedRepo.EXPECT().GetFeedByTokenHash(ctx, hashFeedToken("token")).Return(&entity.Feed{AccountID: accountID, ProjectID: &projectID}, nil)
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: accountID, Name: "Launch"}, nil)
+				taskRepo.EXPECT().ListTasksByProject(ctx, projectID).Return([]*taskEntity.Task{scheduled, unscheduled}, nil)
+			},
+			validate: func(t *testing.T, out string) {
+				assert.Contains(t, out, "X-WR-CALNAME:Launch\r\n")
+				assert.Contains(t, out, "DTSTART:20260105T030000Z\r\n")
+				assert.Equal(t, 1, strings.Count(out, "BEGIN:VEVENT"))
+			},
+		},
+		{
+			name: "success - account feed",
+			setupMock: func(feedRepo *mocks.MockFeedRepository, _ *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository) {
+				feedRepo.EXPECT().GetFeedByTokenHash(ctx, hashFeedToken("token")).Return(&entity.Feed{AccountID: accountID}, nil)
+				taskRepo.EXPECT().ListTasksByAccount(ctx, accountID).Return([]*taskEntity.Task{scheduled}, nil)
+			},
+			validate: func(t *testing.T, out string) {
+				assert.Contains(t, out, "X-WR-CALNAME:"+AccountFeedName+"\r\n")
+				assert.Contains(t, out, "SUMMARY:Review\r\n")
+			},
+		},
+		{
+			name: "error - unknown token",
+			setupMock: func(feedRepo *mocks.MockFeedRepository, _ *mocks.MockProjectRepository, _ *mocks.MockTaskRepository) {
+				feedRepo.EXPECT().GetFeedByTokenHash(ctx, hashFeedToken("token")).Return(nil, apperror.ErrRecordNotFound)
+			},
+			expectedError: "calendar feed not found",
+		},
+		{
+			name: "error - revoked feed",
+			setupMock: func(feedRepo *mocks.MockFeedRepository, _ *mocks.MockProjectRepository, _ *mocks.MockTaskRepository) {
+				feedRepo.EXPECT().GetFeedByTokenHash(ctx, hashFeedToken("token")).Return(&entity.Feed{AccountID: accountID, RevokedAt: &revokedAt}, nil)
+			},
+			expectedError: "calendar feed not found",
+		},
+		{
+			name: "error - repository failure",
+			setupMock: func(feedRepo *mocks.MockFeedRepository, _ *mocks.MockProjectRepository, _ *mocks.MockTaskRepository) {
+				feedRepo.EXPECT().GetFeedByTokenHash(ctx, hashFeedToken("token")).Return(nil, errors.New("db down"))
+			},
+			expectedError: "failed to get calendar feed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, feedRepo, projectRepo, taskRepo, _ := newTestCalendarService(t)
+			tt.setupMock(feedRepo, projectRepo, taskRepo)
+
+			out, err := svc.RenderFeed(ctx, "token")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tt.validate(t, string(out))
+		})
+	}
+}
+
+func TestEventFromTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     *taskEntity.Task
+		ok       bool
+		validate func(t *testing.T, e calendars.Event)
+	}{
+		{
+			name: "unscheduled task is skipped",
+			task: &taskEntity.Task{ID: uuid.New()},
+		},
+		{
+			name: "all-day task keeps its dates",
+			task: &taskEntity.Task{ID: uuid.New(), StartDateTime: timePtr("2026-01-05T00:00:00Z"), EndDateTime: timePtr("2026-01-07T00:00:00Z"), AllDay: true},
+			ok:   true,
+			validate: func(t *testing.T, e calendars.Event) {
+				assert.True(t, e.AllDay)
+				assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), e.Start)
+				assert.Equal(t, time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), e.End)
+			},
+		},
+		{
+			name: "end only becomes a zero-length event",
+			task: &taskEntity.Task{ID: uuid.New(), EndDateTime: timePtr("2026-01-05T10:00:00Z")},
+			ok:   true,
+			validate: func(t *testing.T, e calendars.Event) {
+				assert.Equal(t, time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC), e.Start)
+				assert.True(t, e.End.IsZero())
+			},
+		},
+		{
+			name: "recurrence, location and description carry over",
+			task: &taskEntity.Task{
+				ID:             uuid.New(),
+				Priority:       "High",
+				Description:    lo.ToPtr("Agenda"),
+				Location:       lo.ToPtr("Room 1"),
+				StartDateTime:  timePtr("2026-01-05T10:00:00Z"),
+				EndDateTime:    timePtr("2026-01-05T11:00:00Z"),
+				RecurringDays:  lo.ToPtr(7),
+				RecurringUntil: timePtr("2026-02-15T10:00:00Z"),
+			},
+			ok: true,
+			validate: func(t *testing.T, e calendars.Event) {
+				assert.Equal(t, "FREQ=DAILY;INTERVAL=7;UNTIL=20260215T100000Z", e.RRule())
+				assert.Equal(t, "Agenda", e.Description)
+				assert.Equal(t, "Room 1", e.Location)
+				assert.Equal(t, 1, e.Priority)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := EventFromTask(tt.task)
+
+			assert.Equal(t, tt.ok, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.task.ID.String()+"@"+eventUIDDomain, event.UID)
+			tt.validate(t, event)
+		})
+	}
+}
+
+func TestCalendarService_ImportTasks(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+	ownProject := &projectEntity.Project{ID: projectID, AccountID: accountID}
+
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:lecture@example.com",
+		"SUMMARY:Lecture",
+		"LOCATION:Hall A",
+		"DTSTART;TZID=Asia/Bangkok:20260302T090000",
+		"DTEND;TZID=Asia/Bangkok:20260302T103000",
+		"RRULE:FREQ=WEEKLY;COUNT=4",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:meeting@example.com",
+		"SUMMARY:Meeting",
+		"DTSTART:20260303T140000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	tests := []struct {
+		name          string
+		input         string
+		dryRun        bool
+		setupMock     func(projectRepo *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository)
+		events        []string
+		expectedError string
+		validate      func(t *testing.T, results []ImportedEventResult)
+	}{
+		{
+			name:  "success - creates tasks",
+			input: calendar,
+			setupMock: func(projectRepo *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(ownProject, nil)
+				// Duplicates are only checked once the project is locked
+				gomock.InOrder(
+					projectRepo.EXPECT().LockProject(ctx, projectID).Return(nil),
+					taskRepo.EXPECT().ListExternalUIDs(ctx, projectID, []string{"lecture@example.com", "meeting@example.com"}).Return(nil, nil),
+					taskRepo.EXPECT().CreateTasks(ctx, gomock.Len(2)).Return(nil),
+				)
+			},
+			events: []string{events.TaskCreated, events.TaskCreated},
+			validate: func(t *testing.T, results []ImportedEventResult) {
+				require.Len(t, results, 2)
+				require.Len(t, results[0].Tasks, 1)
+				lecture := results[0].Tasks[0]
+				assert.Equal(t, projectID, lecture.ProjectID)
+				assert.Equal(t, "Lecture", lecture.Name)
+				assert.Equal(t, "todo", lecture.Status)
+				assert.Equal(t, "medium", lecture.Priority)
+				assert.Equal(t, "Hall A", lo.FromPtr(lecture.Location))
+				assert.Equal(t, "2026-03-02T09:00:00+07:00", lecture.StartDateTime.Format(time.RFC3339))
+				assert.Equal(t, "2026-03-02T10:30:00+07:00", lecture.EndDateTime.Format(time.RFC3339))
+				assert.Equal(t, 7, lo.FromPtr(lecture.RecurringDays))
+				assert.Equal(t, "2026-03-23T09:00:00+07:00", lecture.RecurringUntil.Format(time.RFC3339))
+				assert.Equal(t, "lecture@example.com", lo.FromPtr(lecture.ExternalUID))
+			},
+		},
+		{
+			name:   "success - dry run skips duplicates and saves nothing",
+			input:  calendar,
+			dryRun: true,
+			setupMock: func(projectRepo *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(ownProject, nil)
+				taskRepo.EXPECT().ListExternalUIDs(ctx, projectID, gomock.Any()).Return([]string{"meeting@example.com"}, nil)
+			},
+			validate: func(t *testing.T, results []ImportedEventResult) {
+				require.Len(t, results, 2)
+				assert.False(t, results[0].Duplicate)
+				assert.Len(t, results[0].Tasks, 1)
+				assert.True(t, results[1].Duplicate)
+				assert.Empty(t, results[1].Tasks)
+			},
+		},
+		{
+			name:  "error - invalid file",
+			input: "not a calendar",
+			setupMock: func(projectRepo *mocks.MockProjectRepository, _ *mocks.MockTaskRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(ownProject, nil)
+			},
+			expectedError: "invalid calendar file",
+		},
+		{
+			name:  "error - project of another account",
+			input: calendar,
+			setupMock: func(projectRepo *mocks.MockProjectRepository, _ *mocks.MockTaskRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: uuid.New()}, nil)
+			},
+			expectedError: "project not found",
+		},
+		{
+			name:  "error - create fails",
+			input: calendar,
+			setupMock: func(projectRepo *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(ownProject, nil)
+				projectRepo.EXPECT().LockProject(ctx, projectID).Return(nil)
+				taskRepo.EXPECT().ListExternalUIDs(ctx, projectID, gomock.Any()).Return(nil, nil)
+				taskRepo.EXPECT().CreateTasks(ctx, gomock.Any()).Return(errors.New("db down"))
+			},
+			expectedError: "failed to create tasks",
+		},
+		{
+			name:  "success - events imported meanwhile are duplicates",
+			input: calendar,
+			setupMock: func(projectRepo *mocks.MockProjectRepository, taskRepo *mocks.MockTaskRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(ownProject, nil)
+				projectRepo.EXPECT().LockProject(ctx, projectID).Return(nil)
+				taskRepo.EXPECT().ListExternalUIDs(ctx, projectID, gomock.Any()).Return([]string{"lecture@example.com", "meeting@example.com"}, nil)
+			},
+			validate: func(t *testing.T, results []ImportedEventResult) {
+				require.Len(t, results, 2)
+				assert.True(t, results[0].Duplicate)
+				assert.True(t, results[1].Duplicate)
+			},
+		},
+		{
+			name:  "error - project deleted meanwhile",
+			input: calendar,
+			setupMock: func(projectRepo *mocks.MockProjectRepository, _ *mocks.MockTaskRepository) {
+				projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(ownProject, nil)
+				projectRepo.EXPECT().LockProject(ctx, projectID).Return(apperror.ErrRecordNotFound)
+			},
+			expectedError: "project not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, projectRepo, taskRepo, outbox := newTestCalendarService(t)
+			tt.setupMock(projectRepo, taskRepo)
+			expectEvents(outbox, tt.events...)
+
+			results, err := svc.ImportTasks(ctx, accountID, projectID, strings.NewReader(tt.input), ImportOptions{DryRun: tt.dryRun})
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			tt.validate(t, results)
+		})
+	}
+}
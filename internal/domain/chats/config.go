@@ -1,12 +1,10 @@
 package chats
 
+import "github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
+
 // AIConfig represents AI configuration within project config
-// Used to customize the AI assistant's behavior per project
-type AIConfig struct {
-	ChatStyle       string   `json:"chat_style"`       // "formal", "casual", "friendly"
-	DomainKnowledge []string `json:"domain_knowledge"` // Areas of expertise the AI should emphasize
-	Language        string   `json:"language"`         // Preferred response language: "th", "en"
-}
+// The schema lives with the project config; this alias keeps chat code readable
+type AIConfig = projects.AIConfig
 
 // DefaultAIConfig returns the default AI configuration
 // Used when no config is specified in the project
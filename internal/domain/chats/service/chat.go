@@ -11,8 +11,11 @@ import (
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/chats"
 	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
 	projectSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	taskSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/groq"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 	"github.com/google/uuid"
 )
@@ -88,7 +91,10 @@ func (s *chatService) SendMessage(ctx context.Context, req *SendMessageRequest)
 		return nil, apperror.NewInternalServerError("failed to get tasks", "GET_TASKS_ERROR", err)
 	}
 
-	aiConfig := s.getAIConfig(project)
+	aiConfig, err := s.getAIConfig(project)
+	if err != nil {
+		return nil, err
+	}
 	systemPrompt := s.promptBuilder.BuildSystemPrompt(aiConfig, tasks)
 	messages := s.buildMessages(systemPrompt, req.SessionHistory, req.Content)
 	groqReq := groq.NewDefaultRequest(messages)
@@ -107,6 +113,9 @@ func (s *chatService) SendMessage(ctx context.Context, req *SendMessageRequest)
 	// Try to parse as structured JSON response from AI
 	structuredResp := s.parseStructuredResponse(aiResponse)
 	if structuredResp != nil {
+		if err := s.attachConflicts(ctx, project.AccountID, structuredResp.Tasks); err != nil {
+			return nil, err
+		}
 		return structuredResp, nil
 	}
 
@@ -136,6 +145,9 @@ type TaskFromAI struct {
 	Location       string `json:"location,omitempty"`
 	RecurringDays  int    `json:"recurring_days,omitempty"`
 	RecurringUntil string `json:"recurring_until,omitempty"`
+
+	// Conflicts lists the scheduled tasks the suggestion overlaps; never read from the AI
+	Conflicts []tasks.Conflict `json:"-"`
 }
 
 // parseStructuredResponse tries to parse any structured JSON response from AI
@@ -226,6 +238,51 @@ func extractJSON(s string) string {
 	return ""
 }
 
+// attachConflicts runs the scheduling conflict check on every suggested task, so
+// users see double-bookings before they accept a suggestion
+func (s *chatService) attachConflicts(ctx context.Context, accountID uuid.UUID, suggestions []TaskFromAI) error {
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	candidates := make([]*taskEntity.Task, len(suggestions))
+	for i, t := range suggestions {
+		candidates[i] = t.toTask()
+	}
+
+	conflicts, err := s.taskService.FindConflictsForEach(ctx, accountID, candidates)
+	if err != nil {
+		return err
+	}
+	for i := range suggestions {
+		suggestions[i].Conflicts = conflicts[i]
+	}
+	return nil
+}
+
+// toTask builds the task the suggestion would become; a suggested edit of an
+// existing task keeps its ID so that the task does not conflict with itself
+func (t TaskFromAI) toTask() *taskEntity.Task {
+	tsk := &taskEntity.Task{Name: t.Name, Status: t.Status}
+	if id, err := utils.ParseID(t.ID, taskEntity.TaskIDPrefix); err == nil {
+		tsk.ID = id
+	}
+	// Times the AI wrote in another format leave the suggestion unscheduled
+	if start, err := tasks.ParseTime(t.StartDateTime); err == nil {
+		tsk.StartDateTime = &start
+	}
+	if end, err := tasks.ParseTime(t.EndDateTime); err == nil {
+		tsk.EndDateTime = &end
+	}
+	if t.RecurringDays > 0 {
+		tsk.RecurringDays = &t.RecurringDays
+	}
+	if until, err := tasks.ParseTime(t.RecurringUntil); err == nil {
+		tsk.RecurringUntil = &until
+	}
+	return tsk
+}
+
 func (s *chatService) validateRequest(req *SendMessageRequest) error {
 	if req == nil {
 		return apperror.NewBadRequestError("request is required", ErrCodeInvalidMessage, nil)
@@ -281,24 +338,17 @@ func (s *chatService) handleGroqError(err error) error {
 	}
 }
 
-func (s *chatService) getAIConfig(project *projectEntity.Project) *chats.AIConfig {
-	if len(project.Config) == 0 {
-		return &chats.DefaultAIConfig
-	}
-
-	var configWrapper struct {
-		AIConfig *chats.AIConfig `json:"ai_config"`
-	}
-
-	if err := json.Unmarshal(project.Config, &configWrapper); err != nil {
-		return &chats.DefaultAIConfig
+func (s *chatService) getAIConfig(project *projectEntity.Project) (*chats.AIConfig, error) {
+	cfg, err := s.projectService.GetProjectConfig(project)
+	if err != nil {
+		return nil, err
 	}
 
-	if configWrapper.AIConfig == nil {
-		return &chats.DefaultAIConfig
+	if cfg.AIConfig == nil {
+		return &chats.DefaultAIConfig, nil
 	}
 
-	config := configWrapper.AIConfig
+	config := *cfg.AIConfig
 	if config.ChatStyle == "" {
 		config.ChatStyle = chats.DefaultAIConfig.ChatStyle
 	}
@@ -309,7 +359,7 @@ func (s *chatService) getAIConfig(project *projectEntity.Project) *chats.AIConfi
 		config.Language = chats.DefaultAIConfig.Language
 	}
 
-	return config
+	return &config, nil
 }
 
 func (s *chatService) buildMessages(systemPrompt string, sessionHistory []groq.ChatMessage, userContent string) []groq.ChatMessage {
@@ -4,8 +4,10 @@ import (
 	_ "embed"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/chats"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
 	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/utils"
 )
@@ -51,10 +53,10 @@ func (p *promptBuilder) BuildSystemPrompt(config *chats.AIConfig, tasks []*taskE
 				sb.WriteString(fmt.Sprintf(" - %s", *task.Description))
 			}
 			if task.StartDateTime != nil {
-				sb.WriteString(fmt.Sprintf(" เริ่ม: %s", *task.StartDateTime))
+				sb.WriteString(fmt.Sprintf(" เริ่ม: %s", promptTime(task, *task.StartDateTime)))
 			}
 			if task.EndDateTime != nil {
-				sb.WriteString(fmt.Sprintf(" สิ้นสุด: %s", *task.EndDateTime))
+				sb.WriteString(fmt.Sprintf(" สิ้นสุด: %s", promptTime(task, *task.EndDateTime)))
 			}
 			sb.WriteString("\n")
 		}
@@ -66,3 +68,12 @@ func (p *promptBuilder) BuildSystemPrompt(config *chats.AIConfig, tasks []*taskE
 
 	return sb.String()
 }
+
+// promptTime writes the dates of all-day tasks and RFC 3339 times otherwise,
+// the formats the AI is asked to answer in
+func promptTime(task *taskEntity.Task, t time.Time) string {
+	if task.AllDay {
+		return t.UTC().Format(tasks.DateLayout)
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is an event recorded in the transaction of the change it
+// describes. Seq orders the events as they were committed to the outbox.
+// Handled names the handlers that already handled it, so a retry only goes to
+// the ones that failed.
+type OutboxEvent struct {
+	Seq           int64           `gorm:"column:seq;->"`
+	ID            uuid.UUID       `gorm:"column:id;type:char(36);primaryKey"`
+	Type          string          `gorm:"column:type;type:varchar(64);not null"`
+	ProjectID     uuid.UUID       `gorm:"column:project_id;type:char(36);not null"`
+	Data          json.RawMessage `gorm:"column:data;type:jsonb;not null"`
+	OccurredAt    time.Time       `gorm:"column:occurred_at;not null"`
+	Status        string          `gorm:"column:status;type:varchar(16);not null"`
+	Attempts      int             `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt *time.Time      `gorm:"column:next_attempt_at"`
+	LockedUntil   *time.Time      `gorm:"column:locked_until"`
+	Handled       []string        `gorm:"column:handled;type:jsonb;serializer:json;not null"`
+	LastError     *string         `gorm:"column:last_error;type:text"`
+	DispatchedAt  *time.Time      `gorm:"column:dispatched_at"`
+	UpdatedAt     time.Time       `gorm:"column:updated_at;not null"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,158 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/google/uuid"
+)
+
+// EventIDPrefix prefixes the IDs of events shown to clients
+const EventIDPrefix = "evt"
+
+// Event types
+const (
+	TaskCreated = "task.created"
+	TaskUpdated = "task.updated"
+	// TaskStatusChanged is recorded along with task.updated when the status changes
+	TaskStatusChanged = "task.status_changed"
+	TaskDeleted       = "task.deleted"
+	ProjectUpdated    = "project.updated"
+	ProjectDeleted    = "project.deleted"
+)
+
+// Types lists every event type
+var Types = []string{
+	TaskCreated,
+	TaskUpdated,
+	TaskStatusChanged,
+	TaskDeleted,
+	ProjectUpdated,
+	ProjectDeleted,
+}
+
+// Event is a change to a project or its tasks. Data is the JSON encoded
+// TaskEventData or ProjectEventData, depending on the type.
+type Event struct {
+	ID         uuid.UUID
+	Type       string
+	ProjectID  uuid.UUID
+	OccurredAt time.Time
+	Data       json.RawMessage
+}
+
+func NewEvent(eventType string, projectID uuid.UUID, data any) (Event, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		ProjectID:  projectID,
+		OccurredAt: time.Now(),
+		Data:       encoded,
+	}, nil
+}
+
+// Handler reacts to events dispatched by the relay. An event may be handled
+// more than once, so handlers must be idempotent; Event.ID is a stable key.
+type Handler interface {
+	Handle(ctx context.Context, event Event) error
+}
+
+// HandlerFunc adapts a function to Handler
+type HandlerFunc func(ctx context.Context, event Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// TaskEventData is the data of task events
+type TaskEventData struct {
+	Task TaskData `json:"task"`
+	// PreviousStatus is set on task.status_changed
+	PreviousStatus string `json:"previous_status,omitempty"`
+}
+
+// TaskID returns the ID of the task of a task event
+func TaskID(event Event) (uuid.UUID, error) {
+	var data TaskEventData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return uuid.Nil, fmt.Errorf("decode %s event: %w", event.Type, err)
+	}
+	return utils.ParseID(data.Task.ID, taskEntity.TaskIDPrefix)
+}
+
+// TaskData is a task in event data; times are in UTC
+type TaskData struct {
+	ID             string     `json:"id"`
+	ProjectID      string     `json:"project_id"`
+	Name           string     `json:"name"`
+	Description    *string    `json:"description,omitempty"`
+	Priority       string     `json:"priority"`
+	Status         string     `json:"status"`
+	StartDateTime  *time.Time `json:"start_datetime,omitempty"`
+	EndDateTime    *time.Time `json:"end_datetime,omitempty"`
+	AllDay         bool       `json:"all_day"`
+	Location       *string    `json:"location,omitempty"`
+	RecurringDays  *int       `json:"recurring_days,omitempty"`
+	RecurringUntil *time.Time `json:"recurring_until,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func NewTaskData(t *taskEntity.Task) TaskData {
+	return TaskData{
+		ID:             utils.ShortUUIDWithPrefix(t.ID, taskEntity.TaskIDPrefix),
+		ProjectID:      utils.ShortUUIDWithPrefix(t.ProjectID, projectEntity.ProjectIDPrefix),
+		Name:           t.Name,
+		Description:    t.Description,
+		Priority:       t.Priority,
+		Status:         t.Status,
+		StartDateTime:  utc(t.StartDateTime),
+		EndDateTime:    utc(t.EndDateTime),
+		AllDay:         t.AllDay,
+		Location:       t.Location,
+		RecurringDays:  t.RecurringDays,
+		RecurringUntil: utc(t.RecurringUntil),
+		CompletedAt:    utc(t.CompletedAt),
+		CreatedAt:      t.CreatedAt.UTC(),
+		UpdatedAt:      t.UpdatedAt.UTC(),
+	}
+}
+
+// ProjectEventData is the data of project events
+type ProjectEventData struct {
+	Project ProjectData `json:"project"`
+}
+
+type ProjectData struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewProjectData(p *projectEntity.Project) ProjectData {
+	return ProjectData{
+		ID:        utils.ShortUUIDWithPrefix(p.ID, projectEntity.ProjectIDPrefix),
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt.UTC(),
+		UpdatedAt: p.UpdatedAt.UTC(),
+	}
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
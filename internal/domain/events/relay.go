@@ -0,0 +1,28 @@
+package events
+
+import "time"
+
+// Outbox statuses
+const (
+	// StatusPending events are dispatched at NextAttemptAt
+	StatusPending = "pending"
+	// StatusDispatching events are claimed by a relay
+	StatusDispatching = "dispatching"
+	// StatusDispatched events were handled by every handler subscribed to them
+	StatusDispatched = "dispatched"
+	// StatusFailed events gave up after MaxAttempts; they are kept for inspection
+	StatusFailed = "failed"
+)
+
+// MaxAttempts is how often the relay tries one event; with RetryDelay the last
+// attempt is about 50 minutes after the first
+const MaxAttempts = 12
+
+// RetryDelay is the backoff before the next attempt after a failed one:
+// 5 seconds, doubling up to 10 minutes
+func RetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return min(5*time.Second<<min(attempts-1, 10), 10*time.Minute)
+}
@@ -0,0 +1,17 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, RetryDelay(0))
+	assert.Equal(t, 5*time.Second, RetryDelay(1))
+	assert.Equal(t, 40*time.Second, RetryDelay(4))
+	assert.Equal(t, 320*time.Second, RetryDelay(7))
+	assert.Equal(t, 10*time.Minute, RetryDelay(8))
+	assert.Equal(t, 10*time.Minute, RetryDelay(50))
+}
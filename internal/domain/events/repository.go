@@ -0,0 +1,49 @@
+//go:generate go run go.uber.org/mock/mockgen -source=$GOFILE -destination=../../mocks/outbox_repository.go -package=mocks
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events/entity"
+	"github.com/google/uuid"
+)
+
+// Outbox records the events of a change in the transaction that saves it, so
+// an event is recorded if and only if its change is committed
+type Outbox interface {
+	// WithinTransaction runs fn in a transaction. Repositories called with the
+	// ctx passed to fn take part in it; when ctx already carries a transaction,
+	// fn joins that one.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// Record adds events to the outbox, in the transaction of ctx if it has one.
+	// Transactions that record events commit in the order of their seqs, so
+	// once seq N is visible no event below N can still appear.
+	Record(ctx context.Context, events ...Event) error
+}
+
+type OutboxRepository interface {
+	// ClaimDueEvents marks up to limit events that are due at now, or whose claim
+	// has expired, as dispatching until lockedUntil and increments their
+	// attempts. Rows locked by another relay are skipped. Events are returned in
+	// Seq order.
+	ClaimDueEvents(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.OutboxEvent, error)
+	// FinishClaim saves a claimed event; it reports false when the claim was
+	// lost, i.e. the row is no longer dispatching until claimedUntil
+	FinishClaim(ctx context.Context, event *entity.OutboxEvent, claimedUntil time.Time) (bool, error)
+	// DeleteDispatchedEvents deletes up to limit events dispatched before
+	// before, returning how many were deleted
+	DeleteDispatchedEvents(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// EventLog reads the outbox as the recent history of projects. Dispatched
+// events are kept for the retention of the outbox, then purged.
+type EventLog interface {
+	// ListProjectEvents returns up to limit events of the project of types,
+	// recorded after seq, in seq order
+	ListProjectEvents(ctx context.Context, projectID uuid.UUID, afterSeq int64, types []string, limit int) ([]*entity.OutboxEvent, error)
+	// GetEventsBySeq returns the events of seqs that exist, in seq order
+	GetEventsBySeq(ctx context.Context, seqs []int64) ([]*entity.OutboxEvent, error)
+	// GetSeqRange returns the lowest and highest seq in the outbox, both 0 when it is empty
+	GetSeqRange(ctx context.Context) (int64, int64, error)
+}
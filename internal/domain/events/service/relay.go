@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events/entity"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Outcomes of dispatching a claimed event
+const (
+	OutcomeDispatched = "dispatched"
+	// OutcomeRetry is an event some handler failed on, dispatched again later
+	OutcomeRetry  = "retry"
+	OutcomeFailed = "failed"
+	// OutcomeLost is an event whose claim expired before the outcome was saved
+	OutcomeLost = "lost"
+)
+
+// leaseMargin is the time left on a claim below which an event is not started
+const leaseMargin = 10 * time.Second
+
+// DispatchResult is the outcome of one claimed event
+type DispatchResult struct {
+	EventID uuid.UUID
+	Type    string
+	Outcome string
+	Err     error
+}
+
+type subscription struct {
+	name    string
+	types   []string
+	handler events.Handler
+}
+
+func (s subscription) accepts(eventType string) bool {
+	return len(s.types) == 0 || lo.Contains(s.types, eventType)
+}
+
+// RelayService dispatches the events of the outbox to the handlers subscribed
+// in-process. Delivery is at least once: an event is dispatched again until
+// every handler subscribed to it succeeded, or MaxAttempts is reached.
+type RelayService struct {
+	repo          events.OutboxRepository
+	subscriptions []subscription
+}
+
+func NewRelayService(repo events.OutboxRepository) *RelayService {
+	return &RelayService{repo: repo}
+}
+
+// Subscribe registers handler for events of types, or of every type when none
+// are given. It must be called before the relay runs. name identifies the
+// handler in the outbox, so it must be unique and kept across releases.
+func (s *RelayService) Subscribe(name string, handler events.Handler, types ...string) {
+	if lo.ContainsBy(s.subscriptions, func(sub subscription) bool { return sub.name == name }) {
+		panic("events: handler " + name + " subscribed twice")
+	}
+	s.subscriptions = append(s.subscriptions, subscription{name: name, types: types, handler: handler})
+}
+
+// DispatchDue claims up to limit due events for lease and dispatches them in
+// the order they were recorded.
+//
+// As with reminders, an event is dispatched by the one relay holding its claim
+// and the outcome is only saved while the claim is held. A relay that dies
+// mid-event leaves it to be claimed again once the lease expires.
+func (s *RelayService) DispatchDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]DispatchResult, error) {
+	// The claim is matched on locked_until, which Postgres stores in microseconds
+	lockedUntil := now.Add(lease).Truncate(time.Microsecond)
+
+	claimed, err := s.repo.ClaimDueEvents(ctx, now, lockedUntil, limit)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to claim outbox events", "CLAIM_OUTBOX_EVENTS_ERROR", err)
+	}
+
+	results := make([]DispatchResult, 0, len(claimed))
+	for _, row := range claimed {
+		// The rest are claimed again by whichever relay is free once the lease expires
+		if time.Now().After(lockedUntil.Add(-leaseMargin)) {
+			break
+		}
+		results = append(results, s.dispatch(ctx, row, lockedUntil))
+	}
+	return results, nil
+}
+
+// PurgeDispatched deletes up to limit events dispatched before before
+func (s *RelayService) PurgeDispatched(ctx context.Context, before time.Time, limit int) (int64, error) {
+	deleted, err := s.repo.DeleteDispatchedEvents(ctx, before, limit)
+	if err != nil {
+		return 0, apperror.NewInternalServerError("failed to purge outbox events", "PURGE_OUTBOX_EVENTS_ERROR", err)
+	}
+	return deleted, nil
+}
+
+func (s *RelayService) dispatch(ctx context.Context, row *entity.OutboxEvent, lockedUntil time.Time) DispatchResult {
+	event := events.Event{
+		ID:         row.ID,
+		Type:       row.Type,
+		ProjectID:  row.ProjectID,
+		OccurredAt: row.OccurredAt,
+		Data:       row.Data,
+	}
+
+	var errs []error
+	for _, sub := range s.subscriptions {
+		if !sub.accepts(row.Type) || lo.Contains(row.Handled, sub.name) {
+			continue
+		}
+		if err := handle(ctx, sub.handler, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+			continue
+		}
+		row.Handled = append(row.Handled, sub.name)
+	}
+
+	err := errors.Join(errs...)
+	now := time.Now()
+	switch {
+	case err == nil:
+		row.Status = events.StatusDispatched
+		row.NextAttemptAt = nil
+		row.LastError = nil
+		row.DispatchedAt = &now
+		return s.finish(ctx, row, lockedUntil, OutcomeDispatched, nil)
+	case row.Attempts < events.MaxAttempts:
+		row.Status = events.StatusPending
+		row.NextAttemptAt = lo.ToPtr(now.Add(events.RetryDelay(row.Attempts)))
+		row.LastError = lo.ToPtr(err.Error())
+		return s.finish(ctx, row, lockedUntil, OutcomeRetry, err)
+	default:
+		row.Status = events.StatusFailed
+		row.NextAttemptAt = nil
+		row.LastError = lo.ToPtr(err.Error())
+		return s.finish(ctx, row, lockedUntil, OutcomeFailed, err)
+	}
+}
+
+// handle calls handler, turning a panic into an error so that one handler
+// cannot stop the relay
+func handle(ctx context.Context, handler events.Handler, event events.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler.Handle(ctx, event)
+}
+
+// finish saves the outcome of a claimed event, unless the claim was lost
+func (s *RelayService) finish(ctx context.Context, row *entity.OutboxEvent, lockedUntil time.Time, outcome string, handleErr error) DispatchResult {
+	result := DispatchResult{EventID: row.ID, Type: row.Type, Outcome: outcome, Err: handleErr}
+
+	row.LockedUntil = nil
+	row.UpdatedAt = time.Now()
+	saved, err := s.repo.FinishClaim(ctx, row, lockedUntil)
+	if err != nil {
+		result.Err = errors.Join(handleErr, err)
+	}
+	if err == nil && !saved {
+		result.Outcome = OutcomeLost
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type recordingHandler struct {
+	err     error
+	handled []events.Event
+}
+
+func (h *recordingHandler) Handle(_ context.Context, e events.Event) error {
+	h.handled = append(h.handled, e)
+	return h.err
+}
+
+func newOutboxEvent(eventType string, attempts int, handled ...string) *entity.OutboxEvent {
+	return &entity.OutboxEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		ProjectID:  uuid.New(),
+		Data:       []byte(`{}`),
+		OccurredAt: time.Now(),
+		Status:     events.StatusDispatching,
+		Attempts:   attempts,
+		Handled:    append([]string{}, handled...),
+	}
+}
+
+func TestRelayService_DispatchDue(t *testing.T) {
+	ctx := context.Background()
+	lease := time.Minute
+
+	setup := func(t *testing.T, claimed ...*entity.OutboxEvent) (*RelayService, *mocks.MockOutboxRepository) {
+		repo := mocks.NewMockOutboxRepository(gomock.NewController(t))
+		repo.EXPECT().ClaimDueEvents(ctx, gomock.Any(), gomock.Any(), 10).Return(claimed, nil)
+		return NewRelayService(repo), repo
+	}
+
+	t.Run("dispatches to the handlers subscribed to the type", func(t *testing.T) {
+		row := newOutboxEvent(events.TaskCreated, 1)
+		svc, repo := setup(t, row)
+		all, tasks, projects := &recordingHandler{}, &recordingHandler{}, &recordingHandler{}
+		svc.Subscribe("all", all)
+		svc.Subscribe("tasks", tasks, events.TaskCreated, events.TaskUpdated)
+		svc.Subscribe("projects", projects, events.ProjectUpdated)
+		repo.EXPECT().FinishClaim(ctx, row, gomock.Any()).Return(true, nil)
+
+		results, err := svc.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, OutcomeDispatched, results[0].Outcome)
+		assert.Equal(t, events.StatusDispatched, row.Status)
+		assert.NotNil(t, row.DispatchedAt)
+		assert.Equal(t, []string{"all", "tasks"}, row.Handled)
+		require.Len(t, tasks.handled, 1)
+		assert.Equal(t, row.ID, tasks.handled[0].ID)
+		assert.Empty(t, projects.handled)
+	})
+
+	t.Run("retries only the handlers that failed", func(t *testing.T) {
+		row := newOutboxEvent(events.TaskUpdated, 2, "first")
+		svc, repo := setup(t, row)
+		first, second, third := &recordingHandler{}, &recordingHandler{err: errors.New("unavailable")}, &recordingHandler{}
+		svc.Subscribe("first", first)
+		svc.Subscribe("second", second)
+		svc.Subscribe("third", third)
+		repo.EXPECT().FinishClaim(ctx, row, gomock.Any()).Return(true, nil)
+
+		before := time.Now()
+		results, err := svc.DispatchDue(ctx, before, 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeRetry, results[0].Outcome)
+		assert.ErrorContains(t, results[0].Err, "second: unavailable")
+		assert.Equal(t, events.StatusPending, row.Status)
+		assert.Equal(t, []string{"first", "third"}, row.Handled)
+		assert.Empty(t, first.handled)
+		require.NotNil(t, row.NextAttemptAt)
+		assert.True(t, row.NextAttemptAt.After(before.Add(events.RetryDelay(2)-time.Second)))
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		row := newOutboxEvent(events.TaskDeleted, events.MaxAttempts)
+		svc, repo := setup(t, row)
+		svc.Subscribe("broken", &recordingHandler{err: errors.New("unavailable")})
+		repo.EXPECT().FinishClaim(ctx, row, gomock.Any()).Return(true, nil)
+
+		results, err := svc.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeFailed, results[0].Outcome)
+		assert.Equal(t, events.StatusFailed, row.Status)
+		assert.Nil(t, row.NextAttemptAt)
+	})
+
+	t.Run("a panicking handler is retried", func(t *testing.T) {
+		row := newOutboxEvent(events.TaskCreated, 1)
+		svc, repo := setup(t, row)
+		svc.Subscribe("panics", events.HandlerFunc(func(context.Context, events.Event) error { panic("boom") }))
+		repo.EXPECT().FinishClaim(ctx, row, gomock.Any()).Return(true, nil)
+
+		results, err := svc.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeRetry, results[0].Outcome)
+		assert.ErrorContains(t, results[0].Err, "panic: boom")
+	})
+
+	t.Run("claim lost before saving", func(t *testing.T) {
+		row := newOutboxEvent(events.TaskCreated, 1)
+		svc, repo := setup(t, row)
+		svc.Subscribe("all", &recordingHandler{})
+		repo.EXPECT().FinishClaim(ctx, row, gomock.Any()).Return(false, nil)
+
+		results, err := svc.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeLost, results[0].Outcome)
+	})
+}
+
+func TestRelayService_Subscribe_Twice(t *testing.T) {
+	svc := NewRelayService(nil)
+	svc.Subscribe("webhooks", &recordingHandler{})
+
+	assert.Panics(t, func() { svc.Subscribe("webhooks", &recordingHandler{}) })
+}
@@ -0,0 +1,123 @@
+package profiles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"net/http"
+
+	// Register decoders for every accepted upload format
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxAvatarBytes is the largest upload accepted before decoding
+	MaxAvatarBytes = 5 << 20
+	// MaxAvatarPixels guards against decompression bombs with tiny files and huge dimensions
+	MaxAvatarPixels = 40_000_000
+
+	AvatarContentType = "image/jpeg"
+	avatarJPEGQuality = 85
+)
+
+// AvatarSize is one of the square variants generated for every upload
+type AvatarSize struct {
+	Name   string
+	Pixels int
+}
+
+// AvatarSizes lists the variants stored for each avatar, smallest first
+var AvatarSizes = []AvatarSize{
+	{Name: "small", Pixels: 64},
+	{Name: "medium", Pixels: 256},
+	{Name: "large", Pixels: 512},
+}
+
+// allowedAvatarTypes are matched against the sniffed content, never the client-supplied header
+var allowedAvatarTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+var (
+	ErrAvatarTooLarge        = errors.New("avatar exceeds the maximum upload size")
+	ErrAvatarUnsupportedType = errors.New("avatar must be a JPEG, PNG, GIF or WebP image")
+	ErrAvatarInvalidImage    = errors.New("avatar could not be decoded")
+)
+
+// AvatarVariant is an encoded, resized avatar ready to be stored
+type AvatarVariant struct {
+	Size AvatarSize
+	Data []byte
+}
+
+// ProcessAvatar sniffs, decodes and center-crops the upload, then renders every
+// size in AvatarSizes as JPEG. Transparent areas are flattened onto white.
+func ProcessAvatar(r io.Reader) ([]AvatarVariant, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxAvatarBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxAvatarBytes {
+		return nil, ErrAvatarTooLarge
+	}
+
+	if !allowedAvatarTypes[http.DetectContentType(data)] {
+		return nil, ErrAvatarUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrAvatarInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxAvatarPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrAvatarInvalidImage, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrAvatarInvalidImage
+	}
+
+	crop := squareCrop(src.Bounds())
+	variants := make([]AvatarVariant, 0, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		dst := image.NewRGBA(image.Rect(0, 0, size.Pixels, size.Pixels))
+		draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: avatarJPEGQuality}); err != nil {
+			return nil, err
+		}
+		variants = append(variants, AvatarVariant{Size: size, Data: buf.Bytes()})
+	}
+
+	return variants, nil
+}
+
+// AvatarKey is the storage key of one variant below the avatar's base path
+func AvatarKey(basePath string, size AvatarSize) string {
+	return fmt.Sprintf("%s/%d.jpg", basePath, size.Pixels)
+}
+
+// squareCrop returns the largest centered square inside r
+func squareCrop(r image.Rectangle) image.Rectangle {
+	w, h := r.Dx(), r.Dy()
+	if w > h {
+		offset := (w - h) / 2
+		return image.Rect(r.Min.X+offset, r.Min.Y, r.Min.X+offset+h, r.Max.Y)
+	}
+	offset := (h - w) / 2
+	return image.Rect(r.Min.X, r.Min.Y+offset, r.Max.X, r.Min.Y+offset+w)
+}
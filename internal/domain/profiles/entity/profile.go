@@ -8,6 +8,9 @@ import (
 
 const ProfileIDPrefix = "prof"
 
+// DefaultTimezone is used for profiles that have not chosen a zone
+const DefaultTimezone = "UTC"
+
 type Profile struct {
 	ID         uuid.UUID  `gorm:"type:char(36);primaryKey"`
 	NodeID     *uuid.UUID `gorm:"type:char(36)"`
@@ -16,9 +19,11 @@ type Profile struct {
 	LastName   string     `gorm:"type:varchar(100);not null"`
 	Nickname   string     `gorm:"type:varchar(50);not null"`
 	AvatarPath *string    `gorm:"type:varchar(255)"`
-	State      string     `gorm:"type:enum('active','inactive');not null"`
-	CreatedAt  time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
-	UpdatedAt  time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	// Timezone is the IANA zone task times are shown in
+	Timezone  string    `gorm:"type:varchar(64);not null;default:'UTC'"`
+	State     string    `gorm:"type:varchar(16);not null"`
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
 }
 
 func (Profile) TableName() string {
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/profiles"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/entity"
+)
+
+// AvatarURLTTL is how long the URLs returned for an avatar stay valid
+const AvatarURLTTL = time.Hour
+
+// UploadAvatar resizes the image into every avatar size, stores the variants
+// under a fresh path and removes the previous avatar.
+func (s *ProfileService) UploadAvatar(ctx context.Context, accountID string, r io.Reader) (*entity.Profile, error) {
+	prof, err := s.getExistingProfile(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := profiles.ProcessAvatar(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, profiles.ErrAvatarTooLarge):
+			return nil, apperror.NewBadRequestError(
+				fmt.Sprintf("avatar must be at most %d MB", profiles.MaxAvatarBytes>>20),
+				"AVATAR_TOO_LARGE",
+				nil,
+			)
+		case errors.Is(err, profiles.ErrAvatarUnsupportedType):
+			return nil, apperror.NewBadRequestError(err.Error(), "AVATAR_UNSUPPORTED_TYPE", nil)
+		case errors.Is(err, profiles.ErrAvatarInvalidImage):
+			return nil, apperror.NewBadRequestError(err.Error(), "AVATAR_INVALID_IMAGE", nil)
+		default:
+			return nil, apperror.NewInternalServerError("failed to process avatar", "PROCESS_AVATAR_ERROR", err)
+		}
+	}
+
+	// A new path per upload keeps cached URLs of the old avatar from showing the new image
+	basePath := fmt.Sprintf("avatars/%s/%s", prof.AccountID, uuid.NewString())
+	for _, v := range variants {
+		key := profiles.AvatarKey(basePath, v.Size)
+		if err := s.storage.Put(ctx, key, bytes.NewReader(v.Data), int64(len(v.Data)), profiles.AvatarContentType); err != nil {
+			s.deleteAvatarFiles(ctx, basePath)
+			return nil, apperror.NewInternalServerError("failed to store avatar", "STORE_AVATAR_ERROR", err)
+		}
+	}
+
+	previous := prof.AvatarPath
+	prof.AvatarPath = &basePath
+	prof.UpdatedAt = time.Now()
+	if err := s.repo.UpdateProfile(ctx, prof); err != nil {
+		s.deleteAvatarFiles(ctx, basePath)
+		return nil, apperror.NewInternalServerError("failed to update profile", "UPDATE_PROFILE_ERROR", err)
+	}
+
+	if previous != nil {
+		s.deleteAvatarFiles(ctx, *previous)
+	}
+
+	return prof, nil
+}
+
+// DeleteAvatar removes the avatar of the profile and its stored variants
+func (s *ProfileService) DeleteAvatar(ctx context.Context, accountID string) (*entity.Profile, error) {
+	prof, err := s.getExistingProfile(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if prof.AvatarPath == nil {
+		return prof, nil
+	}
+
+	previous := *prof.AvatarPath
+	prof.AvatarPath = nil
+	prof.UpdatedAt = time.Now()
+	if err := s.repo.UpdateProfile(ctx, prof); err != nil {
+		return nil, apperror.NewInternalServerError("failed to update profile", "UPDATE_PROFILE_ERROR", err)
+	}
+
+	s.deleteAvatarFiles(ctx, previous)
+
+	return prof, nil
+}
+
+// AvatarURLs returns a time-limited URL per avatar size, or nil when the profile has no avatar
+func (s *ProfileService) AvatarURLs(ctx context.Context, prof *entity.Profile) (map[string]string, error) {
+	if prof == nil || prof.AvatarPath == nil || *prof.AvatarPath == "" {
+		return nil, nil
+	}
+
+	// Paths that are not storage keys predate uploads and were set by clients directly
+	if storage.ValidateKey(*prof.AvatarPath) != nil {
+		return nil, nil
+	}
+
+	urls := make(map[string]string, len(profiles.AvatarSizes))
+	for _, size := range profiles.AvatarSizes {
+		url, err := s.storage.URL(ctx, profiles.AvatarKey(*prof.AvatarPath, size), AvatarURLTTL)
+		if err != nil {
+			return nil, apperror.NewInternalServerError("failed to create avatar url", "AVATAR_URL_ERROR", err)
+		}
+		urls[size.Name] = url
+	}
+
+	return urls, nil
+}
+
+func (s *ProfileService) getExistingProfile(ctx context.Context, accountID string) (*entity.Profile, error) {
+	prof, err := s.repo.GetProfileByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, apperror.ErrRecordNotFound) {
+		return nil, apperror.NewInternalServerError("failed to get profile by account id", "GET_PROFILE_BY_ACCOUNT_ID_ERROR", err)
+	}
+	if prof == nil {
+		return nil, apperror.NewNotFoundError("profile not found", "PROFILE_NOT_FOUND", nil)
+	}
+
+	return prof, nil
+}
+
+// deleteAvatarFiles is best effort: a leftover file must not fail the request that replaced it
+func (s *ProfileService) deleteAvatarFiles(ctx context.Context, basePath string) {
+	if storage.ValidateKey(basePath) != nil {
+		return
+	}
+	for _, size := range profiles.AvatarSizes {
+		_ = s.storage.Delete(ctx, profiles.AvatarKey(basePath, size))
+	}
+}
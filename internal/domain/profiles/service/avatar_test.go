@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/profiles"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// memoryStorage is an in-memory storage.Storage used by the avatar tests
+type memoryStorage struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{
+		objects: make(map[string][]byte),
+		types:   make(map[string]string),
+	}
+}
+
+func (m *memoryStorage) Put(_ context.Context, key string, body io.Reader, _ int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	m.types[key] = contentType
+	return nil
+}
+
+func (m *memoryStorage) Open(_ context.Context, key string) (io.ReadCloser, *storage.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, nil, storage.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), &storage.ObjectInfo{ContentType: m.types[key], Size: int64(len(data))}, nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	delete(m.types, key)
+	return nil
+}
+
+func (m *memoryStorage) URL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return "https://files.test/" + key, nil
+}
+
+func (m *memoryStorage) keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0, len(m.objects))
+	for k := range m.objects {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProfileService_UploadAvatar(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	oldPath := "avatars/" + accountID.String() + "/old"
+
+	tests := []struct {
+		name          string
+		file          []byte
+		profile       *entity.Profile
+		expectUpdate  bool
+		expectedError string
+	}{
+		{
+			name:         "success - stores every size and removes the previous avatar",
+			file:         testPNG(t, 300, 200),
+			profile:      &entity.Profile{ID: uuid.New(), AccountID: accountID, AvatarPath: &oldPath},
+			expectUpdate: true,
+		},
+		{
+			name:          "error - rejects files that are not images",
+			file:          []byte("definitely not an image, just some plain text"),
+			profile:       &entity.Profile{ID: uuid.New(), AccountID: accountID},
+			expectedError: "avatar must be a JPEG, PNG, GIF or WebP image",
+		},
+		{
+			name:          "error - rejects files over the size limit",
+			file:          append(testPNG(t, 10, 10), make([]byte, profiles.MaxAvatarBytes)...),
+			profile:       &entity.Profile{ID: uuid.New(), AccountID: accountID},
+			expectedError: "avatar must be at most",
+		},
+		{
+			name:          "error - profile not found",
+			file:          testPNG(t, 10, 10),
+			expectedError: "profile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockProfileRepository(ctrl)
+			store := newMemoryStorage()
+			svc := NewProfileService(mockRepo, store)
+
+			for _, size := range profiles.AvatarSizes {
+				require.NoError(t, store.Put(ctx, profiles.AvatarKey(oldPath, size), strings.NewReader("old"), 3, profiles.AvatarContentType))
+			}
+
+			mockRepo.EXPECT().
+				GetProfileByAccountID(ctx, accountID.String()).
+				Return(tt.profile, nil).
+				Times(1)
+			if tt.expectUpdate {
+				mockRepo.EXPECT().
+					UpdateProfile(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			}
+
+			result, err := svc.UploadAvatar(ctx, accountID.String(), bytes.NewReader(tt.file))
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+				assert.Len(t, store.keys(), len(profiles.AvatarSizes))
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, result.AvatarPath)
+			assert.NotEqual(t, oldPath, *result.AvatarPath)
+
+			keys := store.keys()
+			assert.Len(t, keys, len(profiles.AvatarSizes))
+			for _, size := range profiles.AvatarSizes {
+				key := profiles.AvatarKey(*result.AvatarPath, size)
+				assert.Contains(t, keys, key)
+
+				body, info, err := store.Open(ctx, key)
+				require.NoError(t, err)
+				img, format, err := image.Decode(body)
+				require.NoError(t, err)
+				assert.Equal(t, "jpeg", format)
+				assert.Equal(t, profiles.AvatarContentType, info.ContentType)
+				assert.Equal(t, size.Pixels, img.Bounds().Dx())
+				assert.Equal(t, size.Pixels, img.Bounds().Dy())
+			}
+		})
+	}
+}
+
+func TestProfileService_AvatarURLs(t *testing.T) {
+	ctx := context.Background()
+	svc := NewProfileService(nil, newMemoryStorage())
+
+	path := "avatars/abc/def"
+	legacy := "https://example.com/me.png"
+
+	urls, err := svc.AvatarURLs(ctx, &entity.Profile{AvatarPath: &path})
+	require.NoError(t, err)
+	assert.Len(t, urls, len(profiles.AvatarSizes))
+	for _, size := range profiles.AvatarSizes {
+		assert.Equal(t, "https://files.test/"+profiles.AvatarKey(path, size), urls[size.Name])
+	}
+
+	urls, err = svc.AvatarURLs(ctx, &entity.Profile{AvatarPath: &legacy})
+	require.NoError(t, err)
+	assert.Nil(t, urls)
+
+	urls, err = svc.AvatarURLs(ctx, &entity.Profile{})
+	require.NoError(t, err)
+	assert.Nil(t, urls)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/profile"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,11 +17,15 @@ import (
 )
 
 type ProfileService struct {
-	repo profiles.ProfileRepository
+	repo    profiles.ProfileRepository
+	storage storage.Storage
 }
 
-func NewProfileService(repo profiles.ProfileRepository) *ProfileService {
-	return &ProfileService{repo: repo}
+func NewProfileService(repo profiles.ProfileRepository, store storage.Storage) *ProfileService {
+	return &ProfileService{
+		repo:    repo,
+		storage: store,
+	}
 }
 
 func (s *ProfileService) GetProfileByAccountID(ctx context.Context, accountID string) (*entity.Profile, error) {
@@ -32,6 +37,24 @@ func (s *ProfileService) GetProfileByAccountID(ctx context.Context, accountID st
 	return prof, nil
 }
 
+// GetLocation returns the zone the account reads task times in. Accounts
+// without a profile, or with a zone the system no longer knows, get UTC.
+func (s *ProfileService) GetLocation(ctx context.Context, accountID string) (*time.Location, error) {
+	prof, err := s.repo.GetProfileByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.NewInternalServerError("failed to get profile by account id", "GET_PROFILE_BY_ACCOUNT_ID_ERROR", err)
+	}
+	if prof == nil || prof.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(prof.Timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
 func (s *ProfileService) CreateProfile(ctx context.Context, req *profile.CreateProfileRequest) (*entity.Profile, error) {
 	if req == nil {
 		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
@@ -46,18 +69,23 @@ func (s *ProfileService) CreateProfile(ctx context.Context, req *profile.CreateP
 		return nil, apperror.NewBadRequestError("profile already exists", "PROFILE_ALREADY_EXISTS", nil)
 	}
 
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = entity.DefaultTimezone
+	}
+
 	// create domain entity
 	now := time.Now()
 	prof := &entity.Profile{
-		ID:         uuid.New(),
-		AccountID:  uuid.MustParse(req.AccountID),
-		FirstName:  req.FirstName,
-		LastName:   req.LastName,
-		Nickname:   req.Nickname,
-		AvatarPath: req.AvatarPath,
-		State:      "active",
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		ID:        uuid.New(),
+		AccountID: uuid.MustParse(req.AccountID),
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Nickname:  req.Nickname,
+		Timezone:  timezone,
+		State:     "active",
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 
 	// persist account to database
@@ -90,6 +118,11 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, req *profile.UpdateP
 		return nil, apperror.NewBadRequestError("profile not found", "PROFILE_NOT_FOUND", nil)
 	}
 
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = exists.Timezone
+	}
+
 	// create domain entity
 	now := time.Now()
 	prof := &entity.Profile{
@@ -98,7 +131,8 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, req *profile.UpdateP
 		FirstName:  req.FirstName,
 		LastName:   req.LastName,
 		Nickname:   req.Nickname,
-		AvatarPath: req.AvatarPath,
+		AvatarPath: exists.AvatarPath,
+		Timezone:   timezone,
 		State:      "active",
 		CreatedAt:  exists.CreatedAt,
 		UpdatedAt:  now,
@@ -20,7 +20,7 @@ func TestProfileService_CreateProfile(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockProfileRepository(ctrl)
-	svc := NewProfileService(mockRepo)
+	svc := NewProfileService(mockRepo, nil)
 	ctx := context.Background()
 	accountID := uuid.New().String()
 
@@ -34,11 +34,10 @@ func TestProfileService_CreateProfile(t *testing.T) {
 		{
 			name: "success - creates profile with all fields",
 			request: &profile.CreateProfileRequest{
-				AccountID:  accountID,
-				FirstName:  "John",
-				LastName:   "Doe",
-				Nickname:   "johnd",
-				AvatarPath: strPtr("/avatars/john.png"),
+				AccountID: accountID,
+				FirstName: "John",
+				LastName:  "Doe",
+				Nickname:  "johnd",
 			},
 			setupMock: func() {
 				mockRepo.EXPECT().
@@ -51,8 +50,7 @@ func TestProfileService_CreateProfile(t *testing.T) {
 						assert.Equal(t, "John", prof.FirstName)
 						assert.Equal(t, "Doe", prof.LastName)
 						assert.Equal(t, "johnd", prof.Nickname)
-						assert.NotNil(t, prof.AvatarPath)
-						assert.Equal(t, "/avatars/john.png", *prof.AvatarPath)
+						assert.Nil(t, prof.AvatarPath)
 						assert.Equal(t, "active", prof.State)
 						return nil
 					}).
@@ -191,7 +189,7 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockProfileRepository(ctrl)
-	svc := NewProfileService(mockRepo)
+	svc := NewProfileService(mockRepo, nil)
 	ctx := context.Background()
 	accountID := uuid.New().String()
 	profileID := uuid.New()
@@ -206,18 +204,19 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 		{
 			name: "success - updates all fields",
 			request: &profile.UpdateProfileRequest{
-				AccountID:  accountID,
-				FirstName:  "Updated",
-				LastName:   "Name",
-				Nickname:   "updated",
-				AvatarPath: strPtr("/new/avatar.png"),
+				AccountID: accountID,
+				FirstName: "Updated",
+				LastName:  "Name",
+				Nickname:  "updated",
 			},
 			setupMock: func() {
 				existingProfile := &entity.Profile{
-					ID:        profileID,
-					AccountID: uuid.MustParse(accountID),
-					FirstName: "Old",
-					LastName:  "Name",
+					ID:         profileID,
+					AccountID:  uuid.MustParse(accountID),
+					FirstName:  "Old",
+					LastName:   "Name",
+					AvatarPath: strPtr("avatars/existing"),
+					Timezone:   "Asia/Bangkok",
 				}
 				mockRepo.EXPECT().
 					GetProfileByAccountID(ctx, accountID).
@@ -229,6 +228,9 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 						assert.Equal(t, profileID, prof.ID)
 						assert.Equal(t, "Updated", prof.FirstName)
 						assert.Equal(t, "Name", prof.LastName)
+						// the uploaded avatar and the zone are kept
+						assert.Equal(t, strPtr("avatars/existing"), prof.AvatarPath)
+						assert.Equal(t, "Asia/Bangkok", prof.Timezone)
 						return nil
 					}).
 					Times(1)
@@ -328,7 +330,7 @@ func TestProfileService_GetProfileByAccountID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockProfileRepository(ctrl)
-	svc := NewProfileService(mockRepo)
+	svc := NewProfileService(mockRepo, nil)
 	ctx := context.Background()
 	accountID := uuid.New().String()
 
@@ -409,3 +411,42 @@ func TestProfileService_GetProfileByAccountID(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestProfileService_GetLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProfileRepository(ctrl)
+	svc := NewProfileService(mockRepo, nil)
+	ctx := context.Background()
+	accountID := uuid.New().String()
+
+	tests := []struct {
+		name          string
+		profile       *entity.Profile
+		repoErr       error
+		expected      string
+		expectedError string
+	}{
+		{name: "profile zone", profile: &entity.Profile{Timezone: "Asia/Bangkok"}, expected: "Asia/Bangkok"},
+		{name: "no profile", repoErr: gorm.ErrRecordNotFound, expected: "UTC"},
+		{name: "unknown zone", profile: &entity.Profile{Timezone: "Mars/Olympus_Mons"}, expected: "UTC"},
+		{name: "repository fails", repoErr: errors.New("database error"), expectedError: "failed to get profile by account id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().GetProfileByAccountID(ctx, accountID).Return(tt.profile, tt.repoErr)
+
+			loc, err := svc.GetLocation(ctx, accountID)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, loc.String())
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package projects
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// CurrentConfigVersion is the schema version written to every stored project config.
+// Bump it together with a new entry in configMigrations.
+const CurrentConfigVersion = 1
+
+//go:embed config_schema.json
+var configSchema []byte
+
+// ProjectConfig is the typed representation of entity.Project.Config
+type ProjectConfig struct {
+	Version       int                 `json:"version"`
+	AIConfig      *AIConfig           `json:"ai_config,omitempty" validate:"omitempty"`
+	Workflow      *WorkflowConfig     `json:"workflow,omitempty" validate:"omitempty"`
+	WorkingHours  *WorkingHoursConfig `json:"working_hours,omitempty" validate:"omitempty"`
+	Notifications *NotificationConfig `json:"notifications,omitempty" validate:"omitempty"`
+	Scheduling    *SchedulingConfig   `json:"scheduling,omitempty" validate:"omitempty"`
+}
+
+// AIConfig represents AI configuration within project config
+// Used to customize the AI assistant's behavior per project
+type AIConfig struct {
+	ChatStyle       string   `json:"chat_style" validate:"omitempty,oneof=formal casual friendly"`      // "formal", "casual", "friendly"
+	DomainKnowledge []string `json:"domain_knowledge" validate:"omitempty,max=10,dive,required,max=50"` // Areas of expertise the AI should emphasize
+	Language        string   `json:"language" validate:"omitempty,oneof=th en"`                         // Preferred response language: "th", "en"
+}
+
+// WorkflowConfig controls how tasks move through the project
+type WorkflowConfig struct {
+	Statuses        []string `json:"statuses,omitempty" validate:"omitempty,max=10,unique,dive,required,max=30"`
+	DefaultPriority string   `json:"default_priority,omitempty" validate:"omitempty,max=20"`
+}
+
+// WorkingHoursConfig describes when project members are usually available
+type WorkingHoursConfig struct {
+	Timezone string   `json:"timezone" validate:"required,timezone"`
+	Days     []string `json:"days" validate:"required,min=1,max=7,unique,dive,oneof=mon tue wed thu fri sat sun"`
+	Start    string   `json:"start" validate:"required,datetime=15:04"`
+	End      string   `json:"end" validate:"required,datetime=15:04"`
+}
+
+// NotificationConfig holds the project-wide notification preferences
+type NotificationConfig struct {
+	Enabled                bool     `json:"enabled"`
+	Channels               []string `json:"channels,omitempty" validate:"omitempty,unique,dive,oneof=in_app email webhook"`
+	DefaultReminderMinutes []int    `json:"default_reminder_minutes,omitempty" validate:"omitempty,max=5,dive,min=0,max=10080"`
+}
+
+// Conflict policies of SchedulingConfig
+const (
+	ConflictPolicyWarn  = "warn"
+	ConflictPolicyBlock = "block"
+)
+
+// SchedulingConfig controls how double-booked tasks are handled
+type SchedulingConfig struct {
+	Conflicts string `json:"conflicts,omitempty" validate:"omitempty,oneof=warn block"`
+}
+
+// ConflictPolicy returns how overlapping tasks are handled, warning by default
+func (c *ProjectConfig) ConflictPolicy() string {
+	if c == nil || c.Scheduling == nil || c.Scheduling.Conflicts == "" {
+		return ConflictPolicyWarn
+	}
+	return c.Scheduling.Conflicts
+}
+
+// ConfigError is returned when a project config does not match the schema.
+// Fields maps the JSON path of each invalid field to a human-readable reason.
+type ConfigError struct {
+	Fields map[string]string
+}
+
+func (e *ConfigError) Error() string {
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+" "+e.Fields[k])
+	}
+	return "invalid project config: " + strings.Join(parts, "; ")
+}
+
+// configMigration upgrades a raw config document from version N to N+1
+type configMigration func(doc map[string]interface{}) error
+
+// configMigrations is keyed by the version a migration upgrades from
+var configMigrations = map[int]configMigration{
+	0: migrateConfigV0ToV1,
+}
+
+var configValidate = newConfigValidator()
+
+func newConfigValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
+		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// ConfigSchema returns the JSON Schema describing the current config version
+func ConfigSchema() json.RawMessage {
+	return configSchema
+}
+
+// ParseConfig migrates raw to the current version, decodes it strictly and
+// validates every field. An empty document yields a nil config.
+func ParseConfig(raw json.RawMessage) (*ProjectConfig, error) {
+	doc, err := migrateConfig(raw)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ProjectConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, decodeConfigError(err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// LoadConfig reads a stored config without rejecting unknown fields, so that
+// rows written before validation existed can still be used.
+func LoadConfig(raw json.RawMessage) (*ProjectConfig, error) {
+	doc, err := migrateConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return &ProjectConfig{Version: CurrentConfigVersion}, nil
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ProjectConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, decodeConfigError(err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the config against the schema rules
+func (c *ProjectConfig) Validate() error {
+	fields := make(map[string]string)
+
+	if err := configValidate.Struct(c); err != nil {
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			return err
+		}
+		for _, fe := range errs {
+			fields[fieldPath(fe.Namespace())] = configErrorMessage(fe)
+		}
+	}
+
+	// The times are compared parsed, as "9:00" is as valid as "09:00"
+	if wh := c.WorkingHours; wh != nil {
+		start, startErr := time.Parse("15:04", wh.Start)
+		end, endErr := time.Parse("15:04", wh.End)
+		if startErr == nil && endErr == nil && !start.Before(end) {
+			if _, exists := fields["working_hours.end"]; !exists {
+				fields["working_hours.end"] = "must be later than start"
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return &ConfigError{Fields: fields}
+	}
+
+	return nil
+}
+
+// Marshal encodes the config stamped with the current schema version
+func (c *ProjectConfig) Marshal() (json.RawMessage, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	c.Version = CurrentConfigVersion
+	return json.Marshal(c)
+}
+
+func migrateConfig(raw json.RawMessage) (map[string]interface{}, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, nil
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(trimmed, &doc); err != nil {
+		return nil, &ConfigError{Fields: map[string]string{"config": "must be a JSON object"}}
+	}
+
+	version := 0
+	if v, ok := doc["version"]; ok {
+		f, ok := v.(float64)
+		if !ok || f != float64(int(f)) || f < 0 {
+			return nil, &ConfigError{Fields: map[string]string{"version": "must be a non-negative integer"}}
+		}
+		version = int(f)
+	}
+
+	if version > CurrentConfigVersion {
+		return nil, &ConfigError{Fields: map[string]string{
+			"version": fmt.Sprintf("must be at most %d", CurrentConfigVersion),
+		}}
+	}
+
+	for ; version < CurrentConfigVersion; version++ {
+		migrate, ok := configMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no config migration from version %d", version)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, err
+		}
+	}
+	doc["version"] = CurrentConfigVersion
+
+	return doc, nil
+}
+
+// migrateConfigV0ToV1 lifts the flat, unversioned AI settings used before
+// the schema existed into the ai_config section.
+func migrateConfigV0ToV1(doc map[string]interface{}) error {
+	aiConfig, _ := doc["ai_config"].(map[string]interface{})
+	if aiConfig == nil {
+		aiConfig = make(map[string]interface{})
+	}
+
+	for _, key := range []string{"chat_style", "language", "domain_knowledge"} {
+		v, ok := doc[key]
+		if !ok {
+			continue
+		}
+		delete(doc, key)
+		if _, exists := aiConfig[key]; exists {
+			continue
+		}
+		// domain_knowledge used to be free text separated by commas
+		if s, isString := v.(string); isString && key == "domain_knowledge" {
+			items := make([]interface{}, 0)
+			for _, part := range strings.Split(s, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					items = append(items, part)
+				}
+			}
+			v = items
+		}
+		aiConfig[key] = v
+	}
+
+	if len(aiConfig) > 0 {
+		doc["ai_config"] = aiConfig
+	}
+
+	return nil
+}
+
+func decodeConfigError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := typeErr.Field
+		if field == "" {
+			field = "config"
+		}
+		return &ConfigError{Fields: map[string]string{field: "must be of type " + typeErr.Type.String()}}
+	}
+
+	const unknownPrefix = "json: unknown field "
+	if msg := err.Error(); strings.HasPrefix(msg, unknownPrefix) {
+		field := strings.Trim(strings.TrimPrefix(msg, unknownPrefix), `"`)
+		return &ConfigError{Fields: map[string]string{field: "is not allowed"}}
+	}
+
+	return &ConfigError{Fields: map[string]string{"config": err.Error()}}
+}
+
+// fieldPath turns "ProjectConfig.ai_config.chat_style" into "ai_config.chat_style"
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func configErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min", "max":
+		bound := "at least "
+		if fe.Tag() == "max" {
+			bound = "at most "
+		}
+		switch fe.Kind() {
+		case reflect.Slice:
+			return "must contain " + bound + fe.Param() + " items"
+		case reflect.String:
+			return "must be " + bound + fe.Param() + " characters"
+		default:
+			return "must be " + bound + fe.Param()
+		}
+	case "unique":
+		return "must not contain duplicates"
+	case "timezone":
+		return "must be a valid IANA time zone"
+	case "datetime":
+		return "must be in HH:MM format"
+	default:
+		return "is invalid"
+	}
+}
@@ -0,0 +1,60 @@
+package projects
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigSchema_MatchesParseConfig checks that the published schema and
+// ParseConfig accept and reject the same documents. Rules the schema cannot
+// express, such as end being later than start, are left to the service tests.
+func TestConfigSchema_MatchesParseConfig(t *testing.T) {
+	var schema openapi3.Schema
+	require.NoError(t, json.Unmarshal(ConfigSchema(), &schema))
+
+	tests := []struct {
+		name   string
+		config string
+		valid  bool
+	}{
+		{name: "empty config", config: `{}`, valid: true},
+		{name: "ai config", config: `{"version": 1, "ai_config": {"chat_style": "formal", "language": "en"}}`, valid: true},
+		{name: "unknown field", config: `{"color": "blue"}`},
+		{name: "unknown chat style", config: `{"ai_config": {"chat_style": "rude"}}`},
+		{name: "workflow", config: `{"workflow": {"statuses": ["todo", "doing", "done"], "default_priority": "medium"}}`, valid: true},
+		{name: "working hours", config: `{"working_hours": {"timezone": "Asia/Bangkok", "days": ["mon", "fri"], "start": "09:00", "end": "17:30"}}`, valid: true},
+		{name: "single digit hour", config: `{"working_hours": {"timezone": "Asia/Bangkok", "days": ["mon"], "start": "9:00", "end": "17:00"}}`, valid: true},
+		{name: "hour past 23", config: `{"working_hours": {"timezone": "Asia/Bangkok", "days": ["mon"], "start": "09:00", "end": "24:00"}}`},
+		{name: "single digit minute", config: `{"working_hours": {"timezone": "Asia/Bangkok", "days": ["mon"], "start": "9:0", "end": "17:00"}}`},
+		{name: "seconds", config: `{"working_hours": {"timezone": "Asia/Bangkok", "days": ["mon"], "start": "09:00:00", "end": "17:00"}}`},
+		{name: "working hours without days", config: `{"working_hours": {"timezone": "Asia/Bangkok", "start": "09:00", "end": "17:00"}}`},
+		{name: "unknown day", config: `{"working_hours": {"timezone": "Asia/Bangkok", "days": ["someday"], "start": "09:00", "end": "17:00"}}`},
+		{name: "notifications", config: `{"notifications": {"enabled": true, "channels": ["in_app", "email"], "default_reminder_minutes": [0, 15, 1440]}}`, valid: true},
+		{name: "unknown channel", config: `{"notifications": {"enabled": true, "channels": ["sms"]}}`},
+		{name: "reminder over a week", config: `{"notifications": {"enabled": true, "default_reminder_minutes": [10081]}}`},
+		{name: "scheduling", config: `{"scheduling": {"conflicts": "block"}}`, valid: true},
+		{name: "unknown conflict policy", config: `{"scheduling": {"conflicts": "ignore"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc interface{}
+			require.NoError(t, json.Unmarshal([]byte(tt.config), &doc))
+
+			schemaErr := schema.VisitJSON(doc, openapi3.MultiErrors())
+			_, parseErr := ParseConfig(json.RawMessage(tt.config))
+
+			if tt.valid {
+				assert.NoError(t, schemaErr, "schema")
+				assert.NoError(t, parseErr, "ParseConfig")
+			} else {
+				assert.Error(t, schemaErr, "schema")
+				assert.Error(t, parseErr, "ParseConfig")
+			}
+		})
+	}
+}
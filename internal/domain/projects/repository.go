@@ -12,6 +12,11 @@ type ProjectRepository interface {
 	CreateProject(ctx context.Context, proj *entity.Project) error
 	GetProjectByID(ctx context.Context, projectID uuid.UUID) (*entity.Project, error)
 	ListProjectByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int) ([]*entity.Project, int, error)
+	// ListAllProjectsByAccountID returns every project the account owns, oldest first
+	ListAllProjectsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*entity.Project, error)
 	UpdateProject(ctx context.Context, proj *entity.Project) error
 	DeleteProject(ctx context.Context, projectID uuid.UUID) error
+	// LockProject locks the project until the transaction carried by ctx ends,
+	// so that writes checking the state of the project first run one at a time
+	LockProject(ctx context.Context, projectID uuid.UUID) error
 }
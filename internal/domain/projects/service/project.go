@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/project"
@@ -10,20 +12,34 @@ import (
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 	"github.com/google/uuid"
 
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
 )
 
+// MaxStatsRangeDays limits how many daily points a burndown series may contain
+const MaxStatsRangeDays = 366
+
+// ProjectStats combines the aggregate task counts of a project with its daily burndown series
+type ProjectStats struct {
+	Tasks    *tasks.TaskStats
+	Burndown []tasks.BurndownPoint
+}
+
 type ProjectService struct {
 	repo     projects.ProjectRepository
 	taskRepo tasks.TaskRepository
+	outbox   events.Outbox
 }
 
-func NewProjectService(repo projects.ProjectRepository, taskRepo tasks.TaskRepository) *ProjectService {
+// NewProjectService creates the service; every project updated or deleted
+// through it is recorded in outbox along with the change
+func NewProjectService(repo projects.ProjectRepository, taskRepo tasks.TaskRepository, outbox events.Outbox) *ProjectService {
 	return &ProjectService{
 		repo:     repo,
 		taskRepo: taskRepo,
+		outbox:   outbox,
 	}
 }
 
@@ -38,13 +54,18 @@ func (s *ProjectService) CreateProject(ctx context.Context, req *project.CreateP
 		return nil, apperror.NewBadRequestError("invalid account ID format", "INVALID_ACCOUNT_ID", err)
 	}
 
+	config, err := s.normalizeConfig(req.Config)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	proj := &entity.Project{
 		ID:        uuid.New(),
 		AccountID: accountID,
 		Role:      "owner",
 		Name:      req.Name,
-		Config:    req.Config,
+		Config:    config,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -100,12 +121,21 @@ func (s *ProjectService) UpdateProject(ctx context.Context, req *project.UpdateP
 
 	proj.Name = req.Name
 	if req.Config != nil {
-		proj.Config = req.Config
+		config, err := s.normalizeConfig(req.Config)
+		if err != nil {
+			return nil, err
+		}
+		proj.Config = config
 	}
 
 	proj.UpdatedAt = time.Now()
 
-	err = s.repo.UpdateProject(ctx, proj)
+	err = s.outbox.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateProject(ctx, proj); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, events.ProjectUpdated, proj)
+	})
 	if err != nil {
 		return nil, apperror.NewInternalServerError("failed to update project", "UPDATE_PROJECT_ERROR", err)
 	}
@@ -115,7 +145,7 @@ func (s *ProjectService) UpdateProject(ctx context.Context, req *project.UpdateP
 
 func (s *ProjectService) DeleteProject(ctx context.Context, projectID uuid.UUID) error {
 	// Get existing project
-	_, err := s.repo.GetProjectByID(ctx, projectID)
+	proj, err := s.repo.GetProjectByID(ctx, projectID)
 	if err != nil {
 		if errors.Is(err, apperror.ErrRecordNotFound) {
 			return apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", err)
@@ -127,7 +157,13 @@ func (s *ProjectService) DeleteProject(ctx context.Context, projectID uuid.UUID)
 		return err
 	}
 
-	err = s.repo.DeleteProject(ctx, projectID)
+	// The webhooks of the project outlive it for this last event
+	err = s.outbox.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteProject(ctx, projectID); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, events.ProjectDeleted, proj)
+	})
 	if err != nil {
 		return apperror.NewInternalServerError("failed to delete project", "DELETE_PROJECT_ERROR", err)
 	}
@@ -135,6 +171,76 @@ func (s *ProjectService) DeleteProject(ctx context.Context, projectID uuid.UUID)
 	return nil
 }
 
+// GetProjectStats aggregates task counts and the daily burndown between from and to (inclusive dates)
+func (s *ProjectService) GetProjectStats(ctx context.Context, projectID uuid.UUID, from, to time.Time, dueSoon time.Duration) (*ProjectStats, error) {
+	if to.Before(from) {
+		return nil, apperror.NewBadRequestError("to must not be before from", "INVALID_DATE_RANGE", nil)
+	}
+	if to.Sub(from) > (MaxStatsRangeDays-1)*24*time.Hour {
+		return nil, apperror.NewBadRequestError(fmt.Sprintf("date range must not exceed %d days", MaxStatsRangeDays), "INVALID_DATE_RANGE", nil)
+	}
+
+	if _, err := s.GetProjectByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	taskStats, err := s.taskRepo.GetTaskStats(ctx, projectID, now, now.Add(dueSoon))
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to get task stats", "GET_TASK_STATS_ERROR", err)
+	}
+
+	burndown, err := s.taskRepo.GetTaskBurndown(ctx, projectID, from, to)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to get task burndown", "GET_TASK_BURNDOWN_ERROR", err)
+	}
+
+	return &ProjectStats{
+		Tasks:    taskStats,
+		Burndown: burndown,
+	}, nil
+}
+
+// GetProjectConfig returns the typed config of a project, migrated to the current schema version
+func (s *ProjectService) GetProjectConfig(proj *entity.Project) (*projects.ProjectConfig, error) {
+	cfg, err := projects.LoadConfig(proj.Config)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to load project config", "LOAD_PROJECT_CONFIG_ERROR", err)
+	}
+
+	return cfg, nil
+}
+
+// normalizeConfig validates a client-supplied config and re-encodes it with the current schema version
+func (s *ProjectService) normalizeConfig(raw json.RawMessage) (json.RawMessage, error) {
+	cfg, err := projects.ParseConfig(raw)
+	if err != nil {
+		var cfgErr *projects.ConfigError
+		if errors.As(err, &cfgErr) {
+			return nil, apperror.NewValidationError("invalid project config", "INVALID_PROJECT_CONFIG", cfgErr.Fields)
+		}
+		return nil, apperror.NewInternalServerError("failed to parse project config", "PARSE_PROJECT_CONFIG_ERROR", err)
+	}
+
+	config, err := cfg.Marshal()
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to encode project config", "ENCODE_PROJECT_CONFIG_ERROR", err)
+	}
+
+	return config, nil
+}
+
+// recordEvent adds an event of a change to proj to the outbox
+func (s *ProjectService) recordEvent(ctx context.Context, eventType string, proj *entity.Project) error {
+	event, err := events.NewEvent(eventType, proj.ID, events.ProjectEventData{
+		Project: events.NewProjectData(proj),
+	})
+	if err != nil {
+		return err
+	}
+	return s.outbox.Record(ctx, event)
+}
+
 func (s *ProjectService) deleteProjectCheck(ctx context.Context, projectID uuid.UUID) error {
 	count, err := s.taskRepo.CountTasksByProject(ctx, projectID)
 	if err != nil {
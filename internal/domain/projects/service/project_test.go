@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/project"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
 	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -19,7 +25,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 
 	mockRepo := mocks.NewMockProjectRepository(ctrl)
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
-	svc := NewProjectService(mockRepo, mockTaskRepo)
+	svc := NewProjectService(mockRepo, mockTaskRepo, anyEvents(ctrl))
 	ctx := context.Background()
 
 	validAccountID := "550e8400-e29b-41d4-a716-446655440000"
@@ -37,7 +43,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 			request: &project.CreateProjectRequest{
 				AccountID: validAccountID,
 				Name:      "Test Project",
-				Config:    []byte(`{"color": "blue", "theme": "dark"}`),
+				Config:    []byte(`{"ai_config": {"chat_style": "formal", "language": "en"}}`),
 			},
 			setupMock: func() {
 				mockRepo.EXPECT().
@@ -56,7 +62,7 @@ func TestProjectService_CreateProject(t *testing.T) {
 			validate: func(t *testing.T, res *entity.Project) {
 				assert.Equal(t, "Test Project", res.Name)
 				assert.Equal(t, "owner", res.Role)
-				assert.JSONEq(t, `{"color": "blue", "theme": "dark"}`, string(res.Config))
+				assert.JSONEq(t, `{"version": 1, "ai_config": {"chat_style": "formal", "domain_knowledge": null, "language": "en"}}`, string(res.Config))
 			},
 		},
 		{
@@ -152,6 +158,49 @@ func TestProjectService_CreateProject(t *testing.T) {
 				assert.Equal(t, "", res.Name)
 			},
 		},
+		{
+			name: "success - migrates legacy unversioned config",
+			request: &project.CreateProjectRequest{
+				AccountID: validAccountID,
+				Name:      "Legacy Project",
+				Config:    []byte(`{"chat_style": "friendly", "domain_knowledge": "Golang, PostgreSQL"}`),
+			},
+			setupMock: func() {
+				mockRepo.EXPECT().
+					CreateProject(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			expectedError: "",
+			expectNil:     false,
+			validate: func(t *testing.T, res *entity.Project) {
+				assert.JSONEq(t, `{"version": 1, "ai_config": {"chat_style": "friendly", "domain_knowledge": ["Golang", "PostgreSQL"], "language": ""}}`, string(res.Config))
+			},
+		},
+		{
+			name: "error - config with unknown field",
+			request: &project.CreateProjectRequest{
+				AccountID: validAccountID,
+				Name:      "Test Project",
+				Config:    []byte(`{"color": "blue"}`),
+			},
+			setupMock:     func() {},
+			expectedError: "invalid project config",
+			expectNil:     true,
+			validate:      nil,
+		},
+		{
+			name: "error - config from a newer schema version",
+			request: &project.CreateProjectRequest{
+				AccountID: validAccountID,
+				Name:      "Test Project",
+				Config:    []byte(`{"version": 99}`),
+			},
+			setupMock:     func() {},
+			expectedError: "invalid project config",
+			expectNil:     true,
+			validate:      nil,
+		},
 		{
 			name: "success - creates project with nil config",
 			request: &project.CreateProjectRequest{
@@ -197,3 +246,318 @@ func TestProjectService_CreateProject(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectService_CreateProject_ConfigFieldErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProjectRepository(ctrl)
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	svc := NewProjectService(mockRepo, mockTaskRepo, anyEvents(ctrl))
+
+	req := &project.CreateProjectRequest{
+		AccountID: "550e8400-e29b-41d4-a716-446655440000",
+		Name:      "Test Project",
+		Config: []byte(`{
+			"version": 1,
+			"ai_config": {"chat_style": "rude", "language": "fr"},
+			"working_hours": {"timezone": "Mars/Olympus", "days": ["mon"], "start": "18:00", "end": "09:00"},
+			"notifications": {"enabled": true, "channels": ["sms"]}
+		}`),
+	}
+
+	res, err := svc.CreateProject(context.Background(), req)
+	require.Error(t, err)
+	assert.Nil(t, res)
+
+	appErr, ok := apperror.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, "INVALID_PROJECT_CONFIG", appErr.Code)
+	assert.Equal(t, map[string]string{
+		"ai_config.chat_style":      "must be one of: formal, casual, friendly",
+		"ai_config.language":        "must be one of: th, en",
+		"working_hours.timezone":    "must be a valid IANA time zone",
+		"working_hours.end":         "must be later than start",
+		"notifications.channels[0]": "must be one of: in_app, email, webhook",
+	}, appErr.Fields)
+}
+
+func TestProjectService_CreateProject_WorkingHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		wantError  bool
+	}{
+		{name: "single digit hour before two digit hour", start: "9:00", end: "17:00"},
+		{name: "zero padded hours", start: "09:00", end: "17:30"},
+		{name: "end before start", start: "17:00", end: "9:00", wantError: true},
+		{name: "end equal to start", start: "9:00", end: "09:00", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mocks.NewMockProjectRepository(ctrl)
+			svc := NewProjectService(mockRepo, mocks.NewMockTaskRepository(ctrl), anyEvents(ctrl))
+			if !tt.wantError {
+				mockRepo.EXPECT().CreateProject(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			_, err := svc.CreateProject(context.Background(), &project.CreateProjectRequest{
+				AccountID: "550e8400-e29b-41d4-a716-446655440000",
+				Name:      "Test Project",
+				Config:    []byte(`{"working_hours": {"timezone": "Asia/Bangkok", "days": ["mon"], "start": "` + tt.start + `", "end": "` + tt.end + `"}}`),
+			})
+
+			if !tt.wantError {
+				require.NoError(t, err)
+				return
+			}
+			appErr, ok := apperror.IsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, "must be later than start", appErr.Fields["working_hours.end"])
+		})
+	}
+}
+
+func TestProjectService_UpdateProject_Config(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProjectRepository(ctrl)
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	svc := NewProjectService(mockRepo, mockTaskRepo, anyEvents(ctrl))
+	ctx := context.Background()
+
+	projectID := uuid.New()
+	existingConfig := []byte(`{"version": 1, "ai_config": {"language": "th"}}`)
+
+	tests := []struct {
+		name          string
+		config        []byte
+		setupMock     func()
+		expectedError string
+		expectConfig  string
+	}{
+		{
+			name:   "success - replaces config with a valid one",
+			config: []byte(`{"workflow": {"statuses": ["todo", "doing", "done"], "default_priority": "1"}}`),
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetProjectByID(ctx, projectID).
+					Return(&entity.Project{ID: projectID, Config: existingConfig}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					UpdateProject(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			expectConfig: `{"version": 1, "workflow": {"statuses": ["todo", "doing", "done"], "default_priority": "1"}}`,
+		},
+		{
+			name:   "success - keeps config when none is given",
+			config: nil,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetProjectByID(ctx, projectID).
+					Return(&entity.Project{ID: projectID, Config: existingConfig}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					UpdateProject(ctx, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			expectConfig: string(existingConfig),
+		},
+		{
+			name:   "error - rejects invalid config without saving",
+			config: []byte(`{"workflow": {"statuses": ["todo", "todo"]}}`),
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetProjectByID(ctx, projectID).
+					Return(&entity.Project{ID: projectID, Config: existingConfig}, nil).
+					Times(1)
+			},
+			expectedError: "invalid project config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			res, err := svc.UpdateProject(ctx, &project.UpdateProjectRequest{
+				ProjectID: projectID.String(),
+				Name:      "Renamed",
+				Config:    tt.config,
+			})
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectConfig, string(res.Config))
+		})
+	}
+}
+
+func TestProjectService_GetProjectStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProjectRepository(ctrl)
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	svc := NewProjectService(mockRepo, mockTaskRepo, anyEvents(ctrl))
+	ctx := context.Background()
+
+	projectID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		from          time.Time
+		to            time.Time
+		setupMock     func()
+		expectedError string
+		validate      func(t *testing.T, res *ProjectStats)
+	}{
+		{
+			name: "success - returns stats and burndown",
+			from: from,
+			to:   to,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetProjectByID(ctx, projectID).
+					Return(&entity.Project{ID: projectID}, nil).
+					Times(1)
+				mockTaskRepo.EXPECT().
+					GetTaskStats(ctx, projectID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, now, dueSoonUntil time.Time) (*tasks.TaskStats, error) {
+						assert.Equal(t, 48*time.Hour, dueSoonUntil.Sub(now))
+						return &tasks.TaskStats{Total: 4, Completed: 1}, nil
+					}).
+					Times(1)
+				mockTaskRepo.EXPECT().
+					GetTaskBurndown(ctx, projectID, from, to).
+					Return([]tasks.BurndownPoint{
+						{Date: from, Scope: 2, Completed: 0},
+						{Date: from.AddDate(0, 0, 1), Scope: 4, Completed: 1},
+						{Date: to, Scope: 4, Completed: 1},
+					}, nil).
+					Times(1)
+			},
+			validate: func(t *testing.T, res *ProjectStats) {
+				assert.Equal(t, int64(4), res.Tasks.Total)
+				require.Len(t, res.Burndown, 3)
+				assert.Equal(t, int64(3), res.Burndown[2].Remaining())
+			},
+		},
+		{
+			name:          "error - to before from",
+			from:          to,
+			to:            from,
+			setupMock:     func() {},
+			expectedError: "to must not be before from",
+		},
+		{
+			name:          "error - range too long",
+			from:          from,
+			to:            from.AddDate(0, 0, MaxStatsRangeDays),
+			setupMock:     func() {},
+			expectedError: "date range must not exceed",
+		},
+		{
+			name: "error - project not found",
+			from: from,
+			to:   to,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetProjectByID(ctx, projectID).
+					Return(nil, apperror.ErrRecordNotFound).
+					Times(1)
+			},
+			expectedError: "project not found",
+		},
+		{
+			name: "error - stats query fails",
+			from: from,
+			to:   to,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetProjectByID(ctx, projectID).
+					Return(&entity.Project{ID: projectID}, nil).
+					Times(1)
+				mockTaskRepo.EXPECT().
+					GetTaskStats(ctx, projectID, gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			expectedError: "failed to get task stats",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			res, err := svc.GetProjectStats(ctx, projectID, tt.from, tt.to, 48*time.Hour)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.validate(t, res)
+		})
+	}
+}
+
+func TestProjectService_DeleteProject_Event(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProjectRepository(ctrl)
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+	svc := NewProjectService(mockRepo, mockTaskRepo, mockOutbox)
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	mockRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&entity.Project{ID: projectID, Name: "Launch"}, nil)
+	mockTaskRepo.EXPECT().CountTasksByProject(ctx, projectID).Return(int64(0), nil)
+	mockOutbox.EXPECT().
+		WithinTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	mockRepo.EXPECT().DeleteProject(ctx, projectID).Return(nil)
+	mockOutbox.EXPECT().
+		Record(ctx, gomock.Any()).
+		Do(func(_ context.Context, list ...events.Event) {
+			require.Len(t, list, 1)
+			assert.Equal(t, events.ProjectDeleted, list[0].Type)
+			assert.Equal(t, projectID, list[0].ProjectID)
+			var data events.ProjectEventData
+			require.NoError(t, json.Unmarshal(list[0].Data, &data))
+			assert.Equal(t, "Launch", data.Project.Name)
+		})
+
+	require.NoError(t, svc.DeleteProject(ctx, projectID))
+}
+
+// anyEvents runs the transactions of the service and accepts whatever it records
+func anyEvents(ctrl *gomock.Controller) *mocks.MockOutbox {
+	outbox := mocks.NewMockOutbox(ctrl)
+	outbox.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	outbox.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+	return outbox
+}
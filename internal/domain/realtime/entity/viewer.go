@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Viewer is one open event stream of a project. SeenAt is touched by the
+// instance holding the connection while it is open.
+type Viewer struct {
+	ConnectionID uuid.UUID `gorm:"column:connection_id;type:char(36);primaryKey"`
+	ProjectID    uuid.UUID `gorm:"column:project_id;type:char(36);index;not null"`
+	AccountID    uuid.UUID `gorm:"column:account_id;type:char(36);not null"`
+	Username     string    `gorm:"column:username;type:varchar(100);not null"`
+	ConnectedAt  time.Time `gorm:"column:connected_at;not null"`
+	SeenAt       time.Time `gorm:"column:seen_at;not null"`
+}
+
+func (Viewer) TableName() string {
+	return "project_viewers"
+}
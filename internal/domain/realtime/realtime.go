@@ -0,0 +1,130 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/google/uuid"
+)
+
+// Postgres channels the instances fan out over
+const (
+	// ChannelEvents carries "<project id>:<seq>" of every committed outbox event
+	ChannelEvents = "outbox_events"
+	// ChannelPresence carries the ID of a project whose viewers changed
+	ChannelPresence = "project_viewers"
+)
+
+// Names of the messages of a stream besides the event types
+const (
+	// MessageReady starts every stream; its ID is the cursor to resume from
+	MessageReady = "ready"
+	// MessagePresence lists who is viewing the project
+	MessagePresence = "presence"
+	// MessageReset tells the client its cursor is too old to resume from. The
+	// client reloads the board and continues from the ID of the message.
+	MessageReset = "reset"
+	// MessageClosed is the last message of a stream the server ends because
+	// the client must authenticate again
+	MessageClosed = "closed"
+)
+
+// Reasons of closed messages
+const (
+	// ClosedTokenExpired is sent when the access token the stream was opened
+	// with expires. The client refreshes it and reconnects from its cursor.
+	ClosedTokenExpired = "token_expired"
+	// ClosedSessionRevoked is sent when the session was revoked, or the account
+	// deactivated, suspended or given another role. The client refreshes its
+	// token, or signs in again, before reconnecting.
+	ClosedSessionRevoked = "session_revoked"
+)
+
+const (
+	// HeartbeatInterval is how often streams are kept alive and their viewers
+	// touched
+	HeartbeatInterval = 15 * time.Second
+	// ViewerTTL is how long a viewer that is no longer touched stays listed
+	ViewerTTL = 45 * time.Second
+	// MaxReplay is how many missed events a resuming stream is sent; beyond
+	// that it is reset
+	MaxReplay = 500
+)
+
+// Types lists the event types streamed to the viewers of a project
+var Types = []string{events.TaskCreated, events.TaskUpdated, events.TaskDeleted}
+
+// Message is one server-sent event. ID is set on event, ready and reset
+// messages and is the cursor of the stream.
+type Message struct {
+	ID    string
+	Event string
+	Data  json.RawMessage
+}
+
+// EventData is the data of event messages
+type EventData struct {
+	ID         string          `json:"id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Viewer is an account viewing a project, over one or more connections
+type Viewer struct {
+	AccountID   string    `json:"account_id"`
+	Username    string    `json:"username"`
+	Connections int       `json:"connections"`
+	Since       time.Time `json:"since"`
+}
+
+// PresenceData is the data of presence messages
+type PresenceData struct {
+	Viewers []Viewer `json:"viewers"`
+}
+
+// ClosedData is the data of closed messages
+type ClosedData struct {
+	Reason string `json:"reason"`
+}
+
+// ClosedMessage returns the closed message for reason
+func ClosedMessage(reason string) Message {
+	data, _ := json.Marshal(ClosedData{Reason: reason})
+	return Message{Event: MessageClosed, Data: data}
+}
+
+// FormatCursor and ParseCursor convert the outbox seq of an event to and from
+// the ID of its message
+func FormatCursor(seq int64) string {
+	return strconv.FormatInt(seq, 10)
+}
+
+func ParseCursor(s string) (int64, error) {
+	seq, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", s)
+	}
+	return seq, nil
+}
+
+// ParseEventNotification parses a notification of ChannelEvents
+func ParseEventNotification(payload string) (uuid.UUID, int64, error) {
+	project, seq, ok := strings.Cut(payload, ":")
+	if !ok {
+		return uuid.Nil, 0, fmt.Errorf("invalid event notification %q", payload)
+	}
+	projectID, err := uuid.Parse(project)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("invalid event notification %q: %w", payload, err)
+	}
+	parsedSeq, err := ParseCursor(seq)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("invalid event notification %q: %w", payload, err)
+	}
+	return projectID, parsedSeq, nil
+}
@@ -0,0 +1,34 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursor(t *testing.T) {
+	seq, err := ParseCursor(FormatCursor(42))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), seq)
+
+	for _, s := range []string{"", "-1", "abc", "1.5"} {
+		_, err := ParseCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseEventNotification(t *testing.T) {
+	projectID := uuid.New()
+
+	parsedID, seq, err := ParseEventNotification(projectID.String() + ":17")
+	require.NoError(t, err)
+	assert.Equal(t, projectID, parsedID)
+	assert.Equal(t, int64(17), seq)
+
+	for _, payload := range []string{"", projectID.String(), "nope:17", projectID.String() + ":x"} {
+		_, _, err := ParseEventNotification(payload)
+		assert.Error(t, err, payload)
+	}
+}
@@ -0,0 +1,24 @@
+//go:generate go run go.uber.org/mock/mockgen -source=$GOFILE -destination=../../mocks/viewer_repository.go -package=mocks
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/entity"
+	"github.com/google/uuid"
+)
+
+type ViewerRepository interface {
+	// TouchViewers inserts the viewers, or sets SeenAt of the ones that exist
+	TouchViewers(ctx context.Context, viewers []*entity.Viewer) error
+	DeleteViewer(ctx context.Context, connectionID uuid.UUID) error
+	// ListViewersByProject returns the viewers of the project seen after
+	// seenAfter, oldest connection first
+	ListViewersByProject(ctx context.Context, projectID uuid.UUID, seenAfter time.Time) ([]*entity.Viewer, error)
+	// DeleteStaleViewers deletes the viewers not seen since before and returns
+	// the IDs of their projects
+	DeleteStaleViewers(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	// NotifyPresence tells every instance that the viewers of projectID changed
+	NotifyPresence(ctx context.Context, projectID uuid.UUID) error
+}
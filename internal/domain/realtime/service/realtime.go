@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	accountEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	eventEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/events/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Start is how a stream begins: the messages to send first, and the cursors of
+// the events among them so the same events arriving live can be skipped
+type Start struct {
+	Messages []realtime.Message
+	Replayed []string
+}
+
+type RealtimeService struct {
+	projectRepo projects.ProjectRepository
+	eventLog    events.EventLog
+	viewerRepo  realtime.ViewerRepository
+}
+
+func NewRealtimeService(projectRepo projects.ProjectRepository, eventLog events.EventLog, viewerRepo realtime.ViewerRepository) *RealtimeService {
+	return &RealtimeService{
+		projectRepo: projectRepo,
+		eventLog:    eventLog,
+		viewerRepo:  viewerRepo,
+	}
+}
+
+// Authorize checks that the account may follow the project
+func (s *RealtimeService) Authorize(ctx context.Context, accountID, projectID uuid.UUID) error {
+	proj, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", err)
+		}
+		return apperror.NewInternalServerError("failed to get project", "GET_PROJECT_ERROR", err)
+	}
+	if proj.AccountID != accountID {
+		return apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", nil)
+	}
+	return nil
+}
+
+// Start returns the messages a stream of the project begins with. Without a
+// cursor that is a ready message at the head of the outbox. With one, it is
+// a ready message at the cursor followed by the events missed since, or a
+// reset when they are no longer all kept or there are more than MaxReplay.
+// Seqs commit in order, see events.Outbox, so no event below the cursor can
+// commit after the client saw it.
+func (s *RealtimeService) Start(ctx context.Context, projectID uuid.UUID, cursor *int64) (*Start, error) {
+	lowest, head, err := s.eventLog.GetSeqRange(ctx)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to read event log", "GET_EVENT_LOG_ERROR", err)
+	}
+
+	if cursor == nil {
+		return &Start{Messages: []realtime.Message{{ID: realtime.FormatCursor(head), Event: realtime.MessageReady, Data: json.RawMessage("{}")}}}, nil
+	}
+
+	// A cursor past the head comes from another database; one below the lowest
+	// kept seq may have missed purged events
+	if *cursor > head || (lowest > 0 && *cursor < lowest-1) {
+		return resetAt(head), nil
+	}
+
+	missed, err := s.eventLog.ListProjectEvents(ctx, projectID, *cursor, realtime.Types, realtime.MaxReplay+1)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to read event log", "GET_EVENT_LOG_ERROR", err)
+	}
+	if len(missed) > realtime.MaxReplay {
+		return resetAt(head), nil
+	}
+
+	start := &Start{
+		Messages: []realtime.Message{{ID: realtime.FormatCursor(*cursor), Event: realtime.MessageReady, Data: json.RawMessage("{}")}},
+		Replayed: make([]string, 0, len(missed)),
+	}
+	for _, row := range missed {
+		msg, err := eventMessage(row)
+		if err != nil {
+			return nil, apperror.NewInternalServerError("failed to encode event", "ENCODE_EVENT_ERROR", err)
+		}
+		start.Messages = append(start.Messages, msg)
+		start.Replayed = append(start.Replayed, msg.ID)
+	}
+	return start, nil
+}
+
+// EventMessage returns the message of the event at seq, or nil when it is not
+// one streamed to viewers
+func (s *RealtimeService) EventMessage(ctx context.Context, seq int64) (*realtime.Message, error) {
+	rows, err := s.eventLog.GetEventsBySeq(ctx, []int64{seq})
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to read event log", "GET_EVENT_LOG_ERROR", err)
+	}
+	if len(rows) == 0 || !lo.Contains(realtime.Types, rows[0].Type) {
+		return nil, nil
+	}
+
+	msg, err := eventMessage(rows[0])
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to encode event", "ENCODE_EVENT_ERROR", err)
+	}
+	return &msg, nil
+}
+
+// Join lists the viewer of a new stream and tells every instance
+func (s *RealtimeService) Join(ctx context.Context, viewer *entity.Viewer) error {
+	if err := s.viewerRepo.TouchViewers(ctx, []*entity.Viewer{viewer}); err != nil {
+		return apperror.NewInternalServerError("failed to save viewer", "SAVE_VIEWER_ERROR", err)
+	}
+	if err := s.viewerRepo.NotifyPresence(ctx, viewer.ProjectID); err != nil {
+		return apperror.NewInternalServerError("failed to notify viewers", "NOTIFY_VIEWERS_ERROR", err)
+	}
+	return nil
+}
+
+// Leave unlists the viewer of a closed stream and tells every instance
+func (s *RealtimeService) Leave(ctx context.Context, viewer *entity.Viewer) error {
+	if err := s.viewerRepo.DeleteViewer(ctx, viewer.ConnectionID); err != nil {
+		return apperror.NewInternalServerError("failed to delete viewer", "DELETE_VIEWER_ERROR", err)
+	}
+	if err := s.viewerRepo.NotifyPresence(ctx, viewer.ProjectID); err != nil {
+		return apperror.NewInternalServerError("failed to notify viewers", "NOTIFY_VIEWERS_ERROR", err)
+	}
+	return nil
+}
+
+// Touch keeps the viewers of the open streams of an instance listed
+func (s *RealtimeService) Touch(ctx context.Context, viewers []*entity.Viewer, now time.Time) error {
+	for _, v := range viewers {
+		v.SeenAt = now
+	}
+	if err := s.viewerRepo.TouchViewers(ctx, viewers); err != nil {
+		return apperror.NewInternalServerError("failed to save viewers", "SAVE_VIEWER_ERROR", err)
+	}
+	return nil
+}
+
+// SweepStale unlists the viewers no instance touched within ViewerTTL, left
+// behind by instances that stopped without closing their streams
+func (s *RealtimeService) SweepStale(ctx context.Context, now time.Time) error {
+	projectIDs, err := s.viewerRepo.DeleteStaleViewers(ctx, now.Add(-realtime.ViewerTTL))
+	if err != nil {
+		return apperror.NewInternalServerError("failed to delete stale viewers", "DELETE_VIEWER_ERROR", err)
+	}
+	for _, projectID := range projectIDs {
+		if err := s.viewerRepo.NotifyPresence(ctx, projectID); err != nil {
+			return apperror.NewInternalServerError("failed to notify viewers", "NOTIFY_VIEWERS_ERROR", err)
+		}
+	}
+	return nil
+}
+
+// Presence returns the presence message of the project, with one viewer per
+// account however many streams it has open
+func (s *RealtimeService) Presence(ctx context.Context, projectID uuid.UUID, now time.Time) (*realtime.Message, error) {
+	list, err := s.viewerRepo.ListViewersByProject(ctx, projectID, now.Add(-realtime.ViewerTTL))
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list viewers", "LIST_VIEWERS_ERROR", err)
+	}
+
+	viewers := []realtime.Viewer{}
+	index := map[uuid.UUID]int{}
+	for _, v := range list {
+		if i, ok := index[v.AccountID]; ok {
+			viewers[i].Connections++
+			if v.ConnectedAt.Before(viewers[i].Since) {
+				viewers[i].Since = v.ConnectedAt.UTC()
+			}
+			continue
+		}
+		index[v.AccountID] = len(viewers)
+		viewers = append(viewers, realtime.Viewer{
+			AccountID:   utils.ShortUUIDWithPrefix(v.AccountID, accountEntity.AccountIDPrefix),
+			Username:    v.Username,
+			Connections: 1,
+			Since:       v.ConnectedAt.UTC(),
+		})
+	}
+
+	data, err := json.Marshal(realtime.PresenceData{Viewers: viewers})
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to encode viewers", "ENCODE_VIEWERS_ERROR", err)
+	}
+	return &realtime.Message{Event: realtime.MessagePresence, Data: data}, nil
+}
+
+func resetAt(head int64) *Start {
+	return &Start{Messages: []realtime.Message{{ID: realtime.FormatCursor(head), Event: realtime.MessageReset, Data: json.RawMessage("{}")}}}
+}
+
+func eventMessage(row *eventEntity.OutboxEvent) (realtime.Message, error) {
+	data, err := json.Marshal(realtime.EventData{
+		ID:         utils.ShortUUIDWithPrefix(row.ID, events.EventIDPrefix),
+		Event:      row.Type,
+		OccurredAt: row.OccurredAt.UTC(),
+		Data:       json.RawMessage(row.Data),
+	})
+	if err != nil {
+		return realtime.Message{}, fmt.Errorf("encode event %s: %w", row.ID, err)
+	}
+	return realtime.Message{ID: realtime.FormatCursor(row.Seq), Event: row.Type, Data: data}, nil
+}
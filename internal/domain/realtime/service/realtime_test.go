@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	eventEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/events/entity"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testRealtimeService struct {
+	*RealtimeService
+	projectRepo *mocks.MockProjectRepository
+	eventLog    *mocks.MockEventLog
+	viewerRepo  *mocks.MockViewerRepository
+}
+
+func newTestRealtimeService(t *testing.T) *testRealtimeService {
+	ctrl := gomock.NewController(t)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	eventLog := mocks.NewMockEventLog(ctrl)
+	viewerRepo := mocks.NewMockViewerRepository(ctrl)
+
+	svc := NewRealtimeService(projectRepo, eventLog, viewerRepo)
+	return &testRealtimeService{RealtimeService: svc, projectRepo: projectRepo, eventLog: eventLog, viewerRepo: viewerRepo}
+}
+
+func outboxEvent(seq int64, eventType string) *eventEntity.OutboxEvent {
+	return &eventEntity.OutboxEvent{
+		Seq:        seq,
+		ID:         uuid.New(),
+		Type:       eventType,
+		Data:       json.RawMessage(`{"task":{"id":"tsk_1"}}`),
+		OccurredAt: time.Now(),
+	}
+}
+
+func TestRealtimeService_Authorize(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+
+	t.Run("owner is allowed", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+
+		require.NoError(t, s.Authorize(ctx, accountID, projectID))
+	})
+
+	t.Run("project of another account is not found", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: uuid.New()}, nil)
+
+		err := s.Authorize(ctx, accountID, projectID)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "PROJECT_NOT_FOUND", appErr.Code)
+	})
+
+	t.Run("missing project is not found", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(nil, apperror.ErrRecordNotFound)
+
+		err := s.Authorize(ctx, accountID, projectID)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "PROJECT_NOT_FOUND", appErr.Code)
+	})
+}
+
+func TestRealtimeService_Start(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+
+	t.Run("without a cursor starts at the head", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetSeqRange(ctx).Return(int64(3), int64(42), nil)
+
+		start, err := s.Start(ctx, projectID, nil)
+
+		require.NoError(t, err)
+		require.Len(t, start.Messages, 1)
+		assert.Equal(t, realtime.MessageReady, start.Messages[0].Event)
+		assert.Equal(t, "42", start.Messages[0].ID)
+		assert.Empty(t, start.Replayed)
+	})
+
+	t.Run("resumes with the events missed since the cursor", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetSeqRange(ctx).Return(int64(3), int64(42), nil)
+		s.eventLog.EXPECT().ListProjectEvents(ctx, projectID, int64(10), realtime.Types, realtime.MaxReplay+1).
+			Return([]*eventEntity.OutboxEvent{outboxEvent(11, events.TaskCreated), outboxEvent(15, events.TaskUpdated)}, nil)
+
+		start, err := s.Start(ctx, projectID, lo.ToPtr(int64(10)))
+
+		require.NoError(t, err)
+		require.Len(t, start.Messages, 3)
+		assert.Equal(t, realtime.MessageReady, start.Messages[0].Event)
+		assert.Equal(t, "10", start.Messages[0].ID)
+		assert.Equal(t, events.TaskCreated, start.Messages[1].Event)
+		assert.Equal(t, events.TaskUpdated, start.Messages[2].Event)
+		assert.Equal(t, []string{"11", "15"}, start.Replayed)
+
+		var data realtime.EventData
+		require.NoError(t, json.Unmarshal(start.Messages[1].Data, &data))
+		assert.Equal(t, events.TaskCreated, data.Event)
+		assert.Contains(t, data.ID, events.EventIDPrefix+"_")
+		assert.JSONEq(t, `{"task":{"id":"tsk_1"}}`, string(data.Data))
+	})
+
+	t.Run("cursor right below the lowest kept seq resumes", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetSeqRange(ctx).Return(int64(11), int64(42), nil)
+		s.eventLog.EXPECT().ListProjectEvents(ctx, projectID, int64(10), realtime.Types, realtime.MaxReplay+1).Return(nil, nil)
+
+		start, err := s.Start(ctx, projectID, lo.ToPtr(int64(10)))
+
+		require.NoError(t, err)
+		assert.Equal(t, realtime.MessageReady, start.Messages[0].Event)
+	})
+
+	t.Run("cursor of purged events resets to the head", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetSeqRange(ctx).Return(int64(20), int64(42), nil)
+
+		start, err := s.Start(ctx, projectID, lo.ToPtr(int64(10)))
+
+		require.NoError(t, err)
+		require.Len(t, start.Messages, 1)
+		assert.Equal(t, realtime.MessageReset, start.Messages[0].Event)
+		assert.Equal(t, "42", start.Messages[0].ID)
+	})
+
+	t.Run("cursor past the head resets", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetSeqRange(ctx).Return(int64(0), int64(0), nil)
+
+		start, err := s.Start(ctx, projectID, lo.ToPtr(int64(10)))
+
+		require.NoError(t, err)
+		assert.Equal(t, realtime.MessageReset, start.Messages[0].Event)
+		assert.Equal(t, "0", start.Messages[0].ID)
+	})
+
+	t.Run("too many missed events resets", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		missed := make([]*eventEntity.OutboxEvent, realtime.MaxReplay+1)
+		for i := range missed {
+			missed[i] = outboxEvent(int64(11+i), events.TaskUpdated)
+		}
+		s.eventLog.EXPECT().GetSeqRange(ctx).Return(int64(1), int64(1000), nil)
+		s.eventLog.EXPECT().ListProjectEvents(ctx, projectID, int64(10), realtime.Types, realtime.MaxReplay+1).Return(missed, nil)
+
+		start, err := s.Start(ctx, projectID, lo.ToPtr(int64(10)))
+
+		require.NoError(t, err)
+		require.Len(t, start.Messages, 1)
+		assert.Equal(t, realtime.MessageReset, start.Messages[0].Event)
+		assert.Equal(t, "1000", start.Messages[0].ID)
+	})
+
+	t.Run("event log error", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetSeqRange(ctx).Return(int64(0), int64(0), errors.New("db down"))
+
+		_, err := s.Start(ctx, projectID, nil)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "GET_EVENT_LOG_ERROR", appErr.Code)
+	})
+}
+
+func TestRealtimeService_EventMessage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("streamed type", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetEventsBySeq(ctx, []int64{7}).Return([]*eventEntity.OutboxEvent{outboxEvent(7, events.TaskDeleted)}, nil)
+
+		msg, err := s.EventMessage(ctx, 7)
+
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		assert.Equal(t, "7", msg.ID)
+		assert.Equal(t, events.TaskDeleted, msg.Event)
+	})
+
+	t.Run("type not streamed", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetEventsBySeq(ctx, []int64{7}).Return([]*eventEntity.OutboxEvent{outboxEvent(7, events.TaskStatusChanged)}, nil)
+
+		msg, err := s.EventMessage(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Nil(t, msg)
+	})
+
+	t.Run("purged event", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.eventLog.EXPECT().GetEventsBySeq(ctx, []int64{7}).Return(nil, nil)
+
+		msg, err := s.EventMessage(ctx, 7)
+
+		require.NoError(t, err)
+		assert.Nil(t, msg)
+	})
+}
+
+func TestRealtimeService_Presence(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	alice := uuid.New()
+	bob := uuid.New()
+	now := time.Now()
+
+	s := newTestRealtimeService(t)
+	s.viewerRepo.EXPECT().ListViewersByProject(ctx, projectID, now.Add(-realtime.ViewerTTL)).Return([]*entity.Viewer{
+		{ConnectionID: uuid.New(), ProjectID: projectID, AccountID: alice, Username: "alice", ConnectedAt: now.Add(-time.Hour)},
+		{ConnectionID: uuid.New(), ProjectID: projectID, AccountID: bob, Username: "bob", ConnectedAt: now.Add(-time.Minute)},
+		{ConnectionID: uuid.New(), ProjectID: projectID, AccountID: alice, Username: "alice", ConnectedAt: now},
+	}, nil)
+
+	msg, err := s.Presence(ctx, projectID, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, realtime.MessagePresence, msg.Event)
+	assert.Empty(t, msg.ID)
+
+	var data realtime.PresenceData
+	require.NoError(t, json.Unmarshal(msg.Data, &data))
+	require.Len(t, data.Viewers, 2)
+	assert.Equal(t, "alice", data.Viewers[0].Username)
+	assert.Equal(t, 2, data.Viewers[0].Connections)
+	assert.True(t, data.Viewers[0].Since.Equal(now.Add(-time.Hour)))
+	assert.Equal(t, "bob", data.Viewers[1].Username)
+	assert.Equal(t, 1, data.Viewers[1].Connections)
+}
+
+func TestRealtimeService_JoinLeave(t *testing.T) {
+	ctx := context.Background()
+	viewer := &entity.Viewer{ConnectionID: uuid.New(), ProjectID: uuid.New(), AccountID: uuid.New()}
+
+	t.Run("join lists the viewer and notifies", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		gomock.InOrder(
+			s.viewerRepo.EXPECT().TouchViewers(ctx, []*entity.Viewer{viewer}).Return(nil),
+			s.viewerRepo.EXPECT().NotifyPresence(ctx, viewer.ProjectID).Return(nil),
+		)
+
+		require.NoError(t, s.Join(ctx, viewer))
+	})
+
+	t.Run("leave unlists the viewer and notifies", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		gomock.InOrder(
+			s.viewerRepo.EXPECT().DeleteViewer(ctx, viewer.ConnectionID).Return(nil),
+			s.viewerRepo.EXPECT().NotifyPresence(ctx, viewer.ProjectID).Return(nil),
+		)
+
+		require.NoError(t, s.Leave(ctx, viewer))
+	})
+
+	t.Run("join does not notify when saving fails", func(t *testing.T) {
+		s := newTestRealtimeService(t)
+		s.viewerRepo.EXPECT().TouchViewers(ctx, gomock.Any()).Return(errors.New("db down"))
+
+		err := s.Join(ctx, viewer)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "SAVE_VIEWER_ERROR", appErr.Code)
+	})
+}
+
+func TestRealtimeService_SweepStale(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	first := uuid.New()
+	second := uuid.New()
+
+	s := newTestRealtimeService(t)
+	s.viewerRepo.EXPECT().DeleteStaleViewers(ctx, now.Add(-realtime.ViewerTTL)).Return([]uuid.UUID{first, second}, nil)
+	s.viewerRepo.EXPECT().NotifyPresence(ctx, first).Return(nil)
+	s.viewerRepo.EXPECT().NotifyPresence(ctx, second).Return(nil)
+
+	require.NoError(t, s.SweepStale(ctx, now))
+}
+
+func TestRealtimeService_Touch(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	viewers := []*entity.Viewer{{ConnectionID: uuid.New()}, {ConnectionID: uuid.New()}}
+
+	s := newTestRealtimeService(t)
+	s.viewerRepo.EXPECT().TouchViewers(ctx, viewers).Return(nil)
+
+	require.NoError(t, s.Touch(ctx, viewers, now))
+	for _, v := range viewers {
+		assert.Equal(t, now, v.SeenAt)
+	}
+}
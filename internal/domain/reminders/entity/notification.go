@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const NotificationIDPrefix = "ntf"
+
+// Notification is an in-app message of an account. Reminder notifications keep
+// the reminder and occurrence they were sent for, which are unique together.
+type Notification struct {
+	ID              uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	AccountID       uuid.UUID  `gorm:"column:account_id;type:char(36);index;not null"`
+	TaskID          *uuid.UUID `gorm:"column:task_id;type:char(36)"`
+	ReminderID      *uuid.UUID `gorm:"column:reminder_id;type:char(36)"`
+	OccurrenceStart *time.Time `gorm:"column:occurrence_start"`
+	Title           string     `gorm:"column:title;type:varchar(255);not null"`
+	Body            string     `gorm:"column:body;type:text;not null"`
+	ReadAt          *time.Time `gorm:"column:read_at"`
+	CreatedAt       time.Time  `gorm:"column:created_at;not null"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// IsRead reports whether the account has seen the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ReminderIDPrefix = "rmd"
+
+// Reminder notifies AccountID through Channel OffsetMinutes before each
+// occurrence of a task. RemindAt and OccurrenceStart point at the next
+// delivery and are nil while the task has no upcoming occurrence.
+type Reminder struct {
+	ID            uuid.UUID `gorm:"column:id;type:char(36);primaryKey"`
+	TaskID        uuid.UUID `gorm:"column:task_id;type:char(36);index;not null"`
+	AccountID     uuid.UUID `gorm:"column:account_id;type:char(36);index;not null"`
+	OffsetMinutes int       `gorm:"column:offset_minutes;not null"`
+	Channel       string    `gorm:"column:channel;type:varchar(16);not null"`
+	// WebhookURL receives the reminder when Channel is webhook
+	WebhookURL *string `gorm:"column:webhook_url;type:varchar(2048)"`
+	// WebhookSecret signs the webhook payloads; reminders created before
+	// payloads were signed have none
+	WebhookSecret   *string    `gorm:"column:webhook_secret;type:varchar(64)"`
+	Status          string     `gorm:"column:status;type:varchar(16);not null"`
+	RemindAt        *time.Time `gorm:"column:remind_at"`
+	OccurrenceStart *time.Time `gorm:"column:occurrence_start"`
+	// Attempts counts the deliveries of the current occurrence; it also fences
+	// out a worker whose claim has expired
+	Attempts    int        `gorm:"column:attempts;not null;default:0"`
+	LockedUntil *time.Time `gorm:"column:locked_until"`
+	LastError   *string    `gorm:"column:last_error;type:text"`
+	SentAt      *time.Time `gorm:"column:sent_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;not null"`
+}
+
+func (Reminder) TableName() string {
+	return "task_reminders"
+}
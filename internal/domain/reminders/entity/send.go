@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Send marks an occurrence of a reminder as handed to a channel that cannot
+// drop a repeated delivery itself, so it is not sent twice
+type Send struct {
+	ReminderID      uuid.UUID `gorm:"column:reminder_id;type:char(36);primaryKey"`
+	OccurrenceStart time.Time `gorm:"column:occurrence_start;primaryKey"`
+	ClaimedAt       time.Time `gorm:"column:claimed_at;not null"`
+}
+
+func (Send) TableName() string {
+	return "reminder_sends"
+}
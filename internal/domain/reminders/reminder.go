@@ -0,0 +1,166 @@
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/samber/lo"
+)
+
+// Channels a reminder is delivered through
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Channels lists every delivery channel
+var Channels = []string{ChannelInApp, ChannelEmail, ChannelWebhook}
+
+// Reminder statuses
+const (
+	// StatusScheduled reminders are delivered at RemindAt
+	StatusScheduled = "scheduled"
+	// StatusSending reminders are claimed by a worker delivering them
+	StatusSending = "sending"
+	// StatusSent reminders were delivered for the last occurrence of their task
+	StatusSent = "sent"
+	// StatusFailed reminders gave up on the last occurrence of their task
+	StatusFailed = "failed"
+	// StatusIdle reminders wait for their task to get an upcoming start, or to be reopened
+	StatusIdle = "idle"
+)
+
+const (
+	// MaxOffsetMinutes is how early a reminder may be, 30 days
+	MaxOffsetMinutes = 30 * 24 * 60
+
+	// MaxRemindersPerTask bounds the reminders an account sets on one task
+	MaxRemindersPerTask = 10
+
+	// MaxAttempts is how often the delivery of one occurrence is tried
+	MaxAttempts = 5
+
+	// MaxDelay is how late a reminder is still delivered; older ones, e.g. due
+	// while every instance was down, are skipped
+	MaxDelay = time.Hour
+)
+
+// Delivery is a reminder being delivered for one occurrence of its task
+type Delivery struct {
+	Reminder        *entity.Reminder
+	Task            *taskEntity.Task
+	OccurrenceStart time.Time
+	// Location is the zone of the reminded account, for rendering times
+	Location *time.Location
+}
+
+// IdempotencyKey identifies the occurrence, so receivers can drop a redelivery
+func (d Delivery) IdempotencyKey() string {
+	return fmt.Sprintf("%s:%d", d.Reminder.ID, d.OccurrenceStart.Unix())
+}
+
+// Title is the headline of the reminder
+func (d Delivery) Title() string {
+	return "Reminder: " + d.Task.Name
+}
+
+// Body tells when the occurrence starts, in the zone of the account
+func (d Delivery) Body() string {
+	start := d.OccurrenceStart.In(d.Location)
+	body := fmt.Sprintf("%s starts at %s on %s (%s).", d.Task.Name, start.Format("15:04"), start.Format("Mon 2 Jan 2006"), d.Location)
+	if d.Task.AllDay {
+		body = fmt.Sprintf("%s is on %s.", d.Task.Name, start.Format("Mon 2 Jan 2006"))
+	}
+	if d.Task.Location != nil && *d.Task.Location != "" {
+		body += " Location: " + *d.Task.Location
+	}
+	return body
+}
+
+// Channel delivers reminders. Deliver is called by one worker per reminder at
+// a time and must return an error when the reminder should be retried.
+type Channel interface {
+	Deliver(ctx context.Context, d Delivery) error
+}
+
+// LocationProvider returns the time zone an account reads times in
+type LocationProvider interface {
+	GetLocation(ctx context.Context, accountID string) (*time.Location, error)
+}
+
+// NextOccurrence returns the start of the first occurrence of t after after.
+// All-day tasks start at midnight of their date in loc. Tasks without a start
+// have no occurrence.
+func NextOccurrence(t *taskEntity.Task, after time.Time, loc *time.Location) (time.Time, bool) {
+	if t.StartDateTime == nil {
+		return time.Time{}, false
+	}
+
+	first := *t.StartDateTime
+	until := t.RecurringUntil
+	if t.AllDay {
+		first = midnightIn(first, loc)
+		if until != nil {
+			until = lo.ToPtr(midnightIn(*until, loc))
+		}
+	}
+	if first.After(after) {
+		return first, true
+	}
+	if t.RecurringDays == nil || *t.RecurringDays <= 0 {
+		return time.Time{}, false
+	}
+
+	days := *t.RecurringDays
+	nth := func(k int) time.Time {
+		// All-day occurrences stay at midnight across DST changes, like the dates they mark
+		if t.AllDay {
+			return first.AddDate(0, 0, k*days)
+		}
+		return first.Add(time.Duration(k*days) * 24 * time.Hour)
+	}
+
+	k := int(after.Sub(first) / (time.Duration(days) * 24 * time.Hour))
+	occurrence := nth(k)
+	for !occurrence.After(after) {
+		k++
+		occurrence = nth(k)
+	}
+	if until != nil && occurrence.After(*until) {
+		return time.Time{}, false
+	}
+	return occurrence, true
+}
+
+// NextReminder returns the first occurrence of t starting after after and when
+// to remind of it, offsetMinutes before. Completed tasks are not reminded of.
+func NextReminder(t *taskEntity.Task, offsetMinutes int, after time.Time, loc *time.Location) (remindAt, occurrence time.Time, ok bool) {
+	if t.Status == tasks.StatusDone {
+		return time.Time{}, time.Time{}, false
+	}
+	occurrence, ok = NextOccurrence(t, after, loc)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	return occurrence.Add(-time.Duration(offsetMinutes) * time.Minute), occurrence, true
+}
+
+// RetryDelay is the wait after the given number of failed attempts: 1, 2, 4,
+// 8 minutes and so on
+func RetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return time.Minute << (attempts - 1)
+}
+
+// midnightIn returns midnight in loc of the date an all-day task stores as midnight UTC
+func midnightIn(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,110 @@
+package reminders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func timePtr(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestNextOccurrence(t *testing.T) {
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		task     *taskEntity.Task
+		loc      *time.Location
+		ok       bool
+		expected time.Time
+	}{
+		{name: "no start", task: &taskEntity.Task{EndDateTime: timePtr("2026-03-12T10:00:00Z")}},
+		{
+			name:     "upcoming one-off",
+			task:     &taskEntity.Task{StartDateTime: timePtr("2026-03-12T09:00:00+07:00")},
+			ok:       true,
+			expected: time.Date(2026, 3, 12, 2, 0, 0, 0, time.UTC),
+		},
+		{name: "past one-off", task: &taskEntity.Task{StartDateTime: timePtr("2026-03-01T09:00:00Z")}},
+		{
+			name:     "weekly series",
+			task:     &taskEntity.Task{StartDateTime: timePtr("2026-03-02T09:00:00Z"), RecurringDays: lo.ToPtr(7)},
+			ok:       true,
+			expected: time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "occurrence starting at after is not next",
+			task:     &taskEntity.Task{StartDateTime: timePtr("2026-03-03T12:00:00Z"), RecurringDays: lo.ToPtr(7)},
+			ok:       true,
+			expected: time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "series that ended",
+			task: &taskEntity.Task{StartDateTime: timePtr("2026-03-02T09:00:00Z"), RecurringDays: lo.ToPtr(7), RecurringUntil: timePtr("2026-03-15T00:00:00Z")},
+		},
+		{
+			name:     "all-day task starts at midnight in the zone of the account",
+			task:     &taskEntity.Task{StartDateTime: timePtr("2026-03-12T00:00:00Z"), AllDay: true},
+			loc:      bangkok,
+			ok:       true,
+			expected: time.Date(2026, 3, 12, 0, 0, 0, 0, bangkok),
+		},
+		{
+			name:     "all-day series ends on its last date",
+			task:     &taskEntity.Task{StartDateTime: timePtr("2026-03-01T00:00:00Z"), AllDay: true, RecurringDays: lo.ToPtr(10), RecurringUntil: timePtr("2026-03-11T00:00:00Z")},
+			loc:      bangkok,
+			ok:       true,
+			expected: time.Date(2026, 3, 11, 0, 0, 0, 0, bangkok),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+
+			occurrence, ok := NextOccurrence(tt.task, now, loc)
+
+			require.Equal(t, tt.ok, ok)
+			if ok {
+				assert.True(t, tt.expected.Equal(occurrence), "expected %s, got %s", tt.expected, occurrence)
+			}
+		})
+	}
+}
+
+func TestNextReminder(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	task := &taskEntity.Task{Status: "todo", StartDateTime: timePtr("2026-03-12T09:00:00Z")}
+
+	remindAt, occurrence, ok := NextReminder(task, 90, now, time.UTC)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, 3, 12, 7, 30, 0, 0, time.UTC), remindAt)
+	assert.Equal(t, time.Date(2026, 3, 12, 9, 0, 0, 0, time.UTC), occurrence)
+
+	task.Status = tasks.StatusDone
+	_, _, ok = NextReminder(task, 90, now, time.UTC)
+	assert.False(t, ok)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, RetryDelay(0))
+	assert.Equal(t, time.Minute, RetryDelay(1))
+	assert.Equal(t, 2*time.Minute, RetryDelay(2))
+	assert.Equal(t, 16*time.Minute, RetryDelay(5))
+}
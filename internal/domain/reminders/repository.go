@@ -0,0 +1,42 @@
+//go:generate go run go.uber.org/mock/mockgen -source=$GOFILE -destination=../../mocks/reminder_repository.go -package=mocks
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/google/uuid"
+)
+
+type ReminderRepository interface {
+	CreateReminder(ctx context.Context, reminder *entity.Reminder) error
+	GetReminderByID(ctx context.Context, reminderID uuid.UUID) (*entity.Reminder, error)
+	ListRemindersByTask(ctx context.Context, taskID uuid.UUID) ([]*entity.Reminder, error)
+	UpdateReminder(ctx context.Context, reminder *entity.Reminder) error
+	DeleteReminder(ctx context.Context, reminderID uuid.UUID) error
+	DeleteRemindersByTask(ctx context.Context, taskID uuid.UUID) error
+	// ClaimDueReminders marks up to limit reminders that are due at now, or whose
+	// claim has expired, as sending until lockedUntil and increments their
+	// attempts. Rows locked by another worker are skipped, so every reminder is
+	// claimed by one worker at a time.
+	ClaimDueReminders(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.Reminder, error)
+	// FinishClaim saves a claimed reminder; it reports false when the claim was
+	// lost, i.e. the row is no longer sending until claimedUntil
+	FinishClaim(ctx context.Context, reminder *entity.Reminder, claimedUntil time.Time) (bool, error)
+	// ClaimSend records that the occurrence of the reminder is being sent; it
+	// reports false when it already was, by this or an earlier claim
+	ClaimSend(ctx context.Context, send *entity.Send) (bool, error)
+	// ReleaseSend forgets the claim of an occurrence whose send failed
+	ReleaseSend(ctx context.Context, reminderID uuid.UUID, occurrenceStart time.Time) error
+}
+
+type NotificationRepository interface {
+	// CreateNotification reports false when the reminder occurrence was already notified
+	CreateNotification(ctx context.Context, notification *entity.Notification) (bool, error)
+	ListNotifications(ctx context.Context, accountID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entity.Notification, int, error)
+	CountUnreadNotifications(ctx context.Context, accountID uuid.UUID) (int, error)
+	// MarkNotificationRead reports false when the account has no such notification
+	MarkNotificationRead(ctx context.Context, accountID, notificationID uuid.UUID, at time.Time) (bool, error)
+	MarkAllNotificationsRead(ctx context.Context, accountID uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/mailer"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// WebhookEventReminder is the event of reminder webhook payloads
+const WebhookEventReminder = "task.reminder"
+
+// InAppChannel stores reminders as notifications of the account. A notification
+// already stored for the occurrence is not duplicated.
+type InAppChannel struct {
+	repo reminders.NotificationRepository
+}
+
+func NewInAppChannel(repo reminders.NotificationRepository) *InAppChannel {
+	return &InAppChannel{repo: repo}
+}
+
+func (c *InAppChannel) Deliver(ctx context.Context, d reminders.Delivery) error {
+	_, err := c.repo.CreateNotification(ctx, &entity.Notification{
+		ID:              uuid.New(),
+		AccountID:       d.Reminder.AccountID,
+		TaskID:          lo.ToPtr(d.Task.ID),
+		ReminderID:      lo.ToPtr(d.Reminder.ID),
+		OccurrenceStart: lo.ToPtr(d.OccurrenceStart),
+		Title:           d.Title(),
+		Body:            d.Body(),
+		CreatedAt:       time.Now(),
+	})
+	return err
+}
+
+// EmailChannel emails reminders to the address of the account. A mail server
+// cannot be asked whether it already accepted a message, so each occurrence is
+// claimed before it is handed over and a claimed occurrence is not emailed
+// again: a worker that dies mid-send loses the email rather than repeating it.
+// Only a send the mail server refused releases the claim to be retried.
+type EmailChannel struct {
+	mailer      mailer.Mailer
+	accountRepo accounts.AccountRepository
+	repo        reminders.ReminderRepository
+}
+
+func NewEmailChannel(m mailer.Mailer, accountRepo accounts.AccountRepository, repo reminders.ReminderRepository) *EmailChannel {
+	return &EmailChannel{
+		mailer:      m,
+		accountRepo: accountRepo,
+		repo:        repo,
+	}
+}
+
+func (c *EmailChannel) Deliver(ctx context.Context, d reminders.Delivery) error {
+	acc, err := c.accountRepo.GetByID(ctx, d.Reminder.AccountID)
+	if err != nil {
+		return fmt.Errorf("get account: %w", err)
+	}
+
+	claimed, err := c.repo.ClaimSend(ctx, &entity.Send{
+		ReminderID:      d.Reminder.ID,
+		OccurrenceStart: d.OccurrenceStart,
+		ClaimedAt:       time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("claim send: %w", err)
+	}
+	if !claimed {
+		// An earlier attempt handed it over and died before saving the outcome
+		return nil
+	}
+
+	body := d.Body()
+	err = c.mailer.Send(ctx, &mailer.Message{
+		To:      []string{acc.Email},
+		Subject: d.Title(),
+		Text:    fmt.Sprintf("Hi %s,\n\n%s\n", acc.Username, body),
+		HTML:    fmt.Sprintf("<p>Hi %s,</p><p>%s</p>", html.EscapeString(acc.Username), html.EscapeString(body)),
+	})
+	if err != nil {
+		// Released even when the worker is stopping, or the retry would skip the email
+		if releaseErr := c.repo.ReleaseSend(context.WithoutCancel(ctx), d.Reminder.ID, d.OccurrenceStart); releaseErr != nil {
+			return errors.Join(err, fmt.Errorf("release send: %w", releaseErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// WebhookPayload is the JSON body posted to reminder webhooks
+type WebhookPayload struct {
+	Event           string    `json:"event"`
+	ReminderID      string    `json:"reminder_id"`
+	TaskID          string    `json:"task_id"`
+	ProjectID       string    `json:"project_id"`
+	TaskName        string    `json:"task_name"`
+	AllDay          bool      `json:"all_day"`
+	OccurrenceStart time.Time `json:"occurrence_start"`
+	OffsetMinutes   int       `json:"offset_minutes"`
+	Title           string    `json:"title"`
+	Body            string    `json:"body"`
+}
+
+// WebhookChannel posts reminders as JSON to the URL of the reminder. Any
+// status other than 2xx is a failed delivery. The Idempotency-Key header is
+// the same for every attempt at one occurrence, and payloads are signed with
+// the secret of the reminder like project webhooks, see webhooks.Sign.
+type WebhookChannel struct {
+	client *http.Client
+}
+
+// NewWebhookChannel posts with client, whose timeout bounds each attempt. Use
+// safehttp.NewClient outside of tests.
+func NewWebhookChannel(client *http.Client) *WebhookChannel {
+	return &WebhookChannel{client: client}
+}
+
+func (c *WebhookChannel) Deliver(ctx context.Context, d reminders.Delivery) error {
+	if d.Reminder.WebhookURL == nil {
+		return fmt.Errorf("reminder has no webhook URL")
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:           WebhookEventReminder,
+		ReminderID:      utils.ShortUUIDWithPrefix(d.Reminder.ID, entity.ReminderIDPrefix),
+		TaskID:          utils.ShortUUIDWithPrefix(d.Task.ID, taskEntity.TaskIDPrefix),
+		ProjectID:       utils.ShortUUIDWithPrefix(d.Task.ProjectID, projectEntity.ProjectIDPrefix),
+		TaskName:        d.Task.Name,
+		AllDay:          d.Task.AllDay,
+		OccurrenceStart: d.OccurrenceStart.In(d.Location),
+		OffsetMinutes:   d.Reminder.OffsetMinutes,
+		Title:           d.Title(),
+		Body:            d.Body(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *d.Reminder.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "smart-task-ai-reminders")
+	req.Header.Set("Idempotency-Key", d.IdempotencyKey())
+	if d.Reminder.WebhookSecret != nil {
+		timestamp := time.Now().Unix()
+		req.Header.Set(webhooks.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(webhooks.HeaderSignature, webhooks.Sign(*d.Reminder.WebhookSecret, timestamp, body))
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	accountEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/mailer"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeMailer struct {
+	err  error
+	sent []*mailer.Message
+}
+
+func (m *fakeMailer) Send(_ context.Context, msg *mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+func testDelivery() reminders.Delivery {
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	return reminders.Delivery{
+		Reminder:        &entity.Reminder{ID: uuid.New(), AccountID: uuid.New(), OffsetMinutes: 10},
+		Task:            &taskEntity.Task{ID: uuid.New(), ProjectID: uuid.New(), Name: "Standup", StartDateTime: &start},
+		OccurrenceStart: start,
+		Location:        time.UTC,
+	}
+}
+
+func TestEmailChannel_Deliver(t *testing.T) {
+	ctx := context.Background()
+	d := testDelivery()
+	account := &accountEntity.Account{ID: d.Reminder.AccountID, Username: "alice", Email: "alice@example.com"}
+	isOccurrence := func(send *entity.Send) bool {
+		return send.ReminderID == d.Reminder.ID && send.OccurrenceStart.Equal(d.OccurrenceStart)
+	}
+
+	tests := []struct {
+		name          string
+		mailErr       error
+		setupMock     func(repo *mocks.MockReminderRepository)
+		expectedError string
+		sent          int
+	}{
+		{
+			name: "sends an occurrence it claimed",
+			setupMock: func(repo *mocks.MockReminderRepository) {
+				repo.EXPECT().ClaimSend(ctx, gomock.Cond(isOccurrence)).Return(true, nil)
+			},
+			sent: 1,
+		},
+		{
+			name: "does not send an occurrence claimed before",
+			setupMock: func(repo *mocks.MockReminderRepository) {
+				repo.EXPECT().ClaimSend(ctx, gomock.Cond(isOccurrence)).Return(false, nil)
+			},
+		},
+		{
+			name: "does not send without a claim",
+			setupMock: func(repo *mocks.MockReminderRepository) {
+				repo.EXPECT().ClaimSend(ctx, gomock.Any()).Return(false, errors.New("connection refused"))
+			},
+			expectedError: "claim send",
+		},
+		{
+			name:    "releases the claim of a refused send",
+			mailErr: errors.New("550 mailbox unavailable"),
+			setupMock: func(repo *mocks.MockReminderRepository) {
+				repo.EXPECT().ClaimSend(ctx, gomock.Cond(isOccurrence)).Return(true, nil)
+				repo.EXPECT().ReleaseSend(gomock.Any(), d.Reminder.ID, d.OccurrenceStart).Return(nil)
+			},
+			expectedError: "550 mailbox unavailable",
+			sent:          1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockReminderRepository(ctrl)
+			accountRepo := mocks.NewMockAccountRepository(ctrl)
+			accountRepo.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+			tt.setupMock(repo)
+			m := &fakeMailer{err: tt.mailErr}
+
+			err := NewEmailChannel(m, accountRepo, repo).Deliver(ctx, d)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, m.sent, tt.sent)
+			if tt.sent > 0 {
+				assert.Equal(t, []string{account.Email}, m.sent[0].To)
+				assert.Equal(t, "Reminder: Standup", m.sent[0].Subject)
+			}
+		})
+	}
+}
+
+func TestWebhookChannel_Deliver(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		secret *string
+		signed bool
+	}{
+		{name: "signs with the secret of the reminder", secret: lo.ToPtr("whsec_test"), signed: true},
+		{name: "posts unsigned for a reminder without a secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			var body []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+
+			d := testDelivery()
+			d.Reminder.WebhookURL = lo.ToPtr(srv.URL)
+			d.Reminder.WebhookSecret = tt.secret
+
+			require.NoError(t, NewWebhookChannel(srv.Client()).Deliver(ctx, d))
+
+			assert.Equal(t, d.IdempotencyKey(), header.Get("Idempotency-Key"))
+			if !tt.signed {
+				assert.Empty(t, header.Get(webhooks.HeaderTimestamp))
+				assert.Empty(t, header.Get(webhooks.HeaderSignature))
+				return
+			}
+			timestamp, err := strconv.ParseInt(header.Get(webhooks.HeaderTimestamp), 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, webhooks.Sign(*tt.secret, timestamp, body), header.Get(webhooks.HeaderSignature))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Outcomes of dispatching a claimed reminder
+const (
+	OutcomeSent = "sent"
+	// OutcomeRetry is a failed delivery that is tried again later
+	OutcomeRetry  = "retry"
+	OutcomeFailed = "failed"
+	// OutcomeSkipped is an occurrence not delivered: too late, or the task moved or is gone
+	OutcomeSkipped = "skipped"
+	// OutcomeLost is a reminder whose claim expired before the outcome was saved
+	OutcomeLost = "lost"
+)
+
+// leaseMargin is the time left on a claim below which a delivery is not started
+const leaseMargin = 30 * time.Second
+
+// DispatchResult is the outcome of one claimed reminder
+type DispatchResult struct {
+	ReminderID uuid.UUID
+	Channel    string
+	Outcome    string
+	Err        error
+}
+
+// DispatchDue claims up to limit due reminders for lease and delivers them.
+//
+// The claim lets only one worker across instances deliver a reminder at a
+// time, and the outcome is only saved while the claim is held. A worker that
+// dies between delivering and saving leaves the reminder to be claimed again
+// once the lease expires, so each channel decides what a redelivery does:
+// in-app notifications are unique per occurrence, webhooks are posted again
+// with the same Idempotency-Key, and emails are sent at most once.
+func (s *ReminderService) DispatchDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]DispatchResult, error) {
+	// The claim is matched on locked_until, which Postgres stores in microseconds
+	lockedUntil := now.Add(lease).Truncate(time.Microsecond)
+
+	claimed, err := s.repo.ClaimDueReminders(ctx, now, lockedUntil, limit)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to claim reminders", "CLAIM_REMINDERS_ERROR", err)
+	}
+
+	results := make([]DispatchResult, 0, len(claimed))
+	for _, reminder := range claimed {
+		// The rest are claimed again by whichever worker is free once the lease expires
+		if time.Now().After(lockedUntil.Add(-leaseMargin)) {
+			break
+		}
+		results = append(results, s.dispatch(ctx, reminder, lockedUntil))
+	}
+	return results, nil
+}
+
+func (s *ReminderService) dispatch(ctx context.Context, reminder *entity.Reminder, lockedUntil time.Time) DispatchResult {
+	result := DispatchResult{ReminderID: reminder.ID, Channel: reminder.Channel}
+	now := time.Now()
+
+	t, err := s.taskRepo.GetTaskByID(ctx, reminder.TaskID)
+	if errors.Is(err, apperror.ErrRecordNotFound) {
+		result.Outcome = OutcomeSkipped
+		if err := s.repo.DeleteReminder(ctx, reminder.ID); err != nil {
+			result.Err = err
+		}
+		return result
+	}
+	var loc *time.Location
+	if err == nil {
+		loc, err = s.locations.GetLocation(ctx, reminder.AccountID.String())
+	}
+	if err != nil {
+		// Nothing was delivered, so the attempt is not held against the reminder
+		reminder.Attempts--
+		retry(reminder, now, err)
+		return s.finish(ctx, reminder, lockedUntil, OutcomeRetry, err)
+	}
+
+	// The task may have been moved before the outbox handler of task.updated rescheduled it
+	if !isCurrentOccurrence(reminder, t, loc) {
+		reminder.OccurrenceStart = nil
+		schedule(reminder, t, now, loc)
+		return s.finish(ctx, reminder, lockedUntil, OutcomeSkipped, nil)
+	}
+
+	occurrence := *reminder.OccurrenceStart
+	dueAt := occurrence.Add(-time.Duration(reminder.OffsetMinutes) * time.Minute)
+	if now.Sub(dueAt) > reminders.MaxDelay {
+		advance(reminder, t, occurrence, now, loc, reminders.StatusSent)
+		return s.finish(ctx, reminder, lockedUntil, OutcomeSkipped, nil)
+	}
+
+	channel, ok := s.channels[reminder.Channel]
+	if !ok {
+		err = fmt.Errorf("no delivery for channel %q", reminder.Channel)
+	} else {
+		err = channel.Deliver(ctx, reminders.Delivery{
+			Reminder:        reminder,
+			Task:            t,
+			OccurrenceStart: occurrence,
+			Location:        loc,
+		})
+	}
+
+	switch {
+	case err == nil:
+		reminder.SentAt = &now
+		reminder.LastError = nil
+		advance(reminder, t, occurrence, now, loc, reminders.StatusSent)
+		return s.finish(ctx, reminder, lockedUntil, OutcomeSent, nil)
+	case reminder.Attempts < reminders.MaxAttempts:
+		retry(reminder, now, err)
+		return s.finish(ctx, reminder, lockedUntil, OutcomeRetry, err)
+	default:
+		reminder.LastError = lo.ToPtr(err.Error())
+		advance(reminder, t, occurrence, now, loc, reminders.StatusFailed)
+		return s.finish(ctx, reminder, lockedUntil, OutcomeFailed, err)
+	}
+}
+
+// retry schedules another attempt at the same occurrence after a backoff
+func retry(reminder *entity.Reminder, now time.Time, err error) {
+	reminder.Status = reminders.StatusScheduled
+	reminder.RemindAt = lo.ToPtr(now.Add(reminders.RetryDelay(reminder.Attempts)))
+	reminder.LastError = lo.ToPtr(err.Error())
+}
+
+// advance moves reminder past occurrence to the next one of t, or leaves it in
+// status when the task does not repeat
+func advance(reminder *entity.Reminder, t *taskEntity.Task, occurrence, now time.Time, loc *time.Location, status string) {
+	after := occurrence
+	if now.After(after) {
+		after = now
+	}
+
+	remindAt, next, ok := reminders.NextReminder(t, reminder.OffsetMinutes, after, loc)
+	if !ok {
+		reminder.Status = status
+		reminder.RemindAt = nil
+		return
+	}
+	reminder.Status = reminders.StatusScheduled
+	reminder.RemindAt = &remindAt
+	reminder.OccurrenceStart = &next
+	reminder.Attempts = 0
+}
+
+// finish saves the outcome of a claimed reminder, unless the claim was lost
+func (s *ReminderService) finish(ctx context.Context, reminder *entity.Reminder, lockedUntil time.Time, outcome string, deliveryErr error) DispatchResult {
+	result := DispatchResult{ReminderID: reminder.ID, Channel: reminder.Channel, Outcome: outcome, Err: deliveryErr}
+
+	reminder.LockedUntil = nil
+	reminder.UpdatedAt = time.Now()
+	saved, err := s.repo.FinishClaim(ctx, reminder, lockedUntil)
+	if err != nil {
+		result.Err = errors.Join(deliveryErr, err)
+	}
+	if err == nil && !saved {
+		result.Outcome = OutcomeLost
+	}
+	return result
+}
+
+// isCurrentOccurrence reports whether the occurrence the reminder was claimed
+// for is still an occurrence of the task
+func isCurrentOccurrence(reminder *entity.Reminder, t *taskEntity.Task, loc *time.Location) bool {
+	if reminder.OccurrenceStart == nil {
+		return false
+	}
+	occurrence := *reminder.OccurrenceStart
+	_, next, ok := reminders.NextReminder(t, reminder.OffsetMinutes, occurrence.Add(-time.Nanosecond), loc)
+	return ok && next.Equal(occurrence)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+// NotificationService reads and acknowledges the in-app notifications of an account
+type NotificationService struct {
+	repo reminders.NotificationRepository
+}
+
+func NewNotificationService(repo reminders.NotificationRepository) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+// ListNotifications returns a page of notifications, newest first, the total
+// matching and the number of unread notifications
+func (s *NotificationService) ListNotifications(ctx context.Context, accountID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entity.Notification, int, int, error) {
+	items, total, err := s.repo.ListNotifications(ctx, accountID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, 0, apperror.NewInternalServerError("failed to list notifications", "LIST_NOTIFICATIONS_ERROR", err)
+	}
+
+	unread := total
+	if !unreadOnly {
+		if unread, err = s.repo.CountUnreadNotifications(ctx, accountID); err != nil {
+			return nil, 0, 0, apperror.NewInternalServerError("failed to count notifications", "COUNT_NOTIFICATIONS_ERROR", err)
+		}
+	}
+
+	return items, total, unread, nil
+}
+
+func (s *NotificationService) MarkNotificationRead(ctx context.Context, accountID, notificationID uuid.UUID) error {
+	found, err := s.repo.MarkNotificationRead(ctx, accountID, notificationID, time.Now())
+	if err != nil {
+		return apperror.NewInternalServerError("failed to mark notification as read", "MARK_NOTIFICATION_READ_ERROR", err)
+	}
+	if !found {
+		return apperror.NewNotFoundError("notification not found", "NOTIFICATION_NOT_FOUND", nil)
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllNotificationsRead(ctx context.Context, accountID uuid.UUID) error {
+	if err := s.repo.MarkAllNotificationsRead(ctx, accountID, time.Now()); err != nil {
+		return apperror.NewInternalServerError("failed to mark notifications as read", "MARK_NOTIFICATIONS_READ_ERROR", err)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/safehttp"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type ReminderService struct {
+	repo             reminders.ReminderRepository
+	notificationRepo reminders.NotificationRepository
+	taskRepo         tasks.TaskRepository
+	projectRepo      projects.ProjectRepository
+	locations        reminders.LocationProvider
+	channels         map[string]reminders.Channel
+}
+
+// NewReminderService creates the service; channels maps the channel names to
+// their delivery, and reminders on a channel without one fail when due
+func NewReminderService(
+	repo reminders.ReminderRepository,
+	notificationRepo reminders.NotificationRepository,
+	taskRepo tasks.TaskRepository,
+	projectRepo projects.ProjectRepository,
+	locations reminders.LocationProvider,
+	channels map[string]reminders.Channel,
+) *ReminderService {
+	return &ReminderService{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		taskRepo:         taskRepo,
+		projectRepo:      projectRepo,
+		locations:        locations,
+		channels:         channels,
+	}
+}
+
+// CreateReminder reminds accountID of every occurrence of the task,
+// offsetMinutes before it starts
+func (s *ReminderService) CreateReminder(ctx context.Context, accountID, taskID uuid.UUID, offsetMinutes int, channel, webhookURL string) (*entity.Reminder, error) {
+	if offsetMinutes < 0 || offsetMinutes > reminders.MaxOffsetMinutes {
+		return nil, apperror.NewBadRequestError("offset_minutes must be between 0 and 43200", "INVALID_REMINDER_OFFSET", nil)
+	}
+	if !lo.Contains(reminders.Channels, channel) {
+		return nil, apperror.NewBadRequestError("channel must be one of in_app, email, webhook", "INVALID_REMINDER_CHANNEL", nil)
+	}
+
+	reminder := &entity.Reminder{
+		ID:            uuid.New(),
+		TaskID:        taskID,
+		AccountID:     accountID,
+		OffsetMinutes: offsetMinutes,
+		Channel:       channel,
+	}
+	if channel == reminders.ChannelWebhook {
+		if !safehttp.ValidURL(webhookURL) {
+			return nil, apperror.NewBadRequestError("webhook_url must be an absolute http(s) URL of a public host", "INVALID_WEBHOOK_URL", nil)
+		}
+		reminder.WebhookURL = &webhookURL
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			return nil, apperror.NewInternalServerError("failed to create reminder", "CREATE_REMINDER_ERROR", err)
+		}
+		reminder.WebhookSecret = &secret
+	}
+
+	t, err := s.getOwnedTask(ctx, accountID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListRemindersByTask(ctx, taskID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list reminders", "LIST_REMINDERS_ERROR", err)
+	}
+	if lo.CountBy(existing, func(r *entity.Reminder) bool { return r.AccountID == accountID }) >= reminders.MaxRemindersPerTask {
+		return nil, apperror.NewConflictError("a task can have at most 10 reminders", "TOO_MANY_REMINDERS", nil)
+	}
+
+	loc, err := s.locations.GetLocation(ctx, accountID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reminder.Status = reminders.StatusIdle
+	reminder.CreatedAt = now
+	reminder.UpdatedAt = now
+	schedule(reminder, t, now, loc)
+	if err := s.repo.CreateReminder(ctx, reminder); err != nil {
+		return nil, apperror.NewInternalServerError("failed to create reminder", "CREATE_REMINDER_ERROR", err)
+	}
+
+	return reminder, nil
+}
+
+// ListReminders returns the reminders accountID set on the task
+func (s *ReminderService) ListReminders(ctx context.Context, accountID, taskID uuid.UUID) ([]*entity.Reminder, error) {
+	if _, err := s.getOwnedTask(ctx, accountID, taskID); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.ListRemindersByTask(ctx, taskID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list reminders", "LIST_REMINDERS_ERROR", err)
+	}
+
+	return lo.Filter(list, func(r *entity.Reminder, _ int) bool { return r.AccountID == accountID }), nil
+}
+
+func (s *ReminderService) DeleteReminder(ctx context.Context, accountID, taskID, reminderID uuid.UUID) error {
+	reminder, err := s.repo.GetReminderByID(ctx, reminderID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return reminderNotFound()
+		}
+		return apperror.NewInternalServerError("failed to get reminder", "GET_REMINDER_ERROR", err)
+	}
+	if reminder.AccountID != accountID || reminder.TaskID != taskID {
+		return reminderNotFound()
+	}
+
+	if err := s.repo.DeleteReminder(ctx, reminderID); err != nil {
+		return apperror.NewInternalServerError("failed to delete reminder", "DELETE_REMINDER_ERROR", err)
+	}
+	return nil
+}
+
+// RescheduleTask points every reminder of t at its next occurrence. It is
+// called after t changes; reminders whose occurrence is unchanged are left
+// alone, so a change that does not move the task does not repeat a reminder.
+func (s *ReminderService) RescheduleTask(ctx context.Context, t *taskEntity.Task) error {
+	list, err := s.repo.ListRemindersByTask(ctx, t.ID)
+	if err != nil {
+		return apperror.NewInternalServerError("failed to list reminders", "LIST_REMINDERS_ERROR", err)
+	}
+
+	now := time.Now()
+	locations := make(map[uuid.UUID]*time.Location)
+	for _, reminder := range list {
+		loc, ok := locations[reminder.AccountID]
+		if !ok {
+			if loc, err = s.locations.GetLocation(ctx, reminder.AccountID.String()); err != nil {
+				return err
+			}
+			locations[reminder.AccountID] = loc
+		}
+
+		if !schedule(reminder, t, now, loc) {
+			continue
+		}
+		if err := s.repo.UpdateReminder(ctx, reminder); err != nil {
+			return apperror.NewInternalServerError("failed to update reminder", "UPDATE_REMINDER_ERROR", err)
+		}
+	}
+	return nil
+}
+
+// DeleteTaskReminders deletes the reminders of a deleted task
+func (s *ReminderService) DeleteTaskReminders(ctx context.Context, taskID uuid.UUID) error {
+	if err := s.repo.DeleteRemindersByTask(ctx, taskID); err != nil {
+		return apperror.NewInternalServerError("failed to delete reminders", "DELETE_REMINDERS_ERROR", err)
+	}
+	return nil
+}
+
+// HandleTaskEvent reschedules the reminders of an updated task and deletes
+// those of a deleted one. It handles the events of the outbox; an update is
+// applied from the task as it is now, so events handled late or twice do not
+// move reminders back.
+func (s *ReminderService) HandleTaskEvent(ctx context.Context, event events.Event) error {
+	taskID, err := events.TaskID(event)
+	if err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case events.TaskUpdated:
+		t, err := s.taskRepo.GetTaskByID(ctx, taskID)
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			// Deleted since; its task.deleted event removes the reminders
+			return nil
+		}
+		if err != nil {
+			return apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
+		}
+		return s.RescheduleTask(ctx, t)
+	case events.TaskDeleted:
+		return s.DeleteTaskReminders(ctx, taskID)
+	}
+	return nil
+}
+
+func (s *ReminderService) getOwnedTask(ctx context.Context, accountID, taskID uuid.UUID) (*taskEntity.Task, error) {
+	t, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
+	}
+
+	proj, err := s.projectRepo.GetProjectByID(ctx, t.ProjectID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to get project", "GET_PROJECT_ERROR", err)
+	}
+	if proj.AccountID != accountID {
+		return nil, apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", nil)
+	}
+
+	return t, nil
+}
+
+// schedule points reminder at the first occurrence of t starting after now
+// and reports whether it changed. A reminder already at that occurrence keeps
+// its state, so one that was sent or is being retried is not reset.
+func schedule(reminder *entity.Reminder, t *taskEntity.Task, now time.Time, loc *time.Location) bool {
+	remindAt, occurrence, ok := reminders.NextReminder(t, reminder.OffsetMinutes, now, loc)
+	switch {
+	case !ok && reminder.Status == reminders.StatusIdle:
+		return false
+	case ok && reminder.OccurrenceStart != nil && reminder.OccurrenceStart.Equal(occurrence):
+		return false
+	}
+
+	reminder.Status = reminders.StatusIdle
+	reminder.RemindAt = nil
+	reminder.OccurrenceStart = nil
+	if ok {
+		reminder.Status = reminders.StatusScheduled
+		reminder.RemindAt = &remindAt
+		reminder.OccurrenceStart = &occurrence
+	}
+	reminder.Attempts = 0
+	reminder.LockedUntil = nil
+	reminder.LastError = nil
+	reminder.UpdatedAt = now
+	return true
+}
+
+func reminderNotFound() error {
+	return apperror.NewNotFoundError("reminder not found", "REMINDER_NOT_FOUND", nil)
+}
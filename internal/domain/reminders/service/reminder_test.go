@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fixedLocation struct{}
+
+func (fixedLocation) GetLocation(context.Context, string) (*time.Location, error) {
+	return time.UTC, nil
+}
+
+type fakeChannel struct {
+	err        error
+	deliveries []reminders.Delivery
+}
+
+func (c *fakeChannel) Deliver(_ context.Context, d reminders.Delivery) error {
+	c.deliveries = append(c.deliveries, d)
+	return c.err
+}
+
+type testReminderService struct {
+	*ReminderService
+	repo        *mocks.MockReminderRepository
+	taskRepo    *mocks.MockTaskRepository
+	projectRepo *mocks.MockProjectRepository
+	channel     *fakeChannel
+}
+
+func newTestReminderService(t *testing.T) *testReminderService {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockReminderRepository(ctrl)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	channel := &fakeChannel{}
+
+	svc := NewReminderService(repo, mocks.NewMockNotificationRepository(ctrl), taskRepo, projectRepo, fixedLocation{},
+		map[string]reminders.Channel{reminders.ChannelInApp: channel})
+	return &testReminderService{ReminderService: svc, repo: repo, taskRepo: taskRepo, projectRepo: projectRepo, channel: channel}
+}
+
+func TestReminderService_CreateReminder(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	task := &taskEntity.Task{ID: uuid.New(), ProjectID: projectID, Status: "todo", StartDateTime: &start}
+
+	tests := []struct {
+		name          string
+		offset        int
+		channel       string
+		webhookURL    string
+		setupMock     func(s *testReminderService)
+		expectedError string
+	}{
+		{
+			name:    "success",
+			offset:  30,
+			channel: reminders.ChannelInApp,
+			setupMock: func(s *testReminderService) {
+				s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+				s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+				s.repo.EXPECT().ListRemindersByTask(ctx, task.ID).Return(nil, nil)
+				s.repo.EXPECT().CreateReminder(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, r *entity.Reminder) error {
+					assert.Equal(t, reminders.StatusScheduled, r.Status)
+					assert.True(t, start.Equal(*r.OccurrenceStart))
+					assert.True(t, start.Add(-30*time.Minute).Equal(*r.RemindAt))
+					return nil
+				})
+			},
+		},
+		{
+			name:       "success - webhook reminder gets a signing secret",
+			offset:     10,
+			channel:    reminders.ChannelWebhook,
+			webhookURL: "https://hooks.example.com/reminders",
+			setupMock: func(s *testReminderService) {
+				s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+				s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+				s.repo.EXPECT().ListRemindersByTask(ctx, task.ID).Return(nil, nil)
+				s.repo.EXPECT().CreateReminder(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, r *entity.Reminder) error {
+					require.NotNil(t, r.WebhookSecret)
+					assert.True(t, strings.HasPrefix(*r.WebhookSecret, webhooks.SecretPrefix))
+					return nil
+				})
+			},
+		},
+		{
+			name:          "error - negative offset",
+			offset:        -1,
+			channel:       reminders.ChannelInApp,
+			expectedError: "offset_minutes must be between 0 and 43200",
+		},
+		{
+			name:          "error - unknown channel",
+			channel:       "sms",
+			expectedError: "channel must be one of in_app, email, webhook",
+		},
+		{
+			name:          "error - webhook URL not http",
+			channel:       reminders.ChannelWebhook,
+			webhookURL:    "ftp://example.com/hook",
+			expectedError: "webhook_url must be an absolute http(s) URL",
+		},
+		{
+			name:          "error - webhook URL of the cloud metadata service",
+			channel:       reminders.ChannelWebhook,
+			webhookURL:    "http://169.254.169.254/latest/meta-data",
+			expectedError: "webhook_url must be an absolute http(s) URL of a public host",
+		},
+		{
+			name:    "error - task of another account",
+			channel: reminders.ChannelInApp,
+			setupMock: func(s *testReminderService) {
+				s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+				s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: uuid.New()}, nil)
+			},
+			expectedError: "task not found",
+		},
+		{
+			name:    "error - too many reminders",
+			channel: reminders.ChannelInApp,
+			setupMock: func(s *testReminderService) {
+				s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+				s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+				existing := make([]*entity.Reminder, reminders.MaxRemindersPerTask)
+				for i := range existing {
+					existing[i] = &entity.Reminder{ID: uuid.New(), AccountID: accountID}
+				}
+				s.repo.EXPECT().ListRemindersByTask(ctx, task.ID).Return(existing, nil)
+			},
+			expectedError: "a task can have at most 10 reminders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestReminderService(t)
+			if tt.setupMock != nil {
+				tt.setupMock(s)
+			}
+
+			reminder, err := s.CreateReminder(ctx, accountID, task.ID, tt.offset, tt.channel, tt.webhookURL)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, accountID, reminder.AccountID)
+		})
+	}
+}
+
+func TestReminderService_RescheduleTask(t *testing.T) {
+	ctx := context.Background()
+	s := newTestReminderService(t)
+
+	oldStart := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	newStart := oldStart.Add(2 * time.Hour)
+	task := &taskEntity.Task{ID: uuid.New(), Status: "todo", StartDateTime: &newStart}
+
+	moved := &entity.Reminder{
+		ID:              uuid.New(),
+		AccountID:       uuid.New(),
+		OffsetMinutes:   10,
+		Status:          reminders.StatusScheduled,
+		OccurrenceStart: &oldStart,
+		Attempts:        2,
+		LastError:       lo.ToPtr("timeout"),
+	}
+	current := &entity.Reminder{
+		ID:              uuid.New(),
+		AccountID:       moved.AccountID,
+		OffsetMinutes:   10,
+		Status:          reminders.StatusSent,
+		OccurrenceStart: &newStart,
+	}
+
+	s.repo.EXPECT().ListRemindersByTask(ctx, task.ID).Return([]*entity.Reminder{moved, current}, nil)
+	s.repo.EXPECT().UpdateReminder(ctx, moved).Return(nil)
+
+	require.NoError(t, s.RescheduleTask(ctx, task))
+
+	assert.Equal(t, reminders.StatusScheduled, moved.Status)
+	assert.True(t, newStart.Equal(*moved.OccurrenceStart))
+	assert.Zero(t, moved.Attempts)
+	assert.Nil(t, moved.LastError)
+	assert.Equal(t, reminders.StatusSent, current.Status)
+}
+
+func TestReminderService_HandleTaskEvent(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	task := &taskEntity.Task{ID: uuid.New(), ProjectID: uuid.New(), Status: "todo", StartDateTime: &start}
+	newEvent := func(eventType string) events.Event {
+		event, err := events.NewEvent(eventType, task.ProjectID, events.TaskEventData{Task: events.NewTaskData(task)})
+		require.NoError(t, err)
+		return event
+	}
+
+	t.Run("reschedules the task as it is now on task.updated", func(t *testing.T) {
+		s := newTestReminderService(t)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+		s.repo.EXPECT().ListRemindersByTask(ctx, task.ID).Return(nil, nil)
+
+		require.NoError(t, s.HandleTaskEvent(ctx, newEvent(events.TaskUpdated)))
+	})
+
+	t.Run("skips task.updated of a task deleted since", func(t *testing.T) {
+		s := newTestReminderService(t)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(nil, apperror.ErrRecordNotFound)
+
+		require.NoError(t, s.HandleTaskEvent(ctx, newEvent(events.TaskUpdated)))
+	})
+
+	t.Run("fails task.updated to be retried", func(t *testing.T) {
+		s := newTestReminderService(t)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(nil, errors.New("connection refused"))
+
+		assert.Error(t, s.HandleTaskEvent(ctx, newEvent(events.TaskUpdated)))
+	})
+
+	t.Run("deletes the reminders on task.deleted", func(t *testing.T) {
+		s := newTestReminderService(t)
+		s.repo.EXPECT().DeleteRemindersByTask(ctx, task.ID).Return(nil)
+
+		require.NoError(t, s.HandleTaskEvent(ctx, newEvent(events.TaskDeleted)))
+	})
+
+	t.Run("ignores other events", func(t *testing.T) {
+		s := newTestReminderService(t)
+
+		require.NoError(t, s.HandleTaskEvent(ctx, newEvent(events.TaskCreated)))
+	})
+}
+
+func TestReminderService_DispatchDue(t *testing.T) {
+	ctx := context.Background()
+	lease := 5 * time.Minute
+
+	dueReminder := func(task *taskEntity.Task, attempts int) *entity.Reminder {
+		return &entity.Reminder{
+			ID:              uuid.New(),
+			TaskID:          task.ID,
+			AccountID:       uuid.New(),
+			OffsetMinutes:   10,
+			Channel:         reminders.ChannelInApp,
+			Status:          reminders.StatusSending,
+			RemindAt:        lo.ToPtr(task.StartDateTime.Add(-10 * time.Minute)),
+			OccurrenceStart: task.StartDateTime,
+			Attempts:        attempts,
+		}
+	}
+
+	t.Run("delivers and completes a one-off task", func(t *testing.T) {
+		s := newTestReminderService(t)
+		task := &taskEntity.Task{ID: uuid.New(), Status: "todo", StartDateTime: lo.ToPtr(time.Now().Add(5 * time.Minute).Truncate(time.Second))}
+		reminder := dueReminder(task, 1)
+
+		s.repo.EXPECT().ClaimDueReminders(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Reminder{reminder}, nil)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+		s.repo.EXPECT().FinishClaim(ctx, reminder, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, OutcomeSent, results[0].Outcome)
+		require.Len(t, s.channel.deliveries, 1)
+		assert.True(t, task.StartDateTime.Equal(s.channel.deliveries[0].OccurrenceStart))
+		assert.Equal(t, reminders.StatusSent, reminder.Status)
+		assert.NotNil(t, reminder.SentAt)
+		assert.Nil(t, reminder.LockedUntil)
+	})
+
+	t.Run("advances a recurring task to its next occurrence", func(t *testing.T) {
+		s := newTestReminderService(t)
+		start := time.Now().Add(5 * time.Minute).Truncate(time.Second)
+		task := &taskEntity.Task{ID: uuid.New(), Status: "todo", StartDateTime: &start, RecurringDays: lo.ToPtr(1)}
+		reminder := dueReminder(task, 1)
+
+		s.repo.EXPECT().ClaimDueReminders(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Reminder{reminder}, nil)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+		s.repo.EXPECT().FinishClaim(ctx, reminder, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeSent, results[0].Outcome)
+		assert.Equal(t, reminders.StatusScheduled, reminder.Status)
+		assert.True(t, start.Add(24*time.Hour).Equal(*reminder.OccurrenceStart))
+		assert.Zero(t, reminder.Attempts)
+	})
+
+	t.Run("retries a failed delivery with backoff", func(t *testing.T) {
+		s := newTestReminderService(t)
+		s.channel.err = errors.New("unavailable")
+		task := &taskEntity.Task{ID: uuid.New(), Status: "todo", StartDateTime: lo.ToPtr(time.Now().Add(5 * time.Minute).Truncate(time.Second))}
+		reminder := dueReminder(task, 2)
+
+		s.repo.EXPECT().ClaimDueReminders(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Reminder{reminder}, nil)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+		s.repo.EXPECT().FinishClaim(ctx, reminder, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeRetry, results[0].Outcome)
+		assert.Equal(t, reminders.StatusScheduled, reminder.Status)
+		assert.WithinDuration(t, time.Now().Add(2*time.Minute), *reminder.RemindAt, 5*time.Second)
+		assert.Equal(t, "unavailable", *reminder.LastError)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		s := newTestReminderService(t)
+		s.channel.err = errors.New("unavailable")
+		task := &taskEntity.Task{ID: uuid.New(), Status: "todo", StartDateTime: lo.ToPtr(time.Now().Add(5 * time.Minute).Truncate(time.Second))}
+		reminder := dueReminder(task, reminders.MaxAttempts)
+
+		s.repo.EXPECT().ClaimDueReminders(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Reminder{reminder}, nil)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+		s.repo.EXPECT().FinishClaim(ctx, reminder, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeFailed, results[0].Outcome)
+		assert.Equal(t, reminders.StatusFailed, reminder.Status)
+	})
+
+	t.Run("skips an occurrence the task moved away from", func(t *testing.T) {
+		s := newTestReminderService(t)
+		task := &taskEntity.Task{ID: uuid.New(), Status: "todo", StartDateTime: lo.ToPtr(time.Now().Add(5 * time.Minute).Truncate(time.Second))}
+		reminder := dueReminder(task, 1)
+		newStart := task.StartDateTime.Add(3 * time.Hour)
+		task.StartDateTime = &newStart
+
+		s.repo.EXPECT().ClaimDueReminders(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Reminder{reminder}, nil)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+		s.repo.EXPECT().FinishClaim(ctx, reminder, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeSkipped, results[0].Outcome)
+		assert.Empty(t, s.channel.deliveries)
+		assert.Equal(t, reminders.StatusScheduled, reminder.Status)
+		assert.True(t, newStart.Equal(*reminder.OccurrenceStart))
+	})
+
+	t.Run("deletes the reminders of a deleted task", func(t *testing.T) {
+		s := newTestReminderService(t)
+		task := &taskEntity.Task{ID: uuid.New(), StartDateTime: lo.ToPtr(time.Now())}
+		reminder := dueReminder(task, 1)
+
+		s.repo.EXPECT().ClaimDueReminders(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Reminder{reminder}, nil)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(nil, apperror.ErrRecordNotFound)
+		s.repo.EXPECT().DeleteReminder(ctx, reminder.ID).Return(nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeSkipped, results[0].Outcome)
+	})
+
+	t.Run("reports a claim lost before the outcome was saved", func(t *testing.T) {
+		s := newTestReminderService(t)
+		task := &taskEntity.Task{ID: uuid.New(), Status: "todo", StartDateTime: lo.ToPtr(time.Now().Add(5 * time.Minute).Truncate(time.Second))}
+		reminder := dueReminder(task, 1)
+
+		s.repo.EXPECT().ClaimDueReminders(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Reminder{reminder}, nil)
+		s.taskRepo.EXPECT().GetTaskByID(ctx, task.ID).Return(task, nil)
+		s.repo.EXPECT().FinishClaim(ctx, reminder, gomock.Any()).Return(false, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeLost, results[0].Outcome)
+	})
+}
@@ -0,0 +1,16 @@
+//go:generate go run go.uber.org/mock/mockgen -source=$GOFILE -destination=../../mocks/search_repository.go -package=mocks
+package search
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SearchRepository interface {
+	// Search returns the hits of kinds in the projects of the account, best
+	// ranked first, and how many there are in all
+	Search(ctx context.Context, accountID uuid.UUID, query Query, kinds []string, limit, offset int) ([]*Hit, int, error)
+	// Stems returns the English lexemes of text, as matched by Search
+	Stems(ctx context.Context, text string) ([]string, error)
+}
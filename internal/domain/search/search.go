@@ -0,0 +1,225 @@
+package search
+
+import (
+	"html"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of hits
+const (
+	KindTask    = "task"
+	KindProject = "project"
+)
+
+// Kinds lists every kind of hit
+var Kinds = []string{KindTask, KindProject}
+
+const (
+	// MinQueryLength and MaxQueryLength bound the query, in characters
+	MinQueryLength = 2
+	MaxQueryLength = 200
+	// MaxTerms is how many words of the query are matched as substrings
+	MaxTerms = 8
+	// SnippetLength is roughly how many characters of a long field a
+	// highlight shows
+	SnippetLength = 160
+)
+
+// Highlight markers, around matches in HTML-escaped text
+const (
+	MarkStart = "<mark>"
+	MarkEnd   = "</mark>"
+)
+
+// Query is a normalised search. Text is matched as English full text, which
+// stems words and ranks by field. Terms are its words in lower case, each
+// matched as a substring, for languages written without spaces between words
+// such as Thai, and for partial words. Excluded are the words and phrases the
+// full-text syntax negates with a leading "-", which must not appear at all.
+type Query struct {
+	Text     string
+	Terms    []string
+	Excluded []string
+}
+
+// NewQuery normalises the whitespace of text and splits it into terms. ok is
+// false when it is too short or too long.
+func NewQuery(text string) (Query, bool) {
+	words := strings.Fields(text)
+	text = strings.Join(words, " ")
+	if n := utf8.RuneCountInString(text); n < MinQueryLength || n > MaxQueryLength {
+		return Query{}, false
+	}
+
+	var terms, excluded []string
+	for _, c := range splitClauses(text) {
+		if c.negated {
+			if term := toLower(c.text); term != "" && !slices.Contains(excluded, term) && len(excluded) < MaxTerms {
+				excluded = append(excluded, term)
+			}
+			continue
+		}
+		for _, w := range strings.Fields(c.text) {
+			// Quotes belong to the full-text syntax
+			term := toLower(strings.Trim(w, `"'-`))
+			if term == "" || slices.Contains(terms, term) || len(terms) == MaxTerms {
+				continue
+			}
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == 0 && len(excluded) == 0 {
+		return Query{}, false
+	}
+	return Query{Text: text, Terms: terms, Excluded: excluded}, true
+}
+
+// clause is a word or quoted phrase of a query
+type clause struct {
+	text    string
+	negated bool
+}
+
+// splitClauses splits text into words and quoted phrases the way
+// websearch_to_tsquery reads them, where a "-" in front negates one
+func splitClauses(text string) []clause {
+	var clauses []clause
+	for text != "" {
+		text = strings.TrimLeft(text, " ")
+		var c clause
+		if rest, ok := strings.CutPrefix(text, "-"); ok {
+			c.negated, text = true, rest
+		}
+
+		if rest, ok := strings.CutPrefix(text, `"`); ok {
+			c.text, text, _ = strings.Cut(rest, `"`)
+		} else {
+			c.text, text, _ = strings.Cut(text, " ")
+		}
+		c.text = strings.TrimSpace(strings.Trim(c.text, `"'`))
+		if c.text != "" {
+			clauses = append(clauses, c)
+		}
+	}
+	return clauses
+}
+
+// Hit is a task or project matching a query; the task fields are empty on
+// projects
+type Hit struct {
+	Kind        string
+	ID          uuid.UUID
+	ProjectID   uuid.UUID
+	Name        string
+	Description *string
+	Location    *string
+	Status      *string
+	Rank        float64
+	UpdatedAt   time.Time
+}
+
+// Highlight returns text HTML-escaped, with the matches of terms and stems
+// between MarkStart and MarkEnd. Terms match anywhere; stems, the English
+// lexemes of the query, match at the start of a word and mark all of it. A
+// text longer than maxLength is cut down to a snippet around the first match.
+// ok is false when nothing matched.
+func Highlight(text string, terms, stems []string, maxLength int) (string, bool) {
+	runes := []rune(text)
+	lower := []rune(toLower(text))
+
+	var matches [][2]int
+	for _, term := range terms {
+		t := []rune(term)
+		for i := index(lower, t, 0); i >= 0; i = index(lower, t, i+1) {
+			matches = append(matches, [2]int{i, i + len(t)})
+		}
+	}
+	for _, stem := range stems {
+		s := []rune(stem)
+		for i := index(lower, s, 0); i >= 0; i = index(lower, s, i+1) {
+			if i > 0 && isWordRune(lower[i-1]) {
+				continue
+			}
+			end := i + len(s)
+			for end < len(lower) && isWordRune(lower[end]) {
+				end++
+			}
+			matches = append(matches, [2]int{i, end})
+		}
+	}
+	matches = merge(matches)
+
+	start, end := 0, len(runes)
+	if maxLength > 0 && len(runes) > maxLength {
+		if len(matches) > 0 {
+			// Leave some context before the first match
+			start = max(0, matches[0][0]-maxLength/4)
+		}
+		end = min(len(runes), start+maxLength)
+		start = max(0, end-maxLength)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range matches {
+		from, to := max(m[0], start), min(m[1], end)
+		if from >= to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:from])))
+		b.WriteString(MarkStart)
+		b.WriteString(html.EscapeString(string(runes[from:to])))
+		b.WriteString(MarkEnd)
+		pos = to
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), len(matches) > 0
+}
+
+// toLower lowers text rune by rune, so that it keeps its length in runes
+func toLower(text string) string {
+	return strings.Map(unicode.ToLower, text)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// index returns the position of sub in s from from on, or -1
+func index(s, sub []rune, from int) int {
+	if len(sub) == 0 {
+		return -1
+	}
+	for i := from; i+len(sub) <= len(s); i++ {
+		if slices.Equal(s[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// merge sorts the matches and joins the ones that overlap or touch
+func merge(matches [][2]int) [][2]int {
+	slices.SortFunc(matches, func(a, b [2]int) int { return a[0] - b[0] })
+	var merged [][2]int
+	for _, m := range matches {
+		if n := len(merged); n > 0 && m[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], m[1])
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQuery(t *testing.T) {
+	q, ok := NewQuery("  Quarterly   REPORT  report ")
+	require.True(t, ok)
+	assert.Equal(t, "Quarterly REPORT report", q.Text)
+	assert.Equal(t, []string{"quarterly", "report"}, q.Terms)
+
+	q, ok = NewQuery(`"budget review" -draft`)
+	require.True(t, ok)
+	assert.Equal(t, []string{"budget", "review"}, q.Terms)
+	assert.Equal(t, []string{"draft"}, q.Excluded)
+
+	// Negated words and phrases are excluded rather than required
+	q, ok = NewQuery(`meeting -Zoom -"status report" pre-sales`)
+	require.True(t, ok)
+	assert.Equal(t, []string{"meeting", "pre-sales"}, q.Terms)
+	assert.Equal(t, []string{"zoom", "status report"}, q.Excluded)
+
+	q, ok = NewQuery("ประชุมทีม")
+	require.True(t, ok)
+	assert.Equal(t, []string{"ประชุมทีม"}, q.Terms)
+
+	q, ok = NewQuery("a b c d e f g h i j")
+	require.True(t, ok)
+	assert.Len(t, q.Terms, MaxTerms)
+
+	for _, text := range []string{"", "   ", "a", `""`, strings.Repeat("x", MaxQueryLength+1)} {
+		_, ok := NewQuery(text)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		terms   []string
+		stems   []string
+		want    string
+		matched bool
+	}{
+		{
+			name:    "term in any case",
+			text:    "Write the Report",
+			terms:   []string{"report"},
+			want:    "Write the <mark>Report</mark>",
+			matched: true,
+		},
+		{
+			name:    "partial word",
+			text:    "Reporting",
+			terms:   []string{"port"},
+			want:    "Re<mark>port</mark>ing",
+			matched: true,
+		},
+		{
+			name:    "stem marks the whole word",
+			text:    "Ran running runs brunch",
+			stems:   []string{"run"},
+			want:    "Ran <mark>running</mark> <mark>runs</mark> brunch",
+			matched: true,
+		},
+		{
+			name:    "thai inside a run of text",
+			text:    "นัดประชุมทีมวันจันทร์",
+			terms:   []string{"ประชุม"},
+			want:    "นัด<mark>ประชุม</mark>ทีมวันจันทร์",
+			matched: true,
+		},
+		{
+			name:    "overlapping matches are merged",
+			text:    "database",
+			terms:   []string{"data", "tab"},
+			want:    "<mark>datab</mark>ase",
+			matched: true,
+		},
+		{
+			name:    "text is escaped",
+			text:    "<b>Fix</b> & ship",
+			terms:   []string{"fix"},
+			want:    "&lt;b&gt;<mark>Fix</mark>&lt;/b&gt; &amp; ship",
+			matched: true,
+		},
+		{
+			name:  "no match",
+			text:  "Plan <sprint>",
+			terms: []string{"budget"},
+			want:  "Plan &lt;sprint&gt;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, matched := Highlight(tt.text, tt.terms, tt.stems, 0)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
+
+func TestHighlight_Snippet(t *testing.T) {
+	text := strings.Repeat("lorem ", 50) + "budget" + strings.Repeat(" ipsum", 50)
+
+	got, matched := Highlight(text, []string{"budget"}, nil, 60)
+
+	require.True(t, matched)
+	assert.True(t, strings.HasPrefix(got, "…"))
+	assert.True(t, strings.HasSuffix(got, "…"))
+	assert.Contains(t, got, "<mark>budget</mark>")
+	assert.Equal(t, 60+2, len([]rune(strings.NewReplacer(MarkStart, "", MarkEnd, "").Replace(got))))
+
+	got, matched = Highlight(text, []string{"missing"}, nil, 60)
+
+	assert.False(t, matched)
+	assert.True(t, strings.HasPrefix(got, "lorem"))
+	assert.True(t, strings.HasSuffix(got, "…"))
+}
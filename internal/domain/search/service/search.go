@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/search"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+)
+
+// Fields of a hit that are highlighted
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldLocation    = "location"
+)
+
+// Match is a hit with its highlights, the HTML of the fields that matched.
+// The name is always highlighted, as a hit may match on its other fields only.
+type Match struct {
+	*search.Hit
+	Highlights map[string]string
+}
+
+type SearchService struct {
+	repo search.SearchRepository
+}
+
+func NewSearchService(repo search.SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search finds the tasks and projects of the account matching text, of kinds
+// or of every kind when none are given
+func (s *SearchService) Search(ctx context.Context, accountID uuid.UUID, text string, kinds []string, limit, offset int) ([]*Match, int, error) {
+	query, ok := search.NewQuery(text)
+	if !ok {
+		msg := fmt.Sprintf("q must be %d to %d characters", search.MinQueryLength, search.MaxQueryLength)
+		return nil, 0, apperror.NewBadRequestError(msg, "INVALID_SEARCH_QUERY", nil)
+	}
+	if len(kinds) == 0 {
+		kinds = search.Kinds
+	}
+
+	hits, total, err := s.repo.Search(ctx, accountID, query, kinds, limit, offset)
+	if err != nil {
+		return nil, 0, apperror.NewInternalServerError("failed to search", "SEARCH_ERROR", err)
+	}
+	if len(hits) == 0 {
+		return []*Match{}, total, nil
+	}
+
+	// The full-text matches are on stems, which need not appear in the text as
+	// typed; excluded words are left out, as no hit holds them
+	stems, err := s.repo.Stems(ctx, strings.Join(query.Terms, " "))
+	if err != nil {
+		return nil, 0, apperror.NewInternalServerError("failed to search", "SEARCH_ERROR", err)
+	}
+
+	matches := make([]*Match, 0, len(hits))
+	for _, hit := range hits {
+		matches = append(matches, highlight(hit, query.Terms, stems))
+	}
+	return matches, total, nil
+}
+
+func highlight(hit *search.Hit, terms, stems []string) *Match {
+	name, _ := search.Highlight(hit.Name, terms, stems, 0)
+	m := &Match{Hit: hit, Highlights: map[string]string{FieldName: name}}
+
+	if hit.Description != nil {
+		if h, ok := search.Highlight(*hit.Description, terms, stems, search.SnippetLength); ok {
+			m.Highlights[FieldDescription] = h
+		}
+	}
+	if hit.Location != nil {
+		if h, ok := search.Highlight(*hit.Location, terms, stems, 0); ok {
+			m.Highlights[FieldLocation] = h
+		}
+	}
+	return m
+}
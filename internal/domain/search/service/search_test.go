@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/search"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+
+	t.Run("highlights the matching fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockSearchRepository(ctrl)
+		svc := NewSearchService(repo)
+
+		query := search.Query{Text: "running ประชุม", Terms: []string{"running", "ประชุม"}}
+		hits := []*search.Hit{
+			{Kind: search.KindTask, ID: uuid.New(), Name: "Morning run", Description: lo.ToPtr("นัดประชุมทีม"), Location: lo.ToPtr("Office")},
+			{Kind: search.KindProject, ID: uuid.New(), Name: "Runs & races"},
+		}
+		repo.EXPECT().Search(ctx, accountID, query, search.Kinds, 10, 0).Return(hits, 2, nil)
+		repo.EXPECT().Stems(ctx, "running ประชุม").Return([]string{"run", "ประชุม"}, nil)
+
+		matches, total, err := svc.Search(ctx, accountID, " running  ประชุม ", nil, 10, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, matches, 2)
+		assert.Equal(t, map[string]string{
+			FieldName:        "Morning <mark>run</mark>",
+			FieldDescription: "นัด<mark>ประชุม</mark>ทีม",
+		}, matches[0].Highlights)
+		assert.Equal(t, map[string]string{
+			FieldName: "<mark>Runs</mark> &amp; races",
+		}, matches[1].Highlights)
+	})
+
+	t.Run("leaves excluded words out", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockSearchRepository(ctrl)
+		svc := NewSearchService(repo)
+
+		query := search.Query{Text: "meeting -zoom", Terms: []string{"meeting"}, Excluded: []string{"zoom"}}
+		hits := []*search.Hit{{Kind: search.KindTask, ID: uuid.New(), Name: "Meeting room", Location: lo.ToPtr("Zoom")}}
+		repo.EXPECT().Search(ctx, accountID, query, search.Kinds, 10, 0).Return(hits, 1, nil)
+		repo.EXPECT().Stems(ctx, "meeting").Return([]string{"meet"}, nil)
+
+		matches, _, err := svc.Search(ctx, accountID, "meeting -zoom", nil, 10, 0)
+
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, map[string]string{FieldName: "<mark>Meeting</mark> room"}, matches[0].Highlights)
+	})
+
+	t.Run("filters by kind", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockSearchRepository(ctrl)
+		svc := NewSearchService(repo)
+
+		repo.EXPECT().Search(ctx, accountID, gomock.Any(), []string{search.KindProject}, 10, 20).Return(nil, 20, nil)
+
+		matches, total, err := svc.Search(ctx, accountID, "budget", []string{search.KindProject}, 10, 20)
+
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+		assert.Equal(t, 20, total)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		svc := NewSearchService(mocks.NewMockSearchRepository(ctrl))
+
+		_, _, err := svc.Search(ctx, accountID, " x ", nil, 10, 0)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "INVALID_SEARCH_QUERY", appErr.Code)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockSearchRepository(ctrl)
+		svc := NewSearchService(repo)
+
+		repo.EXPECT().Search(ctx, accountID, gomock.Any(), gomock.Any(), 10, 0).Return(nil, 0, errors.New("db down"))
+
+		_, _, err := svc.Search(ctx, accountID, "budget", nil, 10, 0)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "SEARCH_ERROR", appErr.Code)
+	})
+}
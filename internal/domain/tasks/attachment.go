@@ -0,0 +1,73 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAttachmentBytes is the largest single file that can be attached to a task
+	MaxAttachmentBytes = 25 << 20
+
+	// DefaultProjectAttachmentQuota is the total size of attachments allowed per project
+	DefaultProjectAttachmentQuota = 500 << 20
+
+	maxAttachmentNameLength = 255
+)
+
+// ErrProjectQuotaExceeded is returned when an attachment would not fit the storage quota of its project
+var ErrProjectQuotaExceeded = errors.New("project storage quota exceeded")
+
+// AttachmentKey is the storage key of an attachment
+func AttachmentKey(projectID, taskID, attachmentID uuid.UUID) string {
+	return fmt.Sprintf("attachments/%s/%s/%s", projectID, taskID, attachmentID)
+}
+
+// SanitizeFileName strips directories and control characters from a client
+// supplied file name. It returns "" when nothing usable is left.
+func SanitizeFileName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = filepath.Base(strings.TrimSpace(name))
+	if name == "." || name == "/" || name == ".." {
+		return ""
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+
+	if runes := []rune(name); len(runes) > maxAttachmentNameLength {
+		ext := []rune(filepath.Ext(name))
+		if len(ext) > 16 {
+			ext = nil
+		}
+		name = string(runes[:maxAttachmentNameLength-len(ext)]) + string(ext)
+	}
+
+	return strings.TrimSpace(name)
+}
+
+// DetectContentType sniffs the MIME type from the first bytes of the file.
+// The extension is only consulted when the content itself is inconclusive.
+func DetectContentType(head []byte, fileName string) string {
+	sniffed := http.DetectContentType(head)
+	if sniffed != "application/octet-stream" {
+		return sniffed
+	}
+
+	if byExt := mime.TypeByExtension(strings.ToLower(filepath.Ext(fileName))); byExt != "" {
+		return byExt
+	}
+
+	return sniffed
+}
@@ -0,0 +1,173 @@
+package tasks
+
+import (
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/google/uuid"
+)
+
+const (
+	// ConflictHorizon is how far ahead the occurrences of a recurring task are checked
+	ConflictHorizon = 365 * 24 * time.Hour
+
+	// MaxConflicts bounds the tasks reported for one check
+	MaxConflicts = 10
+
+	// maxCheckedOccurrences bounds the work for a task repeating every day
+	maxCheckedOccurrences = 400
+)
+
+// Schedule is the time block of a task, repeating every Every until Until when Every is set
+type Schedule struct {
+	Start time.Time
+	End   time.Time
+	Every time.Duration
+	// Until is the last time an occurrence may start; zero repeats forever
+	Until time.Time
+}
+
+// Conflict is another task overlapping the one being scheduled
+type Conflict struct {
+	TaskID    uuid.UUID
+	ProjectID uuid.UUID
+	Name      string
+	// Start and End are the overlapping occurrence of the other task
+	Start time.Time
+	End   time.Time
+	// OccurrenceStart is the occurrence of the scheduled task it overlaps
+	OccurrenceStart time.Time
+}
+
+// ScheduleOf returns the schedule of a task with both a start and an end.
+// Tasks with a deadline only, or a start only, occupy no time and cannot conflict.
+// Neither can all-day tasks, which mark a date rather than block time in it.
+func ScheduleOf(t *entity.Task) (Schedule, bool) {
+	if t.AllDay || t.StartDateTime == nil || t.EndDateTime == nil || !t.EndDateTime.After(*t.StartDateTime) {
+		return Schedule{}, false
+	}
+
+	s := Schedule{Start: *t.StartDateTime, End: *t.EndDateTime}
+	if t.RecurringDays != nil && *t.RecurringDays > 0 {
+		s.Every = time.Duration(*t.RecurringDays) * 24 * time.Hour
+		if t.RecurringUntil != nil {
+			s.Until = *t.RecurringUntil
+		}
+	}
+	return s, true
+}
+
+// FindConflicts returns the tasks in others whose occurrences overlap an
+// occurrence of s. Occurrences of a recurring s are checked from now up to
+// ConflictHorizon ahead. Task taskID itself and completed tasks are skipped.
+// At most MaxConflicts tasks are returned, each with its first overlap.
+func FindConflicts(s Schedule, taskID uuid.UUID, others []*entity.Task, now time.Time) []Conflict {
+	type candidate struct {
+		task     *entity.Task
+		schedule Schedule
+	}
+	candidates := make([]candidate, 0, len(others))
+	for _, t := range others {
+		if t.ID == taskID || t.Status == StatusDone {
+			continue
+		}
+		if other, ok := ScheduleOf(t); ok {
+			candidates = append(candidates, candidate{task: t, schedule: other})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var conflicts []Conflict
+	found := make(map[uuid.UUID]bool)
+	for _, occurrence := range s.occurrences(now) {
+		for _, c := range candidates {
+			if found[c.task.ID] {
+				continue
+			}
+			start, ok := c.schedule.overlapping(occurrence.Start, occurrence.End)
+			if !ok {
+				continue
+			}
+
+			found[c.task.ID] = true
+			conflicts = append(conflicts, Conflict{
+				TaskID:          c.task.ID,
+				ProjectID:       c.task.ProjectID,
+				Name:            c.task.Name,
+				Start:           start,
+				End:             start.Add(c.schedule.End.Sub(c.schedule.Start)),
+				OccurrenceStart: occurrence.Start,
+			})
+			if len(conflicts) == MaxConflicts {
+				return conflicts
+			}
+		}
+	}
+	return conflicts
+}
+
+type interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// occurrences lists the occurrences to check: a one-off block as is, a series
+// from the first occurrence not over by now until the horizon
+func (s Schedule) occurrences(now time.Time) []interval {
+	duration := s.End.Sub(s.Start)
+	if s.Every <= 0 {
+		return []interval{{Start: s.Start, End: s.End}}
+	}
+
+	k := int64(0)
+	if now.After(s.End) {
+		k = floorDiv(int64(now.Sub(s.End)), int64(s.Every)) + 1
+	}
+	last := now.Add(ConflictHorizon)
+	if s.Start.After(now) {
+		last = s.Start.Add(ConflictHorizon)
+	}
+	if !s.Until.IsZero() && s.Until.Before(last) {
+		last = s.Until
+	}
+
+	var result []interval
+	for ; len(result) < maxCheckedOccurrences; k++ {
+		start := s.Start.Add(time.Duration(k) * s.Every)
+		if start.After(last) {
+			break
+		}
+		result = append(result, interval{Start: start, End: start.Add(duration)})
+	}
+	return result
+}
+
+// overlapping returns the start of the first occurrence of s overlapping
+// [start, end); blocks that only touch do not overlap
+func (s Schedule) overlapping(start, end time.Time) (time.Time, bool) {
+	duration := s.End.Sub(s.Start)
+	if s.Every <= 0 {
+		return s.Start, s.Start.Before(end) && start.Before(s.End)
+	}
+
+	// The first occurrence k ending after start: Start + k*Every + duration > start
+	k := floorDiv(int64(start.Sub(s.Start)-duration), int64(s.Every)) + 1
+	if k < 0 {
+		k = 0
+	}
+	occurrence := s.Start.Add(time.Duration(k) * s.Every)
+	if !occurrence.Before(end) || (!s.Until.IsZero() && occurrence.After(s.Until)) {
+		return time.Time{}, false
+	}
+	return occurrence, true
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,186 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func timePtr(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func newScheduledTask(name, start, end string) *entity.Task {
+	return &entity.Task{ID: uuid.New(), ProjectID: uuid.New(), Name: name, Status: "todo", StartDateTime: timePtr(start), EndDateTime: timePtr(end)}
+}
+
+func TestScheduleOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		task     *entity.Task
+		ok       bool
+		expected Schedule
+	}{
+		{name: "no times", task: &entity.Task{}},
+		{name: "deadline only", task: &entity.Task{EndDateTime: timePtr("2026-03-02T10:00:00Z")}},
+		{
+			name: "all-day",
+			task: func() *entity.Task {
+				tsk := newScheduledTask("", "2026-03-02T00:00:00Z", "2026-03-03T00:00:00Z")
+				tsk.AllDay = true
+				return tsk
+			}(),
+		},
+		{name: "end before start", task: newScheduledTask("", "2026-03-02T10:00:00Z", "2026-03-02T09:00:00Z")},
+		{
+			name: "one-off",
+			task: newScheduledTask("", "2026-03-02T09:00:00+07:00", "2026-03-02T10:00:00+07:00"),
+			ok:   true,
+			expected: Schedule{
+				Start: time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "weekly until",
+			task: func() *entity.Task {
+				tsk := newScheduledTask("", "2026-03-02T02:00:00Z", "2026-03-02T03:00:00Z")
+				tsk.RecurringDays = lo.ToPtr(7)
+				tsk.RecurringUntil = timePtr("2026-04-01T00:00:00Z")
+				return tsk
+			}(),
+			ok: true,
+			expected: Schedule{
+				Start: time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC),
+				Every: 7 * 24 * time.Hour,
+				Until: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := ScheduleOf(tt.task)
+
+			require.Equal(t, tt.ok, ok)
+			if ok {
+				assert.True(t, tt.expected.Start.Equal(s.Start))
+				assert.True(t, tt.expected.End.Equal(s.End))
+				assert.Equal(t, tt.expected.Every, s.Every)
+				assert.True(t, tt.expected.Until.Equal(s.Until))
+			}
+		})
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	weekly := func(tsk *entity.Task, until string) *entity.Task {
+		tsk.RecurringDays = lo.ToPtr(7)
+		if until != "" {
+			tsk.RecurringUntil = timePtr(until)
+		}
+		return tsk
+	}
+	monday := newScheduledTask("Monday meeting", "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z")
+
+	tests := []struct {
+		name     string
+		schedule *entity.Task
+		others   []*entity.Task
+		expected []string
+		validate func(t *testing.T, conflicts []Conflict)
+	}{
+		{
+			name:     "overlap with a one-off task",
+			schedule: newScheduledTask("", "2026-03-02T09:30:00Z", "2026-03-02T10:30:00Z"),
+			others:   []*entity.Task{monday},
+			expected: []string{"Monday meeting"},
+		},
+		{
+			name:     "adjacent blocks do not overlap",
+			schedule: newScheduledTask("", "2026-03-02T10:00:00Z", "2026-03-02T11:00:00Z"),
+			others:   []*entity.Task{monday},
+		},
+		{
+			name:     "overlap with a later occurrence of a series",
+			schedule: newScheduledTask("", "2026-03-23T09:45:00Z", "2026-03-23T11:00:00Z"),
+			others:   []*entity.Task{weekly(newScheduledTask("Weekly sync", "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z"), "")},
+			expected: []string{"Weekly sync"},
+			validate: func(t *testing.T, conflicts []Conflict) {
+				assert.Equal(t, time.Date(2026, 3, 23, 9, 0, 0, 0, time.UTC), conflicts[0].Start)
+				assert.Equal(t, time.Date(2026, 3, 23, 10, 0, 0, 0, time.UTC), conflicts[0].End)
+			},
+		},
+		{
+			name:     "series that ended before the task",
+			schedule: newScheduledTask("", "2026-03-23T09:45:00Z", "2026-03-23T11:00:00Z"),
+			others:   []*entity.Task{weekly(newScheduledTask("Weekly sync", "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z"), "2026-03-16T09:00:00Z")},
+		},
+		{
+			name:     "recurring task meets a one-off task weeks ahead",
+			schedule: weekly(newScheduledTask("", "2026-03-02T14:00:00Z", "2026-03-02T15:00:00Z"), ""),
+			others:   []*entity.Task{newScheduledTask("Dentist", "2026-04-13T14:30:00Z", "2026-04-13T15:30:00Z")},
+			expected: []string{"Dentist"},
+			validate: func(t *testing.T, conflicts []Conflict) {
+				assert.Equal(t, time.Date(2026, 4, 13, 14, 0, 0, 0, time.UTC), conflicts[0].OccurrenceStart)
+			},
+		},
+		{
+			name:     "daily and weekly series meet",
+			schedule: &entity.Task{ID: uuid.New(), StartDateTime: timePtr("2026-03-03T08:00:00Z"), EndDateTime: timePtr("2026-03-03T08:30:00Z"), RecurringDays: lo.ToPtr(1)},
+			others:   []*entity.Task{weekly(newScheduledTask("Gym", "2026-02-06T08:15:00Z", "2026-02-06T09:00:00Z"), "")},
+			expected: []string{"Gym"},
+			validate: func(t *testing.T, conflicts []Conflict) {
+				assert.Equal(t, time.Date(2026, 3, 6, 8, 15, 0, 0, time.UTC), conflicts[0].Start)
+			},
+		},
+		{
+			name:     "completed tasks, unscheduled tasks and the task itself are skipped",
+			schedule: monday,
+			others: []*entity.Task{
+				monday,
+				func() *entity.Task {
+					tsk := newScheduledTask("Done", "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z")
+					tsk.Status = StatusDone
+					return tsk
+				}(),
+				{ID: uuid.New(), Name: "Deadline", EndDateTime: timePtr("2026-03-02T09:30:00Z")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := ScheduleOf(tt.schedule)
+			require.True(t, ok)
+
+			conflicts := FindConflicts(s, tt.schedule.ID, tt.others, now)
+
+			assert.ElementsMatch(t, tt.expected, lo.Map(conflicts, func(c Conflict, _ int) string { return c.Name }))
+			if tt.validate != nil {
+				tt.validate(t, conflicts)
+			}
+		})
+	}
+}
+
+func TestFindConflicts_Limit(t *testing.T) {
+	others := make([]*entity.Task, MaxConflicts+5)
+	for i := range others {
+		others[i] = newScheduledTask("Busy", "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z")
+	}
+	s, _ := ScheduleOf(newScheduledTask("", "2026-03-02T09:00:00Z", "2026-03-02T10:00:00Z"))
+
+	assert.Len(t, FindConflicts(s, uuid.Nil, others, time.Now()), MaxConflicts)
+}
@@ -17,21 +17,22 @@ type Status struct {
 // Task represents the task data exposed via the HTTP API.
 // It is mapped from the domain/entity Task model.
 type Task struct {
-	ID             string    `json:"id"`
-	NodeID         string    `json:"nodeId"`
-	ProjectID      string    `json:"projectId"`
-	Name           string    `json:"name"`
-	Description    string    `json:"description"`
-	Priority       string    `json:"priority"`
-	StartDateTime  string    `json:"startDateTime"`
-	EndDateTime    string    `json:"endDateTime"`
-	Location       string    `json:"location"`
-	RecurringDays  int       `json:"recurringDays"`
-	RecurringUntil string    `json:"recurringUntil"`
-	Status         Status    `json:"status"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
-	DeletedAt      time.Time `json:"deletedAt"`
+	ID             string     `json:"id"`
+	NodeID         string     `json:"nodeId"`
+	ProjectID      string     `json:"projectId"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Priority       string     `json:"priority"`
+	StartDateTime  *time.Time `json:"startDateTime"`
+	EndDateTime    *time.Time `json:"endDateTime"`
+	AllDay         bool       `json:"allDay"`
+	Location       string     `json:"location"`
+	RecurringDays  int        `json:"recurringDays"`
+	RecurringUntil *time.Time `json:"recurringUntil"`
+	Status         Status     `json:"status"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	DeletedAt      time.Time  `json:"deletedAt"`
 }
 
 // FromTaskModel converts a domain/entity Task model to the HTTP Task DTO.
@@ -47,11 +48,12 @@ func FromTaskModel(p *entity.Task) *Task {
 		Name:           p.Name,
 		Description:    lo.FromPtr(p.Description),
 		Priority:       p.Priority,
-		StartDateTime:  lo.FromPtr(p.StartDateTime),
-		EndDateTime:    lo.FromPtr(p.EndDateTime),
+		StartDateTime:  p.StartDateTime,
+		EndDateTime:    p.EndDateTime,
+		AllDay:         p.AllDay,
 		Location:       lo.FromPtr(p.Location),
 		RecurringDays:  lo.FromPtr(p.RecurringDays),
-		RecurringUntil: lo.FromPtr(p.RecurringUntil),
+		RecurringUntil: p.RecurringUntil,
 		Status:         Status{Todo: p.Status, InProgess: p.Status, Review: p.Status, Done: p.Status},
 		CreatedAt:      p.CreatedAt,
 		UpdatedAt:      p.UpdatedAt,
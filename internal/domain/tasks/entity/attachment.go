@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const AttachmentIDPrefix = "att"
+
+// Attachment is a file uploaded to a task. The content lives in object storage
+// under StorageKey; ProjectID is denormalized so quotas can be summed per project.
+type Attachment struct {
+	ID          uuid.UUID `json:"id" gorm:"column:id;type:char(36);primaryKey"`
+	TaskID      uuid.UUID `json:"taskId" gorm:"column:task_id;type:char(36);index;not null"`
+	ProjectID   uuid.UUID `json:"projectId" gorm:"column:project_id;type:char(36);index;not null"`
+	UploadedBy  uuid.UUID `json:"uploadedBy" gorm:"column:uploaded_by;type:char(36);not null"`
+	FileName    string    `json:"fileName" gorm:"column:file_name;type:varchar(255);not null"`
+	ContentType string    `json:"contentType" gorm:"column:content_type;type:varchar(255);not null"`
+	Size        int64     `json:"size" gorm:"column:size;not null"`
+	Checksum    string    `json:"checksum" gorm:"column:checksum;type:char(64);not null"`
+	StorageKey  string    `json:"storageKey" gorm:"column:storage_key;type:varchar(512);uniqueIndex;not null"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"column:created_at;not null"`
+}
+
+func (Attachment) TableName() string {
+	return "task_attachments"
+}
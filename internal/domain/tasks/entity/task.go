@@ -10,21 +10,29 @@ import (
 const TaskIDPrefix = "tsk"
 
 type Task struct {
-	ID             uuid.UUID      `json:"id" gorm:"column:id"`
-	NodeID         *uuid.UUID     `json:"nodeId" gorm:"column:node_id"`
-	ProjectID      uuid.UUID      `json:"projectId" gorm:"column:project_id"`
-	Name           string         `json:"name" gorm:"column:name"`
-	Description    *string        `json:"description" gorm:"column:description"`
-	Priority       string         `json:"priority" gorm:"column:priority"`
-	StartDateTime  *string        `json:"startDateTime" gorm:"column:start_datetime"`
-	EndDateTime    *string        `json:"endDateTime" gorm:"column:end_datetime"`
-	Location       *string        `json:"location" gorm:"column:location"`
-	RecurringDays  *int           `json:"recurringDays" gorm:"column:recurring_days"`
-	RecurringUntil *string        `json:"recurringUntil" gorm:"column:recurring_until"`
-	Status         string         `json:"status" gorm:"column:status"`
-	CreatedAt      time.Time      `json:"createdAt" gorm:"column:created_at"`
-	UpdatedAt      time.Time      `json:"updatedAt" gorm:"column:updated_at"`
-	DeletedAt      gorm.DeletedAt `json:"deletedAt" gorm:"column:deleted_at;index"`
+	ID            uuid.UUID  `json:"id" gorm:"column:id;type:char(36);primaryKey"`
+	NodeID        *uuid.UUID `json:"nodeId" gorm:"column:node_id;type:char(36)"`
+	ProjectID     uuid.UUID  `json:"projectId" gorm:"column:project_id;type:char(36);index;not null"`
+	Name          string     `json:"name" gorm:"column:name;type:varchar(255);not null"`
+	Description   *string    `json:"description" gorm:"column:description;type:text"`
+	Priority      string     `json:"priority" gorm:"column:priority;type:varchar(16);not null"`
+	StartDateTime *time.Time `json:"startDateTime" gorm:"column:start_datetime;type:timestamptz"`
+	EndDateTime   *time.Time `json:"endDateTime" gorm:"column:end_datetime;type:timestamptz"`
+	// AllDay tasks span whole dates: their times are midnight UTC of the first
+	// day and of the day after the last, whatever the zone of the viewer
+	AllDay         bool       `json:"allDay" gorm:"column:all_day;not null;default:false"`
+	Location       *string    `json:"location" gorm:"column:location;type:varchar(255)"`
+	RecurringDays  *int       `json:"recurringDays" gorm:"column:recurring_days;type:integer"`
+	RecurringUntil *time.Time `json:"recurringUntil" gorm:"column:recurring_until;type:timestamptz"`
+	Status         string     `json:"status" gorm:"column:status;type:varchar(32);not null;default:'todo'"`
+	// ExternalUID is the iCalendar UID of an imported task, used to skip it on re-import
+	ExternalUID *string `json:"externalUid" gorm:"column:external_uid;type:varchar(255)"`
+	// CalDAVName is the resource name a CalDAV client created the task under
+	CalDAVName  *string        `json:"-" gorm:"column:caldav_name;type:varchar(255)"`
+	CompletedAt *time.Time     `json:"completedAt" gorm:"column:completed_at;type:timestamptz"`
+	CreatedAt   time.Time      `json:"createdAt" gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time      `json:"updatedAt" gorm:"column:updated_at;not null"`
+	DeletedAt   gorm.DeletedAt `json:"deletedAt" gorm:"column:deleted_at;index"`
 }
 
 func (Task) TableName() string {
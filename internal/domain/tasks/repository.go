@@ -3,6 +3,7 @@ package tasks
 
 import (
 	"context"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	"github.com/google/uuid"
@@ -10,9 +11,30 @@ import (
 
 type TaskRepository interface {
 	CreateTask(ctx context.Context, task *entity.Task) error
+	// CreateTasks inserts every task in a single statement, so either all or none are created
+	CreateTasks(ctx context.Context, tasks []*entity.Task) error
 	GetTaskByID(ctx context.Context, taskID uuid.UUID) (*entity.Task, error)
+	GetTaskByCalDAVName(ctx context.Context, projectID uuid.UUID, name string) (*entity.Task, error)
 	ListTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.Task, error)
+	// ListTasksByAccount returns the tasks of every project the account owns
+	ListTasksByAccount(ctx context.Context, accountID uuid.UUID) ([]*entity.Task, error)
+	// ListExternalUIDs returns which of uids already belong to tasks of the project
+	ListExternalUIDs(ctx context.Context, projectID uuid.UUID, uids []string) ([]string, error)
 	CountTasksByProject(ctx context.Context, projectID uuid.UUID) (int64, error)
 	UpdateTask(ctx context.Context, task *entity.Task) error
 	DeleteTask(ctx context.Context, taskID uuid.UUID) error
+	GetTaskStats(ctx context.Context, projectID uuid.UUID, now, dueSoonUntil time.Time) (*TaskStats, error)
+	GetTaskBurndown(ctx context.Context, projectID uuid.UUID, from, to time.Time) ([]BurndownPoint, error)
+}
+
+type AttachmentRepository interface {
+	// CreateAttachmentWithinQuota inserts the attachment unless the attachments of its project
+	// would then exceed quota bytes, in which case it returns ErrProjectQuotaExceeded. Concurrent
+	// uploads to one project are serialized, so together they cannot exceed the quota either.
+	// It returns the bytes the project used before the insert.
+	CreateAttachmentWithinQuota(ctx context.Context, attachment *entity.Attachment, quota int64) (int64, error)
+	GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*entity.Attachment, error)
+	ListAttachmentsByTask(ctx context.Context, taskID uuid.UUID) ([]*entity.Attachment, error)
+	SumAttachmentSizeByProject(ctx context.Context, projectID uuid.UUID) (int64, error)
+	DeleteAttachment(ctx context.Context, attachmentID uuid.UUID) error
 }
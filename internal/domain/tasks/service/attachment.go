@@ -0,0 +1,272 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+)
+
+type AttachmentService struct {
+	repo     tasks.AttachmentRepository
+	taskRepo tasks.TaskRepository
+	storage  storage.Storage
+	quota    int64
+}
+
+// NewAttachmentService creates the service; quota is the total number of
+// bytes each project may store, or DefaultProjectAttachmentQuota when <= 0.
+func NewAttachmentService(repo tasks.AttachmentRepository, taskRepo tasks.TaskRepository, store storage.Storage, quota int64) *AttachmentService {
+	if quota <= 0 {
+		quota = tasks.DefaultProjectAttachmentQuota
+	}
+	return &AttachmentService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		storage:  store,
+		quota:    quota,
+	}
+}
+
+// UploadAttachment streams r into storage while computing its checksum and
+// MIME type. size is the length declared by the client and must match the body.
+func (s *AttachmentService) UploadAttachment(ctx context.Context, taskID, uploaderID uuid.UUID, fileName string, size int64, r io.Reader) (*entity.Attachment, error) {
+	fileName = tasks.SanitizeFileName(fileName)
+	if fileName == "" {
+		return nil, apperror.NewBadRequestError("file name is required", "INVALID_FILE_NAME", nil)
+	}
+	if size <= 0 {
+		return nil, apperror.NewBadRequestError("file must not be empty", "EMPTY_ATTACHMENT", nil)
+	}
+	if size > tasks.MaxAttachmentBytes {
+		return nil, apperror.NewPayloadTooLargeError(
+			fmt.Sprintf("attachment must be at most %d MB", tasks.MaxAttachmentBytes>>20),
+			"ATTACHMENT_TOO_LARGE",
+			nil,
+		)
+	}
+
+	tsk, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rejects what cannot fit before the upload; the insert checks again against concurrent uploads
+	used, err := s.repo.SumAttachmentSizeByProject(ctx, tsk.ProjectID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to get project storage usage", "GET_STORAGE_USAGE_ERROR", err)
+	}
+	if used+size > s.quota {
+		return nil, s.quotaExceeded(used)
+	}
+
+	br := bufio.NewReaderSize(io.LimitReader(r, size+1), 512)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, apperror.NewBadRequestError("failed to read attachment", "INVALID_ATTACHMENT", nil)
+	}
+	contentType := tasks.DetectContentType(head, fileName)
+
+	hasher := sha256.New()
+	counter := &countingReader{r: io.TeeReader(br, hasher)}
+
+	attachment := &entity.Attachment{
+		ID:          uuid.New(),
+		TaskID:      tsk.ID,
+		ProjectID:   tsk.ProjectID,
+		UploadedBy:  uploaderID,
+		FileName:    fileName,
+		ContentType: contentType,
+		CreatedAt:   time.Now(),
+	}
+	attachment.StorageKey = tasks.AttachmentKey(tsk.ProjectID, tsk.ID, attachment.ID)
+
+	if err := s.storage.Put(ctx, attachment.StorageKey, counter, size, contentType); err != nil {
+		return nil, apperror.NewInternalServerError("failed to store attachment", "STORE_ATTACHMENT_ERROR", err)
+	}
+
+	if counter.n != size {
+		_ = s.storage.Delete(ctx, attachment.StorageKey)
+		return nil, apperror.NewBadRequestError("attachment size does not match the uploaded content", "ATTACHMENT_SIZE_MISMATCH", nil)
+	}
+
+	attachment.Size = counter.n
+	attachment.Checksum = hex.EncodeToString(hasher.Sum(nil))
+
+	if used, err := s.repo.CreateAttachmentWithinQuota(ctx, attachment, s.quota); err != nil {
+		_ = s.storage.Delete(ctx, attachment.StorageKey)
+		if errors.Is(err, tasks.ErrProjectQuotaExceeded) {
+			return nil, s.quotaExceeded(used)
+		}
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to create attachment", "CREATE_ATTACHMENT_ERROR", err)
+	}
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, taskID uuid.UUID) ([]*entity.Attachment, error) {
+	if _, err := s.getTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.ListAttachmentsByTask(ctx, taskID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list attachments", "LIST_ATTACHMENTS_ERROR", err)
+	}
+
+	return attachments, nil
+}
+
+// OpenAttachment returns the attachment metadata and its content. The caller must close the reader.
+func (s *AttachmentService) OpenAttachment(ctx context.Context, taskID, attachmentID uuid.UUID) (*entity.Attachment, io.ReadCloser, error) {
+	attachment, err := s.getAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, _, err := s.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, apperror.NewNotFoundError("attachment content not found", "ATTACHMENT_CONTENT_NOT_FOUND", nil)
+		}
+		return nil, nil, apperror.NewInternalServerError("failed to read attachment", "READ_ATTACHMENT_ERROR", err)
+	}
+
+	return attachment, body, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID, attachmentID uuid.UUID) error {
+	attachment, err := s.getAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteAttachment(ctx, attachment.ID); err != nil {
+		return apperror.NewInternalServerError("failed to delete attachment", "DELETE_ATTACHMENT_ERROR", err)
+	}
+
+	// The row is gone, so a leftover object only costs storage; report it without failing
+	if err := s.storage.Delete(ctx, attachment.StorageKey); err != nil {
+		return &OrphanedObjectsError{Keys: []string{attachment.StorageKey}, Err: err}
+	}
+
+	return nil
+}
+
+// DeleteTaskAttachments removes every attachment of a deleted task. A row is
+// only deleted once its object is, so a failed call can be retried.
+func (s *AttachmentService) DeleteTaskAttachments(ctx context.Context, taskID uuid.UUID) error {
+	attachments, err := s.repo.ListAttachmentsByTask(ctx, taskID)
+	if err != nil {
+		return apperror.NewInternalServerError("failed to list attachments", "LIST_ATTACHMENTS_ERROR", err)
+	}
+
+	var failed []string
+	var storageErr error
+	for _, a := range attachments {
+		if err := s.storage.Delete(ctx, a.StorageKey); err != nil {
+			failed = append(failed, a.StorageKey)
+			storageErr = err
+			continue
+		}
+		if err := s.repo.DeleteAttachment(ctx, a.ID); err != nil {
+			return apperror.NewInternalServerError("failed to delete attachment", "DELETE_ATTACHMENT_ERROR", err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d stored object(s): %w", len(failed), storageErr)
+	}
+
+	return nil
+}
+
+// HandleTaskEvent deletes the attachments of the task of a task.deleted event.
+// It handles the events of the outbox, so the attachments are removed once the
+// deletion commits and again until every object is gone.
+func (s *AttachmentService) HandleTaskEvent(ctx context.Context, event events.Event) error {
+	if event.Type != events.TaskDeleted {
+		return nil
+	}
+	taskID, err := events.TaskID(event)
+	if err != nil {
+		return err
+	}
+	return s.DeleteTaskAttachments(ctx, taskID)
+}
+
+// OrphanedObjectsError reports stored objects whose metadata was deleted but
+// which could not be removed from storage.
+type OrphanedObjectsError struct {
+	Keys []string
+	Err  error
+}
+
+func (e *OrphanedObjectsError) Error() string {
+	return fmt.Sprintf("failed to delete %d stored object(s): %v", len(e.Keys), e.Err)
+}
+
+func (e *OrphanedObjectsError) Unwrap() error {
+	return e.Err
+}
+
+func (s *AttachmentService) quotaExceeded(used int64) error {
+	return apperror.NewPayloadTooLargeError("project storage quota exceeded", "STORAGE_QUOTA_EXCEEDED", map[string]int64{
+		"used_bytes":  used,
+		"quota_bytes": s.quota,
+	})
+}
+
+func (s *AttachmentService) getTask(ctx context.Context, taskID uuid.UUID) (*entity.Task, error) {
+	tsk, err := s.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
+	}
+
+	return tsk, nil
+}
+
+func (s *AttachmentService) getAttachment(ctx context.Context, taskID, attachmentID uuid.UUID) (*entity.Attachment, error) {
+	attachment, err := s.repo.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("attachment not found", "ATTACHMENT_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to get attachment", "GET_ATTACHMENT_ERROR", err)
+	}
+
+	// Attachments are only reachable through the task they belong to
+	if attachment.TaskID != taskID {
+		return nil, apperror.NewNotFoundError("attachment not found", "ATTACHMENT_NOT_FOUND", nil)
+	}
+
+	return attachment, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
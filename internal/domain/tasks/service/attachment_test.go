@@ -0,0 +1,370 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// memoryStorage is an in-memory storage.Storage used by the attachment tests
+type memoryStorage struct {
+	mu        sync.Mutex
+	objects   map[string][]byte
+	deleteErr error
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{objects: make(map[string][]byte)}
+}
+
+func (m *memoryStorage) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	return nil
+}
+
+func (m *memoryStorage) Open(_ context.Context, key string) (io.ReadCloser, *storage.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, nil, storage.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), &storage.ObjectInfo{Size: int64(len(data))}, nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memoryStorage) URL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return "https://files.test/" + key, nil
+}
+
+func (m *memoryStorage) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.objects)
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	ctx := context.Background()
+	taskID := uuid.New()
+	projectID := uuid.New()
+	uploaderID := uuid.New()
+	tsk := &entity.Task{ID: taskID, ProjectID: projectID}
+
+	pngHeader := []byte("\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 32))
+	text := []byte("meeting notes\nline two\n")
+
+	tests := []struct {
+		name                string
+		fileName            string
+		content             []byte
+		declaredSize        int64
+		quota               int64
+		setupMock           func(repo *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository)
+		expectedError       string
+		expectedStatus      int
+		expectedName        string
+		expectedContentType string
+	}{
+		{
+			name:         "success - sniffs content type and stores checksum",
+			fileName:     "../../etc/diagram.bin",
+			content:      pngHeader,
+			declaredSize: int64(len(pngHeader)),
+			setupMock: func(repo *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByID(ctx, taskID).Return(tsk, nil).Times(1)
+				repo.EXPECT().SumAttachmentSizeByProject(ctx, projectID).Return(int64(0), nil).Times(1)
+				repo.EXPECT().CreateAttachmentWithinQuota(ctx, gomock.Any(), int64(tasks.DefaultProjectAttachmentQuota)).Return(int64(0), nil).Times(1)
+			},
+			expectedName:        "diagram.bin",
+			expectedContentType: "image/png",
+		},
+		{
+			name:         "success - text file",
+			fileName:     "notes.txt",
+			content:      text,
+			declaredSize: int64(len(text)),
+			setupMock: func(repo *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByID(ctx, taskID).Return(tsk, nil).Times(1)
+				repo.EXPECT().SumAttachmentSizeByProject(ctx, projectID).Return(int64(100), nil).Times(1)
+				repo.EXPECT().CreateAttachmentWithinQuota(ctx, gomock.Any(), int64(tasks.DefaultProjectAttachmentQuota)).Return(int64(0), nil).Times(1)
+			},
+			expectedName:        "notes.txt",
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:          "error - missing file name",
+			fileName:      "  ",
+			content:       text,
+			declaredSize:  int64(len(text)),
+			setupMock:     func(_ *mocks.MockAttachmentRepository, _ *mocks.MockTaskRepository) {},
+			expectedError: "file name is required",
+		},
+		{
+			name:           "error - file too large",
+			fileName:       "big.bin",
+			content:        text,
+			declaredSize:   tasks.MaxAttachmentBytes + 1,
+			setupMock:      func(_ *mocks.MockAttachmentRepository, _ *mocks.MockTaskRepository) {},
+			expectedError:  "attachment must be at most",
+			expectedStatus: 413,
+		},
+		{
+			name:         "error - task not found",
+			fileName:     "notes.txt",
+			content:      text,
+			declaredSize: int64(len(text)),
+			setupMock: func(_ *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByID(ctx, taskID).Return(nil, apperror.ErrRecordNotFound).Times(1)
+			},
+			expectedError: "task not found",
+		},
+		{
+			name:         "error - project quota exceeded",
+			fileName:     "notes.txt",
+			content:      text,
+			declaredSize: int64(len(text)),
+			quota:        1000,
+			setupMock: func(repo *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByID(ctx, taskID).Return(tsk, nil).Times(1)
+				repo.EXPECT().SumAttachmentSizeByProject(ctx, projectID).Return(int64(990), nil).Times(1)
+			},
+			expectedError:  "project storage quota exceeded",
+			expectedStatus: 413,
+		},
+		{
+			name:         "error - declared size does not match content",
+			fileName:     "notes.txt",
+			content:      text,
+			declaredSize: int64(len(text)) - 5,
+			setupMock: func(repo *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByID(ctx, taskID).Return(tsk, nil).Times(1)
+				repo.EXPECT().SumAttachmentSizeByProject(ctx, projectID).Return(int64(0), nil).Times(1)
+			},
+			expectedError: "attachment size does not match",
+		},
+		{
+			name:         "error - metadata insert fails and object is removed",
+			fileName:     "notes.txt",
+			content:      text,
+			declaredSize: int64(len(text)),
+			setupMock: func(repo *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByID(ctx, taskID).Return(tsk, nil).Times(1)
+				repo.EXPECT().SumAttachmentSizeByProject(ctx, projectID).Return(int64(0), nil).Times(1)
+				repo.EXPECT().CreateAttachmentWithinQuota(ctx, gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db down")).Times(1)
+			},
+			expectedError: "failed to create attachment",
+		},
+		{
+			name:         "error - concurrent upload filled the quota and object is removed",
+			fileName:     "notes.txt",
+			content:      text,
+			declaredSize: int64(len(text)),
+			quota:        1000,
+			setupMock: func(repo *mocks.MockAttachmentRepository, taskRepo *mocks.MockTaskRepository) {
+				taskRepo.EXPECT().GetTaskByID(ctx, taskID).Return(tsk, nil).Times(1)
+				repo.EXPECT().SumAttachmentSizeByProject(ctx, projectID).Return(int64(900), nil).Times(1)
+				repo.EXPECT().CreateAttachmentWithinQuota(ctx, gomock.Any(), int64(1000)).Return(int64(990), tasks.ErrProjectQuotaExceeded).Times(1)
+			},
+			expectedError:  "project storage quota exceeded",
+			expectedStatus: 413,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+			mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+			store := newMemoryStorage()
+			svc := NewAttachmentService(mockRepo, mockTaskRepo, store, tt.quota)
+
+			tt.setupMock(mockRepo, mockTaskRepo)
+
+			result, err := svc.UploadAttachment(ctx, taskID, uploaderID, tt.fileName, tt.declaredSize, bytes.NewReader(tt.content))
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				if tt.expectedStatus != 0 {
+					appErr, ok := apperror.IsAppError(err)
+					require.True(t, ok)
+					assert.Equal(t, tt.expectedStatus, appErr.Status)
+				}
+				assert.Nil(t, result)
+				assert.Equal(t, 0, store.len())
+				return
+			}
+
+			require.NoError(t, err)
+			sum := sha256.Sum256(tt.content)
+			assert.Equal(t, hex.EncodeToString(sum[:]), result.Checksum)
+			assert.Equal(t, int64(len(tt.content)), result.Size)
+			assert.Equal(t, tt.expectedName, result.FileName)
+			assert.Equal(t, tt.expectedContentType, result.ContentType)
+			assert.Equal(t, uploaderID, result.UploadedBy)
+			assert.Equal(t, projectID, result.ProjectID)
+			assert.Equal(t, tasks.AttachmentKey(projectID, taskID, result.ID), result.StorageKey)
+			assert.Equal(t, tt.content, store.objects[result.StorageKey])
+		})
+	}
+}
+
+func TestAttachmentService_OpenAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	store := newMemoryStorage()
+	svc := NewAttachmentService(mockRepo, mocks.NewMockTaskRepository(ctrl), store, 0)
+
+	taskID := uuid.New()
+	attachment := &entity.Attachment{ID: uuid.New(), TaskID: taskID, StorageKey: "attachments/p/t/a"}
+	store.objects[attachment.StorageKey] = []byte("content")
+
+	mockRepo.EXPECT().GetAttachmentByID(ctx, attachment.ID).Return(attachment, nil).Times(2)
+
+	_, body, err := svc.OpenAttachment(ctx, taskID, attachment.ID)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	// Attachments of other tasks are hidden
+	_, _, err = svc.OpenAttachment(ctx, uuid.New(), attachment.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "attachment not found")
+}
+
+func TestAttachmentService_DeleteTaskAttachments(t *testing.T) {
+	ctx := context.Background()
+	taskID := uuid.New()
+	first := &entity.Attachment{ID: uuid.New(), TaskID: taskID, StorageKey: "attachments/p/t/1"}
+	second := &entity.Attachment{ID: uuid.New(), TaskID: taskID, StorageKey: "attachments/p/t/2"}
+
+	tests := []struct {
+		name          string
+		attachments   []*entity.Attachment
+		deleteErr     error
+		setupMock     func(repo *mocks.MockAttachmentRepository)
+		expectedError string
+		remaining     int
+	}{
+		{
+			name:        "success - removes stored objects and rows",
+			attachments: []*entity.Attachment{first, second},
+			setupMock: func(repo *mocks.MockAttachmentRepository) {
+				repo.EXPECT().ListAttachmentsByTask(ctx, taskID).Return([]*entity.Attachment{first, second}, nil).Times(1)
+				repo.EXPECT().DeleteAttachment(ctx, first.ID).Return(nil).Times(1)
+				repo.EXPECT().DeleteAttachment(ctx, second.ID).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "success - nothing to delete",
+			setupMock: func(repo *mocks.MockAttachmentRepository) {
+				repo.EXPECT().ListAttachmentsByTask(ctx, taskID).Return(nil, nil).Times(1)
+			},
+		},
+		{
+			name:        "error - keeps the rows of objects left in storage",
+			attachments: []*entity.Attachment{first},
+			deleteErr:   errors.New("storage unavailable"),
+			setupMock: func(repo *mocks.MockAttachmentRepository) {
+				repo.EXPECT().ListAttachmentsByTask(ctx, taskID).Return([]*entity.Attachment{first}, nil).Times(1)
+			},
+			expectedError: "failed to delete 1 stored object(s)",
+			remaining:     1,
+		},
+		{
+			name:        "error - row not deleted",
+			attachments: []*entity.Attachment{first},
+			setupMock: func(repo *mocks.MockAttachmentRepository) {
+				repo.EXPECT().ListAttachmentsByTask(ctx, taskID).Return([]*entity.Attachment{first}, nil).Times(1)
+				repo.EXPECT().DeleteAttachment(ctx, first.ID).Return(errors.New("connection refused")).Times(1)
+			},
+			expectedError: "failed to delete attachment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+			store := newMemoryStorage()
+			store.deleteErr = tt.deleteErr
+			for _, a := range tt.attachments {
+				store.objects[a.StorageKey] = []byte("x")
+			}
+			svc := NewAttachmentService(mockRepo, mocks.NewMockTaskRepository(ctrl), store, 0)
+
+			tt.setupMock(mockRepo)
+
+			err := svc.DeleteTaskAttachments(ctx, taskID)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.remaining, store.len())
+		})
+	}
+}
+
+func TestAttachmentService_HandleTaskEvent(t *testing.T) {
+	ctx := context.Background()
+	task := &entity.Task{ID: uuid.New(), ProjectID: uuid.New()}
+	newEvent := func(eventType string) events.Event {
+		event, err := events.NewEvent(eventType, task.ProjectID, events.TaskEventData{Task: events.NewTaskData(task)})
+		require.NoError(t, err)
+		return event
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	svc := NewAttachmentService(mockRepo, mocks.NewMockTaskRepository(ctrl), newMemoryStorage(), 0)
+
+	// Only task.deleted reaches the repository
+	mockRepo.EXPECT().ListAttachmentsByTask(ctx, task.ID).Return(nil, nil).Times(1)
+
+	require.NoError(t, svc.HandleTaskEvent(ctx, newEvent(events.TaskDeleted)))
+	require.NoError(t, svc.HandleTaskEvent(ctx, newEvent(events.TaskUpdated)))
+}
@@ -8,8 +8,11 @@ import (
 	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 )
@@ -17,45 +20,54 @@ import (
 type TaskService struct {
 	repo        tasks.TaskRepository
 	projectRepo projects.ProjectRepository
+	outbox      events.Outbox
 }
 
-func NewTaskService(repo tasks.TaskRepository, projectRepo projects.ProjectRepository) *TaskService {
+// NewTaskService creates the service; every task created, updated or deleted
+// through it is recorded in outbox along with the change
+func NewTaskService(repo tasks.TaskRepository, projectRepo projects.ProjectRepository, outbox events.Outbox) *TaskService {
 	return &TaskService{
 		repo:        repo,
 		projectRepo: projectRepo,
+		outbox:      outbox,
 	}
 }
 
-func (s *TaskService) CreateTask(ctx context.Context, projectID uuid.UUID, req *task.CreateTaskRequest) (*entity.Task, error) {
+// CreateTask returns the tasks of the account the new task overlaps, or fails
+// with SCHEDULE_CONFLICT when the project blocks double-booking
+func (s *TaskService) CreateTask(ctx context.Context, projectID uuid.UUID, req *task.CreateTaskRequest) (*entity.Task, []tasks.Conflict, error) {
 	if req == nil {
-		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+		return nil, nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
 	}
 
 	// Validate that project exists
-	_, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	proj, err := s.getProject(ctx, projectID)
 	if err != nil {
-		if errors.Is(err, apperror.ErrRecordNotFound) {
-			return nil, apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", err)
-		}
-		return nil, apperror.NewInternalServerError("failed to validate project", "VALIDATE_PROJECT_ERROR", err)
+		return nil, nil, err
 	}
 
-	if err := s.validateTimeRange(req.StartDateTime, req.EndDateTime); err != nil {
-		return nil, err
+	times, err := parseTaskTimes(req.StartDateTime, req.EndDateTime, req.RecurringUntil, req.AllDay)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := validateTimeRange(times.start, times.end); err != nil {
+		return nil, nil, err
 	}
 
 	// create domain entity
 	now := time.Now()
 	task := &entity.Task{
-		ID:            uuid.New(),
-		ProjectID:     projectID,
-		Name:          req.Name,
-		Priority:      req.Priority,
-		StartDateTime: req.StartDateTime,
-		EndDateTime:   req.EndDateTime,
-		Status:        "todo",
-		CreatedAt:     now,
-		UpdatedAt:     now,
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		Name:           req.Name,
+		Priority:       req.Priority,
+		StartDateTime:  times.start,
+		EndDateTime:    times.end,
+		AllDay:         req.AllDay,
+		RecurringUntil: times.until,
+		Status:         "todo",
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 
 	if req.Description != nil {
@@ -70,17 +82,61 @@ func (s *TaskService) CreateTask(ctx context.Context, projectID uuid.UUID, req *
 		task.RecurringDays = req.RecurringDays
 	}
 
-	if req.RecurringUntil != nil {
-		task.RecurringUntil = req.RecurringUntil
+	conflicts, err := s.create(ctx, proj, task)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// persist account to database
-	err = s.repo.CreateTask(ctx, task)
+	return task, conflicts, nil
+}
+
+// AddTask creates t, built by the caller rather than from a request, such as
+// the task of a calendar object. Conflicts are checked like in CreateTask.
+func (s *TaskService) AddTask(ctx context.Context, t *entity.Task) ([]tasks.Conflict, error) {
+	proj, err := s.getProject(ctx, t.ProjectID)
 	if err != nil {
-		return nil, apperror.NewInternalServerError("failed to create task", "CREATE_TASK_ERROR", err)
+		return nil, err
 	}
 
-	return task, nil
+	return s.create(ctx, proj, t)
+}
+
+// ImportTasks creates the tasks that build returns, given which of uids the
+// project already holds as external UIDs, in one transaction. Conflicts are
+// not checked. The project is locked meanwhile, so that imports of the same
+// events running at once create their tasks once.
+func (s *TaskService) ImportTasks(ctx context.Context, projectID uuid.UUID, uids []string, build func(existing []string) ([]*entity.Task, error)) error {
+	err := s.outbox.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.projectRepo.LockProject(ctx, projectID); err != nil {
+			if errors.Is(err, apperror.ErrRecordNotFound) {
+				return apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", err)
+			}
+			return apperror.NewInternalServerError("failed to lock project", "LOCK_PROJECT_ERROR", err)
+		}
+
+		existing, err := s.repo.ListExternalUIDs(ctx, projectID, uids)
+		if err != nil {
+			return apperror.NewInternalServerError("failed to check imported tasks", "CHECK_IMPORTED_TASKS_ERROR", err)
+		}
+		list, err := build(existing)
+		if err != nil || len(list) == 0 {
+			return err
+		}
+
+		if err := s.repo.CreateTasks(ctx, list); err != nil {
+			return apperror.NewInternalServerError("failed to create tasks", "CREATE_TASKS_ERROR", err)
+		}
+		for _, t := range list {
+			if err := s.recordEvent(ctx, events.TaskCreated, t, ""); err != nil {
+				return apperror.NewInternalServerError("failed to create tasks", "CREATE_TASKS_ERROR", err)
+			}
+		}
+		return nil
+	})
+	if _, ok := apperror.IsAppError(err); err != nil && !ok {
+		return apperror.NewInternalServerError("failed to create tasks", "CREATE_TASKS_ERROR", err)
+	}
+	return err
 }
 
 func (s *TaskService) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*entity.Task, error) {
@@ -104,31 +160,50 @@ func (s *TaskService) ListTasksByProject(ctx context.Context, projectID uuid.UUI
 	return tasks, nil
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, taskID uuid.UUID, req *task.UpdateTaskRequest) (*entity.Task, error) {
+// UpdateTask checks for conflicts like CreateTask, but only when the schedule changes
+func (s *TaskService) UpdateTask(ctx context.Context, taskID uuid.UUID, req *task.UpdateTaskRequest) (*entity.Task, []tasks.Conflict, error) {
 	// Get task by ID for update
 	tsk, err := s.repo.GetTaskByID(ctx, taskID)
 	if err != nil {
 		if errors.Is(err, apperror.ErrRecordNotFound) {
-			return nil, apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", err)
+			return nil, nil, apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", err)
 		}
-		return nil, apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
+		return nil, nil, apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
 	}
 
 	// Rule: If status != todo, cannot change start_datetime
 	if tsk.Status != "todo" && req.StartDateTime != nil {
-		return nil, apperror.NewBadRequestError("cannot update start_datetime when status is not todo", "INVALID_REQUEST", nil)
+		return nil, nil, apperror.NewBadRequestError("cannot update start_datetime when status is not todo", "INVALID_REQUEST", nil)
 	}
 
-	// Additional validation same as CreateTask
-	if err := s.validateTimeRange(req.StartDateTime, req.EndDateTime); err != nil {
-		return nil, err
+	allDay := tsk.AllDay
+	if req.AllDay != nil {
+		allDay = *req.AllDay
+	}
+	// The stored times only make sense in the mode they were written for
+	if allDay != tsk.AllDay && req.StartDateTime == nil {
+		return nil, nil, apperror.NewBadRequestError("start_datetime is required when changing all_day", "INVALID_REQUEST", nil)
+	}
+
+	times, err := parseTaskTimes(req.StartDateTime, req.EndDateTime, req.RecurringUntil, allDay)
+	if err != nil {
+		return nil, nil, err
 	}
+	rescheduled := req.StartDateTime != nil || req.EndDateTime != nil || req.RecurringDays != nil || req.RecurringUntil != nil || allDay != tsk.AllDay
+	previousStatus := tsk.Status
 
 	// Update fields only if provided (PATCH semantics)
+	now := time.Now()
 	if req.Name != "" {
 		tsk.Name = req.Name
 	}
 	if req.Status != nil {
+		// Track when the task was completed for stats and burndown charts
+		if *req.Status == tasks.StatusDone && tsk.Status != tasks.StatusDone {
+			tsk.CompletedAt = &now
+		} else if *req.Status != tasks.StatusDone {
+			tsk.CompletedAt = nil
+		}
 		tsk.Status = *req.Status
 	}
 	if req.Description != nil {
@@ -144,26 +219,45 @@ func (s *TaskService) UpdateTask(ctx context.Context, taskID uuid.UUID, req *tas
 		tsk.RecurringDays = req.RecurringDays
 	}
 	if req.RecurringUntil != nil {
-		tsk.RecurringUntil = req.RecurringUntil
+		tsk.RecurringUntil = times.until
 	}
 	if req.StartDateTime != nil {
-		tsk.StartDateTime = req.StartDateTime
+		tsk.StartDateTime = times.start
 	}
-	if req.EndDateTime != nil {
-		tsk.EndDateTime = req.EndDateTime
+	if req.EndDateTime != nil || allDay != tsk.AllDay {
+		tsk.EndDateTime = times.end
+	}
+	tsk.AllDay = allDay
+	tsk.UpdatedAt = now
+
+	// Checked after merging, so that moving one end past the other is caught too
+	if rescheduled {
+		if err := validateTimeRange(tsk.StartDateTime, tsk.EndDateTime); err != nil {
+			return nil, nil, err
+		}
 	}
-	tsk.UpdatedAt = time.Now()
 
-	err = s.repo.UpdateTask(ctx, tsk)
+	conflicts, err := s.update(ctx, tsk, previousStatus, rescheduled)
 	if err != nil {
-		return nil, apperror.NewInternalServerError("failed to update task", "UPDATE_TASK_ERROR", err)
+		return nil, nil, err
 	}
 
-	return tsk, nil
+	return tsk, conflicts, nil
+}
+
+// ReplaceTask saves t, a changed copy of the stored task previous built by
+// the caller, such as from a calendar object. Conflicts are checked like in
+// UpdateTask.
+func (s *TaskService) ReplaceTask(ctx context.Context, previous, t *entity.Task) ([]tasks.Conflict, error) {
+	before, wasScheduled := tasks.ScheduleOf(previous)
+	after, scheduled := tasks.ScheduleOf(t)
+	rescheduled := wasScheduled != scheduled || !sameSchedule(before, after)
+
+	return s.update(ctx, t, previous.Status, rescheduled)
 }
 
 func (s *TaskService) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
-	_, err := s.repo.GetTaskByID(ctx, taskID)
+	tsk, err := s.repo.GetTaskByID(ctx, taskID)
 	if err != nil {
 		if errors.Is(err, apperror.ErrRecordNotFound) {
 			return apperror.NewNotFoundError("task not found", "TASK_NOT_FOUND", err)
@@ -171,7 +265,12 @@ func (s *TaskService) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
 		return apperror.NewInternalServerError("failed to get task", "GET_TASK_ERROR", err)
 	}
 
-	err = s.repo.DeleteTask(ctx, taskID)
+	err = s.outbox.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteTask(ctx, taskID); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, events.TaskDeleted, tsk, "")
+	})
 	if err != nil {
 		return apperror.NewInternalServerError("failed to delete task", "DELETE_TASK_ERROR", err)
 	}
@@ -179,21 +278,196 @@ func (s *TaskService) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
 	return nil
 }
 
-func (s *TaskService) validateTimeRange(startStr, endStr *string) error {
-	if startStr != nil && endStr != nil {
-		start, err := time.Parse(time.RFC3339, *startStr)
-		if err != nil {
-			return apperror.NewBadRequestError("invalid start_datetime format", "INVALID_DATE_FORMAT", err)
+// FindConflicts returns the tasks of the account that t overlaps, including
+// occurrences of recurring tasks
+func (s *TaskService) FindConflicts(ctx context.Context, accountID uuid.UUID, t *entity.Task) ([]tasks.Conflict, error) {
+	conflicts, err := s.FindConflictsForEach(ctx, accountID, []*entity.Task{t})
+	if err != nil {
+		return nil, err
+	}
+	return conflicts[0], nil
+}
+
+// FindConflictsForEach is FindConflicts for several candidates, loading the
+// tasks of the account once. Conflicts are returned in the order of candidates.
+func (s *TaskService) FindConflictsForEach(ctx context.Context, accountID uuid.UUID, candidates []*entity.Task) ([][]tasks.Conflict, error) {
+	conflicts := make([][]tasks.Conflict, len(candidates))
+	schedules := make([]*tasks.Schedule, len(candidates))
+	for i, t := range candidates {
+		if schedule, ok := tasks.ScheduleOf(t); ok {
+			schedules[i] = &schedule
 		}
-		end, err := time.Parse(time.RFC3339, *endStr)
+	}
+	if lo.EveryBy(schedules, func(s *tasks.Schedule) bool { return s == nil }) {
+		return conflicts, nil
+	}
+
+	others, err := s.repo.ListTasksByAccount(ctx, accountID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list tasks", "LIST_TASKS_ERROR", err)
+	}
+
+	now := time.Now()
+	for i, schedule := range schedules {
+		if schedule != nil {
+			conflicts[i] = tasks.FindConflicts(*schedule, candidates[i].ID, others, now)
+		}
+	}
+	return conflicts, nil
+}
+
+// create checks the conflicts of t in proj and saves it along with its event
+func (s *TaskService) create(ctx context.Context, proj *projectEntity.Project, t *entity.Task) ([]tasks.Conflict, error) {
+	conflicts, err := s.checkConflicts(ctx, proj, t)
+	if err != nil {
+		return nil, err
+	}
+
+	// persist the task and its event together
+	err = s.outbox.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateTask(ctx, t); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, events.TaskCreated, t, "")
+	})
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to create task", "CREATE_TASK_ERROR", err)
+	}
+
+	return conflicts, nil
+}
+
+// update saves t along with its events, checking its conflicts when its
+// schedule changed
+func (s *TaskService) update(ctx context.Context, t *entity.Task, previousStatus string, rescheduled bool) ([]tasks.Conflict, error) {
+	var conflicts []tasks.Conflict
+	if _, scheduled := tasks.ScheduleOf(t); rescheduled && scheduled {
+		proj, err := s.getProject(ctx, t.ProjectID)
 		if err != nil {
-			return apperror.NewBadRequestError("invalid end_datetime format", "INVALID_DATE_FORMAT", err)
+			return nil, err
+		}
+		if conflicts, err = s.checkConflicts(ctx, proj, t); err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.outbox.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateTask(ctx, t); err != nil {
+			return err
+		}
+		if err := s.recordEvent(ctx, events.TaskUpdated, t, ""); err != nil {
+			return err
+		}
+		if t.Status != previousStatus {
+			return s.recordEvent(ctx, events.TaskStatusChanged, t, previousStatus)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to update task", "UPDATE_TASK_ERROR", err)
+	}
+
+	return conflicts, nil
+}
+
+// checkConflicts applies the conflict policy of the project to the conflicts of t
+func (s *TaskService) checkConflicts(ctx context.Context, proj *projectEntity.Project, t *entity.Task) ([]tasks.Conflict, error) {
+	conflicts, err := s.FindConflicts(ctx, proj.AccountID, t)
+	if err != nil || len(conflicts) == 0 {
+		return nil, err
+	}
+
+	// A config that no longer loads falls back to warnings rather than blocking every task
+	cfg, err := projects.LoadConfig(proj.Config)
+	if err == nil && cfg.ConflictPolicy() == projects.ConflictPolicyBlock {
+		return nil, apperror.NewConflictError("task overlaps other scheduled tasks", "SCHEDULE_CONFLICT", task.NewScheduleConflicts(conflicts, time.UTC))
+	}
+	return conflicts, nil
+}
+
+// recordEvent adds an event of a change to t to the outbox; previousStatus is
+// set for status changes
+func (s *TaskService) recordEvent(ctx context.Context, eventType string, t *entity.Task, previousStatus string) error {
+	event, err := events.NewEvent(eventType, t.ProjectID, events.TaskEventData{
+		Task:           events.NewTaskData(t),
+		PreviousStatus: previousStatus,
+	})
+	if err != nil {
+		return err
+	}
+	return s.outbox.Record(ctx, event)
+}
+
+func (s *TaskService) getProject(ctx context.Context, projectID uuid.UUID) (*projectEntity.Project, error) {
+	proj, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to validate project", "VALIDATE_PROJECT_ERROR", err)
+	}
+	return proj, nil
+}
+
+// taskTimes holds the parsed times of a request; a field not sent, or sent
+// empty, is nil
+type taskTimes struct {
+	start, end, until *time.Time
+}
+
+// parseTaskTimes parses RFC 3339 times, or dates for all-day tasks. The end
+// date of an all-day task is its last day, stored as midnight of the day after
+// it; without an end the task lasts one day.
+func parseTaskTimes(start, end, until *string, allDay bool) (taskTimes, error) {
+	parse := tasks.ParseTime
+	if allDay {
+		parse = tasks.ParseDate
+	}
+
+	var times taskTimes
+	var err error
+	if times.start, err = parseOptionalTime(parse, start); err != nil {
+		return times, apperror.NewBadRequestError("invalid start_datetime format", "INVALID_DATE_FORMAT", err)
+	}
+	if times.end, err = parseOptionalTime(parse, end); err != nil {
+		return times, apperror.NewBadRequestError("invalid end_datetime format", "INVALID_DATE_FORMAT", err)
+	}
+	if times.until, err = parseOptionalTime(parse, until); err != nil {
+		return times, apperror.NewBadRequestError("invalid recurring_until format", "INVALID_DATE_FORMAT", err)
+	}
+
+	if allDay {
+		switch {
+		case times.end != nil:
+			times.end = lo.ToPtr(times.end.AddDate(0, 0, 1))
+		case times.start != nil:
+			times.end = lo.ToPtr(times.start.AddDate(0, 0, 1))
 		}
+	}
+	return times, nil
+}
+
+func parseOptionalTime(parse func(string) (time.Time, error), s *string) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := parse(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func sameSchedule(a, b tasks.Schedule) bool {
+	return a.Start.Equal(b.Start) && a.End.Equal(b.End) && a.Every == b.Every && a.Until.Equal(b.Until)
+}
 
-		if start.Equal(end) {
+func validateTimeRange(start, end *time.Time) error {
+	if start != nil && end != nil {
+		if start.Equal(*end) {
 			return apperror.NewBadRequestError("start_datetime and end_datetime cannot be the same", "INVALID_REQUEST", nil)
 		}
-		if end.Before(start) {
+		if end.Before(*start) {
 			return apperror.NewBadRequestError("end_datetime must be greater than start_datetime", "INVALID_REQUEST", nil)
 		}
 	}
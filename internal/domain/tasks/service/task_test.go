@@ -2,16 +2,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/application/task"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
 	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
 	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -23,9 +26,10 @@ func TestTaskService_CreateTask(t *testing.T) {
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockProjectRepository(ctrl)
-	svc := NewTaskService(mockRepo, mockProjectRepo)
+	svc := NewTaskService(mockRepo, mockProjectRepo, anyEvents(ctrl))
 	ctx := context.Background()
 	projectID := uuid.New()
+	accountID := uuid.New()
 
 	// Helper to create time strings
 	now := time.Now()
@@ -56,7 +60,11 @@ func TestTaskService_CreateTask(t *testing.T) {
 			setupMock: func() {
 				mockProjectRepo.EXPECT().
 					GetProjectByID(ctx, projectID).
-					Return(&projectEntity.Project{ID: projectID}, nil).
+					Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					ListTasksByAccount(ctx, accountID).
+					Return(nil, nil).
 					Times(1)
 				mockRepo.EXPECT().
 					CreateTask(ctx, gomock.Any()).
@@ -239,7 +247,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			res, err := svc.CreateTask(ctx, tt.projectID, tt.request)
+			res, _, err := svc.CreateTask(ctx, tt.projectID, tt.request)
 
 			if tt.expectedError != "" {
 				require.Error(t, err)
@@ -266,7 +274,7 @@ func TestTaskService_GetTaskByID(t *testing.T) {
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockProjectRepository(ctrl)
-	svc := NewTaskService(mockRepo, mockProjectRepo)
+	svc := NewTaskService(mockRepo, mockProjectRepo, anyEvents(ctrl))
 	ctx := context.Background()
 	taskID := uuid.New()
 
@@ -349,7 +357,7 @@ func TestTaskService_UpdateTask(t *testing.T) {
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockProjectRepository(ctrl)
-	svc := NewTaskService(mockRepo, mockProjectRepo)
+	svc := NewTaskService(mockRepo, mockProjectRepo, anyEvents(ctrl))
 	ctx := context.Background()
 	taskID := uuid.New()
 
@@ -477,7 +485,7 @@ func TestTaskService_UpdateTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
-			res, err := svc.UpdateTask(ctx, tt.taskID, tt.request)
+			res, _, err := svc.UpdateTask(ctx, tt.taskID, tt.request)
 
 			if tt.expectedError != "" {
 				require.Error(t, err)
@@ -495,13 +503,332 @@ func TestTaskService_UpdateTask(t *testing.T) {
 	}
 }
 
+func TestTaskService_UpdateTask_CompletedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjectRepo := mocks.NewMockProjectRepository(ctrl)
+	svc := NewTaskService(mockRepo, mockProjectRepo, anyEvents(ctrl))
+	ctx := context.Background()
+	taskID := uuid.New()
+	completedAt := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		existing *entity.Task
+		status   string
+		validate func(t *testing.T, res *entity.Task)
+	}{
+		{
+			name:     "sets completed_at when task is done",
+			existing: &entity.Task{ID: taskID, Status: "todo"},
+			status:   "done",
+			validate: func(t *testing.T, res *entity.Task) {
+				require.NotNil(t, res.CompletedAt)
+				assert.WithinDuration(t, time.Now(), *res.CompletedAt, time.Minute)
+			},
+		},
+		{
+			name:     "keeps original completed_at when already done",
+			existing: &entity.Task{ID: taskID, Status: "done", CompletedAt: &completedAt},
+			status:   "done",
+			validate: func(t *testing.T, res *entity.Task) {
+				require.NotNil(t, res.CompletedAt)
+				assert.Equal(t, completedAt, *res.CompletedAt)
+			},
+		},
+		{
+			name:     "clears completed_at when task is reopened",
+			existing: &entity.Task{ID: taskID, Status: "done", CompletedAt: &completedAt},
+			status:   "todo",
+			validate: func(t *testing.T, res *entity.Task) {
+				assert.Nil(t, res.CompletedAt)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo.EXPECT().
+				GetTaskByID(ctx, taskID).
+				Return(tt.existing, nil).
+				Times(1)
+			mockRepo.EXPECT().
+				UpdateTask(ctx, gomock.Any()).
+				Return(nil).
+				Times(1)
+
+			status := tt.status
+			res, _, err := svc.UpdateTask(ctx, taskID, &task.UpdateTaskRequest{Status: &status})
+
+			require.NoError(t, err)
+			tt.validate(t, res)
+		})
+	}
+}
+
+func TestTaskService_UpdateTask_Events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+	svc := NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mockOutbox)
+	ctx := context.Background()
+	type txKey struct{}
+	txCtx := context.WithValue(ctx, txKey{}, "tx")
+	projectID := uuid.New()
+	taskID := uuid.New()
+
+	var recorded []events.Event
+	mockRepo.EXPECT().GetTaskByID(ctx, taskID).Return(&entity.Task{ID: taskID, ProjectID: projectID, Status: "todo"}, nil)
+	// The task and its events are saved in the same transaction
+	mockOutbox.EXPECT().
+		WithinTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(context.Context) error) error { return fn(txCtx) })
+	mockRepo.EXPECT().UpdateTask(txCtx, gomock.Any()).Return(nil)
+	mockOutbox.EXPECT().
+		Record(txCtx, gomock.Any()).
+		Do(func(_ context.Context, list ...events.Event) { recorded = append(recorded, list...) }).
+		Times(2)
+
+	status := "done"
+	_, _, err := svc.UpdateTask(ctx, taskID, &task.UpdateTaskRequest{Status: &status})
+
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	assert.Equal(t, events.TaskUpdated, recorded[0].Type)
+	assert.Equal(t, events.TaskStatusChanged, recorded[1].Type)
+	assert.Equal(t, projectID, recorded[1].ProjectID)
+	var data events.TaskEventData
+	require.NoError(t, json.Unmarshal(recorded[1].Data, &data))
+	assert.Equal(t, "todo", data.PreviousStatus)
+	assert.Equal(t, "done", data.Task.Status)
+}
+
+func TestTaskService_DeleteTask_RecordFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockOutbox := mocks.NewMockOutbox(ctrl)
+	svc := NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), mockOutbox)
+	ctx := context.Background()
+	taskID := uuid.New()
+
+	mockRepo.EXPECT().GetTaskByID(ctx, taskID).Return(&entity.Task{ID: taskID, ProjectID: uuid.New()}, nil)
+	mockOutbox.EXPECT().
+		WithinTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	mockRepo.EXPECT().DeleteTask(ctx, taskID).Return(nil)
+	mockOutbox.EXPECT().Record(ctx, gomock.Any()).Return(errors.New("outbox unavailable"))
+
+	err := svc.DeleteTask(ctx, taskID)
+
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "DELETE_TASK_ERROR", appErr.Code)
+}
+
+func TestTaskService_ScheduleConflicts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjectRepo := mocks.NewMockProjectRepository(ctrl)
+	svc := NewTaskService(mockRepo, mockProjectRepo, anyEvents(ctrl))
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+
+	now := time.Now().Truncate(time.Second)
+	start := now.Add(time.Hour).Format(time.RFC3339)
+	end := now.Add(2 * time.Hour).Format(time.RFC3339)
+	existing := &entity.Task{
+		ID:            uuid.New(),
+		ProjectID:     uuid.New(),
+		Name:          "Dentist",
+		Status:        "todo",
+		StartDateTime: lo.ToPtr(now.Add(90 * time.Minute)),
+		EndDateTime:   lo.ToPtr(now.Add(3 * time.Hour)),
+	}
+	blocking := json.RawMessage(`{"version":1,"scheduling":{"conflicts":"block"}}`)
+
+	t.Run("create warns by default", func(t *testing.T) {
+		mockProjectRepo.EXPECT().
+			GetProjectByID(ctx, projectID).
+			Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+		mockRepo.EXPECT().ListTasksByAccount(ctx, accountID).Return([]*entity.Task{existing}, nil)
+		mockRepo.EXPECT().CreateTask(ctx, gomock.Any()).Return(nil)
+
+		res, conflicts, err := svc.CreateTask(ctx, projectID, &task.CreateTaskRequest{Name: "Meeting", StartDateTime: &start, EndDateTime: &end})
+
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		require.Len(t, conflicts, 1)
+		assert.Equal(t, existing.ID, conflicts[0].TaskID)
+	})
+
+	t.Run("create is blocked by project policy", func(t *testing.T) {
+		mockProjectRepo.EXPECT().
+			GetProjectByID(ctx, projectID).
+			Return(&projectEntity.Project{ID: projectID, AccountID: accountID, Config: blocking}, nil)
+		mockRepo.EXPECT().ListTasksByAccount(ctx, accountID).Return([]*entity.Task{existing}, nil)
+
+		res, conflicts, err := svc.CreateTask(ctx, projectID, &task.CreateTaskRequest{Name: "Meeting", StartDateTime: &start, EndDateTime: &end})
+
+		require.Error(t, err)
+		assert.Nil(t, res)
+		assert.Nil(t, conflicts)
+		appErr, ok := apperror.IsAppError(err)
+		require.True(t, ok)
+		assert.Equal(t, "SCHEDULE_CONFLICT", appErr.Code)
+	})
+
+	t.Run("update checks rescheduled task", func(t *testing.T) {
+		taskID := uuid.New()
+		mockRepo.EXPECT().
+			GetTaskByID(ctx, taskID).
+			Return(&entity.Task{ID: taskID, ProjectID: projectID, Status: "todo", EndDateTime: lo.ToPtr(now.Add(2 * time.Hour))}, nil)
+		mockProjectRepo.EXPECT().
+			GetProjectByID(ctx, projectID).
+			Return(&projectEntity.Project{ID: projectID, AccountID: accountID}, nil)
+		mockRepo.EXPECT().ListTasksByAccount(ctx, accountID).Return([]*entity.Task{existing}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, gomock.Any()).Return(nil)
+
+		_, conflicts, err := svc.UpdateTask(ctx, taskID, &task.UpdateTaskRequest{StartDateTime: &start})
+
+		require.NoError(t, err)
+		assert.Len(t, conflicts, 1)
+	})
+
+	t.Run("update without schedule change is not checked", func(t *testing.T) {
+		taskID := uuid.New()
+		mockRepo.EXPECT().
+			GetTaskByID(ctx, taskID).
+			Return(&entity.Task{ID: taskID, ProjectID: projectID, Status: "todo", StartDateTime: lo.ToPtr(now.Add(time.Hour)), EndDateTime: lo.ToPtr(now.Add(2 * time.Hour))}, nil)
+		mockRepo.EXPECT().UpdateTask(ctx, gomock.Any()).Return(nil)
+
+		_, conflicts, err := svc.UpdateTask(ctx, taskID, &task.UpdateTaskRequest{Name: "Renamed"})
+
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+	})
+}
+
+func TestParseTaskTimes(t *testing.T) {
+	utc := func(y int, m time.Month, d, h int) *time.Time {
+		return lo.ToPtr(time.Date(y, m, d, h, 0, 0, 0, time.UTC))
+	}
+
+	tests := []struct {
+		name          string
+		start         *string
+		end           *string
+		until         *string
+		allDay        bool
+		expected      taskTimes
+		expectedError string
+	}{
+		{
+			name:     "times keep their instant",
+			start:    strPtr("2026-03-02T09:00:00+07:00"),
+			end:      strPtr("2026-03-02T10:00:00+07:00"),
+			expected: taskTimes{start: utc(2026, 3, 2, 2), end: utc(2026, 3, 2, 3)},
+		},
+		{
+			name:     "empty values are unset",
+			start:    strPtr(""),
+			expected: taskTimes{},
+		},
+		{
+			name:          "dates need all_day",
+			start:         strPtr("2026-03-02"),
+			expectedError: "invalid start_datetime format",
+		},
+		{
+			name:     "all-day without end lasts one day",
+			start:    strPtr("2026-03-02"),
+			allDay:   true,
+			expected: taskTimes{start: utc(2026, 3, 2, 0), end: utc(2026, 3, 3, 0)},
+		},
+		{
+			name:     "all-day ends after its last day",
+			start:    strPtr("2026-03-02"),
+			end:      strPtr("2026-03-04T23:00:00-05:00"),
+			until:    strPtr("2026-06-01"),
+			allDay:   true,
+			expected: taskTimes{start: utc(2026, 3, 2, 0), end: utc(2026, 3, 5, 0), until: utc(2026, 6, 1, 0)},
+		},
+		{
+			name:          "invalid recurring_until",
+			until:         strPtr("soon"),
+			expectedError: "invalid recurring_until format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, err := parseTaskTimes(tt.start, tt.end, tt.until, tt.allDay)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			for _, pair := range [][2]*time.Time{{tt.expected.start, times.start}, {tt.expected.end, times.end}, {tt.expected.until, times.until}} {
+				if pair[0] == nil {
+					assert.Nil(t, pair[1])
+				} else if assert.NotNil(t, pair[1]) {
+					assert.True(t, pair[0].Equal(*pair[1]), "expected %s, got %s", pair[0], pair[1])
+				}
+			}
+		})
+	}
+}
+
+func TestTaskService_UpdateTask_TimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	svc := NewTaskService(mockRepo, mocks.NewMockProjectRepository(ctrl), anyEvents(ctrl))
+	ctx := context.Background()
+	taskID := uuid.New()
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("moving the end before the stored start fails", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetTaskByID(ctx, taskID).
+			Return(&entity.Task{ID: taskID, Status: "todo", StartDateTime: &start}, nil)
+
+		_, _, err := svc.UpdateTask(ctx, taskID, &task.UpdateTaskRequest{EndDateTime: strPtr("2026-03-02T08:00:00Z")})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "end_datetime must be greater than start_datetime")
+	})
+
+	t.Run("changing all_day needs a start", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetTaskByID(ctx, taskID).
+			Return(&entity.Task{ID: taskID, Status: "todo", StartDateTime: &start}, nil)
+
+		_, _, err := svc.UpdateTask(ctx, taskID, &task.UpdateTaskRequest{AllDay: lo.ToPtr(true)})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "start_datetime is required when changing all_day")
+	})
+}
+
 func TestTaskService_DeleteTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockProjectRepository(ctrl)
-	svc := NewTaskService(mockRepo, mockProjectRepo)
+	svc := NewTaskService(mockRepo, mockProjectRepo, anyEvents(ctrl))
 	ctx := context.Background()
 	taskID := uuid.New()
 
@@ -587,7 +914,7 @@ func TestTaskService_ListTasksByProject(t *testing.T) {
 
 	mockRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockProjectRepository(ctrl)
-	svc := NewTaskService(mockRepo, mockProjectRepo)
+	svc := NewTaskService(mockRepo, mockProjectRepo, anyEvents(ctrl))
 	ctx := context.Background()
 	projectID := uuid.New()
 
@@ -661,3 +988,14 @@ func TestTaskService_ListTasksByProject(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+// anyEvents runs the transactions of the service and accepts whatever it records
+func anyEvents(ctrl *gomock.Controller) *mocks.MockOutbox {
+	outbox := mocks.NewMockOutbox(ctrl)
+	outbox.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	outbox.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+	return outbox
+}
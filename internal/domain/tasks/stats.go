@@ -0,0 +1,34 @@
+package tasks
+
+import "time"
+
+// StatusDone is the status a task reaches once it is completed
+const StatusDone = "done"
+
+// GroupCount is the number of tasks sharing the same value of a grouped column
+type GroupCount struct {
+	Key   string
+	Count int64
+}
+
+// TaskStats is an aggregate snapshot of the tasks in a project
+type TaskStats struct {
+	Total      int64
+	Completed  int64
+	Overdue    int64
+	DueSoon    int64
+	ByStatus   []GroupCount
+	ByPriority []GroupCount
+}
+
+// BurndownPoint is the state of a project at the end of a single day
+type BurndownPoint struct {
+	Date      time.Time
+	Scope     int64
+	Completed int64
+}
+
+// Remaining returns the number of tasks still open at the end of the day
+func (p BurndownPoint) Remaining() int64 {
+	return p.Scope - p.Completed
+}
@@ -0,0 +1,31 @@
+package tasks
+
+import "time"
+
+// DateLayout is how the dates of all-day tasks are written
+const DateLayout = "2006-01-02"
+
+// ParseTime parses a task time, which must carry its offset (RFC 3339)
+func ParseTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, s)
+}
+
+// ParseDate parses the date of an all-day task, written as YYYY-MM-DD or as an
+// RFC 3339 time whose local date is taken
+func ParseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(DateLayout, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return StartOfDate(t), nil
+}
+
+// StartOfDate returns midnight UTC of the date of t in its own zone, which is
+// how all-day tasks store their dates
+func StartOfDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
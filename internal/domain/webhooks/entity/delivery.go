@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const DeliveryIDPrefix = "whd"
+
+// Delivery is an event posted, or to be posted, to one subscription. It keeps
+// the outcome of its last attempt; NextAttemptAt is when a pending delivery is
+// tried next. A redelivery is a new delivery of the same event and payload.
+type Delivery struct {
+	ID             uuid.UUID  `gorm:"column:id;type:char(36);primaryKey"`
+	SubscriptionID uuid.UUID  `gorm:"column:subscription_id;type:char(36);index;not null"`
+	EventID        uuid.UUID  `gorm:"column:event_id;type:char(36);not null"`
+	Event          string     `gorm:"column:event;type:varchar(64);not null"`
+	Payload        string     `gorm:"column:payload;type:text;not null"`
+	RedeliveryOf   *uuid.UUID `gorm:"column:redelivery_of;type:char(36)"`
+	Status         string     `gorm:"column:status;type:varchar(16);not null"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at"`
+	LockedUntil    *time.Time `gorm:"column:locked_until"`
+	ResponseStatus *int       `gorm:"column:response_status"`
+	// ResponseBody is the start of the body of the last response
+	ResponseBody *string    `gorm:"column:response_body;type:text"`
+	DurationMs   *int       `gorm:"column:duration_ms"`
+	LastError    *string    `gorm:"column:last_error;type:text"`
+	DeliveredAt  *time.Time `gorm:"column:delivered_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;not null"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+const SubscriptionIDPrefix = "whk"
+
+// Subscription posts the events of a project to URL. Events lists the event
+// types delivered, or "*" for every type. Secret signs the payloads and is
+// only shown when the subscription is created.
+type Subscription struct {
+	ID          uuid.UUID `gorm:"column:id;type:char(36);primaryKey"`
+	ProjectID   uuid.UUID `gorm:"column:project_id;type:char(36);index;not null"`
+	AccountID   uuid.UUID `gorm:"column:account_id;type:char(36);not null"`
+	URL         string    `gorm:"column:url;type:varchar(2048);not null"`
+	Description *string   `gorm:"column:description;type:varchar(255)"`
+	Events      []string  `gorm:"column:events;type:jsonb;serializer:json;not null"`
+	Secret      string    `gorm:"column:secret;type:varchar(64);not null"`
+	Active      bool      `gorm:"column:active;not null;default:true"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Matches reports whether the subscription receives events of eventType
+func (s *Subscription) Matches(eventType string) bool {
+	return s.Active && (lo.Contains(s.Events, "*") || lo.Contains(s.Events, eventType))
+}
@@ -0,0 +1,35 @@
+//go:generate go run go.uber.org/mock/mockgen -source=$GOFILE -destination=../../mocks/webhook_repository.go -package=mocks
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/entity"
+	"github.com/google/uuid"
+)
+
+type SubscriptionRepository interface {
+	CreateSubscription(ctx context.Context, subscription *entity.Subscription) error
+	GetSubscriptionByID(ctx context.Context, subscriptionID uuid.UUID) (*entity.Subscription, error)
+	ListSubscriptionsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription *entity.Subscription) error
+	// DeleteSubscription deletes the subscription and its delivery log
+	DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error
+}
+
+type DeliveryRepository interface {
+	// CreateDeliveries skips deliveries of an event that is already queued for
+	// their subscription; redeliveries are always created
+	CreateDeliveries(ctx context.Context, deliveries []*entity.Delivery) error
+	GetDeliveryByID(ctx context.Context, deliveryID uuid.UUID) (*entity.Delivery, error)
+	// ListDeliveriesBySubscription returns a page of deliveries, newest first, and the total
+	ListDeliveriesBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*entity.Delivery, int, error)
+	// ClaimDueDeliveries marks up to limit deliveries that are due at now, or
+	// whose claim has expired, as sending until lockedUntil and increments their
+	// attempts. Rows locked by another worker are skipped.
+	ClaimDueDeliveries(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.Delivery, error)
+	// FinishClaim saves a claimed delivery; it reports false when the claim was
+	// lost, i.e. the row is no longer sending until claimedUntil
+	FinishClaim(ctx context.Context, delivery *entity.Delivery, claimedUntil time.Time) (bool, error)
+}
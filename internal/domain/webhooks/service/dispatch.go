@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// Outcomes of dispatching a claimed delivery
+const (
+	OutcomeSucceeded = "succeeded"
+	// OutcomeRetry is a failed attempt that is tried again later
+	OutcomeRetry  = "retry"
+	OutcomeFailed = "failed"
+	// OutcomeSkipped is a delivery whose subscription was disabled or deleted
+	OutcomeSkipped = "skipped"
+	// OutcomeLost is a delivery whose claim expired before the outcome was saved
+	OutcomeLost = "lost"
+)
+
+// leaseMargin is the time left on a claim below which an attempt is not started
+const leaseMargin = 30 * time.Second
+
+// DispatchResult is the outcome of one claimed delivery
+type DispatchResult struct {
+	DeliveryID uuid.UUID
+	Event      string
+	Outcome    string
+	Err        error
+}
+
+// Publish queues a delivery of event to every active subscription of its
+// project that accepts its type. The deliveries are posted by DispatchDue.
+// It handles the events of the outbox; an event published again queues no
+// second delivery to the same subscription.
+func (s *WebhookService) Publish(ctx context.Context, event events.Event) error {
+	subscriptions, err := s.repo.ListSubscriptionsByProject(ctx, event.ProjectID)
+	if err != nil {
+		return fmt.Errorf("list webhooks: %w", err)
+	}
+	subscriptions = lo.Filter(subscriptions, func(sub *entity.Subscription, _ int) bool { return sub.Matches(event.Type) })
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(webhooks.NewPayload(event))
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := lo.Map(subscriptions, func(sub *entity.Subscription, _ int) *entity.Delivery {
+		return newDelivery(sub.ID, event.ID, event.Type, string(payload), now)
+	})
+	if err := s.deliveryRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("create deliveries: %w", err)
+	}
+	return nil
+}
+
+// DispatchDue claims up to limit due deliveries for lease and posts them.
+//
+// As with reminders, a delivery is posted by the one worker holding its claim
+// and the outcome is only saved while the claim is held. A worker that dies
+// mid-attempt leaves the delivery to be claimed again once the lease expires,
+// so receivers should de-duplicate on X-Webhook-Delivery.
+func (s *WebhookService) DispatchDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]DispatchResult, error) {
+	// The claim is matched on locked_until, which Postgres stores in microseconds
+	lockedUntil := now.Add(lease).Truncate(time.Microsecond)
+
+	claimed, err := s.deliveryRepo.ClaimDueDeliveries(ctx, now, lockedUntil, limit)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to claim webhook deliveries", "CLAIM_WEBHOOK_DELIVERIES_ERROR", err)
+	}
+
+	// Subscriptions are looked up once per batch
+	subscriptions := make(map[uuid.UUID]*entity.Subscription)
+	results := make([]DispatchResult, 0, len(claimed))
+	for _, delivery := range claimed {
+		// The rest are claimed again by whichever worker is free once the lease expires
+		if time.Now().After(lockedUntil.Add(-leaseMargin)) {
+			break
+		}
+		results = append(results, s.dispatch(ctx, delivery, subscriptions, lockedUntil))
+	}
+	return results, nil
+}
+
+func (s *WebhookService) dispatch(ctx context.Context, delivery *entity.Delivery, subscriptions map[uuid.UUID]*entity.Subscription, lockedUntil time.Time) DispatchResult {
+	subscription, ok := subscriptions[delivery.SubscriptionID]
+	if !ok {
+		var err error
+		subscription, err = s.repo.GetSubscriptionByID(ctx, delivery.SubscriptionID)
+		switch {
+		case errors.Is(err, apperror.ErrRecordNotFound):
+			subscription = nil
+		case err != nil:
+			// Nothing was posted, so the attempt is not held against the delivery
+			delivery.Attempts--
+			retry(delivery, time.Now(), err)
+			return s.finish(ctx, delivery, lockedUntil, OutcomeRetry, err)
+		}
+		subscriptions[delivery.SubscriptionID] = subscription
+	}
+
+	if subscription == nil || !subscription.Active {
+		delivery.Status = webhooks.StatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = lo.ToPtr("webhook is disabled or deleted")
+		return s.finish(ctx, delivery, lockedUntil, OutcomeSkipped, nil)
+	}
+
+	err := s.post(ctx, subscription, delivery)
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = webhooks.StatusSucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+		return s.finish(ctx, delivery, lockedUntil, OutcomeSucceeded, nil)
+	case delivery.Attempts < webhooks.MaxAttempts:
+		retry(delivery, now, err)
+		return s.finish(ctx, delivery, lockedUntil, OutcomeRetry, err)
+	default:
+		delivery.Status = webhooks.StatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = lo.ToPtr(err.Error())
+		return s.finish(ctx, delivery, lockedUntil, OutcomeFailed, err)
+	}
+}
+
+// post sends one attempt of delivery, recording the response on it. Any
+// status other than 2xx is an error.
+func (s *WebhookService) post(ctx context.Context, subscription *entity.Subscription, delivery *entity.Delivery) error {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "smart-task-ai-webhooks")
+	req.Header.Set(webhooks.HeaderEvent, delivery.Event)
+	req.Header.Set(webhooks.HeaderDelivery, utils.ShortUUIDWithPrefix(delivery.ID, entity.DeliveryIDPrefix))
+	req.Header.Set(webhooks.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhooks.HeaderSignature, webhooks.Sign(subscription.Secret, timestamp, body))
+
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = nil
+	start := time.Now()
+	res, err := s.client.Do(req)
+	delivery.DurationMs = lo.ToPtr(int(time.Since(start).Milliseconds()))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	snippet, _ := io.ReadAll(io.LimitReader(res.Body, webhooks.MaxResponseBody))
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	delivery.ResponseStatus = lo.ToPtr(res.StatusCode)
+	if len(snippet) > 0 {
+		delivery.ResponseBody = lo.ToPtr(toValidUTF8(snippet))
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// retry schedules another attempt after a backoff
+func retry(delivery *entity.Delivery, now time.Time, err error) {
+	delivery.Status = webhooks.StatusPending
+	delivery.NextAttemptAt = lo.ToPtr(now.Add(webhooks.RetryDelay(delivery.Attempts)))
+	delivery.LastError = lo.ToPtr(err.Error())
+}
+
+// finish saves the outcome of a claimed delivery, unless the claim was lost
+func (s *WebhookService) finish(ctx context.Context, delivery *entity.Delivery, lockedUntil time.Time, outcome string, postErr error) DispatchResult {
+	result := DispatchResult{DeliveryID: delivery.ID, Event: delivery.Event, Outcome: outcome, Err: postErr}
+
+	delivery.LockedUntil = nil
+	delivery.UpdatedAt = time.Now()
+	saved, err := s.deliveryRepo.FinishClaim(ctx, delivery, lockedUntil)
+	if err != nil {
+		result.Err = errors.Join(postErr, err)
+	}
+	if err == nil && !saved {
+		result.Outcome = OutcomeLost
+	}
+	return result
+}
+
+// toValidUTF8 keeps a response body, possibly cut mid-rune, storable as text
+func toValidUTF8(b []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(b), "\uFFFD"), "\x00", "")
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/webhook"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/projects"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/safehttp"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type WebhookService struct {
+	repo         webhooks.SubscriptionRepository
+	deliveryRepo webhooks.DeliveryRepository
+	projectRepo  projects.ProjectRepository
+	client       *http.Client
+}
+
+// NewWebhookService creates the service; client posts the deliveries and its
+// timeout bounds each attempt. Use safehttp.NewClient outside of tests.
+func NewWebhookService(
+	repo webhooks.SubscriptionRepository,
+	deliveryRepo webhooks.DeliveryRepository,
+	projectRepo projects.ProjectRepository,
+	client *http.Client,
+) *WebhookService {
+	return &WebhookService{
+		repo:         repo,
+		deliveryRepo: deliveryRepo,
+		projectRepo:  projectRepo,
+		client:       client,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, accountID, projectID uuid.UUID, req *webhook.CreateWebhookRequest) (*entity.Subscription, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+	if !safehttp.ValidURL(req.URL) {
+		return nil, invalidURL()
+	}
+	events, err := normalizeEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListSubscriptionsByProject(ctx, projectID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list webhooks", "LIST_WEBHOOKS_ERROR", err)
+	}
+	if len(existing) >= webhooks.MaxSubscriptionsPerProject {
+		return nil, apperror.NewConflictError("a project can have at most 10 webhooks", "TOO_MANY_WEBHOOKS", nil)
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to generate webhook secret", "GENERATE_WEBHOOK_SECRET_ERROR", err)
+	}
+
+	now := time.Now()
+	subscription := &entity.Subscription{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		AccountID:   accountID,
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      events,
+		Secret:      secret,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, apperror.NewInternalServerError("failed to create webhook", "CREATE_WEBHOOK_ERROR", err)
+	}
+
+	return subscription, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context, accountID, projectID uuid.UUID) ([]*entity.Subscription, error) {
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.ListSubscriptionsByProject(ctx, projectID)
+	if err != nil {
+		return nil, apperror.NewInternalServerError("failed to list webhooks", "LIST_WEBHOOKS_ERROR", err)
+	}
+	return list, nil
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, accountID, projectID, subscriptionID uuid.UUID) (*entity.Subscription, error) {
+	if _, err := s.getOwnedProject(ctx, accountID, projectID); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.repo.GetSubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, webhookNotFound()
+		}
+		return nil, apperror.NewInternalServerError("failed to get webhook", "GET_WEBHOOK_ERROR", err)
+	}
+	if subscription.ProjectID != projectID {
+		return nil, webhookNotFound()
+	}
+
+	return subscription, nil
+}
+
+// UpdateSubscription changes the fields sent. Deliveries still pending when a
+// subscription is disabled are not posted.
+func (s *WebhookService) UpdateSubscription(ctx context.Context, accountID, projectID, subscriptionID uuid.UUID, req *webhook.UpdateWebhookRequest) (*entity.Subscription, error) {
+	if req == nil {
+		return nil, apperror.NewBadRequestError("invalid request body", "INVALID_REQUEST", nil)
+	}
+
+	subscription, err := s.GetSubscription(ctx, accountID, projectID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if !safehttp.ValidURL(*req.URL) {
+			return nil, invalidURL()
+		}
+		subscription.URL = *req.URL
+	}
+	if req.Events != nil {
+		if subscription.Events, err = normalizeEvents(req.Events); err != nil {
+			return nil, err
+		}
+	}
+	if req.Description != nil {
+		subscription.Description = req.Description
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, apperror.NewInternalServerError("failed to update webhook", "UPDATE_WEBHOOK_ERROR", err)
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, accountID, projectID, subscriptionID uuid.UUID) error {
+	if _, err := s.GetSubscription(ctx, accountID, projectID, subscriptionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSubscription(ctx, subscriptionID); err != nil {
+		return apperror.NewInternalServerError("failed to delete webhook", "DELETE_WEBHOOK_ERROR", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns a page of the delivery log of a subscription, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, accountID, projectID, subscriptionID uuid.UUID, limit, offset int) ([]*entity.Delivery, int, error) {
+	if _, err := s.GetSubscription(ctx, accountID, projectID, subscriptionID); err != nil {
+		return nil, 0, err
+	}
+
+	list, total, err := s.deliveryRepo.ListDeliveriesBySubscription(ctx, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, 0, apperror.NewInternalServerError("failed to list webhook deliveries", "LIST_WEBHOOK_DELIVERIES_ERROR", err)
+	}
+	return list, total, nil
+}
+
+// Redeliver queues a new delivery of the event and payload of a logged one.
+// It goes to the current URL of the subscription, which must be active.
+func (s *WebhookService) Redeliver(ctx context.Context, accountID, projectID, subscriptionID, deliveryID uuid.UUID) (*entity.Delivery, error) {
+	subscription, err := s.GetSubscription(ctx, accountID, projectID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.Active {
+		return nil, apperror.NewConflictError("webhook is disabled", "WEBHOOK_DISABLED", nil)
+	}
+
+	original, err := s.deliveryRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, deliveryNotFound()
+		}
+		return nil, apperror.NewInternalServerError("failed to get webhook delivery", "GET_WEBHOOK_DELIVERY_ERROR", err)
+	}
+	if original.SubscriptionID != subscriptionID {
+		return nil, deliveryNotFound()
+	}
+
+	delivery := newDelivery(subscriptionID, original.EventID, original.Event, original.Payload, time.Now())
+	delivery.RedeliveryOf = lo.ToPtr(original.ID)
+	if err := s.deliveryRepo.CreateDeliveries(ctx, []*entity.Delivery{delivery}); err != nil {
+		return nil, apperror.NewInternalServerError("failed to create webhook delivery", "CREATE_WEBHOOK_DELIVERY_ERROR", err)
+	}
+	return delivery, nil
+}
+
+func (s *WebhookService) getOwnedProject(ctx context.Context, accountID, projectID uuid.UUID) (*projectEntity.Project, error) {
+	proj, err := s.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", err)
+		}
+		return nil, apperror.NewInternalServerError("failed to get project", "GET_PROJECT_ERROR", err)
+	}
+	if proj.AccountID != accountID {
+		return nil, apperror.NewNotFoundError("project not found", "PROJECT_NOT_FOUND", nil)
+	}
+	return proj, nil
+}
+
+// normalizeEvents checks the event filter and drops duplicates; "*" makes
+// every other type redundant
+func normalizeEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, apperror.NewBadRequestError("events must not be empty", "INVALID_WEBHOOK_EVENTS", nil)
+	}
+	for _, e := range events {
+		if e != webhooks.EventAll && !lo.Contains(webhooks.Events, e) {
+			return nil, apperror.NewBadRequestError("unknown event "+e+", expected * or one of "+strings.Join(webhooks.Events, ", "), "INVALID_WEBHOOK_EVENTS", nil)
+		}
+	}
+	if lo.Contains(events, webhooks.EventAll) {
+		return []string{webhooks.EventAll}, nil
+	}
+	return lo.Uniq(events), nil
+}
+
+func newDelivery(subscriptionID, eventID uuid.UUID, event, payload string, now time.Time) *entity.Delivery {
+	return &entity.Delivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		EventID:        eventID,
+		Event:          event,
+		Payload:        payload,
+		Status:         webhooks.StatusPending,
+		NextAttemptAt:  &now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func invalidURL() error {
+	return apperror.NewBadRequestError("url must be an absolute http(s) URL of a public host", "INVALID_WEBHOOK_URL", nil)
+}
+
+func webhookNotFound() error {
+	return apperror.NewNotFoundError("webhook not found", "WEBHOOK_NOT_FOUND", nil)
+}
+
+func deliveryNotFound() error {
+	return apperror.NewNotFoundError("webhook delivery not found", "WEBHOOK_DELIVERY_NOT_FOUND", nil)
+}
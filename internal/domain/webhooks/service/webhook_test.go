@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/webhook"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testWebhookService struct {
+	*WebhookService
+	repo         *mocks.MockSubscriptionRepository
+	deliveryRepo *mocks.MockDeliveryRepository
+	projectRepo  *mocks.MockProjectRepository
+}
+
+func newTestWebhookService(t *testing.T) *testWebhookService {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSubscriptionRepository(ctrl)
+	deliveryRepo := mocks.NewMockDeliveryRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+
+	svc := NewWebhookService(repo, deliveryRepo, projectRepo, &http.Client{Timeout: 5 * time.Second})
+	return &testWebhookService{WebhookService: svc, repo: repo, deliveryRepo: deliveryRepo, projectRepo: projectRepo}
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+	project := &projectEntity.Project{ID: projectID, AccountID: accountID}
+
+	t.Run("creates an active subscription with a secret", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(project, nil)
+		s.repo.EXPECT().ListSubscriptionsByProject(ctx, projectID).Return(nil, nil)
+		s.repo.EXPECT().CreateSubscription(ctx, gomock.Any()).Return(nil)
+
+		created, err := s.CreateSubscription(ctx, accountID, projectID, &webhook.CreateWebhookRequest{
+			URL:    "https://example.com/hook",
+			Events: []string{webhooks.EventTaskCreated, webhooks.EventTaskCreated, webhooks.EventTaskDeleted},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, created.Active)
+		assert.Equal(t, []string{webhooks.EventTaskCreated, webhooks.EventTaskDeleted}, created.Events)
+		assert.Contains(t, created.Secret, webhooks.SecretPrefix)
+	})
+
+	t.Run("wildcard replaces the other events", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(project, nil)
+		s.repo.EXPECT().ListSubscriptionsByProject(ctx, projectID).Return(nil, nil)
+		s.repo.EXPECT().CreateSubscription(ctx, gomock.Any()).Return(nil)
+
+		created, err := s.CreateSubscription(ctx, accountID, projectID, &webhook.CreateWebhookRequest{
+			URL:    "https://example.com/hook",
+			Events: []string{webhooks.EventTaskCreated, webhooks.EventAll},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{webhooks.EventAll}, created.Events)
+	})
+
+	tests := []struct {
+		name string
+		req  *webhook.CreateWebhookRequest
+		code string
+	}{
+		{"relative url", &webhook.CreateWebhookRequest{URL: "/hook", Events: []string{webhooks.EventAll}}, "INVALID_WEBHOOK_URL"},
+		{"unsupported scheme", &webhook.CreateWebhookRequest{URL: "ftp://example.com", Events: []string{webhooks.EventAll}}, "INVALID_WEBHOOK_URL"},
+		{"loopback host", &webhook.CreateWebhookRequest{URL: "http://localhost:5432", Events: []string{webhooks.EventAll}}, "INVALID_WEBHOOK_URL"},
+		{"no events", &webhook.CreateWebhookRequest{URL: "https://example.com"}, "INVALID_WEBHOOK_EVENTS"},
+		{"unknown event", &webhook.CreateWebhookRequest{URL: "https://example.com", Events: []string{"task.archived"}}, "INVALID_WEBHOOK_EVENTS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestWebhookService(t)
+
+			_, err := s.CreateSubscription(ctx, accountID, projectID, tt.req)
+
+			var appErr *apperror.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+
+	t.Run("limits the webhooks of a project", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(project, nil)
+		s.repo.EXPECT().ListSubscriptionsByProject(ctx, projectID).
+			Return(make([]*entity.Subscription, webhooks.MaxSubscriptionsPerProject), nil)
+
+		_, err := s.CreateSubscription(ctx, accountID, projectID, &webhook.CreateWebhookRequest{
+			URL:    "https://example.com/hook",
+			Events: []string{webhooks.EventAll},
+		})
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "TOO_MANY_WEBHOOKS", appErr.Code)
+	})
+
+	t.Run("project of another account", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(&projectEntity.Project{ID: projectID, AccountID: uuid.New()}, nil)
+
+		_, err := s.CreateSubscription(ctx, accountID, projectID, &webhook.CreateWebhookRequest{
+			URL:    "https://example.com/hook",
+			Events: []string{webhooks.EventAll},
+		})
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "PROJECT_NOT_FOUND", appErr.Code)
+	})
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	all := &entity.Subscription{ID: uuid.New(), Events: []string{webhooks.EventAll}, Active: true}
+	deleted := &entity.Subscription{ID: uuid.New(), Events: []string{webhooks.EventTaskDeleted}, Active: true}
+	disabled := &entity.Subscription{ID: uuid.New(), Events: []string{webhooks.EventAll}, Active: false}
+
+	t.Run("queues a delivery per matching subscription", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		s.repo.EXPECT().ListSubscriptionsByProject(ctx, projectID).Return([]*entity.Subscription{all, deleted, disabled}, nil)
+		s.deliveryRepo.EXPECT().CreateDeliveries(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, deliveries []*entity.Delivery) error {
+				require.Len(t, deliveries, 1)
+				assert.Equal(t, all.ID, deliveries[0].SubscriptionID)
+				assert.Equal(t, webhooks.StatusPending, deliveries[0].Status)
+				assert.Contains(t, deliveries[0].Payload, `"event":"task.created"`)
+				return nil
+			})
+
+		event, err := events.NewEvent(events.TaskCreated, projectID, map[string]string{"k": "v"})
+		require.NoError(t, err)
+
+		err = s.Publish(ctx, event)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("nothing is queued without a match", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		s.repo.EXPECT().ListSubscriptionsByProject(ctx, projectID).Return([]*entity.Subscription{deleted, disabled}, nil)
+
+		event, err := events.NewEvent(events.ProjectUpdated, projectID, nil)
+		require.NoError(t, err)
+
+		err = s.Publish(ctx, event)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestWebhookService_DispatchDue(t *testing.T) {
+	ctx := context.Background()
+	lease := 5 * time.Minute
+
+	newDue := func(subscriptionID uuid.UUID, attempts int) *entity.Delivery {
+		d := newDelivery(subscriptionID, uuid.New(), webhooks.EventTaskCreated, `{"event":"task.created"}`, time.Now())
+		d.Status = webhooks.StatusSending
+		d.Attempts = attempts
+		return d
+	}
+
+	t.Run("posts a signed request and records success", func(t *testing.T) {
+		var got *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			body, _ = io.ReadAll(r.Body)
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		s := newTestWebhookService(t)
+		subscription := &entity.Subscription{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Active: true}
+		delivery := newDue(subscription.ID, 1)
+		s.deliveryRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Delivery{delivery}, nil)
+		s.repo.EXPECT().GetSubscriptionByID(ctx, subscription.ID).Return(subscription, nil)
+		s.deliveryRepo.EXPECT().FinishClaim(ctx, delivery, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, OutcomeSucceeded, results[0].Outcome)
+		assert.Equal(t, webhooks.StatusSucceeded, delivery.Status)
+		assert.NotNil(t, delivery.DeliveredAt)
+		assert.Equal(t, http.StatusOK, *delivery.ResponseStatus)
+		assert.Equal(t, "ok", *delivery.ResponseBody)
+
+		require.NotNil(t, got)
+		timestamp, err := strconv.ParseInt(got.Header.Get(webhooks.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, webhooks.Sign("whsec_test", timestamp, body), got.Header.Get(webhooks.HeaderSignature))
+		assert.Equal(t, webhooks.EventTaskCreated, got.Header.Get(webhooks.HeaderEvent))
+	})
+
+	t.Run("retries after an error response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		s := newTestWebhookService(t)
+		subscription := &entity.Subscription{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Active: true}
+		delivery := newDue(subscription.ID, 2)
+		s.deliveryRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Delivery{delivery}, nil)
+		s.repo.EXPECT().GetSubscriptionByID(ctx, subscription.ID).Return(subscription, nil)
+		s.deliveryRepo.EXPECT().FinishClaim(ctx, delivery, gomock.Any()).Return(true, nil)
+
+		before := time.Now()
+		results, err := s.DispatchDue(ctx, before, 10, lease)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, OutcomeRetry, results[0].Outcome)
+		assert.Equal(t, webhooks.StatusPending, delivery.Status)
+		assert.Equal(t, http.StatusInternalServerError, *delivery.ResponseStatus)
+		require.NotNil(t, delivery.NextAttemptAt)
+		assert.True(t, delivery.NextAttemptAt.After(before.Add(webhooks.RetryDelay(2)-time.Second)))
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		s := newTestWebhookService(t)
+		subscription := &entity.Subscription{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Active: true}
+		delivery := newDue(subscription.ID, webhooks.MaxAttempts)
+		s.deliveryRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Delivery{delivery}, nil)
+		s.repo.EXPECT().GetSubscriptionByID(ctx, subscription.ID).Return(subscription, nil)
+		s.deliveryRepo.EXPECT().FinishClaim(ctx, delivery, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeFailed, results[0].Outcome)
+		assert.Equal(t, webhooks.StatusFailed, delivery.Status)
+		assert.Nil(t, delivery.NextAttemptAt)
+	})
+
+	t.Run("skips deliveries of deleted subscriptions", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		subscriptionID := uuid.New()
+		first, second := newDue(subscriptionID, 1), newDue(subscriptionID, 1)
+		s.deliveryRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Delivery{first, second}, nil)
+		// Looked up once per batch
+		s.repo.EXPECT().GetSubscriptionByID(ctx, subscriptionID).Return(nil, apperror.ErrRecordNotFound)
+		s.deliveryRepo.EXPECT().FinishClaim(ctx, gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, OutcomeSkipped, results[0].Outcome)
+		assert.Equal(t, webhooks.StatusFailed, first.Status)
+	})
+
+	t.Run("lookup error does not use up an attempt", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		delivery := newDue(uuid.New(), 3)
+		s.deliveryRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Delivery{delivery}, nil)
+		s.repo.EXPECT().GetSubscriptionByID(ctx, delivery.SubscriptionID).Return(nil, errors.New("connection reset"))
+		s.deliveryRepo.EXPECT().FinishClaim(ctx, delivery, gomock.Any()).Return(true, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeRetry, results[0].Outcome)
+		assert.Equal(t, 2, delivery.Attempts)
+	})
+
+	t.Run("claim lost before saving", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		defer server.Close()
+
+		s := newTestWebhookService(t)
+		subscription := &entity.Subscription{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Active: true}
+		delivery := newDue(subscription.ID, 1)
+		s.deliveryRepo.EXPECT().ClaimDueDeliveries(ctx, gomock.Any(), gomock.Any(), 10).Return([]*entity.Delivery{delivery}, nil)
+		s.repo.EXPECT().GetSubscriptionByID(ctx, subscription.ID).Return(subscription, nil)
+		s.deliveryRepo.EXPECT().FinishClaim(ctx, delivery, gomock.Any()).Return(false, nil)
+
+		results, err := s.DispatchDue(ctx, time.Now(), 10, lease)
+
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeLost, results[0].Outcome)
+	})
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	projectID := uuid.New()
+	project := &projectEntity.Project{ID: projectID, AccountID: accountID}
+	subscription := &entity.Subscription{ID: uuid.New(), ProjectID: projectID, Active: true}
+
+	t.Run("queues a copy of the delivery", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		original := newDelivery(subscription.ID, uuid.New(), webhooks.EventTaskDeleted, `{}`, time.Now())
+		original.Status = webhooks.StatusFailed
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(project, nil)
+		s.repo.EXPECT().GetSubscriptionByID(ctx, subscription.ID).Return(subscription, nil)
+		s.deliveryRepo.EXPECT().GetDeliveryByID(ctx, original.ID).Return(original, nil)
+		s.deliveryRepo.EXPECT().CreateDeliveries(ctx, gomock.Len(1)).Return(nil)
+
+		delivery, err := s.Redeliver(ctx, accountID, projectID, subscription.ID, original.ID)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, original.ID, delivery.ID)
+		assert.Equal(t, original.EventID, delivery.EventID)
+		assert.Equal(t, original.ID, *delivery.RedeliveryOf)
+		assert.Equal(t, webhooks.StatusPending, delivery.Status)
+	})
+
+	t.Run("delivery of another webhook", func(t *testing.T) {
+		s := newTestWebhookService(t)
+		other := newDelivery(uuid.New(), uuid.New(), webhooks.EventTaskDeleted, `{}`, time.Now())
+		s.projectRepo.EXPECT().GetProjectByID(ctx, projectID).Return(project, nil)
+		s.repo.EXPECT().GetSubscriptionByID(ctx, subscription.ID).Return(subscription, nil)
+		s.deliveryRepo.EXPECT().GetDeliveryByID(ctx, other.ID).Return(other, nil)
+
+		_, err := s.Redeliver(ctx, accountID, projectID, subscription.ID, other.ID)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "WEBHOOK_DELIVERY_NOT_FOUND", appErr.Code)
+	})
+}
@@ -0,0 +1,120 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+)
+
+// Event types a subscription can filter on
+const (
+	EventTaskCreated       = events.TaskCreated
+	EventTaskUpdated       = events.TaskUpdated
+	EventTaskStatusChanged = events.TaskStatusChanged
+	EventTaskDeleted       = events.TaskDeleted
+	EventProjectUpdated    = events.ProjectUpdated
+	EventProjectDeleted    = events.ProjectDeleted
+
+	// EventAll subscribes to every event type, including ones added later
+	EventAll = "*"
+)
+
+// Events lists every event type
+var Events = events.Types
+
+// Delivery statuses
+const (
+	// StatusPending deliveries are posted at NextAttemptAt
+	StatusPending = "pending"
+	// StatusSending deliveries are claimed by a worker posting them
+	StatusSending = "sending"
+	// StatusSucceeded deliveries got a 2xx response
+	StatusSucceeded = "succeeded"
+	// StatusFailed deliveries gave up, or their subscription was disabled
+	StatusFailed = "failed"
+)
+
+const (
+	// EventIDPrefix prefixes the event IDs in payloads
+	EventIDPrefix = events.EventIDPrefix
+
+	// MaxSubscriptionsPerProject bounds the webhooks of one project
+	MaxSubscriptionsPerProject = 10
+
+	// MaxAttempts is how often one delivery is tried; with RetryDelay the last
+	// attempt is about four hours after the first
+	MaxAttempts = 8
+
+	// MaxResponseBody is how much of a response body the delivery log keeps
+	MaxResponseBody = 1024
+)
+
+// Headers of webhook requests
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>"
+	// keyed with the secret of the subscription, see Sign
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Payload is the JSON body of webhook requests
+type Payload struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	ProjectID string          `json:"project_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func NewPayload(e events.Event) Payload {
+	return Payload{
+		ID:        utils.ShortUUIDWithPrefix(e.ID, EventIDPrefix),
+		Event:     e.Type,
+		ProjectID: utils.ShortUUIDWithPrefix(e.ProjectID, projectEntity.ProjectIDPrefix),
+		CreatedAt: e.OccurredAt.UTC(),
+		Data:      e.Data,
+	}
+}
+
+// SecretPrefix marks webhook secrets, so they are recognised when leaked
+const SecretPrefix = "whsec_"
+
+// NewSecret returns a random secret to sign webhook payloads with
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return SecretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Sign returns the X-Webhook-Signature of body sent at timestamp. Receivers
+// recompute it with their copy of the secret, compare in constant time, and
+// should reject timestamps more than a few minutes old to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// RetryDelay is the backoff before the next attempt after a failed one:
+// 1, 2, 4 ... 64 minutes
+func RetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return time.Minute << min(attempts-1, 6)
+}
@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"task.created"}`)
+
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte(`1767225600.{"event":"task.created"}`))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, Sign("whsec_test", 1767225600, body))
+	assert.NotEqual(t, expected, Sign("whsec_test", 1767225601, body))
+	assert.NotEqual(t, expected, Sign("whsec_other", 1767225600, body))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, RetryDelay(0))
+	assert.Equal(t, time.Minute, RetryDelay(1))
+	assert.Equal(t, 8*time.Minute, RetryDelay(4))
+	assert.Equal(t, 64*time.Minute, RetryDelay(7))
+	assert.Equal(t, 64*time.Minute, RetryDelay(20))
+}
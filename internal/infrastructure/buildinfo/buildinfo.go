@@ -0,0 +1,59 @@
+// Package buildinfo exposes the version the binary was built from.
+// Release builds set the variables with -ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/FrostBitzX/smart-task-ai/internal/infrastructure/buildinfo.Version=v1.2.0 \
+//		-X github.com/FrostBitzX/smart-task-ai/internal/infrastructure/buildinfo.Commit=$(git rev-parse HEAD)"
+package buildinfo
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	// Version is the release the binary was built from
+	Version = "dev"
+	// Commit is the git revision; it falls back to the VCS stamp of `go build`
+	Commit = ""
+	// BuildTime is the RFC 3339 time of the build
+	BuildTime = ""
+)
+
+// Info describes the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time,omitempty"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build information, filling gaps from the Go build stamp
+func Get() Info {
+	once.Do(func() {
+		info = Info{Version: Version, Commit: Commit, BuildTime: BuildTime}
+		bi, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			}
+		}
+		if info.Commit == "" {
+			info.Commit = "unknown"
+		}
+	})
+	return info
+}
@@ -1,63 +1,321 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
 )
 
+// Environments selected with APP_ENV
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// Config holds every setting of the API. Values are layered, later ones winning:
+// defaults, CONFIG_DIR/config.yaml, CONFIG_DIR/config.<APP_ENV>.yaml, .env, environment.
+// YAML keys are the environment variable names in lower case, e.g. `db_host: localhost`.
 type Config struct {
+	AppEnv   string `mapstructure:"APP_ENV"`
+	LogLevel string `mapstructure:"LOG_LEVEL"`
+	Port     int    `mapstructure:"PORT"`
+	// ShutdownDrainDelay is how long /readyz fails before the server stops accepting requests
+	ShutdownDrainDelay time.Duration `mapstructure:"SHUTDOWN_DRAIN_DELAY"`
+
 	FrontendURL string `mapstructure:"FRONTEND_URL"`
-	DBHost      string `mapstructure:"DB_HOST"`
-	DBName      string `mapstructure:"DB_DATABASE"`
-	DBUsername  string `mapstructure:"DB_USERNAME"`
-	DBPassword  string `mapstructure:"DB_PASSWORD"`
-	DBPort      string `mapstructure:"DB_PORT"`
-	GroqAPIKey  string `mapstructure:"GROQ_API_KEY"`
-	GroqAPIURL  string `mapstructure:"GROQ_API_URL"`
+	// PublicAPIURL is the address clients outside the browser, e.g. calendar apps, reach the API at
+	PublicAPIURL     string `mapstructure:"PUBLIC_API_URL"`
+	CORSAllowOrigins string `mapstructure:"CORS_ALLOW_ORIGINS"`
+
+	DBHost     string `mapstructure:"DB_HOST"`
+	DBName     string `mapstructure:"DB_DATABASE"`
+	DBUsername string `mapstructure:"DB_USERNAME"`
+	DBPassword string `mapstructure:"DB_PASSWORD"`
+	DBPort     string `mapstructure:"DB_PORT"`
+	// MigrateOnStart applies pending migrations before the schema check
+	MigrateOnStart bool `mapstructure:"MIGRATE_ON_START"`
+
+	JWTSecret string `mapstructure:"JWT_SECRET"`
+	// EmailVerificationGracePeriod is nil when unverified accounts are not limited
+	EmailVerificationGracePeriod *time.Duration `mapstructure:"EMAIL_VERIFICATION_GRACE_PERIOD"`
+
+	GroqAPIKey string `mapstructure:"GROQ_API_KEY"`
+	GroqAPIURL string `mapstructure:"GROQ_API_URL"`
+
+	MailDriver    string `mapstructure:"MAIL_DRIVER"`
+	MailFrom      string `mapstructure:"MAIL_FROM"`
+	MailOutboxDir string `mapstructure:"MAIL_OUTBOX_DIR"`
+	SMTPHost      string `mapstructure:"SMTP_HOST"`
+	SMTPPort      string `mapstructure:"SMTP_PORT"`
+	SMTPUsername  string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword  string `mapstructure:"SMTP_PASSWORD"`
+
+	// StorageDriver defaults to s3 when S3_BUCKET is set, local otherwise
+	StorageDriver    string `mapstructure:"STORAGE_DRIVER"`
+	StorageURLMode   string `mapstructure:"STORAGE_URL_MODE"`
+	StorageLocalDir  string `mapstructure:"STORAGE_LOCAL_DIR"`
+	StoragePublicURL string `mapstructure:"STORAGE_PUBLIC_URL"`
+	S3Endpoint       string `mapstructure:"S3_ENDPOINT"`
+	S3Region         string `mapstructure:"S3_REGION"`
+	S3AccessKey      string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey      string `mapstructure:"S3_SECRET_KEY"`
+	S3Bucket         string `mapstructure:"S3_BUCKET"`
+	// AttachmentProjectQuotaMB of 0 selects the default quota
+	AttachmentProjectQuotaMB int64 `mapstructure:"ATTACHMENT_PROJECT_QUOTA_MB"`
+
+	// ReminderWorkerEnabled runs the reminder worker in this instance
+	ReminderWorkerEnabled  bool          `mapstructure:"REMINDER_WORKER_ENABLED"`
+	ReminderPollInterval   time.Duration `mapstructure:"REMINDER_POLL_INTERVAL"`
+	ReminderBatchSize      int           `mapstructure:"REMINDER_BATCH_SIZE"`
+	ReminderWebhookTimeout time.Duration `mapstructure:"REMINDER_WEBHOOK_TIMEOUT"`
+
+	// WebhookWorkerEnabled runs the worker posting project webhooks in this instance
+	WebhookWorkerEnabled bool          `mapstructure:"WEBHOOK_WORKER_ENABLED"`
+	WebhookPollInterval  time.Duration `mapstructure:"WEBHOOK_POLL_INTERVAL"`
+	WebhookBatchSize     int           `mapstructure:"WEBHOOK_BATCH_SIZE"`
+	WebhookTimeout       time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+
+	// OutboxRelayEnabled runs the relay dispatching domain events in this instance
+	OutboxRelayEnabled bool          `mapstructure:"OUTBOX_RELAY_ENABLED"`
+	OutboxPollInterval time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxBatchSize    int           `mapstructure:"OUTBOX_BATCH_SIZE"`
+	OutboxRetention    time.Duration `mapstructure:"OUTBOX_RETENTION"`
+
+	RateLimitStore string `mapstructure:"RATE_LIMIT_STORE"`
+	MetricsToken   string `mapstructure:"METRICS_TOKEN"`
+
+	// The OTLP exporter reads the standard OTEL_EXPORTER_OTLP_* variables itself
+	TracesExporter string `mapstructure:"OTEL_TRACES_EXPORTER"`
+	ServiceName    string `mapstructure:"OTEL_SERVICE_NAME"`
+}
+
+var defaults = map[string]interface{}{
+	"APP_ENV":                  EnvDevelopment,
+	"LOG_LEVEL":                "info",
+	"PORT":                     8080,
+	"SHUTDOWN_DRAIN_DELAY":     "0s",
+	"FRONTEND_URL":             "http://localhost:3000",
+	"PUBLIC_API_URL":           "http://localhost:8080",
+	"CORS_ALLOW_ORIGINS":       "http://localhost:3000",
+	"DB_PORT":                  "5432",
+	"GROQ_API_URL":             "https://api.groq.com/openai/v1/chat/completions",
+	"MAIL_DRIVER":              "outbox",
+	"MAIL_FROM":                "Smart Task AI <no-reply@smart-task-ai.local>",
+	"MAIL_OUTBOX_DIR":          "tmp/outbox",
+	"SMTP_PORT":                "587",
+	"STORAGE_URL_MODE":         "presign",
+	"STORAGE_LOCAL_DIR":        "tmp/storage",
+	"REMINDER_WORKER_ENABLED":  true,
+	"REMINDER_POLL_INTERVAL":   "30s",
+	"REMINDER_BATCH_SIZE":      20,
+	"REMINDER_WEBHOOK_TIMEOUT": "10s",
+	"WEBHOOK_WORKER_ENABLED":   true,
+	"WEBHOOK_POLL_INTERVAL":    "10s",
+	"WEBHOOK_BATCH_SIZE":       20,
+	"WEBHOOK_TIMEOUT":          "10s",
+	"OUTBOX_RELAY_ENABLED":     true,
+	"OUTBOX_POLL_INTERVAL":     "1s",
+	"OUTBOX_BATCH_SIZE":        100,
+	"OUTBOX_RETENTION":         "168h",
+	"RATE_LIMIT_STORE":         "memory",
+	"OTEL_TRACES_EXPORTER":     "none",
+	"OTEL_SERVICE_NAME":        "smart-task-ai",
 }
 
+// NewConfig loads and validates the configuration, exiting with the list of problems on failure
 func NewConfig() *Config {
-	config := &Config{}
+	cfg, err := Load()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	return cfg
+}
+
+// Load reads the configuration layers and validates the result
+func Load() (*Config, error) {
+	v := viper.New()
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
 
-	if _, err := os.Stat(".env"); err == nil {
-		viper.SetConfigFile(".env")
-		if err := viper.ReadInConfig(); err != nil {
-			log.Fatalln("Unable to read .env file", err)
+	// Every field is bound so Unmarshal sees variables that no file mentions
+	for _, key := range keys() {
+		if err := v.BindEnv(key); err != nil {
+			return nil, fmt.Errorf("unable to bind %s: %w", key, err)
 		}
 	}
 
-	viper.AutomaticEnv()
+	dir := os.Getenv("CONFIG_DIR")
+	if dir == "" {
+		dir = "config"
+	}
+	if err := mergeFile(v, filepath.Join(dir, "config.yaml")); err != nil {
+		return nil, err
+	}
+	// The profile is chosen after the base file, which may set APP_ENV itself
+	profile := strings.ToLower(v.GetString("APP_ENV"))
+	if err := mergeFile(v, filepath.Join(dir, "config."+profile+".yaml")); err != nil {
+		return nil, err
+	}
+	if err := mergeFile(v, ".env"); err != nil {
+		return nil, err
+	}
 
-	if err := viper.BindEnv("FRONTEND_URL"); err != nil {
-		log.Fatalf("Unable to bind FRONTEND_URL: %v", err)
+	cfg := &Config{}
+	hooks := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		emptyToNilHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	))
+	if err := v.Unmarshal(cfg, hooks); err != nil {
+		return nil, fmt.Errorf("unable to decode configuration: %w", err)
 	}
-	if err := viper.BindEnv("DB_HOST"); err != nil {
-		log.Fatalf("Unable to bind DB_HOST: %v", err)
+	cfg.normalize()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
-	if err := viper.BindEnv("DB_DATABASE"); err != nil {
-		log.Fatalf("Unable to bind DB_DATABASE: %v", err)
+	return cfg, nil
+}
+
+// mergeFile merges an optional file; a missing file is not an error
+func mergeFile(v *viper.Viper, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return nil
 	}
-	if err := viper.BindEnv("DB_USERNAME"); err != nil {
-		log.Fatalf("Unable to bind DB_USERNAME: %v", err)
+	v.SetConfigFile(path)
+	if err := v.MergeInConfig(); err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
 	}
-	if err := viper.BindEnv("DB_PASSWORD"); err != nil {
-		log.Fatalf("Unable to bind DB_PASSWORD: %v", err)
+	return nil
+}
+
+// emptyToNilHook leaves optional settings unset when a file assigns them an empty string
+func emptyToNilHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() == reflect.String && to.Kind() == reflect.Ptr && data == "" {
+		return reflect.Zero(to).Interface(), nil
 	}
-	if err := viper.BindEnv("DB_PORT"); err != nil {
-		log.Fatalf("Unable to bind DB_PORT: %v", err)
+	return data, nil
+}
+
+func keys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (c *Config) normalize() {
+	c.AppEnv = strings.ToLower(c.AppEnv)
+	c.LogLevel = strings.ToLower(c.LogLevel)
+	c.MailDriver = strings.ToLower(c.MailDriver)
+	c.StorageDriver = strings.ToLower(c.StorageDriver)
+	c.StorageURLMode = strings.ToLower(c.StorageURLMode)
+	c.RateLimitStore = strings.ToLower(c.RateLimitStore)
+	c.TracesExporter = strings.ToLower(c.TracesExporter)
+
+	if c.StorageDriver == "" {
+		c.StorageDriver = "local"
+		if c.S3Bucket != "" {
+			c.StorageDriver = "s3"
+		}
+	}
+}
+
+// Validate reports every invalid setting at once so a deployment can be fixed in one go
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+	oneOf := func(key, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		problems = append(problems, fmt.Sprintf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value))
+	}
+
+	oneOf("APP_ENV", c.AppEnv, EnvDevelopment, EnvStaging, EnvProduction)
+	oneOf("LOG_LEVEL", c.LogLevel, "debug", "info", "warn", "error")
+	check(c.Port > 0 && c.Port < 65536, "PORT must be between 1 and 65535, got %d", c.Port)
+	check(c.ShutdownDrainDelay >= 0, "SHUTDOWN_DRAIN_DELAY must not be negative")
+
+	check(strings.HasPrefix(c.PublicAPIURL, "http://") || strings.HasPrefix(c.PublicAPIURL, "https://"),
+		"PUBLIC_API_URL must be an absolute http(s) URL, got %q", c.PublicAPIURL)
+
+	check(c.DBHost != "", "DB_HOST is required")
+	check(c.DBName != "", "DB_DATABASE is required")
+	check(c.DBUsername != "", "DB_USERNAME is required")
+	check(c.DBPort != "", "DB_PORT is required")
+
+	check(c.JWTSecret != "", "JWT_SECRET is required")
+	if c.AppEnv == EnvProduction {
+		check(len(c.JWTSecret) >= 32, "JWT_SECRET must be at least 32 characters in production")
 	}
-	if err := viper.BindEnv("GROQ_API_KEY"); err != nil {
-		log.Fatalf("Unable to bind GROQ_API_KEY: %v", err)
+	if c.EmailVerificationGracePeriod != nil {
+		check(*c.EmailVerificationGracePeriod >= 0, "EMAIL_VERIFICATION_GRACE_PERIOD must not be negative")
 	}
-	if err := viper.BindEnv("GROQ_API_URL"); err != nil {
-		log.Fatalf("Unable to bind GROQ_API_URL: %v", err)
+
+	oneOf("MAIL_DRIVER", c.MailDriver, "outbox", "smtp")
+	if c.MailDriver == "smtp" {
+		check(c.SMTPHost != "", "SMTP_HOST is required when MAIL_DRIVER=smtp")
+		check(c.SMTPPort != "", "SMTP_PORT is required when MAIL_DRIVER=smtp")
+	}
+
+	oneOf("STORAGE_DRIVER", c.StorageDriver, "local", "s3")
+	oneOf("STORAGE_URL_MODE", c.StorageURLMode, "presign", "proxy")
+	if c.StorageDriver == "s3" {
+		check(c.S3Bucket != "", "S3_BUCKET is required when STORAGE_DRIVER=s3")
 	}
+	check(c.AttachmentProjectQuotaMB >= 0, "ATTACHMENT_PROJECT_QUOTA_MB must not be negative")
 
-	if err := viper.Unmarshal(config); err != nil {
-		log.Fatalln("Unable to decode into struct", err)
+	check(c.ReminderPollInterval >= time.Second, "REMINDER_POLL_INTERVAL must be at least 1s")
+	check(c.ReminderBatchSize > 0 && c.ReminderBatchSize <= 500, "REMINDER_BATCH_SIZE must be between 1 and 500, got %d", c.ReminderBatchSize)
+	check(c.ReminderWebhookTimeout > 0 && c.ReminderWebhookTimeout <= time.Minute, "REMINDER_WEBHOOK_TIMEOUT must be between 0 and 1m")
+	check(c.WebhookPollInterval >= time.Second, "WEBHOOK_POLL_INTERVAL must be at least 1s")
+	check(c.WebhookBatchSize > 0 && c.WebhookBatchSize <= 500, "WEBHOOK_BATCH_SIZE must be between 1 and 500, got %d", c.WebhookBatchSize)
+	check(c.WebhookTimeout > 0 && c.WebhookTimeout <= time.Minute, "WEBHOOK_TIMEOUT must be between 0 and 1m")
+	check(c.OutboxPollInterval >= 100*time.Millisecond, "OUTBOX_POLL_INTERVAL must be at least 100ms")
+	check(c.OutboxBatchSize > 0 && c.OutboxBatchSize <= 1000, "OUTBOX_BATCH_SIZE must be between 1 and 1000, got %d", c.OutboxBatchSize)
+	check(c.OutboxRetention >= time.Hour, "OUTBOX_RETENTION must be at least 1h")
+
+	oneOf("RATE_LIMIT_STORE", c.RateLimitStore, "memory", "postgres")
+	oneOf("OTEL_TRACES_EXPORTER", c.TracesExporter, "none", "stdout", "otlp")
+
+	if len(problems) == 0 {
+		return nil
 	}
+	return errors.New("invalid configuration:\n  - " + strings.Join(problems, "\n  - "))
+}
+
+// IsProduction reports whether APP_ENV is production
+func (c *Config) IsProduction() bool {
+	return c.AppEnv == EnvProduction
+}
+
+// ListenAddr is the address the HTTP server binds to
+func (c *Config) ListenAddr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
 
-	return config
+// AttachmentProjectQuota returns the per-project attachment quota in bytes; 0 selects the default
+func (c *Config) AttachmentProjectQuota() int64 {
+	return c.AttachmentProjectQuotaMB << 20
 }
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validConfig() *Config {
+	return &Config{
+		AppEnv:                 EnvDevelopment,
+		LogLevel:               "info",
+		Port:                   8080,
+		PublicAPIURL:           "http://localhost:8080",
+		DBHost:                 "localhost",
+		DBName:                 "smart_task",
+		DBUsername:             "postgres",
+		DBPort:                 "5432",
+		JWTSecret:              "secret",
+		MailDriver:             "outbox",
+		StorageDriver:          "local",
+		StorageURLMode:         "presign",
+		ReminderPollInterval:   30 * time.Second,
+		ReminderBatchSize:      20,
+		ReminderWebhookTimeout: 10 * time.Second,
+		WebhookPollInterval:    10 * time.Second,
+		WebhookBatchSize:       20,
+		WebhookTimeout:         10 * time.Second,
+		OutboxPollInterval:     time.Second,
+		OutboxBatchSize:        100,
+		OutboxRetention:        168 * time.Hour,
+		RateLimitStore:         "memory",
+		TracesExporter:         "none",
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(c *Config)
+		problems []string
+	}{
+		{name: "valid", modify: func(c *Config) {}},
+		{
+			name:     "unknown enum value",
+			modify:   func(c *Config) { c.MailDriver = "sendgrid" },
+			problems: []string{`MAIL_DRIVER must be one of outbox, smtp, got "sendgrid"`},
+		},
+		{
+			name:     "short secret in production",
+			modify:   func(c *Config) { c.AppEnv = EnvProduction },
+			problems: []string{"JWT_SECRET must be at least 32 characters in production"},
+		},
+		{
+			name:     "smtp without host",
+			modify:   func(c *Config) { c.MailDriver = "smtp" },
+			problems: []string{"SMTP_HOST is required when MAIL_DRIVER=smtp", "SMTP_PORT is required when MAIL_DRIVER=smtp"},
+		},
+		{
+			name: "every problem is reported",
+			modify: func(c *Config) {
+				c.Port = 0
+				c.DBHost = ""
+				c.JWTSecret = ""
+				c.StorageDriver = "s3"
+				c.OutboxBatchSize = 5000
+			},
+			problems: []string{
+				"PORT must be between 1 and 65535, got 0",
+				"DB_HOST is required",
+				"JWT_SECRET is required",
+				"S3_BUCKET is required when STORAGE_DRIVER=s3",
+				"OUTBOX_BATCH_SIZE must be between 1 and 1000, got 5000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if len(tt.problems) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, "invalid configuration:\n  - "+strings.Join(tt.problems, "\n  - "), err.Error())
+		})
+	}
+}
+
+// setupLoad runs Load in an empty directory with no configuration in the environment
+func setupLoad(t *testing.T) string {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, key := range keys() {
+		t.Setenv(key, "")
+	}
+	t.Setenv("CONFIG_DIR", filepath.Join(dir, "config"))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "config"), 0o755))
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	const base = "db_host: base\ndb_database: base\ndb_username: base\njwt_secret: base\nlog_level: warn\nport: 9000\napp_env: staging\n"
+
+	tests := []struct {
+		name     string
+		profile  string
+		dotenv   string
+		env      map[string]string
+		expected func(c *Config)
+	}{
+		{
+			name: "base file over defaults",
+			expected: func(c *Config) {
+				c.DBHost, c.LogLevel, c.Port = "base", "warn", 9000
+			},
+		},
+		{
+			name:    "profile of the APP_ENV set by the base file over base file",
+			profile: "db_host: profile\nlog_level: error\n",
+			expected: func(c *Config) {
+				c.DBHost, c.LogLevel, c.Port = "profile", "error", 9000
+			},
+		},
+		{
+			name:    ".env over profile",
+			profile: "db_host: profile\nlog_level: error\n",
+			dotenv:  "DB_HOST=dotenv\n",
+			expected: func(c *Config) {
+				c.DBHost, c.LogLevel, c.Port = "dotenv", "error", 9000
+			},
+		},
+		{
+			name:    "environment over .env",
+			profile: "db_host: profile\nlog_level: error\n",
+			dotenv:  "DB_HOST=dotenv\nPORT=9100\n",
+			env:     map[string]string{"DB_HOST": "env"},
+			expected: func(c *Config) {
+				c.DBHost, c.LogLevel, c.Port = "env", "error", 9100
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := setupLoad(t)
+			writeFile(t, filepath.Join(dir, "config", "config.yaml"), base)
+			if tt.profile != "" {
+				writeFile(t, filepath.Join(dir, "config", "config.staging.yaml"), tt.profile)
+			}
+			if tt.dotenv != "" {
+				writeFile(t, filepath.Join(dir, ".env"), tt.dotenv)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			require.NoError(t, err)
+
+			expected := &Config{}
+			tt.expected(expected)
+			assert.Equal(t, EnvStaging, cfg.AppEnv)
+			assert.Equal(t, expected.DBHost, cfg.DBHost)
+			assert.Equal(t, expected.LogLevel, cfg.LogLevel)
+			assert.Equal(t, expected.Port, cfg.Port)
+			// Untouched settings keep their defaults
+			assert.Equal(t, "5432", cfg.DBPort)
+			assert.Equal(t, 30*time.Second, cfg.ReminderPollInterval)
+		})
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := setupLoad(t)
+	writeFile(t, filepath.Join(dir, "config", "config.yaml"), "db_host: localhost\nreminder_poll_interval: 10ms\n")
+
+	_, err := Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_DATABASE is required")
+	assert.Contains(t, err.Error(), "JWT_SECRET is required")
+	assert.Contains(t, err.Error(), "REMINDER_POLL_INTERVAL must be at least 1s")
+}
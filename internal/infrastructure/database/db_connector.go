@@ -16,9 +16,9 @@ type DBConnector struct {
 	DB *gorm.DB
 }
 
-// NewDB creates a new database connection with connection pooling configured
-func NewDB(cfg *config.Config) *gorm.DB {
-	dsn := fmt.Sprintf(
+// DSN returns the connection string of the configured database
+func DSN(cfg *config.Config) string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		cfg.DBHost,
 		cfg.DBUsername,
@@ -26,8 +26,11 @@ func NewDB(cfg *config.Config) *gorm.DB {
 		cfg.DBName,
 		cfg.DBPort,
 	)
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// NewDB creates a new database connection with connection pooling configured
+func NewDB(cfg *config.Config) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(DSN(cfg)), &gorm.Config{})
 	if err != nil {
 		log.Fatalf("❌ failed to connect PostgreSQL: %v", err)
 	}
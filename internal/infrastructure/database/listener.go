@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// listenerRetryDelay is how long the listener waits before connecting again
+const listenerRetryDelay = 5 * time.Second
+
+// Listener receives Postgres notifications on a connection of its own, as
+// LISTEN does not survive being returned to a pool
+type Listener struct {
+	dsn      string
+	channels []string
+	logger   logger.Logger
+}
+
+func NewListener(dsn string, l logger.Logger, channels ...string) *Listener {
+	return &Listener{
+		dsn:      dsn,
+		channels: channels,
+		logger:   l,
+	}
+}
+
+// Run passes every notification to handle until ctx is cancelled. When the
+// connection drops it connects again; notifications sent in between are lost,
+// so onConnect is called after every connection but the first.
+func (l *Listener) Run(ctx context.Context, onConnect func(), handle func(channel, payload string)) {
+	connected := false
+	for ctx.Err() == nil {
+		err := l.listen(ctx, func() {
+			if connected {
+				onConnect()
+			}
+			connected = true
+		}, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		l.logger.Warn("Database listener disconnected", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenerRetryDelay):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context, onConnect func(), handle func(channel, payload string)) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	for _, channel := range l.channels {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return err
+		}
+	}
+	onConnect()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(notification.Channel, notification.Payload)
+	}
+}
@@ -0,0 +1,125 @@
+-- Baseline schema, matching the entities at the time migrations were introduced.
+-- Databases created before that are adopted: existing tables and indexes are kept
+-- and only the columns added since then are created.
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id                char(36)     PRIMARY KEY,
+    node_id           char(36),
+    username          varchar(100) NOT NULL,
+    email             varchar(255) NOT NULL,
+    password          varchar(255) NOT NULL,
+    state             varchar(16)  NOT NULL DEFAULT 'active'
+                      CHECK (state IN ('active', 'inactive', 'suspended')),
+    role              varchar(16)  NOT NULL DEFAULT 'user'
+                      CHECK (role IN ('user', 'admin')),
+    email_verified_at timestamptz,
+    created_at        timestamptz  NOT NULL,
+    updated_at        timestamptz  NOT NULL
+);
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS role varchar(16) NOT NULL DEFAULT 'user';
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS email_verified_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_username ON accounts (username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_email ON accounts (email);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id                  char(36)     PRIMARY KEY,
+    account_id          char(36)     NOT NULL,
+    refresh_token_hash  char(64)     NOT NULL,
+    previous_token_hash char(64),
+    user_agent          varchar(255),
+    ip_address          varchar(45),
+    expires_at          timestamptz  NOT NULL,
+    last_used_at        timestamptz  NOT NULL,
+    revoked_at          timestamptz,
+    created_at          timestamptz  NOT NULL,
+    updated_at          timestamptz  NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_account_id ON sessions (account_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_refresh_token_hash ON sessions (refresh_token_hash);
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_token_hash ON sessions (previous_token_hash);
+CREATE INDEX IF NOT EXISTS idx_sessions_revoked_at ON sessions (revoked_at);
+
+CREATE TABLE IF NOT EXISTS account_tokens (
+    id         char(36)    PRIMARY KEY,
+    account_id char(36)    NOT NULL,
+    purpose    varchar(32) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at    timestamptz,
+    created_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_account_tokens_account_id ON account_tokens (account_id);
+
+CREATE TABLE IF NOT EXISTS profiles (
+    id          char(36)     PRIMARY KEY,
+    node_id     char(36),
+    account_id  char(36)     NOT NULL,
+    first_name  varchar(100) NOT NULL,
+    last_name   varchar(100) NOT NULL,
+    nickname    varchar(50)  NOT NULL,
+    avatar_path varchar(255),
+    state       varchar(16)  NOT NULL CHECK (state IN ('active', 'inactive')),
+    created_at  timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS avatar_path varchar(255);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_profiles_account_id ON profiles (account_id);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id         char(36)     PRIMARY KEY,
+    node_id    char(36),
+    account_id char(36)     NOT NULL,
+    role       varchar(16)  NOT NULL CHECK (role IN ('owner', 'member')),
+    name       varchar(255) NOT NULL,
+    config     jsonb,
+    created_at timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_projects_account_id ON projects (account_id);
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at);
+
+-- Task date-times are RFC 3339 strings, as sent by the API
+CREATE TABLE IF NOT EXISTS tasks (
+    id              char(36)     PRIMARY KEY,
+    node_id         char(36),
+    project_id      char(36)     NOT NULL,
+    name            varchar(255) NOT NULL,
+    description     text,
+    priority        varchar(16)  NOT NULL,
+    start_datetime  varchar(64),
+    end_datetime    varchar(64),
+    location        varchar(255),
+    recurring_days  integer,
+    recurring_until varchar(64),
+    status          varchar(32)  NOT NULL DEFAULT 'todo',
+    completed_at    timestamptz,
+    created_at      timestamptz  NOT NULL,
+    updated_at      timestamptz  NOT NULL,
+    deleted_at      timestamptz
+);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id           char(36)     PRIMARY KEY,
+    task_id      char(36)     NOT NULL,
+    project_id   char(36)     NOT NULL,
+    uploaded_by  char(36)     NOT NULL,
+    file_name    varchar(255) NOT NULL,
+    content_type varchar(255) NOT NULL,
+    size         bigint       NOT NULL,
+    checksum     char(64)     NOT NULL,
+    storage_key  varchar(512) NOT NULL,
+    created_at   timestamptz  NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_project_id ON task_attachments (project_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_attachments_storage_key ON task_attachments (storage_key);
+
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket_key varchar(255)     PRIMARY KEY,
+    tokens     double precision NOT NULL,
+    updated_at timestamptz      NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
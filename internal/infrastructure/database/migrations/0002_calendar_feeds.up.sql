@@ -0,0 +1,12 @@
+-- Secret iCalendar subscription URLs; project_id is NULL for feeds of every project
+CREATE TABLE calendar_feeds (
+    id         char(36)    PRIMARY KEY,
+    account_id char(36)    NOT NULL,
+    project_id char(36),
+    token_hash char(64)    NOT NULL,
+    created_at timestamptz NOT NULL,
+    revoked_at timestamptz
+);
+CREATE INDEX idx_calendar_feeds_account_id ON calendar_feeds (account_id);
+CREATE INDEX idx_calendar_feeds_project_id ON calendar_feeds (project_id);
+CREATE UNIQUE INDEX idx_calendar_feeds_token_hash ON calendar_feeds (token_hash);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_project_id_external_uid;
+ALTER TABLE tasks DROP COLUMN IF EXISTS external_uid;
@@ -0,0 +1,3 @@
+-- iCalendar UID of imported tasks; one event may become several tasks
+ALTER TABLE tasks ADD COLUMN external_uid varchar(255);
+CREATE INDEX idx_tasks_project_id_external_uid ON tasks (project_id, external_uid) WHERE external_uid IS NOT NULL;
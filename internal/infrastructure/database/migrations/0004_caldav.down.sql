@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_project_id_caldav_name;
+ALTER TABLE tasks DROP COLUMN IF EXISTS caldav_name;
+DROP TABLE IF EXISTS app_passwords;
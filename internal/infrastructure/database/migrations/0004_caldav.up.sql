@@ -0,0 +1,16 @@
+-- App passwords authenticate CalDAV clients with HTTP Basic auth
+CREATE TABLE app_passwords (
+    id            char(36)     PRIMARY KEY,
+    account_id    char(36)     NOT NULL,
+    name          varchar(100) NOT NULL,
+    password_hash char(64)     NOT NULL,
+    last_used_at  timestamptz,
+    created_at    timestamptz  NOT NULL,
+    revoked_at    timestamptz
+);
+CREATE INDEX idx_app_passwords_account_id ON app_passwords (account_id);
+CREATE UNIQUE INDEX idx_app_passwords_password_hash ON app_passwords (password_hash);
+
+-- Resource name a CalDAV client stored the task under, e.g. "<uuid>.ics"
+ALTER TABLE tasks ADD COLUMN caldav_name varchar(255);
+CREATE UNIQUE INDEX idx_tasks_project_id_caldav_name ON tasks (project_id, caldav_name) WHERE caldav_name IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE profiles DROP COLUMN IF EXISTS timezone;
+
+DROP INDEX IF EXISTS idx_tasks_project_id_start_datetime;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS all_day,
+    ALTER COLUMN start_datetime TYPE varchar(64) USING to_char(start_datetime AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+    ALTER COLUMN end_datetime TYPE varchar(64) USING to_char(end_datetime AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+    ALTER COLUMN recurring_until TYPE varchar(64) USING to_char(recurring_until AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"');
@@ -0,0 +1,38 @@
+-- Task times were RFC 3339 strings, which sort and compare wrongly across offsets.
+-- Values without an offset are read as UTC, whatever the zone of the session.
+SET LOCAL TimeZone = 'UTC';
+
+CREATE FUNCTION pg_temp.is_task_time(value varchar) RETURNS boolean AS $$
+BEGIN
+    PERFORM NULLIF(value, '')::timestamptz;
+    RETURN true;
+EXCEPTION WHEN others THEN
+    RETURN false;
+END
+$$ LANGUAGE plpgsql;
+
+-- Values that do not parse would be lost, so they stop the migration until fixed
+DO $$
+DECLARE
+    invalid bigint;
+BEGIN
+    SELECT count(*) INTO invalid FROM tasks
+    WHERE NOT (pg_temp.is_task_time(start_datetime)
+        AND pg_temp.is_task_time(end_datetime)
+        AND pg_temp.is_task_time(recurring_until));
+    IF invalid > 0 THEN
+        RAISE EXCEPTION '% tasks have a start_datetime, end_datetime or recurring_until that is not a time', invalid
+            USING HINT = 'Correct or clear those values, then migrate again.';
+    END IF;
+END
+$$;
+
+ALTER TABLE tasks
+    ALTER COLUMN start_datetime TYPE timestamptz USING NULLIF(start_datetime, '')::timestamptz,
+    ALTER COLUMN end_datetime TYPE timestamptz USING NULLIF(end_datetime, '')::timestamptz,
+    ALTER COLUMN recurring_until TYPE timestamptz USING NULLIF(recurring_until, '')::timestamptz,
+    ADD COLUMN all_day boolean NOT NULL DEFAULT false;
+CREATE INDEX idx_tasks_project_id_start_datetime ON tasks (project_id, start_datetime);
+
+-- IANA zone task times are rendered in for the account
+ALTER TABLE profiles ADD COLUMN timezone varchar(64) NOT NULL DEFAULT 'UTC';
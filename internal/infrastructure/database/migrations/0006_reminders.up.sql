@@ -0,0 +1,38 @@
+-- Reminders of a task for one account, delivered offset_minutes before each occurrence.
+-- remind_at is when the next delivery is due; workers claim due rows with
+-- FOR UPDATE SKIP LOCKED and hold them until locked_until.
+CREATE TABLE task_reminders (
+    id               char(36)      PRIMARY KEY,
+    task_id          char(36)      NOT NULL,
+    account_id       char(36)      NOT NULL,
+    offset_minutes   integer       NOT NULL,
+    channel          varchar(16)   NOT NULL,
+    webhook_url      varchar(2048),
+    status           varchar(16)   NOT NULL,
+    remind_at        timestamptz,
+    occurrence_start timestamptz,
+    attempts         integer       NOT NULL DEFAULT 0,
+    locked_until     timestamptz,
+    last_error       text,
+    sent_at          timestamptz,
+    created_at       timestamptz   NOT NULL,
+    updated_at       timestamptz   NOT NULL
+);
+CREATE INDEX idx_task_reminders_task_id ON task_reminders (task_id);
+CREATE INDEX idx_task_reminders_account_id ON task_reminders (account_id);
+CREATE INDEX idx_task_reminders_due ON task_reminders (remind_at) WHERE status IN ('scheduled', 'sending');
+
+-- In-app notifications; one per reminder occurrence, so a redelivery cannot duplicate it
+CREATE TABLE notifications (
+    id               char(36)     PRIMARY KEY,
+    account_id       char(36)     NOT NULL,
+    task_id          char(36),
+    reminder_id      char(36),
+    occurrence_start timestamptz,
+    title            varchar(255) NOT NULL,
+    body             text         NOT NULL,
+    read_at          timestamptz,
+    created_at       timestamptz  NOT NULL
+);
+CREATE INDEX idx_notifications_account_id_created_at ON notifications (account_id, created_at DESC);
+CREATE UNIQUE INDEX idx_notifications_reminder_occurrence ON notifications (reminder_id, occurrence_start) WHERE reminder_id IS NOT NULL;
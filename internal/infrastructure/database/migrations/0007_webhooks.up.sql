@@ -0,0 +1,41 @@
+-- Outgoing webhooks of a project. events lists the event types delivered,
+-- "*" for every type; secret signs the payloads, so it is kept as is.
+CREATE TABLE webhook_subscriptions (
+    id          char(36)      PRIMARY KEY,
+    project_id  char(36)      NOT NULL,
+    account_id  char(36)      NOT NULL,
+    url         varchar(2048) NOT NULL,
+    description varchar(255),
+    events      jsonb         NOT NULL,
+    secret      varchar(64)   NOT NULL,
+    active      boolean       NOT NULL DEFAULT true,
+    created_at  timestamptz   NOT NULL,
+    updated_at  timestamptz   NOT NULL
+);
+CREATE INDEX idx_webhook_subscriptions_project_id ON webhook_subscriptions (project_id);
+
+-- Delivery log of the webhooks, one row per event and subscription. A pending
+-- delivery is due at next_attempt_at; like reminders, workers claim due rows
+-- with FOR UPDATE SKIP LOCKED and hold them until locked_until. The payload is
+-- text so a redelivery sends the very same bytes.
+CREATE TABLE webhook_deliveries (
+    id              char(36)    PRIMARY KEY,
+    subscription_id char(36)    NOT NULL,
+    event_id        char(36)    NOT NULL,
+    event           varchar(64) NOT NULL,
+    payload         text        NOT NULL,
+    redelivery_of   char(36),
+    status          varchar(16) NOT NULL,
+    attempts        integer     NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz,
+    locked_until    timestamptz,
+    response_status integer,
+    response_body   text,
+    duration_ms     integer,
+    last_error      text,
+    delivered_at    timestamptz,
+    created_at      timestamptz NOT NULL,
+    updated_at      timestamptz NOT NULL
+);
+CREATE INDEX idx_webhook_deliveries_subscription_id_created_at ON webhook_deliveries (subscription_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status IN ('pending', 'sending');
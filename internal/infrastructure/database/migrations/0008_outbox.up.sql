@@ -0,0 +1,25 @@
+-- Events of task and project changes, recorded in the transaction of the
+-- change. A relay dispatches them to the in-process handlers; like reminders,
+-- relays claim due rows with FOR UPDATE SKIP LOCKED and hold them until
+-- locked_until. handled lists the handlers that are done with an event.
+CREATE TABLE outbox_events (
+    seq             bigserial   NOT NULL UNIQUE,
+    id              char(36)    PRIMARY KEY,
+    type            varchar(64) NOT NULL,
+    project_id      char(36)    NOT NULL,
+    data            jsonb       NOT NULL,
+    occurred_at     timestamptz NOT NULL,
+    status          varchar(16) NOT NULL,
+    attempts        integer     NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz,
+    locked_until    timestamptz,
+    handled         jsonb       NOT NULL DEFAULT '[]',
+    last_error      text,
+    dispatched_at   timestamptz,
+    updated_at      timestamptz NOT NULL
+);
+CREATE INDEX idx_outbox_events_due ON outbox_events (seq) WHERE status IN ('pending', 'dispatching');
+CREATE INDEX idx_outbox_events_dispatched_at ON outbox_events (dispatched_at) WHERE status = 'dispatched';
+
+-- An event handled again must not queue its webhook deliveries twice
+CREATE UNIQUE INDEX idx_webhook_deliveries_subscription_id_event_id ON webhook_deliveries (subscription_id, event_id) WHERE redelivery_of IS NULL;
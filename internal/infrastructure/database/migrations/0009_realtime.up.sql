@@ -0,0 +1,26 @@
+-- Open event streams of a project, one row per connection. Instances touch
+-- seen_at of their connections while they are open; a row left behind by an
+-- instance that died expires when it is not seen for a while.
+CREATE TABLE project_viewers (
+    connection_id char(36)     PRIMARY KEY,
+    project_id    char(36)     NOT NULL,
+    account_id    char(36)     NOT NULL,
+    username      varchar(100) NOT NULL,
+    connected_at  timestamptz  NOT NULL,
+    seen_at       timestamptz  NOT NULL
+);
+CREATE INDEX idx_project_viewers_project_id ON project_viewers (project_id);
+CREATE INDEX idx_project_viewers_seen_at ON project_viewers (seen_at);
+
+-- Announces every outbox event to the instances streaming its project once
+-- its transaction commits, as "<project id>:<seq>"
+CREATE FUNCTION notify_outbox_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', NEW.project_id || ':' || NEW.seq);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER outbox_events_notify
+    AFTER INSERT ON outbox_events
+    FOR EACH ROW EXECUTE FUNCTION notify_outbox_event();
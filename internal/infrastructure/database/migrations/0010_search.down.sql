@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_projects_search_name;
+DROP INDEX IF EXISTS idx_projects_search_vector;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector;
+DROP INDEX IF EXISTS idx_tasks_search_text;
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_text;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,22 @@
+-- Full-text search over tasks and projects. search_vector holds the English
+-- lexemes weighted by field for ranking; Thai is written without spaces
+-- between words, which the parser cannot split, so search_text is matched by
+-- substring as well, served by trigram indexes.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE tasks ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(location, '')), 'C')
+) STORED;
+ALTER TABLE tasks ADD COLUMN search_text text GENERATED ALWAYS AS (
+    lower(coalesce(name, '') || ' ' || coalesce(description, '') || ' ' || coalesce(location, ''))
+) STORED;
+CREATE INDEX idx_tasks_search_vector ON tasks USING gin (search_vector);
+CREATE INDEX idx_tasks_search_text ON tasks USING gin (search_text gin_trgm_ops);
+
+ALTER TABLE projects ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A')
+) STORED;
+CREATE INDEX idx_projects_search_vector ON projects USING gin (search_vector);
+CREATE INDEX idx_projects_search_name ON projects USING gin (lower(name) gin_trgm_ops);
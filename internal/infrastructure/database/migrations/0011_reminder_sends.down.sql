@@ -0,0 +1,2 @@
+ALTER TABLE task_reminders DROP COLUMN IF EXISTS webhook_secret;
+DROP TABLE IF EXISTS reminder_sends;
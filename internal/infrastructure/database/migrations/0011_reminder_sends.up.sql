@@ -0,0 +1,13 @@
+-- Email reminders sent, one row per reminder occurrence. A worker inserts the
+-- row before handing the email to the mail server, so an occurrence claimed
+-- again after a crash finds it and is not emailed a second time.
+CREATE TABLE reminder_sends (
+    reminder_id      char(36)    NOT NULL REFERENCES task_reminders (id) ON DELETE CASCADE,
+    occurrence_start timestamptz NOT NULL,
+    claimed_at       timestamptz NOT NULL,
+    PRIMARY KEY (reminder_id, occurrence_start)
+);
+
+-- Signs the payloads of webhook reminders. Reminders created before it have
+-- none and are posted unsigned.
+ALTER TABLE task_reminders ADD COLUMN webhook_secret varchar(64);
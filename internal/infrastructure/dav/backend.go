@@ -0,0 +1,302 @@
+package dav
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/service"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/google/uuid"
+)
+
+// MaxObjectBytes bounds one calendar object; a task event is well under a kilobyte
+const MaxObjectBytes = 1 << 20
+
+// calendarsSegment names the calendar home set below the principal
+const calendarsSegment = "calendars"
+
+type accountKey struct{}
+
+type ifMatchKey struct{}
+
+func withAccount(ctx context.Context, acc *entity.Account) context.Context {
+	return context.WithValue(ctx, accountKey{}, acc)
+}
+
+func accountFromContext(ctx context.Context) (*entity.Account, error) {
+	acc, ok := ctx.Value(accountKey{}).(*entity.Account)
+	if !ok {
+		return nil, webdav.NewHTTPError(http.StatusUnauthorized, nil)
+	}
+	return acc, nil
+}
+
+// Calendars is the calendar data the server reads and writes, implemented by
+// service.CalendarService
+type Calendars interface {
+	ListCalendarProjects(ctx context.Context, accountID uuid.UUID) ([]*projectEntity.Project, error)
+	GetCalendarProject(ctx context.Context, accountID, projectID uuid.UUID) (*projectEntity.Project, error)
+	ListCalendarObjects(ctx context.Context, accountID, projectID uuid.UUID) ([]service.CalendarObject, error)
+	GetCalendarObject(ctx context.Context, accountID, projectID uuid.UUID, name string) (*service.CalendarObject, error)
+	PutCalendarObject(ctx context.Context, accountID, projectID uuid.UUID, name string, r io.Reader, pre service.ObjectPreconditions) (*service.CalendarObject, bool, error)
+	DeleteCalendarObject(ctx context.Context, accountID, projectID uuid.UUID, name string, pre service.ObjectPreconditions) (*taskEntity.Task, error)
+}
+
+// backend serves the projects of the authenticated account as calendars:
+//
+//	<prefix>/<account ID>/                                 principal
+//	<prefix>/<account ID>/calendars/                       calendar home set
+//	<prefix>/<account ID>/calendars/<project ID>/          calendar
+//	<prefix>/<account ID>/calendars/<project ID>/<name>    calendar object
+type backend struct {
+	calendars Calendars
+	prefix    string
+	logger    logger.Logger
+}
+
+var _ caldav.Backend = (*backend)(nil)
+
+func (b *backend) CurrentUserPrincipal(ctx context.Context) (string, error) {
+	acc, err := accountFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return b.prefix + "/" + utils.ShortUUIDWithPrefix(acc.ID, entity.AccountIDPrefix) + "/", nil
+}
+
+func (b *backend) CalendarHomeSetPath(ctx context.Context) (string, error) {
+	principal, err := b.CurrentUserPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	return principal + calendarsSegment + "/", nil
+}
+
+// CreateCalendar refuses MKCALENDAR; calendars are created as projects in the app
+func (b *backend) CreateCalendar(ctx context.Context, calendar *caldav.Calendar) error {
+	return webdav.NewHTTPError(http.StatusForbidden, errors.New("calendars are created as projects"))
+}
+
+func (b *backend) ListCalendars(ctx context.Context) ([]caldav.Calendar, error) {
+	acc, err := accountFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	home, err := b.CalendarHomeSetPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := b.calendars.ListCalendarProjects(ctx, acc.ID)
+	if err != nil {
+		return nil, b.httpError(ctx, err)
+	}
+
+	calendars := make([]caldav.Calendar, len(projects))
+	for i, p := range projects {
+		calendars[i] = toCalendar(home, p)
+	}
+	return calendars, nil
+}
+
+func (b *backend) GetCalendar(ctx context.Context, reqPath string) (*caldav.Calendar, error) {
+	acc, projectID, _, err := b.parsePath(ctx, reqPath, false)
+	if err != nil {
+		return nil, err
+	}
+	home, err := b.CalendarHomeSetPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := b.calendars.GetCalendarProject(ctx, acc.ID, projectID)
+	if err != nil {
+		return nil, b.httpError(ctx, err)
+	}
+
+	calendar := toCalendar(home, project)
+	return &calendar, nil
+}
+
+func (b *backend) GetCalendarObject(ctx context.Context, reqPath string, req *caldav.CalendarCompRequest) (*caldav.CalendarObject, error) {
+	acc, projectID, name, err := b.parsePath(ctx, reqPath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := b.calendars.GetCalendarObject(ctx, acc.ID, projectID, name)
+	if err != nil {
+		return nil, b.httpError(ctx, err)
+	}
+
+	return b.toCalendarObject(ctx, path.Dir(reqPath)+"/", object)
+}
+
+func (b *backend) ListCalendarObjects(ctx context.Context, reqPath string, req *caldav.CalendarCompRequest) ([]caldav.CalendarObject, error) {
+	acc, projectID, _, err := b.parsePath(ctx, reqPath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := b.calendars.ListCalendarObjects(ctx, acc.ID, projectID)
+	if err != nil {
+		return nil, b.httpError(ctx, err)
+	}
+
+	collection := strings.TrimSuffix(reqPath, "/") + "/"
+	result := make([]caldav.CalendarObject, 0, len(objects))
+	for i := range objects {
+		co, err := b.toCalendarObject(ctx, collection, &objects[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *co)
+	}
+	return result, nil
+}
+
+// QueryCalendarObjects filters in memory, projects are small enough for that
+func (b *backend) QueryCalendarObjects(ctx context.Context, reqPath string, query *caldav.CalendarQuery) ([]caldav.CalendarObject, error) {
+	objects, err := b.ListCalendarObjects(ctx, reqPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return caldav.Filter(query, objects)
+}
+
+func (b *backend) PutCalendarObject(ctx context.Context, reqPath string, calendar *ical.Calendar, opts *caldav.PutCalendarObjectOptions) (*caldav.CalendarObject, error) {
+	acc, projectID, name, err := b.parsePath(ctx, reqPath, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := ical.NewEncoder(&buf).Encode(calendar); err != nil {
+		return nil, webdav.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	pre := service.ObjectPreconditions{
+		IfMatch:     conditionalETag(opts.IfMatch),
+		IfNoneMatch: conditionalETag(opts.IfNoneMatch),
+	}
+	if _, _, err := b.calendars.PutCalendarObject(ctx, acc.ID, projectID, name, &buf, pre); err != nil {
+		return nil, b.httpError(ctx, err)
+	}
+
+	// No ETag: the stored object is rendered from the task and differs from the
+	// request body, so clients must fetch it again (RFC 4791 section 5.3.4)
+	return &caldav.CalendarObject{Path: reqPath}, nil
+}
+
+func (b *backend) DeleteCalendarObject(ctx context.Context, reqPath string) error {
+	acc, projectID, name, err := b.parsePath(ctx, reqPath, true)
+	if err != nil {
+		return err
+	}
+
+	ifMatch, _ := ctx.Value(ifMatchKey{}).(webdav.ConditionalMatch)
+	pre := service.ObjectPreconditions{IfMatch: conditionalETag(ifMatch)}
+	if _, err := b.calendars.DeleteCalendarObject(ctx, acc.ID, projectID, name, pre); err != nil {
+		return b.httpError(ctx, err)
+	}
+	return nil
+}
+
+// parsePath returns the project of a calendar path, and the object name as well
+// when object is true. Paths of other accounts are not found.
+func (b *backend) parsePath(ctx context.Context, reqPath string, object bool) (*entity.Account, uuid.UUID, string, error) {
+	acc, err := accountFromContext(ctx)
+	if err != nil {
+		return nil, uuid.Nil, "", err
+	}
+	notFound := webdav.NewHTTPError(http.StatusNotFound, nil)
+
+	rest, ok := strings.CutPrefix(path.Clean(reqPath), b.prefix+"/")
+	if !ok {
+		return nil, uuid.Nil, "", notFound
+	}
+	segments := strings.Split(rest, "/")
+	if (object && len(segments) != 4) || (!object && len(segments) != 3) {
+		return nil, uuid.Nil, "", notFound
+	}
+	if segments[0] != utils.ShortUUIDWithPrefix(acc.ID, entity.AccountIDPrefix) || segments[1] != calendarsSegment {
+		return nil, uuid.Nil, "", notFound
+	}
+
+	projectID, err := utils.ParseID(segments[2], projectEntity.ProjectIDPrefix)
+	if err != nil || !utils.HasIDPrefix(segments[2], projectEntity.ProjectIDPrefix) {
+		return nil, uuid.Nil, "", notFound
+	}
+
+	if !object {
+		return acc, projectID, "", nil
+	}
+	return acc, projectID, segments[3], nil
+}
+
+func (b *backend) toCalendarObject(ctx context.Context, collection string, object *service.CalendarObject) (*caldav.CalendarObject, error) {
+	data, err := ical.NewDecoder(bytes.NewReader(object.Data)).Decode()
+	if err != nil {
+		return nil, b.httpError(ctx, apperror.NewInternalServerError("failed to decode calendar object", "ENCODE_CALENDAR_ERROR", err))
+	}
+
+	return &caldav.CalendarObject{
+		Path:          collection + object.Name,
+		ModTime:       object.Task.UpdatedAt,
+		ContentLength: int64(len(object.Data)),
+		ETag:          object.ETag,
+		Data:          data,
+	}, nil
+}
+
+// httpError maps application errors to WebDAV responses. The library writes the
+// error text into the response body, so server errors are logged and masked.
+func (b *backend) httpError(ctx context.Context, err error) error {
+	appErr, ok := apperror.IsAppError(err)
+	if !ok || appErr.Status >= http.StatusInternalServerError {
+		b.logger.ErrorContext(ctx, "CalDAV request failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return webdav.NewHTTPError(http.StatusInternalServerError, nil)
+	}
+
+	if appErr.Code == "CALENDAR_OBJECT_UID_CONFLICT" {
+		return caldav.NewPreconditionError(caldav.PreconditionNoUIDConflict)
+	}
+	return webdav.NewHTTPError(appErr.Status, errors.New(appErr.Message))
+}
+
+func toCalendar(home string, p *projectEntity.Project) caldav.Calendar {
+	return caldav.Calendar{
+		Path:                  home + utils.ShortUUIDWithPrefix(p.ID, projectEntity.ProjectIDPrefix) + "/",
+		Name:                  p.Name,
+		MaxResourceSize:       MaxObjectBytes,
+		SupportedComponentSet: []string{ical.CompEvent},
+	}
+}
+
+// conditionalETag unquotes an If-Match or If-None-Match value, keeping "*"
+func conditionalETag(m webdav.ConditionalMatch) string {
+	if !m.IsSet() || m.IsWildcard() {
+		return string(m)
+	}
+	etag, err := m.ETag()
+	if err != nil {
+		// Matches no ETag, so the write fails its precondition
+		return string(m)
+	}
+	return etag
+}
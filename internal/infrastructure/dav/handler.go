@@ -0,0 +1,78 @@
+package dav
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+)
+
+// RequestMethods are the WebDAV methods the HTTP server must accept besides the standard ones
+var RequestMethods = []string{"PROPFIND", "PROPPATCH", "REPORT", "MKCOL", "COPY", "MOVE"}
+
+// maxRequestBytes leaves room for REPORT bodies listing many objects
+const maxRequestBytes = 4 * MaxObjectBytes
+
+const basicRealm = `Basic realm="Smart Task AI", charset="UTF-8"`
+
+// AuthenticateFunc checks HTTP Basic credentials
+type AuthenticateFunc func(ctx context.Context, user, password string) (*entity.Account, error)
+
+// Handler is a CalDAV server for the projects of the account logging in with
+// an app password
+type Handler struct {
+	caldav       *caldav.Handler
+	authenticate AuthenticateFunc
+	logger       logger.Logger
+}
+
+// NewHandler serves CalDAV below prefix, e.g. "/dav"
+func NewHandler(calendars Calendars, authenticate AuthenticateFunc, prefix string, l logger.Logger) *Handler {
+	return &Handler{
+		caldav: &caldav.Handler{
+			Backend: &backend{calendars: calendars, prefix: prefix, logger: l},
+			Prefix:  prefix,
+		},
+		authenticate: authenticate,
+		logger:       l,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		h.challenge(w)
+		return
+	}
+
+	acc, err := h.authenticate(r.Context(), user, password)
+	if err != nil {
+		if appErr, ok := apperror.IsAppError(err); ok && appErr.Status < http.StatusInternalServerError {
+			h.challenge(w)
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "CalDAV authentication failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	ctx := withAccount(r.Context(), acc)
+	if r.Method == http.MethodDelete {
+		// The library does not pass If-Match on to DeleteCalendarObject
+		ctx = context.WithValue(ctx, ifMatchKey{}, webdav.ConditionalMatch(r.Header.Get("If-Match")))
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBytes)
+
+	h.caldav.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func (h *Handler) challenge(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", basicRealm)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
@@ -0,0 +1,210 @@
+package dav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/service"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	taskSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/FrostBitzX/smart-task-ai/internal/utils"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestServer serves one account with one project, backed by an in-memory task store
+func newTestServer(t *testing.T) (*httptest.Server, *entity.Account, *projectEntity.Project, map[uuid.UUID]*taskEntity.Task) {
+	ctrl := gomock.NewController(t)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+
+	acc := &entity.Account{ID: uuid.New(), Username: "testuser", State: entity.AccountStateActive}
+	project := &projectEntity.Project{ID: uuid.New(), AccountID: acc.ID, Name: "Work"}
+	existing := &taskEntity.Task{
+		ID:            uuid.New(),
+		ProjectID:     project.ID,
+		Name:          "Review",
+		Status:        "todo",
+		StartDateTime: lo.ToPtr(time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC)),
+		UpdatedAt:     time.Now(),
+	}
+	store := map[uuid.UUID]*taskEntity.Task{existing.ID: existing}
+
+	projectRepo.EXPECT().ListAllProjectsByAccountID(gomock.Any(), acc.ID).Return([]*projectEntity.Project{project}, nil).AnyTimes()
+	projectRepo.EXPECT().GetProjectByID(gomock.Any(), project.ID).Return(project, nil).AnyTimes()
+	taskRepo.EXPECT().ListTasksByProject(gomock.Any(), project.ID).DoAndReturn(func(context.Context, uuid.UUID) ([]*taskEntity.Task, error) {
+		return lo.Values(store), nil
+	}).AnyTimes()
+	taskRepo.EXPECT().GetTaskByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uuid.UUID) (*taskEntity.Task, error) {
+		if task, ok := store[id]; ok {
+			return task, nil
+		}
+		return nil, apperror.ErrRecordNotFound
+	}).AnyTimes()
+	taskRepo.EXPECT().GetTaskByCalDAVName(gomock.Any(), project.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, name string) (*taskEntity.Task, error) {
+		for _, task := range store {
+			if lo.FromPtr(task.CalDAVName) == name {
+				return task, nil
+			}
+		}
+		return nil, apperror.ErrRecordNotFound
+	}).AnyTimes()
+	taskRepo.EXPECT().ListExternalUIDs(gomock.Any(), project.ID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, uids []string) ([]string, error) {
+		var found []string
+		for _, task := range store {
+			if lo.Contains(uids, lo.FromPtr(task.ExternalUID)) {
+				found = append(found, *task.ExternalUID)
+			}
+		}
+		return found, nil
+	}).AnyTimes()
+	save := func(_ context.Context, task *taskEntity.Task) error {
+		store[task.ID] = task
+		return nil
+	}
+	taskRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any()).DoAndReturn(save).AnyTimes()
+	taskRepo.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).DoAndReturn(save).AnyTimes()
+	taskRepo.EXPECT().DeleteTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id uuid.UUID) error {
+		delete(store, id)
+		return nil
+	}).AnyTimes()
+	taskRepo.EXPECT().ListTasksByAccount(gomock.Any(), acc.ID).DoAndReturn(func(context.Context, uuid.UUID) ([]*taskEntity.Task, error) {
+		return lo.Values(store), nil
+	}).AnyTimes()
+
+	outbox := mocks.NewMockOutbox(ctrl)
+	outbox.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	outbox.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	authenticate := func(_ context.Context, user, password string) (*entity.Account, error) {
+		if user != acc.Username || password != "app-password" {
+			return nil, apperror.NewUnauthorizedError("invalid username or app password", "INVALID_APP_PASSWORD", nil)
+		}
+		return acc, nil
+	}
+
+	taskService := taskSvc.NewTaskService(taskRepo, projectRepo, outbox)
+	calendarService := service.NewCalendarService(mocks.NewMockFeedRepository(ctrl), projectRepo, taskRepo, taskService)
+	srv := httptest.NewServer(NewHandler(calendarService, authenticate, "/dav", logger.NewZapLogger("test", "error")))
+	t.Cleanup(srv.Close)
+	return srv, acc, project, store
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, password string) *caldav.Client {
+	client, err := caldav.NewClient(webdav.HTTPClientWithBasicAuth(srv.Client(), "testuser", password), srv.URL+"/dav/")
+	require.NoError(t, err)
+	return client
+}
+
+func newEvent(uid, summary string, start time.Time) *ical.Calendar {
+	event := ical.NewEvent()
+	event.Props.SetText(ical.PropUID, uid)
+	event.Props.SetText(ical.PropSummary, summary)
+	event.Props.SetDateTime(ical.PropDateTimeStamp, start)
+	event.Props.SetDateTime(ical.PropDateTimeStart, start)
+	event.Props.SetDateTime(ical.PropDateTimeEnd, start.Add(time.Hour))
+
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//test//EN")
+	cal.Children = append(cal.Children, event.Component)
+	return cal
+}
+
+func TestHandler_Sync(t *testing.T) {
+	srv, acc, project, store := newTestServer(t)
+	client := newTestClient(t, srv, "app-password")
+	ctx := context.Background()
+
+	principal, err := client.FindCurrentUserPrincipal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "/dav/"+utils.ShortUUIDWithPrefix(acc.ID, entity.AccountIDPrefix)+"/", principal)
+
+	home, err := client.FindCalendarHomeSet(ctx, principal)
+	require.NoError(t, err)
+
+	calendars, err := client.FindCalendars(ctx, home)
+	require.NoError(t, err)
+	require.Len(t, calendars, 1)
+	assert.Equal(t, "Work", calendars[0].Name)
+	assert.Equal(t, home+utils.ShortUUIDWithPrefix(project.ID, projectEntity.ProjectIDPrefix)+"/", calendars[0].Path)
+	calendarPath := calendars[0].Path
+
+	// Create from the client
+	start := time.Date(2026, 3, 2, 2, 0, 0, 0, time.UTC)
+	_, err = client.PutCalendarObject(ctx, calendarPath+"lecture.ics", newEvent("lecture@example.com", "Lecture", start))
+	require.NoError(t, err)
+
+	query := &caldav.CalendarQuery{
+		CompRequest: caldav.CalendarCompRequest{Name: ical.CompCalendar, Comps: []caldav.CalendarCompRequest{{Name: ical.CompEvent, AllProps: true}}},
+		CompFilter:  caldav.CompFilter{Name: ical.CompCalendar, Comps: []caldav.CompFilter{{Name: ical.CompEvent}}},
+	}
+	objects, err := client.QueryCalendar(ctx, calendarPath, query)
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	summaries := lo.Map(objects, func(o caldav.CalendarObject, _ int) string {
+		return o.Data.Events()[0].Props.Get(ical.PropSummary).Value
+	})
+	assert.ElementsMatch(t, []string{"Review", "Lecture"}, summaries)
+
+	// Update from the client, the ETag changes
+	before, err := client.GetCalendarObject(ctx, calendarPath+"lecture.ics")
+	require.NoError(t, err)
+	require.NotEmpty(t, before.ETag)
+	_, err = client.PutCalendarObject(ctx, calendarPath+"lecture.ics", newEvent("lecture@example.com", "Lecture (moved)", start.Add(time.Hour)))
+	require.NoError(t, err)
+	after, err := client.GetCalendarObject(ctx, calendarPath+"lecture.ics")
+	require.NoError(t, err)
+	assert.NotEqual(t, before.ETag, after.ETag)
+	assert.Equal(t, "Lecture (moved)", after.Data.Events()[0].Props.Get(ical.PropSummary).Value)
+
+	// A stale ETag does not overwrite or delete
+	req, err := http.NewRequest(http.MethodDelete, srv.URL+calendarPath+"lecture.ics", nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("testuser", "app-password")
+	req.Header.Set("If-Match", `"`+before.ETag+`"`)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	// A second object with the same UID conflicts
+	_, err = client.PutCalendarObject(ctx, calendarPath+"copy.ics", newEvent("lecture@example.com", "Copy", start))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "409")
+
+	require.NoError(t, client.RemoveAll(ctx, calendarPath+"lecture.ics"))
+	assert.Len(t, store, 1)
+}
+
+func TestHandler_Authentication(t *testing.T) {
+	srv, _, _, _ := newTestServer(t)
+
+	_, err := newTestClient(t, srv, "wrong").FindCurrentUserPrincipal(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+
+	resp, err := srv.Client().Get(srv.URL + "/.well-known/caldav")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.True(t, strings.HasPrefix(resp.Header.Get("WWW-Authenticate"), "Basic "))
+}
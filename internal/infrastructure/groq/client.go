@@ -5,12 +5,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
-	"os"
 	"strings"
 	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -27,6 +37,8 @@ const (
 type GroqClient interface {
 	SendChatCompletion(ctx context.Context, req *ChatCompletionRequest) (*ChatCompletionResponse, error)
 	SendChatCompletionStream(ctx context.Context, req *ChatCompletionRequest) (<-chan StreamChunk, error)
+	// Ping lists the models, which checks reachability and the API key without using tokens
+	Ping(ctx context.Context) error
 }
 
 // ChatCompletionRequest represents a request to the Groq chat completion API
@@ -114,6 +126,7 @@ type groqClient struct {
 	apiKey     string
 	apiURL     string
 	httpClient *http.Client
+	logger     logger.Logger
 }
 
 // ClientOption is a function that configures the groqClient
@@ -133,31 +146,25 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
-// NewGroqClient creates a new Groq client
-func NewGroqClient(opts ...ClientOption) (GroqClient, error) {
-	apiKey := os.Getenv("GROQ_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("GROQ_API_KEY environment variable is not set")
-	}
-
-	apiURL := os.Getenv("GROQ_API_URL")
-	if apiURL == "" {
-		apiURL = DefaultAPIURL
+// WithLogger logs every API call with the correlation fields of its context
+func WithLogger(l logger.Logger) ClientOption {
+	return func(c *groqClient) {
+		c.logger = l
 	}
+}
 
-	client := &groqClient{
-		apiKey: apiKey,
-		apiURL: apiURL,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
+// NewGroqClient creates a new Groq client from GROQ_API_KEY and GROQ_API_URL
+func NewGroqClient(cfg *config.Config, opts ...ClientOption) (GroqClient, error) {
+	if cfg.GroqAPIKey == "" {
+		return nil, fmt.Errorf("GROQ_API_KEY is not set")
 	}
 
-	for _, opt := range opts {
-		opt(client)
+	apiURL := cfg.GroqAPIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
 	}
 
-	return client, nil
+	return NewGroqClientWithKey(cfg.GroqAPIKey, append([]ClientOption{WithAPIURL(apiURL)}, opts...)...)
 }
 
 // NewGroqClientWithKey creates a new Groq client with explicit API key
@@ -166,16 +173,12 @@ func NewGroqClientWithKey(apiKey string, opts ...ClientOption) (GroqClient, erro
 		return nil, fmt.Errorf("API key is required")
 	}
 
-	apiURL := os.Getenv("GROQ_API_URL")
-	if apiURL == "" {
-		apiURL = DefaultAPIURL
-	}
-
 	client := &groqClient{
 		apiKey: apiKey,
-		apiURL: apiURL,
+		apiURL: DefaultAPIURL,
 		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
+			Timeout:   DefaultTimeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		},
 	}
 
@@ -209,6 +212,9 @@ func (c *groqClient) SendChatCompletion(ctx context.Context, req *ChatCompletion
 	// Ensure stream is false for non-streaming requests
 	req.Stream = false
 
+	ctx, span := startSpan(ctx, req)
+	defer span.End()
+
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -219,11 +225,12 @@ func (c *groqClient) SendChatCompletion(ctx context.Context, req *ChatCompletion
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(ctx, httpReq)
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
+		c.recordCall(ctx, req, start, 0, err, nil)
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("request timeout after %v", DefaultTimeout)
 		}
@@ -233,22 +240,23 @@ func (c *groqClient) SendChatCompletion(ctx context.Context, req *ChatCompletion
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		c.recordCall(ctx, req, start, resp.StatusCode, err, nil)
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error.Message != "" {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, apiErr.Error.Message)
-		}
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		err := apiError(resp.StatusCode, respBody)
+		c.recordCall(ctx, req, start, resp.StatusCode, err, nil)
+		return nil, err
 	}
 
 	var chatResp ChatCompletionResponse
 	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		c.recordCall(ctx, req, start, resp.StatusCode, err, nil)
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	c.recordCall(ctx, req, start, resp.StatusCode, nil, &chatResp.Usage)
 	return &chatResp, nil
 }
 
@@ -262,6 +270,10 @@ func (c *groqClient) SendChatCompletionStream(ctx context.Context, req *ChatComp
 	// Ensure stream is true for streaming requests
 	req.Stream = true
 
+	// The span covers the time to the first byte, like the latency metric
+	ctx, span := startSpan(ctx, req)
+	defer span.End()
+
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
@@ -272,14 +284,15 @@ func (c *groqClient) SendChatCompletionStream(ctx context.Context, req *ChatComp
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	c.setHeaders(ctx, httpReq)
 	httpReq.Header.Set("Accept", "text/event-stream")
 
 	// Create a client without timeout for streaming
-	streamClient := &http.Client{}
+	streamClient := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	start := time.Now()
 	resp, err := streamClient.Do(httpReq)
 	if err != nil {
+		c.recordCall(ctx, req, start, 0, err, nil)
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("request timeout")
 		}
@@ -289,13 +302,14 @@ func (c *groqClient) SendChatCompletionStream(ctx context.Context, req *ChatComp
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
 		respBody, _ := io.ReadAll(resp.Body)
-		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error.Message != "" {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, apiErr.Error.Message)
-		}
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		err := apiError(resp.StatusCode, respBody)
+		c.recordCall(ctx, req, start, resp.StatusCode, err, nil)
+		return nil, err
 	}
 
+	// Latency here is the time to the first byte; the stream itself may run much longer
+	c.recordCall(ctx, req, start, resp.StatusCode, nil, nil)
+
 	chunkChan := make(chan StreamChunk)
 
 	go func() {
@@ -364,3 +378,125 @@ func (c *groqClient) SendChatCompletionStream(ctx context.Context, req *ChatComp
 
 	return chunkChan, nil
 }
+
+// Ping sends GET /models next to the chat completions endpoint
+func (c *groqClient) Ping(ctx context.Context) error {
+	modelsURL := strings.TrimSuffix(c.apiURL, "/chat/completions") + "/models"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(ctx, httpReq)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return apiError(resp.StatusCode, body)
+	}
+	return nil
+}
+
+// setHeaders adds the credentials and forwards the request ID for support tickets
+func (c *groqClient) setHeaders(ctx context.Context, httpReq *http.Request) {
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if id := logger.RequestIDFromContext(ctx); id != "" {
+		httpReq.Header.Set("X-Request-ID", id)
+	}
+}
+
+// apiError prefers the message of a structured Groq error over the raw body
+func apiError(status int, body []byte) error {
+	var apiErr APIError
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Message != "" {
+		return fmt.Errorf("API error (status %d): %s", status, apiErr.Error.Message)
+	}
+	return fmt.Errorf("API error (status %d): %s", status, string(body))
+}
+
+// startSpan follows the OpenTelemetry GenAI conventions; the HTTP round trip is a child span
+func startSpan(ctx context.Context, req *ChatCompletionRequest) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "chat "+req.Model,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.GenAIProviderNameGroq,
+			semconv.GenAIOperationNameChat,
+			semconv.GenAIRequestModel(req.Model),
+		),
+	)
+}
+
+// recordCall logs and measures one API call; status is 0 when no response was received
+func (c *groqClient) recordCall(ctx context.Context, req *ChatCompletionRequest, start time.Time, status int, err error, usage *Usage) {
+	latency := time.Since(start)
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+		metrics.GroqErrors.WithLabelValues(req.Model, errorClass(ctx, status, err)).Inc()
+	}
+	metrics.GroqDuration.WithLabelValues(req.Model, outcome).Observe(latency.Seconds())
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if status != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	}
+	if usage != nil {
+		span.SetAttributes(
+			semconv.GenAIUsageInputTokens(usage.PromptTokens),
+			semconv.GenAIUsageOutputTokens(usage.CompletionTokens),
+		)
+		metrics.GroqTokens.WithLabelValues(req.Model, "prompt").Add(float64(usage.PromptTokens))
+		metrics.GroqTokens.WithLabelValues(req.Model, "completion").Add(float64(usage.CompletionTokens))
+	}
+
+	if c.logger == nil {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"model":   req.Model,
+		"stream":  req.Stream,
+		"status":  status,
+		"latency": latency.String(),
+	}
+	if usage != nil {
+		fields["prompt_tokens"] = usage.PromptTokens
+		fields["completion_tokens"] = usage.CompletionTokens
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		c.logger.WarnContext(ctx, "Groq API call failed", fields)
+		return
+	}
+	c.logger.InfoContext(ctx, "Groq API call", fields)
+}
+
+// errorClass buckets failures into a small fixed set of metric label values
+func errorClass(ctx context.Context, status int, err error) string {
+	var netErr net.Error
+	switch {
+	case status == http.StatusTooManyRequests:
+		return "rate_limited"
+	case status >= 500:
+		return "server_error"
+	case status >= 400:
+		return "client_error"
+	case errors.Is(ctx.Err(), context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(ctx.Err(), context.Canceled):
+		return "canceled"
+	case status == 0:
+		return "network"
+	default:
+		return "invalid_response"
+	}
+}
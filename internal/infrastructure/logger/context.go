@@ -0,0 +1,39 @@
+package logger
+
+import "context"
+
+// Correlation field names shared by every log line of a request
+const (
+	RequestIDField = "request_id"
+	AccountIDField = "account_id"
+)
+
+type contextFieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx carrying fields in addition to the ones already set
+func ContextWithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	parent := FieldsFromContext(ctx)
+	merged := make(map[string]interface{}, len(parent)+len(fields))
+	for k, v := range parent {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, contextFieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the correlation fields stored in ctx; the map must not be modified
+func FieldsFromContext(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextFieldsKey{}).(map[string]interface{})
+	return fields
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := FieldsFromContext(ctx)[RequestIDField].(string)
+	return id
+}
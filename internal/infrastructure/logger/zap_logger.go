@@ -1,7 +1,7 @@
 package logger
 
 import (
-	"os"
+	"context"
 	"strings"
 
 	"go.uber.org/zap"
@@ -14,17 +14,23 @@ type Logger interface {
 	Error(msg string, fields ...map[string]interface{})
 	Debug(msg string, fields ...map[string]interface{})
 	With(fields map[string]interface{}) Logger
+
+	// The Context variants add the correlation fields stored by ContextWithFields
+	InfoContext(ctx context.Context, msg string, fields ...map[string]interface{})
+	WarnContext(ctx context.Context, msg string, fields ...map[string]interface{})
+	ErrorContext(ctx context.Context, msg string, fields ...map[string]interface{})
+	DebugContext(ctx context.Context, msg string, fields ...map[string]interface{})
 }
 
 type ZapLogger struct {
 	logger *zap.Logger
 }
 
-// APP_ENV=development | staging | production
-// LOG_LEVEL=debug | info | warn | error
-func NewZapLogger() *ZapLogger {
-	env := strings.ToLower(os.Getenv("APP_ENV"))
-	level := strings.ToLower(os.Getenv("LOG_LEVEL"))
+// env is APP_ENV (development | staging | production),
+// level is LOG_LEVEL (debug | info | warn | error)
+func NewZapLogger(env, level string) *ZapLogger {
+	env = strings.ToLower(env)
+	level = strings.ToLower(level)
 
 	var zapConfig zap.Config
 	if env == "production" {
@@ -97,3 +103,54 @@ func (z *ZapLogger) With(fields map[string]interface{}) Logger {
 	}
 	return &ZapLogger{logger: z.logger.With(f...)}
 }
+
+func (z *ZapLogger) InfoContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	logger := z.fromContext(ctx)
+	if len(fields) > 0 {
+		logger.Info(msg, zap.Any("fields", fields[0]))
+	} else {
+		logger.Info(msg)
+	}
+}
+
+func (z *ZapLogger) WarnContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	logger := z.fromContext(ctx)
+	if len(fields) > 0 {
+		logger.Warn(msg, zap.Any("fields", fields[0]))
+	} else {
+		logger.Warn(msg)
+	}
+}
+
+func (z *ZapLogger) ErrorContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	logger := z.fromContext(ctx)
+	if len(fields) > 0 {
+		logger.Error(msg, zap.Any("fields", fields[0]))
+	} else {
+		logger.Error(msg)
+	}
+}
+
+func (z *ZapLogger) DebugContext(ctx context.Context, msg string, fields ...map[string]interface{}) {
+	logger := z.fromContext(ctx)
+	if len(fields) > 0 {
+		logger.Debug(msg, zap.Any("fields", fields[0]))
+	} else {
+		logger.Debug(msg)
+	}
+}
+
+// fromContext skips the wrapper frame and attaches the correlation fields at the top level
+func (z *ZapLogger) fromContext(ctx context.Context) *zap.Logger {
+	logger := z.logger.WithOptions(zap.AddCallerSkip(1))
+	fields := FieldsFromContext(ctx)
+	if len(fields) == 0 {
+		return logger
+	}
+
+	f := make([]zap.Field, 0, len(fields))
+	for k, v := range fields {
+		f = append(f, zap.Any(k, v))
+	}
+	return logger.With(f...)
+}
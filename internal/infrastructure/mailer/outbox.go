@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/google/uuid"
+)
+
+// OutboxMailer is a development stand-in for SMTP. Every message is written
+// to an .eml file in Dir and logged, so links can be copied from the log.
+type OutboxMailer struct {
+	Dir    string
+	from   string
+	logger logger.Logger
+}
+
+// NewOutboxMailer creates a mailer that writes messages to dir.
+// An empty dir only logs the messages.
+func NewOutboxMailer(dir, from string, l logger.Logger) *OutboxMailer {
+	return &OutboxMailer{
+		Dir:    dir,
+		from:   from,
+		logger: l,
+	}
+}
+
+func (m *OutboxMailer) Send(ctx context.Context, msg *Message) error {
+	now := time.Now()
+	body, err := buildMessage(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	path := ""
+	if m.Dir != "" {
+		if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create outbox directory: %w", err)
+		}
+		path = filepath.Join(m.Dir, fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), uuid.NewString()[:8]))
+		if err := os.WriteFile(path, body, 0o644); err != nil {
+			return fmt.Errorf("failed to write outbox message: %w", err)
+		}
+	}
+
+	if m.logger != nil {
+		m.logger.InfoContext(ctx, "Email written to outbox", map[string]interface{}{
+			"to":      strings.Join(msg.To, ", "),
+			"subject": msg.Subject,
+			"file":    path,
+			"body":    msg.Text,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPConfig holds the connection settings of the SMTP relay
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+	// envelopeFrom is the bare address of From used in MAIL FROM
+	envelopeFrom string
+}
+
+// NewSMTPMailer creates a mailer that relays through an SMTP server.
+// STARTTLS is used whenever the server advertises it.
+func NewSMTPMailer(cfg SMTPConfig) (Mailer, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("SMTP_HOST environment variable is not set")
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_FROM address: %w", err)
+	}
+
+	m := &smtpMailer{
+		addr:         net.JoinHostPort(cfg.Host, cfg.Port),
+		from:         from.String(),
+		envelopeFrom: from.Address,
+	}
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return m, nil
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	body, err := buildMessage(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// smtp.SendMail has no context support, so run it aside and stop waiting on cancellation
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.addr, m.auth, m.envelopeFrom, msg.To, body)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
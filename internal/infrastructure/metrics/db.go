@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsFunc returns connection pool statistics in the shape of DBConnector.GetStats
+type StatsFunc func() map[string]interface{}
+
+type dbStat struct {
+	key       string
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+}
+
+// dbStatsCollector reads the pool statistics on every scrape instead of polling
+type dbStatsCollector struct {
+	stats StatsFunc
+	descs []dbStat
+}
+
+// RegisterDBStats exposes the connection pool statistics returned by stats
+func RegisterDBStats(stats StatsFunc) error {
+	return Registry.Register(newDBStatsCollector(stats))
+}
+
+func newDBStatsCollector(stats StatsFunc) *dbStatsCollector {
+	stat := func(key, name, help string, valueType prometheus.ValueType) dbStat {
+		return dbStat{
+			key:       key,
+			desc:      prometheus.NewDesc(prometheus.BuildFQName(namespace, "db", name), help, nil, nil),
+			valueType: valueType,
+		}
+	}
+
+	return &dbStatsCollector{
+		stats: stats,
+		descs: []dbStat{
+			stat("max_open_connections", "max_open_connections", "Maximum number of open connections.", prometheus.GaugeValue),
+			stat("open_connections", "open_connections", "Established connections, in use and idle.", prometheus.GaugeValue),
+			stat("in_use", "in_use_connections", "Connections currently in use.", prometheus.GaugeValue),
+			stat("idle", "idle_connections", "Idle connections.", prometheus.GaugeValue),
+			stat("wait_count", "wait_count_total", "Connections waited for.", prometheus.CounterValue),
+			stat("wait_duration", "wait_duration_seconds_total", "Time blocked waiting for a connection.", prometheus.CounterValue),
+			stat("max_idle_closed", "max_idle_closed_total", "Connections closed due to SetMaxIdleConns.", prometheus.CounterValue),
+			stat("max_lifetime_closed", "max_lifetime_closed_total", "Connections closed due to SetConnMaxLifetime.", prometheus.CounterValue),
+		},
+	}
+}
+
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range c.descs {
+		ch <- d.desc
+	}
+}
+
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	for _, d := range c.descs {
+		value, ok := toFloat(stats[d.key])
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(d.desc, d.valueType, value)
+	}
+}
+
+// toFloat accepts the numeric types and duration strings produced by GetStats
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case time.Duration:
+		return n.Seconds(), true
+	case string:
+		d, err := time.ParseDuration(n)
+		if err != nil {
+			return 0, false
+		}
+		return d.Seconds(), true
+	default:
+		return 0, false
+	}
+}
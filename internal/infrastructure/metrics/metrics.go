@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "smart_task_ai"
+
+// Registry holds every collector exposed on /metrics; a dedicated registry keeps
+// library defaults from leaking in
+var Registry = prometheus.NewRegistry()
+
+// HTTP metrics, labelled by the route template so IDs in paths do not explode cardinality
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// Groq metrics
+var (
+	GroqDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "groq",
+		Name:      "request_duration_seconds",
+		Help:      "Groq API latency by model and outcome; streams are measured to the first byte.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+	}, []string{"model", "outcome"})
+
+	GroqErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "groq",
+		Name:      "errors_total",
+		Help:      "Failed Groq API calls by error class.",
+	}, []string{"model", "class"})
+
+	GroqTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "groq",
+		Name:      "tokens_total",
+		Help:      "Tokens consumed by model and kind (prompt or completion).",
+	}, []string{"model", "kind"})
+)
+
+// Business metrics
+var (
+	TasksCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_created_total",
+		Help:      "Tasks created by source (manual, ai_suggestion or ics_import). The ai_suggestion source is reported by the client.",
+	}, []string{"source"})
+
+	AITaskSuggestions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ai_task_suggestions_total",
+		Help:      "Tasks suggested by the AI assistant.",
+	})
+
+	// AITaskSuggestionsAccepted counts tasks the client says it saved from a
+	// suggestion. Nothing ties them to a suggestion the server made, so the
+	// ratio to AITaskSuggestions is an estimate that any client can skew.
+	AITaskSuggestionsAccepted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ai_task_suggestions_accepted_total",
+		Help:      "Tasks created with source ai_suggestion, as reported by the client; not verified against the suggestions made.",
+	})
+
+	RemindersDispatched = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reminders_dispatched_total",
+		Help:      "Reminders claimed by the reminder worker, by channel and outcome (sent, retry, failed, skipped or lost).",
+	}, []string{"channel", "outcome"})
+
+	WebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook deliveries claimed by the webhook worker, by event and outcome (succeeded, retry, failed, skipped or lost).",
+	}, []string{"event", "outcome"})
+
+	OutboxEventsDispatched = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_dispatched_total",
+		Help:      "Outbox events claimed by the relay, by type and outcome (dispatched, retry, failed or lost).",
+	}, []string{"type", "outcome"})
+
+	RealtimeStreams = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "realtime_streams",
+		Help:      "Project event streams open on this instance.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		GroqDuration,
+		GroqErrors,
+		GroqTokens,
+		TasksCreated,
+		AITaskSuggestions,
+		AITaskSuggestionsAccepted,
+		RemindersDispatched,
+		WebhookDeliveries,
+		OutboxEventsDispatched,
+		RealtimeStreams,
+	)
+}
@@ -2,9 +2,12 @@ package persistence
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -17,12 +20,12 @@ func NewAccountRepository(db *gorm.DB) accounts.AccountRepository {
 }
 
 func (r *accountRepository) CreateAccount(ctx context.Context, acc *entity.Account) error {
-	return r.db.WithContext(ctx).Create(acc).Error
+	return conn(ctx, r.db).Create(acc).Error
 }
 
 func (r *accountRepository) ExistsAccount(ctx context.Context, username, email string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.Account{}).
 		Where("username = ? OR email = ?", username, email).
 		Count(&count).Error
@@ -33,7 +36,7 @@ func (r *accountRepository) ExistsAccount(ctx context.Context, username, email s
 func (r *accountRepository) GetByUsername(ctx context.Context, username string) (*entity.Account, error) {
 	var account entity.Account
 
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("username = ?", username).
 		First(&account).Error
 
@@ -44,20 +47,134 @@ func (r *accountRepository) GetByUsername(ctx context.Context, username string)
 	return &account, nil
 }
 
-func (r *accountRepository) ListAccounts(ctx context.Context, limit, offset int) ([]*entity.Account, int, error) {
-	var accounts []*entity.Account
+func (r *accountRepository) GetByID(ctx context.Context, accountID uuid.UUID) (*entity.Account, error) {
+	var account entity.Account
+
+	err := conn(ctx, r.db).
+		Where("id = ?", accountID).
+		First(&account).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *accountRepository) ListAccounts(ctx context.Context, filter accounts.AccountFilter, limit, offset int) ([]*entity.Account, int, error) {
+	var result []*entity.Account
 	var total int64
 
+	query := conn(ctx, r.db).Model(&entity.Account{})
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Query)) + "%"
+		query = query.Where("(LOWER(username) LIKE ? OR LOWER(email) LIKE ?)", pattern, pattern)
+	}
+	if filter.State != "" {
+		query = query.Where("state = ?", filter.State)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+
 	// Get total count
-	if err := r.db.WithContext(ctx).Model(&entity.Account{}).Count(&total).Error; err != nil {
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	err := r.db.WithContext(ctx).
+	err := query.
+		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
-		Find(&accounts).Error
+		Find(&result).Error
+
+	return result, int(total), err
+}
+
+func (r *accountRepository) GetByEmail(ctx context.Context, email string) (*entity.Account, error) {
+	var account entity.Account
+
+	err := conn(ctx, r.db).
+		Where("LOWER(email) = LOWER(?)", email).
+		First(&account).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *accountRepository) UpdatePassword(ctx context.Context, accountID uuid.UUID, passwordHash string, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.Account{}).
+		Where("id = ?", accountID).
+		Updates(map[string]interface{}{
+			"password":   passwordHash,
+			"updated_at": at,
+		}).Error
+}
+
+func (r *accountRepository) MarkEmailVerified(ctx context.Context, accountID uuid.UUID, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.Account{}).
+		Where("id = ? AND email_verified_at IS NULL", accountID).
+		Updates(map[string]interface{}{
+			"email_verified_at": at,
+			"updated_at":        at,
+		}).Error
+}
+
+func (r *accountRepository) ExistsUsername(ctx context.Context, username string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).
+		Model(&entity.Account{}).
+		Where("username = ? AND id <> ?", username, excludeID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+func (r *accountRepository) ExistsEmail(ctx context.Context, email string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).
+		Model(&entity.Account{}).
+		Where("LOWER(email) = LOWER(?) AND id <> ?", email, excludeID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// UpdateAccount saves the self-service fields of the account
+func (r *accountRepository) UpdateAccount(ctx context.Context, acc *entity.Account) error {
+	return conn(ctx, r.db).
+		Model(acc).
+		Select("username", "email", "email_verified_at", "updated_at").
+		Updates(acc).Error
+}
+
+func (r *accountRepository) UpdateState(ctx context.Context, accountID uuid.UUID, state string, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.Account{}).
+		Where("id = ?", accountID).
+		Updates(map[string]interface{}{
+			"state":      state,
+			"updated_at": at,
+		}).Error
+}
+
+func (r *accountRepository) UpdateRole(ctx context.Context, accountID uuid.UUID, role string, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.Account{}).
+		Where("id = ?", accountID).
+		Updates(map[string]interface{}{
+			"role":       role,
+			"updated_at": at,
+		}).Error
+}
 
-	return accounts, int(total), err
+// escapeLike escapes the LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
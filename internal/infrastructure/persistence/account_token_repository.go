@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type accountTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountTokenRepository(db *gorm.DB) accounts.AccountTokenRepository {
+	return &accountTokenRepository{db: db}
+}
+
+func (r *accountTokenRepository) CreateAccountToken(ctx context.Context, token *entity.AccountToken) error {
+	return conn(ctx, r.db).Create(token).Error
+}
+
+func (r *accountTokenRepository) ConsumeAccountToken(ctx context.Context, tokenID uuid.UUID, purpose string, now time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.AccountToken{}).
+		Where("id = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenID, purpose, now).
+		Update("used_at", now)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *accountTokenRepository) InvalidateAccountTokens(ctx context.Context, accountID uuid.UUID, purpose string, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.AccountToken{}).
+		Where("account_id = ? AND purpose = ? AND used_at IS NULL", accountID, purpose).
+		Update("used_at", at).Error
+}
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type appPasswordRepository struct {
+	db *gorm.DB
+}
+
+func NewAppPasswordRepository(db *gorm.DB) accounts.AppPasswordRepository {
+	return &appPasswordRepository{db: db}
+}
+
+func (r *appPasswordRepository) CreateAppPassword(ctx context.Context, appPassword *entity.AppPassword) error {
+	return conn(ctx, r.db).Create(appPassword).Error
+}
+
+func (r *appPasswordRepository) GetAppPasswordByHash(ctx context.Context, passwordHash string) (*entity.AppPassword, error) {
+	var appPassword entity.AppPassword
+	err := conn(ctx, r.db).
+		Where("password_hash = ?", passwordHash).
+		First(&appPassword).Error
+	if err != nil {
+		return nil, err
+	}
+	return &appPassword, nil
+}
+
+func (r *appPasswordRepository) ListActiveAppPasswords(ctx context.Context, accountID uuid.UUID) ([]*entity.AppPassword, error) {
+	var appPasswords []*entity.AppPassword
+	err := conn(ctx, r.db).
+		Where("account_id = ? AND revoked_at IS NULL", accountID).
+		Order("created_at DESC").
+		Find(&appPasswords).Error
+	if err != nil {
+		return nil, err
+	}
+	return appPasswords, nil
+}
+
+func (r *appPasswordRepository) RevokeAppPassword(ctx context.Context, accountID, appPasswordID uuid.UUID, at time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.AppPassword{}).
+		Where("id = ? AND account_id = ? AND revoked_at IS NULL", appPasswordID, accountID).
+		Update("revoked_at", at)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *appPasswordRepository) TouchAppPassword(ctx context.Context, appPasswordID uuid.UUID, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.AppPassword{}).
+		Where("id = ?", appPasswordID).
+		Update("last_used_at", at).Error
+}
@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) tasks.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) CreateAttachmentWithinQuota(ctx context.Context, attachment *entity.Attachment, quota int64) (int64, error) {
+	var used int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// The project row is the lock every upload to the project waits on
+		var id uuid.UUID
+		res := tx.Raw("SELECT id FROM projects WHERE id = ? FOR UPDATE", attachment.ProjectID).Scan(&id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		err := tx.Model(&entity.Attachment{}).
+			Where("project_id = ?", attachment.ProjectID).
+			Select("COALESCE(SUM(size), 0)").
+			Scan(&used).Error
+		if err != nil {
+			return err
+		}
+		if used+attachment.Size > quota {
+			return tasks.ErrProjectQuotaExceeded
+		}
+
+		return tx.Create(attachment).Error
+	})
+	return used, err
+}
+
+func (r *attachmentRepository) GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+	err := conn(ctx, r.db).
+		Where("id = ?", attachmentID).
+		First(&attachment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) ListAttachmentsByTask(ctx context.Context, taskID uuid.UUID) ([]*entity.Attachment, error) {
+	var attachments []*entity.Attachment
+	err := conn(ctx, r.db).
+		Where("task_id = ?", taskID).
+		Order("created_at ASC").
+		Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) SumAttachmentSizeByProject(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	var total int64
+	err := conn(ctx, r.db).
+		Model(&entity.Attachment{}).
+		Where("project_id = ?", projectID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *attachmentRepository) DeleteAttachment(ctx context.Context, attachmentID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Where("id = ?", attachmentID).
+		Delete(&entity.Attachment{}).Error
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) calendars.FeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+func (r *calendarFeedRepository) CreateFeed(ctx context.Context, feed *entity.Feed) error {
+	return conn(ctx, r.db).Create(feed).Error
+}
+
+func (r *calendarFeedRepository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*entity.Feed, error) {
+	var feed entity.Feed
+	err := conn(ctx, r.db).
+		Where("token_hash = ?", tokenHash).
+		First(&feed).Error
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *calendarFeedRepository) ListActiveFeeds(ctx context.Context, accountID uuid.UUID) ([]*entity.Feed, error) {
+	var feeds []*entity.Feed
+	err := conn(ctx, r.db).
+		Where("account_id = ? AND revoked_at IS NULL", accountID).
+		Order("created_at DESC").
+		Find(&feeds).Error
+	if err != nil {
+		return nil, err
+	}
+	return feeds, nil
+}
+
+func (r *calendarFeedRepository) RevokeFeed(ctx context.Context, accountID, feedID uuid.UUID, at time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Feed{}).
+		Where("id = ? AND account_id = ? AND revoked_at IS NULL", feedID, accountID).
+		Update("revoked_at", at)
+	return res.RowsAffected > 0, res.Error
+}
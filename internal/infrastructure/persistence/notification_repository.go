@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) reminders.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// CreateNotification relies on the unique index over the reminder occurrence
+func (r *notificationRepository) CreateNotification(ctx context.Context, notification *entity.Notification) (bool, error) {
+	res := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(notification)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *notificationRepository) ListNotifications(ctx context.Context, accountID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entity.Notification, int, error) {
+	query := conn(ctx, r.db).
+		Model(&entity.Notification{}).
+		Where("account_id = ?", accountID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []*entity.Notification
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, int(total), nil
+}
+
+func (r *notificationRepository) CountUnreadNotifications(ctx context.Context, accountID uuid.UUID) (int, error) {
+	var count int64
+	err := conn(ctx, r.db).
+		Model(&entity.Notification{}).
+		Where("account_id = ? AND read_at IS NULL", accountID).
+		Count(&count).Error
+	return int(count), err
+}
+
+func (r *notificationRepository) MarkNotificationRead(ctx context.Context, accountID, notificationID uuid.UUID, at time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Notification{}).
+		Where("id = ? AND account_id = ?", notificationID, accountID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", at))
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *notificationRepository) MarkAllNotificationsRead(ctx context.Context, accountID uuid.UUID, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.Notification{}).
+		Where("account_id = ? AND read_at IS NULL", accountID).
+		Update("read_at", at).Error
+}
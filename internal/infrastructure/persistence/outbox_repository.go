@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events/entity"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutbox(db *gorm.DB) events.Outbox {
+	return &outboxRepository{db: db}
+}
+
+func NewOutboxRepository(db *gorm.DB) events.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func NewEventLog(db *gorm.DB) events.EventLog {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(withTx(ctx, tx))
+	})
+}
+
+// outboxLockID is the advisory lock serializing the transactions that record events
+const outboxLockID = 7_143_285_002
+
+// Record takes the outbox lock until the transaction ends before its rows take
+// their seqs. A seq is drawn at insert, not at commit, so without the lock a
+// transaction could commit seq N+1 while seq N is still open and a reader at
+// N+1 would never see N. Services record events last in their transactions,
+// so the lock is held only for the inserts and the commit.
+func (r *outboxRepository) Record(ctx context.Context, list ...events.Event) error {
+	if len(list) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := lo.Map(list, func(e events.Event, _ int) *entity.OutboxEvent {
+		return &entity.OutboxEvent{
+			ID:            e.ID,
+			Type:          e.Type,
+			ProjectID:     e.ProjectID,
+			Data:          e.Data,
+			OccurredAt:    e.OccurredAt,
+			Status:        events.StatusPending,
+			NextAttemptAt: &now,
+			Handled:       []string{},
+			UpdatedAt:     now,
+		}
+	})
+	return r.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := conn(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(?)", outboxLockID).Error; err != nil {
+			return err
+		}
+		return conn(ctx, r.db).Create(rows).Error
+	})
+}
+
+// ClaimDueEvents locks the due rows with SKIP LOCKED, like ClaimDueReminders
+func (r *outboxRepository) ClaimDueEvents(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	var claimed []*entity.OutboxEvent
+	err := conn(ctx, r.db).Raw(`
+		UPDATE outbox_events
+		SET status = ?, locked_until = ?, attempts = attempts + 1, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE (status = ? AND next_attempt_at <= ?) OR (status = ? AND locked_until < ?)
+			ORDER BY seq
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		events.StatusDispatching, lockedUntil, now,
+		events.StatusPending, now, events.StatusDispatching, now,
+		limit,
+	).Scan(&claimed).Error
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING does not keep the order of the subquery
+	slices.SortFunc(claimed, func(a, b *entity.OutboxEvent) int { return cmp.Compare(a.Seq, b.Seq) })
+	return claimed, nil
+}
+
+func (r *outboxRepository) FinishClaim(ctx context.Context, event *entity.OutboxEvent, claimedUntil time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.OutboxEvent{}).
+		Where("id = ? AND status = ? AND locked_until = ?", event.ID, events.StatusDispatching, claimedUntil).
+		Select("status", "attempts", "next_attempt_at", "locked_until", "handled", "last_error", "dispatched_at", "updated_at").
+		Updates(event)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *outboxRepository) DeleteDispatchedEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	res := conn(ctx, r.db).Exec(`
+		DELETE FROM outbox_events
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = ? AND dispatched_at < ?
+			LIMIT ?
+		)`,
+		events.StatusDispatched, before, limit,
+	)
+	return res.RowsAffected, res.Error
+}
+
+func (r *outboxRepository) ListProjectEvents(ctx context.Context, projectID uuid.UUID, afterSeq int64, types []string, limit int) ([]*entity.OutboxEvent, error) {
+	var list []*entity.OutboxEvent
+	err := conn(ctx, r.db).
+		Where("project_id = ? AND seq > ? AND type IN ?", projectID, afterSeq, types).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *outboxRepository) GetEventsBySeq(ctx context.Context, seqs []int64) ([]*entity.OutboxEvent, error) {
+	var list []*entity.OutboxEvent
+	if len(seqs) == 0 {
+		return list, nil
+	}
+	err := conn(ctx, r.db).
+		Where("seq IN ?", seqs).
+		Order("seq ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *outboxRepository) GetSeqRange(ctx context.Context) (int64, int64, error) {
+	var seqRange struct {
+		Min int64
+		Max int64
+	}
+	err := conn(ctx, r.db).
+		Raw("SELECT COALESCE(MIN(seq), 0) AS min, COALESCE(MAX(seq), 0) AS max FROM outbox_events").
+		Scan(&seqRange).Error
+	return seqRange.Min, seqRange.Max, err
+}
@@ -0,0 +1,165 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func TestOutbox_Record_CommitsInSeqOrder(t *testing.T) {
+	ctx := context.Background()
+	fake := &outboxDB{lock: make(chan struct{}, 1)}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(fake)}), &gorm.Config{
+		Logger: gormLogger.Discard,
+	})
+	require.NoError(t, err)
+	outbox := NewOutbox(db)
+
+	newEvent := func() events.Event {
+		event, err := events.NewEvent(events.TaskUpdated, uuid.New(), map[string]string{})
+		require.NoError(t, err)
+		return event
+	}
+
+	// The first transaction takes its seq and stays open
+	recorded := make(chan struct{})
+	commit := make(chan struct{})
+	first := make(chan error, 1)
+	go func() {
+		first <- outbox.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := outbox.Record(ctx, newEvent()); err != nil {
+				return err
+			}
+			close(recorded)
+			<-commit
+			return nil
+		})
+	}()
+	select {
+	case <-recorded:
+	case err := <-first:
+		t.Fatalf("first event was not recorded: %v", err)
+	}
+
+	// The second would take the next seq and commit before it
+	second := make(chan error, 1)
+	go func() {
+		second <- outbox.Record(ctx, newEvent())
+	}()
+	select {
+	case err := <-second:
+		t.Fatalf("second event committed while the first was open: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(commit)
+	require.NoError(t, <-first)
+	require.NoError(t, <-second)
+	assert.Equal(t, []int64{1, 2}, fake.committedSeqs())
+}
+
+// outboxDB models the outbox_events table of Postgres as far as the commit
+// order of seqs goes: seqs are drawn at insert, advisory locks are held until
+// the transaction ends, and committed seqs are kept in commit order
+type outboxDB struct {
+	lock chan struct{}
+
+	mu        sync.Mutex
+	seq       int64
+	committed []int64
+}
+
+func (d *outboxDB) committedSeqs() []int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.committed
+}
+
+func (d *outboxDB) Connect(context.Context) (driver.Conn, error) {
+	return &outboxConn{db: d}, nil
+}
+
+func (d *outboxDB) Driver() driver.Driver {
+	return d
+}
+
+func (d *outboxDB) Open(string) (driver.Conn, error) {
+	return &outboxConn{db: d}, nil
+}
+
+type outboxConn struct {
+	db      *outboxDB
+	locked  bool
+	pending []int64
+}
+
+func (c *outboxConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *outboxConn) Close() error {
+	return nil
+}
+
+func (c *outboxConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *outboxConn) Commit() error {
+	c.db.mu.Lock()
+	c.db.committed = append(c.db.committed, c.pending...)
+	c.db.mu.Unlock()
+	c.end()
+	return nil
+}
+
+func (c *outboxConn) Rollback() error {
+	c.end()
+	return nil
+}
+
+func (c *outboxConn) end() {
+	c.pending = nil
+	if c.locked {
+		c.locked = false
+		<-c.db.lock
+	}
+}
+
+func (c *outboxConn) ExecContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	switch {
+	case strings.HasPrefix(query, "SELECT pg_advisory_xact_lock"):
+		if c.locked {
+			return driver.RowsAffected(1), nil
+		}
+		select {
+		case c.db.lock <- struct{}{}:
+			c.locked = true
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	case strings.HasPrefix(query, `INSERT INTO "outbox_events"`):
+		c.db.mu.Lock()
+		for range strings.Count(query, "),(") + 1 {
+			c.db.seq++
+			c.pending = append(c.pending, c.db.seq)
+		}
+		c.db.mu.Unlock()
+	default:
+		return nil, fmt.Errorf("unexpected statement %q", query)
+	}
+	return driver.RowsAffected(1), nil
+}
@@ -17,13 +17,13 @@ func NewProfileRepository(db *gorm.DB) profiles.ProfileRepository {
 }
 
 func (r *profileRepository) CreateProfile(ctx context.Context, prof *entity.Profile) error {
-	return r.db.WithContext(ctx).Create(prof).Error
+	return conn(ctx, r.db).Create(prof).Error
 }
 
 func (r *profileRepository) GetProfileByAccountID(ctx context.Context, accountID string) (*entity.Profile, error) {
 	var profile entity.Profile
-	err := r.db.WithContext(ctx).
-		Select("id, account_id, first_name, last_name, nickname, avatar_path, state, created_at, updated_at").
+	err := conn(ctx, r.db).
+		Select("id, account_id, first_name, last_name, nickname, avatar_path, timezone, state, created_at, updated_at").
 		Where("account_id = ?", accountID).
 		First(&profile).Error
 	if err != nil {
@@ -33,5 +33,5 @@ func (r *profileRepository) GetProfileByAccountID(ctx context.Context, accountID
 }
 
 func (r *profileRepository) UpdateProfile(ctx context.Context, prof *entity.Profile) error {
-	return r.db.WithContext(ctx).Save(prof).Error
+	return conn(ctx, r.db).Save(prof).Error
 }
@@ -18,12 +18,12 @@ func NewProjectRepository(db *gorm.DB) projects.ProjectRepository {
 }
 
 func (r *projectRepository) CreateProject(ctx context.Context, proj *entity.Project) error {
-	return r.db.WithContext(ctx).Create(proj).Error
+	return conn(ctx, r.db).Create(proj).Error
 }
 
 func (r *projectRepository) GetProjectByID(ctx context.Context, projectID uuid.UUID) (*entity.Project, error) {
 	var proj entity.Project
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("id = ?", projectID).
 		First(&proj).Error
 	if err != nil {
@@ -37,12 +37,12 @@ func (r *projectRepository) ListProjectByAccountID(ctx context.Context, accountI
 	var total int64
 
 	// Get total count
-	if err := r.db.WithContext(ctx).Model(&entity.Project{}).Count(&total).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&entity.Project{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Limit(limit).
 		Offset(offset).
 		Find(&projects).Error
@@ -50,10 +50,36 @@ func (r *projectRepository) ListProjectByAccountID(ctx context.Context, accountI
 	return projects, int(total), err
 }
 
+func (r *projectRepository) ListAllProjectsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*entity.Project, error) {
+	var projects []*entity.Project
+	err := conn(ctx, r.db).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
 func (r *projectRepository) UpdateProject(ctx context.Context, proj *entity.Project) error {
-	return r.db.WithContext(ctx).Save(proj).Error
+	return conn(ctx, r.db).Save(proj).Error
 }
 
 func (r *projectRepository) DeleteProject(ctx context.Context, projectID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Project{}, projectID).Error
+	return conn(ctx, r.db).Delete(&entity.Project{}, projectID).Error
+}
+
+func (r *projectRepository) LockProject(ctx context.Context, projectID uuid.UUID) error {
+	var id uuid.UUID
+	res := conn(ctx, r.db).
+		Raw("SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL FOR UPDATE", projectID).
+		Scan(&id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
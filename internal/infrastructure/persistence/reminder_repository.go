@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) reminders.ReminderRepository {
+	return &reminderRepository{db: db}
+}
+
+func (r *reminderRepository) CreateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	return conn(ctx, r.db).Create(reminder).Error
+}
+
+func (r *reminderRepository) GetReminderByID(ctx context.Context, reminderID uuid.UUID) (*entity.Reminder, error) {
+	var reminder entity.Reminder
+	err := conn(ctx, r.db).
+		Where("id = ?", reminderID).
+		First(&reminder).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+func (r *reminderRepository) ListRemindersByTask(ctx context.Context, taskID uuid.UUID) ([]*entity.Reminder, error) {
+	var list []*entity.Reminder
+	err := conn(ctx, r.db).
+		Where("task_id = ?", taskID).
+		Order("offset_minutes DESC, created_at ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *reminderRepository) UpdateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	return conn(ctx, r.db).Save(reminder).Error
+}
+
+func (r *reminderRepository) DeleteReminder(ctx context.Context, reminderID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Where("id = ?", reminderID).
+		Delete(&entity.Reminder{}).Error
+}
+
+func (r *reminderRepository) DeleteRemindersByTask(ctx context.Context, taskID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Where("task_id = ?", taskID).
+		Delete(&entity.Reminder{}).Error
+}
+
+// ClaimDueReminders locks the due rows with SKIP LOCKED, so concurrent workers
+// each claim different reminders instead of waiting on one another
+func (r *reminderRepository) ClaimDueReminders(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.Reminder, error) {
+	var claimed []*entity.Reminder
+	err := conn(ctx, r.db).Raw(`
+		UPDATE task_reminders
+		SET status = ?, locked_until = ?, attempts = attempts + 1, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM task_reminders
+			WHERE (status = ? AND remind_at <= ?) OR (status = ? AND locked_until < ?)
+			ORDER BY remind_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		reminders.StatusSending, lockedUntil, now,
+		reminders.StatusScheduled, now, reminders.StatusSending, now,
+		limit,
+	).Scan(&claimed).Error
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (r *reminderRepository) FinishClaim(ctx context.Context, reminder *entity.Reminder, claimedUntil time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Reminder{}).
+		Where("id = ? AND status = ? AND locked_until = ?", reminder.ID, reminders.StatusSending, claimedUntil).
+		Select("status", "remind_at", "occurrence_start", "attempts", "locked_until", "last_error", "sent_at", "updated_at").
+		Updates(reminder)
+	return res.RowsAffected > 0, res.Error
+}
+
+// ClaimSend relies on the primary key over the reminder occurrence
+func (r *reminderRepository) ClaimSend(ctx context.Context, send *entity.Send) (bool, error) {
+	res := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(send)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *reminderRepository) ReleaseSend(ctx context.Context, reminderID uuid.UUID, occurrenceStart time.Time) error {
+	return conn(ctx, r.db).
+		Where("reminder_id = ? AND occurrence_start = ?", reminderID, occurrenceStart).
+		Delete(&entity.Send{}).Error
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/search"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// likeEscaper escapes the wildcards of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) search.SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search matches the search_vector and search_text columns of migration
+// 0010_search. A row matches on English full text, or when its text contains
+// every term and none of the excluded words. The rank is that of the full-text match, raised when the name
+// contains the whole query, which is all there is to rank text in Thai by.
+func (r *searchRepository) Search(ctx context.Context, accountID uuid.UUID, query search.Query, kinds []string, limit, offset int) ([]*search.Hit, int, error) {
+	args := map[string]interface{}{
+		"account": accountID,
+		"text":    query.Text,
+		"phrase":  "%" + likeEscaper.Replace(strings.ToLower(query.Text)) + "%",
+		"limit":   limit,
+		"offset":  offset,
+	}
+	taskTerms := make([]string, 0, len(query.Terms))
+	projectTerms := make([]string, 0, len(query.Terms))
+	for i, term := range query.Terms {
+		name := fmt.Sprintf("term%d", i)
+		args[name] = "%" + likeEscaper.Replace(term) + "%"
+		taskTerms = append(taskTerms, "t.search_text LIKE @"+name)
+		projectTerms = append(projectTerms, "lower(p.name) LIKE @"+name)
+	}
+	for i, term := range query.Excluded {
+		name := fmt.Sprintf("excluded%d", i)
+		args[name] = "%" + likeEscaper.Replace(term) + "%"
+		taskTerms = append(taskTerms, "t.search_text NOT LIKE @"+name)
+		projectTerms = append(projectTerms, "lower(p.name) NOT LIKE @"+name)
+	}
+
+	var branches []string
+	for _, kind := range kinds {
+		switch kind {
+		case search.KindTask:
+			branches = append(branches, `
+				SELECT 'task' AS kind, t.id, t.project_id, t.name, t.description, t.location, t.status, t.updated_at,
+					ts_rank_cd(t.search_vector, q.query) + CASE WHEN lower(t.name) LIKE @phrase THEN 0.5 ELSE 0 END AS rank
+				FROM tasks t
+				JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL, q
+				WHERE p.account_id = @account AND t.deleted_at IS NULL
+					AND (t.search_vector @@ q.query OR (`+strings.Join(taskTerms, " AND ")+`))`)
+		case search.KindProject:
+			branches = append(branches, `
+				SELECT 'project' AS kind, p.id, p.id AS project_id, p.name, NULL AS description, NULL AS location, NULL AS status, p.updated_at,
+					ts_rank_cd(p.search_vector, q.query) + CASE WHEN lower(p.name) LIKE @phrase THEN 0.5 ELSE 0 END AS rank
+				FROM projects p, q
+				WHERE p.account_id = @account AND p.deleted_at IS NULL
+					AND (p.search_vector @@ q.query OR (`+strings.Join(projectTerms, " AND ")+`))`)
+		}
+	}
+	if len(branches) == 0 {
+		return []*search.Hit{}, 0, nil
+	}
+	hits := "WITH q AS (SELECT websearch_to_tsquery('english', @text) AS query), hits AS (" +
+		strings.Join(branches, " UNION ALL ") + ")"
+
+	var total int
+	if err := conn(ctx, r.db).Raw(hits+" SELECT count(*) FROM hits", args).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []*search.Hit
+	err := conn(ctx, r.db).
+		Raw(hits+" SELECT * FROM hits ORDER BY rank DESC, updated_at DESC, id LIMIT @limit OFFSET @offset", args).
+		Scan(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+func (r *searchRepository) Stems(ctx context.Context, text string) ([]string, error) {
+	var stems []string
+	err := conn(ctx, r.db).
+		Raw("SELECT unnest(tsvector_to_array(to_tsvector('english', ?)))", text).
+		Scan(&stems).Error
+	if err != nil {
+		return nil, err
+	}
+	return stems, nil
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) accounts.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, session *entity.Session) error {
+	return conn(ctx, r.db).Create(session).Error
+}
+
+func (r *sessionRepository) GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	var session entity.Session
+	err := conn(ctx, r.db).
+		Where("id = ?", sessionID).
+		First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) FindSessionByTokenHash(ctx context.Context, tokenHash string) (*entity.Session, error) {
+	var session entity.Session
+	err := conn(ctx, r.db).
+		Where("refresh_token_hash = ? OR previous_token_hash = ?", tokenHash, tokenHash).
+		First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) RotateSession(ctx context.Context, sessionID uuid.UUID, oldHash, newHash string, expiresAt, usedAt time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionID, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  newHash,
+			"previous_token_hash": oldHash,
+			"expires_at":          expiresAt,
+			"last_used_at":        usedAt,
+			"updated_at":          usedAt,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *sessionRepository) ListActiveSessions(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	err := conn(ctx, r.db).
+		Where("account_id = ? AND revoked_at IS NULL AND expires_at > ?", accountID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *sessionRepository) RevokeSession(ctx context.Context, accountID, sessionID uuid.UUID, at time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Session{}).
+		Where("id = ? AND account_id = ? AND revoked_at IS NULL", sessionID, accountID).
+		Updates(map[string]interface{}{
+			"revoked_at": at,
+			"updated_at": at,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *sessionRepository) RevokeAllSessions(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Session{}).
+		Where("account_id = ? AND revoked_at IS NULL", accountID).
+		Updates(map[string]interface{}{
+			"revoked_at": at,
+			"updated_at": at,
+		})
+	return res.RowsAffected, res.Error
+}
+
+func (r *sessionRepository) RevokeOtherSessions(ctx context.Context, accountID, keepSessionID uuid.UUID, at time.Time) (int64, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Session{}).
+		Where("account_id = ? AND id <> ? AND revoked_at IS NULL", accountID, keepSessionID).
+		Updates(map[string]interface{}{
+			"revoked_at": at,
+			"updated_at": at,
+		})
+	return res.RowsAffected, res.Error
+}
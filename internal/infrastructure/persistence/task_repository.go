@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"time"
 
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
 	"github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
@@ -18,12 +19,16 @@ func NewTaskRepository(db *gorm.DB) tasks.TaskRepository {
 }
 
 func (r *taskRepository) CreateTask(ctx context.Context, task *entity.Task) error {
-	return r.db.WithContext(ctx).Create(task).Error
+	return conn(ctx, r.db).Create(task).Error
+}
+
+func (r *taskRepository) CreateTasks(ctx context.Context, tasks []*entity.Task) error {
+	return conn(ctx, r.db).Create(&tasks).Error
 }
 
 func (r *taskRepository) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*entity.Task, error) {
 	var task entity.Task
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("id = ?", taskID).
 		First(&task).Error
 	if err != nil {
@@ -32,9 +37,20 @@ func (r *taskRepository) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*en
 	return &task, nil
 }
 
+func (r *taskRepository) GetTaskByCalDAVName(ctx context.Context, projectID uuid.UUID, name string) (*entity.Task, error) {
+	var task entity.Task
+	err := conn(ctx, r.db).
+		Where("project_id = ? AND caldav_name = ?", projectID, name).
+		First(&task).Error
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
 func (r *taskRepository) ListTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.Task, error) {
 	var tasks []*entity.Task
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("project_id = ?", projectID).
 		Find(&tasks).Error
 	if err != nil {
@@ -43,9 +59,35 @@ func (r *taskRepository) ListTasksByProject(ctx context.Context, projectID uuid.
 	return tasks, nil
 }
 
+func (r *taskRepository) ListTasksByAccount(ctx context.Context, accountID uuid.UUID) ([]*entity.Task, error) {
+	var tasks []*entity.Task
+	err := conn(ctx, r.db).
+		Select("tasks.*").
+		Joins("JOIN projects ON projects.id = tasks.project_id AND projects.deleted_at IS NULL").
+		Where("projects.account_id = ?", accountID).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) ListExternalUIDs(ctx context.Context, projectID uuid.UUID, uids []string) ([]string, error) {
+	var existing []string
+	if len(uids) == 0 {
+		return existing, nil
+	}
+	err := conn(ctx, r.db).
+		Model(&entity.Task{}).
+		Distinct("external_uid").
+		Where("project_id = ? AND external_uid IN ?", projectID, uids).
+		Pluck("external_uid", &existing).Error
+	return existing, err
+}
+
 func (r *taskRepository) CountTasksByProject(ctx context.Context, projectID uuid.UUID) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Model(&entity.Task{}).
 		Where("project_id = ?", projectID).
 		Count(&count).Error
@@ -53,9 +95,96 @@ func (r *taskRepository) CountTasksByProject(ctx context.Context, projectID uuid
 }
 
 func (r *taskRepository) UpdateTask(ctx context.Context, task *entity.Task) error {
-	return r.db.WithContext(ctx).Save(task).Error
+	return conn(ctx, r.db).Save(task).Error
 }
 
 func (r *taskRepository) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", taskID).Delete(&entity.Task{}).Error
+	return conn(ctx, r.db).Where("id = ?", taskID).Delete(&entity.Task{}).Error
+}
+
+// taskCompletedAtExpr falls back to updated_at for tasks completed before completed_at existed
+const taskCompletedAtExpr = "COALESCE(t.completed_at, CASE WHEN t.status = 'done' THEN t.updated_at END)"
+
+func (r *taskRepository) GetTaskStats(ctx context.Context, projectID uuid.UUID, now, dueSoonUntil time.Time) (*tasks.TaskStats, error) {
+	stats := &tasks.TaskStats{}
+
+	var totals struct {
+		Total     int64
+		Completed int64
+		Overdue   int64
+		DueSoon   int64
+	}
+	err := conn(ctx, r.db).
+		Model(&entity.Task{}).
+		Select(
+			"COUNT(*) AS total, "+
+				"COUNT(*) FILTER (WHERE status = ?) AS completed, "+
+				"COUNT(*) FILTER (WHERE status <> ? AND end_datetime < ?) AS overdue, "+
+				"COUNT(*) FILTER (WHERE status <> ? AND end_datetime >= ? AND end_datetime < ?) AS due_soon",
+			tasks.StatusDone, tasks.StatusDone, now, tasks.StatusDone, now, dueSoonUntil,
+		).
+		Where("project_id = ?", projectID).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	stats.Total = totals.Total
+	stats.Completed = totals.Completed
+	stats.Overdue = totals.Overdue
+	stats.DueSoon = totals.DueSoon
+
+	if stats.ByStatus, err = r.countTasksBy(ctx, projectID, "status"); err != nil {
+		return nil, err
+	}
+	if stats.ByPriority, err = r.countTasksBy(ctx, projectID, "priority"); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *taskRepository) countTasksBy(ctx context.Context, projectID uuid.UUID, column string) ([]tasks.GroupCount, error) {
+	var rows []tasks.GroupCount
+	err := conn(ctx, r.db).
+		Model(&entity.Task{}).
+		Select(column+" AS key, COUNT(*) AS count").
+		Where("project_id = ?", projectID).
+		Group(column).
+		Order(column).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *taskRepository) GetTaskBurndown(ctx context.Context, projectID uuid.UUID, from, to time.Time) ([]tasks.BurndownPoint, error) {
+	var rows []struct {
+		Day       time.Time
+		Scope     int64
+		Completed int64
+	}
+
+	// One row per day; a task counts towards a day once it was created
+	// (scope) or completed before the end of that day.
+	err := conn(ctx, r.db).Raw(`
+		SELECT d.day AS day,
+			COUNT(t.id) FILTER (WHERE t.created_at < d.day + INTERVAL '1 day') AS scope,
+			COUNT(t.id) FILTER (WHERE `+taskCompletedAtExpr+` < d.day + INTERVAL '1 day') AS completed
+		FROM generate_series(?::date, ?::date, INTERVAL '1 day') AS d(day)
+		LEFT JOIN tasks t ON t.project_id = ? AND t.deleted_at IS NULL
+		GROUP BY d.day
+		ORDER BY d.day`,
+		from, to, projectID,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]tasks.BurndownPoint, len(rows))
+	for i, row := range rows {
+		points[i] = tasks.BurndownPoint{
+			Date:      row.Day,
+			Scope:     row.Scope,
+			Completed: row.Completed,
+		}
+	}
+	return points, nil
 }
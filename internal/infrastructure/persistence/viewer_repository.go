@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type viewerRepository struct {
+	db *gorm.DB
+}
+
+func NewViewerRepository(db *gorm.DB) realtime.ViewerRepository {
+	return &viewerRepository{db: db}
+}
+
+func (r *viewerRepository) TouchViewers(ctx context.Context, viewers []*entity.Viewer) error {
+	if len(viewers) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "connection_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"seen_at"}),
+		}).
+		Create(viewers).Error
+}
+
+func (r *viewerRepository) DeleteViewer(ctx context.Context, connectionID uuid.UUID) error {
+	return conn(ctx, r.db).Where("connection_id = ?", connectionID).Delete(&entity.Viewer{}).Error
+}
+
+func (r *viewerRepository) ListViewersByProject(ctx context.Context, projectID uuid.UUID, seenAfter time.Time) ([]*entity.Viewer, error) {
+	var list []*entity.Viewer
+	err := conn(ctx, r.db).
+		Where("project_id = ? AND seen_at > ?", projectID, seenAfter).
+		Order("connected_at ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *viewerRepository) DeleteStaleViewers(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	var projectIDs []uuid.UUID
+	err := conn(ctx, r.db).Raw(`
+		WITH deleted AS (
+			DELETE FROM project_viewers WHERE seen_at < ? RETURNING project_id
+		)
+		SELECT DISTINCT project_id FROM deleted`,
+		before,
+	).Scan(&projectIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return projectIDs, nil
+}
+
+func (r *viewerRepository) NotifyPresence(ctx context.Context, projectID uuid.UUID) error {
+	return conn(ctx, r.db).Exec("SELECT pg_notify(?, ?)", realtime.ChannelPresence, projectID.String()).Error
+}
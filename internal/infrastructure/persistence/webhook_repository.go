@@ -0,0 +1,138 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) webhooks.SubscriptionRepository {
+	return &webhookSubscriptionRepository{db: db}
+}
+
+func (r *webhookSubscriptionRepository) CreateSubscription(ctx context.Context, subscription *entity.Subscription) error {
+	return conn(ctx, r.db).Create(subscription).Error
+}
+
+func (r *webhookSubscriptionRepository) GetSubscriptionByID(ctx context.Context, subscriptionID uuid.UUID) (*entity.Subscription, error) {
+	var subscription entity.Subscription
+	err := conn(ctx, r.db).
+		Where("id = ?", subscriptionID).
+		First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookSubscriptionRepository) ListSubscriptionsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.Subscription, error) {
+	var list []*entity.Subscription
+	err := conn(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("created_at ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *webhookSubscriptionRepository) UpdateSubscription(ctx context.Context, subscription *entity.Subscription) error {
+	return conn(ctx, r.db).Save(subscription).Error
+}
+
+func (r *webhookSubscriptionRepository) DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", subscriptionID).Delete(&entity.Delivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", subscriptionID).Delete(&entity.Subscription{}).Error
+	})
+}
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) webhooks.DeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.Delivery) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(deliveries).Error
+}
+
+func (r *webhookDeliveryRepository) GetDeliveryByID(ctx context.Context, deliveryID uuid.UUID) (*entity.Delivery, error) {
+	var delivery entity.Delivery
+	err := conn(ctx, r.db).
+		Where("id = ?", deliveryID).
+		First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) ListDeliveriesBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*entity.Delivery, int, error) {
+	query := conn(ctx, r.db).
+		Model(&entity.Delivery{}).
+		Where("subscription_id = ?", subscriptionID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var list []*entity.Delivery
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, int(total), nil
+}
+
+// ClaimDueDeliveries locks the due rows with SKIP LOCKED, like ClaimDueReminders
+func (r *webhookDeliveryRepository) ClaimDueDeliveries(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.Delivery, error) {
+	var claimed []*entity.Delivery
+	err := conn(ctx, r.db).Raw(`
+		UPDATE webhook_deliveries
+		SET status = ?, locked_until = ?, attempts = attempts + 1, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE (status = ? AND next_attempt_at <= ?) OR (status = ? AND locked_until < ?)
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		webhooks.StatusSending, lockedUntil, now,
+		webhooks.StatusPending, now, webhooks.StatusSending, now,
+		limit,
+	).Scan(&claimed).Error
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (r *webhookDeliveryRepository) FinishClaim(ctx context.Context, delivery *entity.Delivery, claimedUntil time.Time) (bool, error) {
+	res := conn(ctx, r.db).
+		Model(&entity.Delivery{}).
+		Where("id = ? AND status = ? AND locked_until = ?", delivery.ID, webhooks.StatusSending, claimedUntil).
+		Select("status", "attempts", "next_attempt_at", "locked_until", "response_status", "response_body", "duration_ms", "last_error", "delivered_at", "updated_at").
+		Updates(delivery)
+	return res.RowsAffected > 0, res.Error
+}
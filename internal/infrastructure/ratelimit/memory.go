@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many calls to Take pass between removals of idle buckets
+const sweepEvery = 1024
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt is when the bucket will have refilled completely and can be forgotten
+	fullAt time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	calls   int
+}
+
+// NewMemoryStore keeps buckets in process memory. Limits are per instance,
+// so use the Postgres store when running more than one replica.
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Limit), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	tokens, res := take(b.tokens, b.updatedAt, policy, now)
+	b.tokens = tokens
+	b.updatedAt = now
+	b.fullAt = now.Add(res.ResetAfter)
+
+	return res, nil
+}
+
+// sweep drops buckets that are full again; they behave exactly like new ones
+func (s *memoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
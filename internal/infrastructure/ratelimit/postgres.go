@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// purgeInterval is how often rows of idle buckets are deleted
+	purgeInterval = time.Hour
+	// purgeIdleAfter must exceed the longest policy window
+	purgeIdleAfter = 24 * time.Hour
+)
+
+// bucketRow is a token bucket shared by every API instance
+type bucketRow struct {
+	Key       string    `gorm:"column:bucket_key;type:varchar(255);primaryKey"`
+	Tokens    float64   `gorm:"column:tokens;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;index"`
+}
+
+func (bucketRow) TableName() string {
+	return "rate_limit_buckets"
+}
+
+type postgresStore struct {
+	db  *gorm.DB
+	log logger.Logger
+
+	mu        sync.Mutex
+	lastPurge time.Time
+}
+
+// NewPostgresStore keeps buckets in the rate_limit_buckets table so limits hold across replicas
+func NewPostgresStore(db *gorm.DB, log logger.Logger) Store {
+	return &postgresStore{db: db, log: log}
+}
+
+func (s *postgresStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	var res Result
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Creates the bucket full on first use; concurrent callers then serialize on the row lock
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&bucketRow{Key: key, Tokens: float64(policy.Limit), UpdatedAt: now}).Error
+		if err != nil {
+			return err
+		}
+
+		var row bucketRow
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("bucket_key = ?", key).
+			First(&row).Error
+		if err != nil {
+			return err
+		}
+
+		var tokens float64
+		tokens, res = take(row.Tokens, row.UpdatedAt, policy, now)
+
+		return tx.Model(&bucketRow{}).
+			Where("bucket_key = ?", key).
+			Updates(map[string]interface{}{
+				"tokens":     tokens,
+				"updated_at": now,
+			}).Error
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	s.maybePurge(now)
+
+	return res, nil
+}
+
+// maybePurge deletes idle buckets in the background at most once per purgeInterval
+func (s *postgresStore) maybePurge(now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastPurge) < purgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurge = now
+	s.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := s.db.WithContext(ctx).
+			Where("updated_at < ?", now.Add(-purgeIdleAfter)).
+			Delete(&bucketRow{}).Error
+		if err != nil {
+			s.log.Warn("Failed to purge idle rate limit buckets", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+}
@@ -0,0 +1,92 @@
+// Package ratelimit implements token buckets with pluggable storage.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+// Policy allows Limit requests per Window. Tokens refill continuously, so a
+// drained bucket regains one request every Window/Limit.
+type Policy struct {
+	Limit  int
+	Window time.Duration
+}
+
+// String renders the policy for the RateLimit-Policy header, e.g. "5;w=60"
+func (p Policy) String() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, int(math.Ceil(p.Window.Seconds())))
+}
+
+func (p Policy) ratePerSecond() float64 {
+	return float64(p.Limit) / p.Window.Seconds()
+}
+
+// Result is the outcome of taking one token from a bucket
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is how long until the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is how long until the next request is allowed; zero when Allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. Take must be atomic per key.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error)
+}
+
+// NewStore builds the store selected by RATE_LIMIT_STORE (memory or postgres, default memory)
+func NewStore(driver string, db *gorm.DB, log logger.Logger) (Store, error) {
+	switch driver {
+	case "", StoreMemory:
+		return NewMemoryStore(), nil
+	case StorePostgres:
+		if db == nil {
+			return nil, fmt.Errorf("RATE_LIMIT_STORE=postgres requires a database")
+		}
+		return NewPostgresStore(db, log), nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_STORE %q", driver)
+	}
+}
+
+// take refills a bucket holding tokens, last updated at updatedAt, and tries
+// to remove one token. It returns the new token count alongside the result.
+func take(tokens float64, updatedAt time.Time, policy Policy, now time.Time) (float64, Result) {
+	capacity := float64(policy.Limit)
+	rate := policy.ratePerSecond()
+
+	if elapsed := now.Sub(updatedAt).Seconds(); elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed*rate)
+	}
+
+	res := Result{Limit: policy.Limit}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	res.Remaining = int(math.Floor(tokens))
+	res.ResetAfter = secondsToDuration((capacity - tokens) / rate)
+
+	return tokens, res
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,231 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func TestTake(t *testing.T) {
+	// Refills half a token per second
+	policy := Policy{Limit: 5, Window: 10 * time.Second}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		tokens   float64
+		elapsed  time.Duration
+		expected Result
+		left     float64
+	}{
+		{
+			name:     "full bucket",
+			tokens:   5,
+			expected: Result{Allowed: true, Limit: 5, Remaining: 4, ResetAfter: 2 * time.Second},
+			left:     4,
+		},
+		{
+			name:     "empty bucket",
+			tokens:   0,
+			expected: Result{Limit: 5, ResetAfter: 10 * time.Second, RetryAfter: 2 * time.Second},
+			left:     0,
+		},
+		{
+			name:     "refilled less than one token",
+			tokens:   0,
+			elapsed:  time.Second,
+			expected: Result{Limit: 5, ResetAfter: 9 * time.Second, RetryAfter: time.Second},
+			left:     0.5,
+		},
+		{
+			name:     "refilled one token",
+			tokens:   0,
+			elapsed:  3 * time.Second,
+			expected: Result{Allowed: true, Limit: 5, ResetAfter: 9 * time.Second},
+			left:     0.5,
+		},
+		{
+			name:     "refill stops at the limit",
+			tokens:   2,
+			elapsed:  time.Hour,
+			expected: Result{Allowed: true, Limit: 5, Remaining: 4, ResetAfter: 2 * time.Second},
+			left:     4,
+		},
+		{
+			name:     "clock going backwards does not refill",
+			tokens:   2,
+			elapsed:  -5 * time.Second,
+			expected: Result{Allowed: true, Limit: 5, Remaining: 1, ResetAfter: 8 * time.Second},
+			left:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, res := take(tt.tokens, now.Add(-tt.elapsed), policy, now)
+			assert.Equal(t, tt.expected, res)
+			assert.InDelta(t, tt.left, left, 1e-9)
+		})
+	}
+}
+
+func TestPolicy_String(t *testing.T) {
+	assert.Equal(t, "5;w=60", Policy{Limit: 5, Window: time.Minute}.String())
+	assert.Equal(t, "10;w=2", Policy{Limit: 10, Window: 1500 * time.Millisecond}.String())
+}
+
+func TestStores(t *testing.T) {
+	// Refills one token per second
+	policy := Policy{Limit: 2, Window: 2 * time.Second}
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		key        string
+		at         time.Duration
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}{
+		{key: "a", allowed: true, remaining: 1},
+		{key: "a", allowed: true, remaining: 0},
+		{key: "a", retryAfter: time.Second},
+		{key: "b", allowed: true, remaining: 1},
+		{key: "a", at: 500 * time.Millisecond, retryAfter: 500 * time.Millisecond},
+		{key: "a", at: time.Second, allowed: true, remaining: 0},
+		{key: "a", at: time.Minute, allowed: true, remaining: 1},
+	}
+
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store {
+			return NewMemoryStore()
+		},
+		"postgres": func(t *testing.T) Store {
+			db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(&bucketsDB{rows: map[string]bucketRow{}})}), &gorm.Config{
+				Logger: gormLogger.Discard,
+			})
+			require.NoError(t, err)
+			return NewPostgresStore(db, nil)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			for i, step := range steps {
+				res, err := store.Take(context.Background(), step.key, policy, start.Add(step.at))
+				require.NoError(t, err, "step %d", i)
+				assert.Equal(t, step.allowed, res.Allowed, "step %d", i)
+				assert.Equal(t, step.remaining, res.Remaining, "step %d", i)
+				assert.Equal(t, step.retryAfter, res.RetryAfter, "step %d", i)
+			}
+		})
+	}
+}
+
+// bucketsDB is an in-memory rate_limit_buckets table answering the statements
+// of the Postgres store, enough to run it without a server
+type bucketsDB struct {
+	mu   sync.Mutex
+	rows map[string]bucketRow
+}
+
+func (d *bucketsDB) Connect(context.Context) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d *bucketsDB) Driver() driver.Driver {
+	return d
+}
+
+func (d *bucketsDB) Open(string) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d *bucketsDB) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (d *bucketsDB) Close() error {
+	return nil
+}
+
+func (d *bucketsDB) Begin() (driver.Tx, error) {
+	return d, nil
+}
+
+func (d *bucketsDB) Commit() error {
+	return nil
+}
+
+func (d *bucketsDB) Rollback() error {
+	return nil
+}
+
+func (d *bucketsDB) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(query, "INSERT"):
+		key := args[0].Value.(string)
+		if _, ok := d.rows[key]; ok {
+			return driver.RowsAffected(0), nil
+		}
+		d.rows[key] = bucketRow{Key: key, Tokens: args[1].Value.(float64), UpdatedAt: args[2].Value.(time.Time)}
+	case strings.HasPrefix(query, "UPDATE"):
+		key := args[2].Value.(string)
+		d.rows[key] = bucketRow{Key: key, Tokens: args[0].Value.(float64), UpdatedAt: args[1].Value.(time.Time)}
+	case strings.HasPrefix(query, "DELETE"):
+	default:
+		return nil, fmt.Errorf("unexpected statement %q", query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (d *bucketsDB) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !strings.HasPrefix(query, "SELECT") || !strings.HasSuffix(query, "FOR UPDATE") {
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+	r := &rows{columns: []string{"bucket_key", "tokens", "updated_at"}}
+	if row, ok := d.rows[args[0].Value.(string)]; ok {
+		r.values = [][]driver.Value{{row.Key, row.Tokens, row.UpdatedAt}}
+	}
+	return r, nil
+}
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -12,20 +12,23 @@ import (
 type AccountHandler struct {
 	CreateAccountUC *usecase.CreateAccountUseCase
 	LoginUC         *usecase.LoginUseCase
-	ListAccountUC   *usecase.ListAccountUseCase
+	RefreshTokenUC  *usecase.RefreshTokenUseCase
+	ReactivateUC    *usecase.ReactivateAccountUseCase
 	logger          logger.Logger
 }
 
 func NewAccountHandler(
 	create *usecase.CreateAccountUseCase,
-	listUC *usecase.ListAccountUseCase,
 	login *usecase.LoginUseCase,
+	refresh *usecase.RefreshTokenUseCase,
+	reactivate *usecase.ReactivateAccountUseCase,
 	l logger.Logger,
 ) *AccountHandler {
 	return &AccountHandler{
 		CreateAccountUC: create,
-		ListAccountUC:   listUC,
 		LoginUC:         login,
+		RefreshTokenUC:  refresh,
+		ReactivateUC:    reactivate,
 		logger:          l,
 	}
 
@@ -34,13 +37,13 @@ func NewAccountHandler(
 func (h *AccountHandler) CreateAccount(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidate[account.CreateAccountRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
 	}
 
-	data, err := h.CreateAccountUC.Execute(c.Context(), req)
+	data, err := h.CreateAccountUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -48,36 +51,63 @@ func (h *AccountHandler) CreateAccount(c *fiber.Ctx) error {
 	return responses.Success(c, data, "Account created successfully")
 }
 
-func (h *AccountHandler) ListAccounts(c *fiber.Ctx) error {
-	req, err := requests.ParseAndValidateQuery[account.ListAccountsRequest](c)
+func (h *AccountHandler) Login(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.LoginRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid query parameters", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Failed to validate request", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
 	}
 
-	data, err := h.ListAccountUC.Execute(c.Context(), req)
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	req.IPAddress = c.IP()
+
+	data, err := h.LoginUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
-	return responses.Success(c, data, "List accounts successfully")
+	return responses.Success(c, data, "Login successfully")
 }
 
-func (h *AccountHandler) Login(c *fiber.Ctx) error {
+func (h *AccountHandler) RefreshToken(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.RefreshTokenRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Failed to validate request", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	req.IPAddress = c.IP()
+
+	data, err := h.RefreshTokenUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Token refreshed successfully")
+}
+
+// ReactivateAccount logs in a deactivated account and makes it active again
+func (h *AccountHandler) ReactivateAccount(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidate[account.LoginRequest](c)
 	if err != nil {
-		h.logger.Warn("Failed to validate request", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Failed to validate request", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
 	}
 
-	data, err := h.LoginUC.Execute(c.Context(), req)
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+	req.IPAddress = c.IP()
+
+	data, err := h.ReactivateUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
-	return responses.Success(c, data, "Login successfully")
+	return responses.Success(c, data, "Account reactivated successfully")
 }
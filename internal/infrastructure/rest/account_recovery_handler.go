@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AccountRecoveryHandler handles password reset and email verification
+type AccountRecoveryHandler struct {
+	ForgotPasswordUC     *usecase.ForgotPasswordUseCase
+	ResetPasswordUC      *usecase.ResetPasswordUseCase
+	VerifyEmailUC        *usecase.VerifyEmailUseCase
+	ResendVerificationUC *usecase.ResendVerificationUseCase
+	logger               logger.Logger
+}
+
+func NewAccountRecoveryHandler(
+	forgot *usecase.ForgotPasswordUseCase,
+	reset *usecase.ResetPasswordUseCase,
+	verify *usecase.VerifyEmailUseCase,
+	resend *usecase.ResendVerificationUseCase,
+	l logger.Logger,
+) *AccountRecoveryHandler {
+	return &AccountRecoveryHandler{
+		ForgotPasswordUC:     forgot,
+		ResetPasswordUC:      reset,
+		VerifyEmailUC:        verify,
+		ResendVerificationUC: resend,
+		logger:               l,
+	}
+}
+
+func (h *AccountRecoveryHandler) ForgotPassword(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.ForgotPasswordRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	if err := h.ForgotPasswordUC.Execute(c.UserContext(), req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "If the email is registered, a password reset link has been sent")
+}
+
+func (h *AccountRecoveryHandler) ResetPassword(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.ResetPasswordRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	if err := h.ResetPasswordUC.Execute(c.UserContext(), req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Password reset successfully")
+}
+
+func (h *AccountRecoveryHandler) VerifyEmail(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.VerifyEmailRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	if err := h.VerifyEmailUC.Execute(c.UserContext(), req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Email verified successfully")
+}
+
+func (h *AccountRecoveryHandler) ResendVerification(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.ResendVerificationRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	if err := h.ResendVerificationUC.Execute(c.UserContext(), req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "If the email is registered and not yet verified, a verification link has been sent")
+}
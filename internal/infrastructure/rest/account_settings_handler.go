@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AccountSettingsHandler lets the authenticated account change its credentials and deactivate itself
+type AccountSettingsHandler struct {
+	GetCurrentAccountUC *usecase.GetCurrentAccountUseCase
+	ChangePasswordUC    *usecase.ChangePasswordUseCase
+	ChangeEmailUC       *usecase.ChangeEmailUseCase
+	ChangeUsernameUC    *usecase.ChangeUsernameUseCase
+	DeactivateUC        *usecase.DeactivateAccountUseCase
+	logger              logger.Logger
+}
+
+func NewAccountSettingsHandler(
+	get *usecase.GetCurrentAccountUseCase,
+	changePassword *usecase.ChangePasswordUseCase,
+	changeEmail *usecase.ChangeEmailUseCase,
+	changeUsername *usecase.ChangeUsernameUseCase,
+	deactivate *usecase.DeactivateAccountUseCase,
+	l logger.Logger,
+) *AccountSettingsHandler {
+	return &AccountSettingsHandler{
+		GetCurrentAccountUC: get,
+		ChangePasswordUC:    changePassword,
+		ChangeEmailUC:       changeEmail,
+		ChangeUsernameUC:    changeUsername,
+		DeactivateUC:        deactivate,
+		logger:              l,
+	}
+}
+
+func (h *AccountSettingsHandler) GetCurrentAccount(c *fiber.Ctx) error {
+	accountID, _, err := h.getAccountFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.GetCurrentAccountUC.Execute(c.UserContext(), accountID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Account retrieved successfully")
+}
+
+func (h *AccountSettingsHandler) ChangePassword(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.ChangePasswordRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	accountID, sessionID, err := h.getAccountFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.ChangePasswordUC.Execute(c.UserContext(), accountID, sessionID, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Password changed successfully")
+}
+
+func (h *AccountSettingsHandler) ChangeEmail(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.ChangeEmailRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	accountID, _, err := h.getAccountFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ChangeEmailUC.Execute(c.UserContext(), accountID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Email changed successfully")
+}
+
+func (h *AccountSettingsHandler) ChangeUsername(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.ChangeUsernameRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	accountID, _, err := h.getAccountFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ChangeUsernameUC.Execute(c.UserContext(), accountID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Username changed successfully")
+}
+
+func (h *AccountSettingsHandler) DeactivateAccount(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidate[account.DeactivateAccountRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	accountID, _, err := h.getAccountFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.DeactivateUC.Execute(c.UserContext(), accountID, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Account deactivated successfully")
+}
+
+// getAccountFromContext extracts account and session IDs from JWT claims in context
+func (h *AccountSettingsHandler) getAccountFromContext(c *fiber.Ctx) (string, string, error) {
+	claims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		return "", "", apperror.NewUnauthorizedError("authentication required", "UNAUTHORIZED", nil)
+	}
+
+	accountID, ok := claims["AccountId"].(string)
+	if !ok || accountID == "" {
+		return "", "", apperror.NewUnauthorizedError("invalid token claims", "INVALID_TOKEN_CLAIMS", nil)
+	}
+
+	sessionID, _ := claims["SessionId"].(string)
+
+	return accountID, sessionID, nil
+}
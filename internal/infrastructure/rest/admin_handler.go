@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminHandler serves the /api/admin routes; every route requires the admin role
+type AdminHandler struct {
+	ListAccountUC      *usecase.ListAccountUseCase
+	GetSupportViewUC   *usecase.GetAccountSupportViewUseCase
+	SuspendAccountUC   *usecase.SuspendAccountUseCase
+	UnsuspendAccountUC *usecase.UnsuspendAccountUseCase
+	SetAccountRoleUC   *usecase.SetAccountRoleUseCase
+	logger             logger.Logger
+}
+
+func NewAdminHandler(
+	list *usecase.ListAccountUseCase,
+	supportView *usecase.GetAccountSupportViewUseCase,
+	suspend *usecase.SuspendAccountUseCase,
+	unsuspend *usecase.UnsuspendAccountUseCase,
+	setRole *usecase.SetAccountRoleUseCase,
+	l logger.Logger,
+) *AdminHandler {
+	return &AdminHandler{
+		ListAccountUC:      list,
+		GetSupportViewUC:   supportView,
+		SuspendAccountUC:   suspend,
+		UnsuspendAccountUC: unsuspend,
+		SetAccountRoleUC:   setRole,
+		logger:             l,
+	}
+}
+
+// ListAccounts lists and searches accounts (?q=, ?state=, ?role=)
+func (h *AdminHandler) ListAccounts(c *fiber.Ctx) error {
+	req, err := requests.ParseAndValidateQuery[account.ListAccountsRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid query parameters", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ListAccountUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "List accounts successfully")
+}
+
+func (h *AdminHandler) GetAccount(c *fiber.Ctx) error {
+	accountID := c.Params("accountId")
+	if accountID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("account ID is required", "INVALID_ACCOUNT_ID", nil))
+	}
+
+	data, err := h.GetSupportViewUC.Execute(c.UserContext(), accountID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Account retrieved successfully")
+}
+
+func (h *AdminHandler) SuspendAccount(c *fiber.Ctx) error {
+	adminID, err := h.getAdminIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.SuspendAccountUC.Execute(c.UserContext(), adminID, c.Params("accountId"))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Account suspended successfully")
+}
+
+func (h *AdminHandler) UnsuspendAccount(c *fiber.Ctx) error {
+	adminID, err := h.getAdminIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.UnsuspendAccountUC.Execute(c.UserContext(), adminID, c.Params("accountId"))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Account unsuspended successfully")
+}
+
+func (h *AdminHandler) SetAccountRole(c *fiber.Ctx) error {
+	adminID, err := h.getAdminIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	req, err := requests.ParseAndValidate[account.SetAccountRoleRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.SetAccountRoleUC.Execute(c.UserContext(), adminID, c.Params("accountId"), req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Account role updated successfully")
+}
+
+// getAdminIDFromContext extracts the acting admin's account ID from JWT claims in context
+func (h *AdminHandler) getAdminIDFromContext(c *fiber.Ctx) (string, error) {
+	claims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		return "", apperror.NewUnauthorizedError("authentication required", "UNAUTHORIZED", nil)
+	}
+
+	accountID, ok := claims["AccountId"].(string)
+	if !ok || accountID == "" {
+		return "", apperror.NewUnauthorizedError("invalid token claims", "INVALID_TOKEN_CLAIMS", nil)
+	}
+
+	return accountID, nil
+}
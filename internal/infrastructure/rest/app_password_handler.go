@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AppPasswordHandler manages the app passwords CalDAV clients of the authenticated account log in with
+type AppPasswordHandler struct {
+	CreateAppPasswordUC *usecase.CreateAppPasswordUseCase
+	ListAppPasswordsUC  *usecase.ListAppPasswordsUseCase
+	RevokeAppPasswordUC *usecase.RevokeAppPasswordUseCase
+	logger              logger.Logger
+}
+
+func NewAppPasswordHandler(
+	create *usecase.CreateAppPasswordUseCase,
+	list *usecase.ListAppPasswordsUseCase,
+	revoke *usecase.RevokeAppPasswordUseCase,
+	l logger.Logger,
+) *AppPasswordHandler {
+	return &AppPasswordHandler{
+		CreateAppPasswordUC: create,
+		ListAppPasswordsUC:  list,
+		RevokeAppPasswordUC: revoke,
+		logger:              l,
+	}
+}
+
+// CreateAppPassword returns the new password once; only its hash is stored
+func (h *AppPasswordHandler) CreateAppPassword(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	req, err := requests.ParseAndValidate[account.CreateAppPasswordRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.CreateAppPasswordUC.Execute(c.UserContext(), accountID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "App password created successfully")
+}
+
+func (h *AppPasswordHandler) ListAppPasswords(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ListAppPasswordsUC.Execute(c.UserContext(), accountID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "List app passwords successfully")
+}
+
+func (h *AppPasswordHandler) RevokeAppPassword(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	appPasswordID := c.Params("appPasswordId")
+	if appPasswordID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("missing appPasswordId", "MISSING_APP_PASSWORD_ID", nil))
+	}
+
+	if err := h.RevokeAppPasswordUC.Execute(c.UserContext(), accountID, appPasswordID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "App password revoked successfully")
+}
+
+func (h *AppPasswordHandler) getAccountIDFromContext(c *fiber.Ctx) (string, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	return accountID, nil
+}
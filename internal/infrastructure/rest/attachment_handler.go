@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"mime"
+	"strconv"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/task/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttachmentHandler struct {
+	UploadAttachmentUC   *usecase.UploadAttachmentUseCase
+	ListAttachmentsUC    *usecase.ListAttachmentsUseCase
+	DownloadAttachmentUC *usecase.DownloadAttachmentUseCase
+	DeleteAttachmentUC   *usecase.DeleteAttachmentUseCase
+	logger               logger.Logger
+}
+
+func NewAttachmentHandler(
+	upload *usecase.UploadAttachmentUseCase,
+	list *usecase.ListAttachmentsUseCase,
+	download *usecase.DownloadAttachmentUseCase,
+	delete *usecase.DeleteAttachmentUseCase,
+	l logger.Logger,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		UploadAttachmentUC:   upload,
+		ListAttachmentsUC:    list,
+		DownloadAttachmentUC: download,
+		DeleteAttachmentUC:   delete,
+		logger:               l,
+	}
+}
+
+// UploadAttachment accepts a multipart form with the file in the "file" field
+func (h *AttachmentHandler) UploadAttachment(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	taskID := c.Params("taskId")
+	if taskID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("task ID is required", "INVALID_TASK_ID", nil))
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return responses.Error(c, apperror.NewBadRequestError("file is required", "MISSING_FILE", nil))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return responses.Error(c, apperror.NewBadRequestError("failed to read file", "INVALID_ATTACHMENT", nil))
+	}
+	defer file.Close()
+
+	data, err := h.UploadAttachmentUC.Execute(c.UserContext(), &usecase.UploadAttachmentInput{
+		TaskID:    taskID,
+		AccountID: accountID,
+		FileName:  fileHeader.Filename,
+		Size:      fileHeader.Size,
+		File:      file,
+	})
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Attachment uploaded successfully")
+}
+
+func (h *AttachmentHandler) ListAttachments(c *fiber.Ctx) error {
+	taskID := c.Params("taskId")
+	if taskID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("task ID is required", "INVALID_TASK_ID", nil))
+	}
+
+	data, err := h.ListAttachmentsUC.Execute(c.UserContext(), taskID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Attachments retrieved successfully")
+}
+
+// DownloadAttachment streams the file with its original name
+func (h *AttachmentHandler) DownloadAttachment(c *fiber.Ctx) error {
+	taskID := c.Params("taskId")
+	attachmentID := c.Params("attachmentId")
+	if taskID == "" || attachmentID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("task ID and attachment ID are required", "INVALID_ATTACHMENT_ID", nil))
+	}
+
+	data, body, err := h.DownloadAttachmentUC.Execute(c.UserContext(), taskID, attachmentID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, data.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": data.FileName}))
+	c.Set(fiber.HeaderETag, strconv.Quote(data.Checksum))
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	c.Set("X-Content-Type-Options", "nosniff")
+
+	// Fiber closes the body once it has been written
+	return c.SendStream(body, int(data.Size))
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
+	taskID := c.Params("taskId")
+	attachmentID := c.Params("attachmentId")
+	if taskID == "" || attachmentID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("task ID and attachment ID are required", "INVALID_ATTACHMENT_ID", nil))
+	}
+
+	if err := h.DeleteAttachmentUC.Execute(c.UserContext(), taskID, attachmentID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Attachment deleted successfully")
+}
+
+func (h *AttachmentHandler) getAccountIDFromContext(c *fiber.Ctx) (string, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	return accountID, nil
+}
@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/calendar"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/calendar/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/calendars"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// feedCacheMaxAge matches the refresh interval advertised inside the feeds
+const feedCacheMaxAge = 3600
+
+// CalendarHandler manages the iCalendar feeds of the authenticated account
+type CalendarHandler struct {
+	CreateFeedUC  *usecase.CreateFeedUseCase
+	ListFeedsUC   *usecase.ListFeedsUseCase
+	RevokeFeedUC  *usecase.RevokeFeedUseCase
+	ImportTasksUC *usecase.ImportTasksUseCase
+	logger        logger.Logger
+}
+
+func NewCalendarHandler(
+	create *usecase.CreateFeedUseCase,
+	list *usecase.ListFeedsUseCase,
+	revoke *usecase.RevokeFeedUseCase,
+	importTasks *usecase.ImportTasksUseCase,
+	l logger.Logger,
+) *CalendarHandler {
+	return &CalendarHandler{
+		CreateFeedUC:  create,
+		ListFeedsUC:   list,
+		RevokeFeedUC:  revoke,
+		ImportTasksUC: importTasks,
+		logger:        l,
+	}
+}
+
+// CreateFeed creates a feed of one project, or of every project when no project_id is sent
+func (h *CalendarHandler) CreateFeed(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	req := &calendar.CreateFeedRequest{}
+	if len(c.Body()) > 0 {
+		req, err = requests.ParseAndValidate[calendar.CreateFeedRequest](c)
+		if err != nil {
+			h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return responses.Error(c, err)
+		}
+	}
+
+	data, err := h.CreateFeedUC.Execute(c.UserContext(), accountID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Calendar feed created successfully")
+}
+
+func (h *CalendarHandler) ListFeeds(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ListFeedsUC.Execute(c.UserContext(), accountID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "List calendar feeds successfully")
+}
+
+func (h *CalendarHandler) RevokeFeed(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	feedID := c.Params("feedId")
+	if feedID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("missing feedId", "MISSING_CALENDAR_FEED_ID", nil))
+	}
+
+	if err := h.RevokeFeedUC.Execute(c.UserContext(), accountID, feedID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Calendar feed revoked successfully")
+}
+
+// ImportTasks accepts a multipart form with the .ics file in the "file" field.
+// dry_run=true previews the tasks without creating them; timezone applies to floating times.
+func (h *CalendarHandler) ImportTasks(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	projectID := c.Params("projectId")
+	if projectID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("missing projectId", "MISSING_PROJECT_ID", nil))
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return responses.Error(c, apperror.NewBadRequestError("file is required", "MISSING_FILE", nil))
+	}
+	if fileHeader.Size > calendars.MaxImportBytes {
+		return responses.Error(c, apperror.NewBadRequestError(
+			fmt.Sprintf("calendar file exceeds %d MB", calendars.MaxImportBytes>>20),
+			"CALENDAR_FILE_TOO_LARGE",
+			nil,
+		))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return responses.Error(c, apperror.NewBadRequestError("failed to read file", "INVALID_CALENDAR_FILE", nil))
+	}
+	defer file.Close()
+
+	data, err := h.ImportTasksUC.Execute(c.UserContext(), &usecase.ImportTasksInput{
+		AccountID: accountID,
+		ProjectID: projectID,
+		File:      file,
+		TimeZone:  c.Query("timezone"),
+		DryRun:    c.QueryBool("dry_run"),
+	})
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	if data.DryRun {
+		return responses.Success(c, data, "Calendar import previewed successfully")
+	}
+	return responses.Success(c, data, "Calendar imported successfully")
+}
+
+func (h *CalendarHandler) getAccountIDFromContext(c *fiber.Ctx) (string, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	return accountID, nil
+}
+
+// CalendarFeedHandler serves feeds to calendar apps, which cannot send an access token
+type CalendarFeedHandler struct {
+	GetFeedUC *usecase.GetFeedUseCase
+	logger    logger.Logger
+}
+
+func NewCalendarFeedHandler(get *usecase.GetFeedUseCase, l logger.Logger) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		GetFeedUC: get,
+		logger:    l,
+	}
+}
+
+// GetFeed serves a feed to calendar apps; the secret token in the path is the only credential
+func (h *CalendarFeedHandler) GetFeed(c *fiber.Ctx) error {
+	data, err := h.GetFeedUC.Execute(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="calendar.ics"`)
+	c.Set(fiber.HeaderCacheControl, "private, max-age="+strconv.Itoa(feedCacheMaxAge))
+
+	return c.Send(data)
+}
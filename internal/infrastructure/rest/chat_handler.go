@@ -34,7 +34,7 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 
 	req, err := requests.ParseAndValidate[chat.SendMessageRequestDTO](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
@@ -49,9 +49,9 @@ func (h *ChatHandler) SendMessage(c *fiber.Ctx) error {
 		return responses.Error(c, err)
 	}
 
-	resp, err := h.sendMessageUC.Execute(c.Context(), accountID, req)
+	resp, err := h.sendMessageUC.Execute(c.UserContext(), accountID, req)
 	if err != nil {
-		h.logger.Error("Failed to send message", map[string]interface{}{
+		h.logger.ErrorContext(c.UserContext(), "Failed to send message", map[string]interface{}{
 			"error":      err.Error(),
 			"account_id": accountID,
 			"project_id": req.ProjectID,
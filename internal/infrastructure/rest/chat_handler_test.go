@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/chat/usecase"
+	chatSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/chats/service"
+	profileSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
+	projectEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/entity"
+	projectSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/service"
+	taskEntity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
+	taskSvc "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/groq"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/tracing"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/middlewares"
+	"github.com/FrostBitzX/smart-task-ai/internal/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// groqServer answers every chat completion with a plain text reply and
+// records the headers of the last request
+type groqServer struct {
+	*httptest.Server
+	header http.Header
+}
+
+func newGroqServer(t *testing.T) *groqServer {
+	s := &groqServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.header = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello"}}]}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newChatTestApp serves the chat endpoint behind the request ID middleware and
+// extra, as an account whose JWT has already been verified
+func newChatTestApp(accountID uuid.UUID, chatService chatSvc.ChatService, profileService *profileSvc.ProfileService, extra ...fiber.Handler) *fiber.App {
+	log := logger.NewZapLogger("test", "error")
+	h := NewChatHandler(usecase.NewSendMessageUseCase(chatService, profileService, log), log)
+
+	app := fiber.New()
+	app.Use(middlewares.RequestIDMiddleware())
+	for _, h := range extra {
+		app.Use(h)
+	}
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("jwt_claims", map[string]interface{}{"AccountId": accountID.String()})
+		return c.Next()
+	})
+	app.Post("/api/:projectId/chat", h.SendMessage)
+	return app
+}
+
+func TestChatHandler_SendMessage_RequestContext(t *testing.T) {
+	const requestID = "req-chat-123"
+
+	ctrl := gomock.NewController(t)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	profileRepo := mocks.NewMockProfileRepository(ctrl)
+
+	accountID := uuid.New()
+	project := &projectEntity.Project{ID: uuid.New(), AccountID: accountID, Name: "Work"}
+
+	// The repositories see the context of the request, as do the logs they write
+	hasRequestID := gomock.Cond(func(ctx any) bool {
+		return logger.RequestIDFromContext(ctx.(context.Context)) == requestID
+	})
+	projectRepo.EXPECT().GetProjectByID(hasRequestID, project.ID).Return(project, nil)
+	taskRepo.EXPECT().ListTasksByProject(hasRequestID, project.ID).Return([]*taskEntity.Task{}, nil)
+	profileRepo.EXPECT().GetProfileByAccountID(hasRequestID, accountID.String()).Return(nil, gorm.ErrRecordNotFound)
+
+	groqSrv := newGroqServer(t)
+	groqClient, err := groq.NewGroqClientWithKey("test-key", groq.WithAPIURL(groqSrv.URL))
+	require.NoError(t, err)
+
+	chatService := chatSvc.NewChatService(
+		groqClient,
+		taskSvc.NewTaskService(taskRepo, projectRepo, nil),
+		projectSvc.NewProjectService(projectRepo, taskRepo, nil),
+	)
+	app := newChatTestApp(accountID, chatService, profileSvc.NewProfileService(profileRepo, nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/"+project.ID.String()+"/chat", strings.NewReader(`{"content":"hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middlewares.RequestIDHeader, requestID)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, requestID, resp.Header.Get(middlewares.RequestIDHeader))
+	// and so does Groq
+	assert.Equal(t, requestID, groqSrv.header.Get("X-Request-ID"))
+}
+
+// projectsDB is a database holding one project and nothing else, enough for
+// the repositories to run their queries without a server
+type projectsDB struct {
+	project *projectEntity.Project
+}
+
+func (d projectsDB) Connect(context.Context) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d projectsDB) Driver() driver.Driver {
+	return d
+}
+
+func (d projectsDB) Open(string) (driver.Conn, error) {
+	return d, nil
+}
+
+func (d projectsDB) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (d projectsDB) Close() error {
+	return nil
+}
+
+func (d projectsDB) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (d projectsDB) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if strings.Contains(query, `FROM "projects"`) {
+		return &rows{
+			columns: []string{"id", "account_id", "name"},
+			values:  [][]driver.Value{{d.project.ID.String(), d.project.AccountID.String(), d.project.Name}},
+		}, nil
+	}
+	return &rows{}, nil
+}
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestChatHandler_SendMessage_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	accountID := uuid.New()
+	project := &projectEntity.Project{ID: uuid.New(), AccountID: accountID, Name: "Work"}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(projectsDB{project: project})}), &gorm.Config{
+		Logger: gormLogger.Discard,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(tracing.NewGormPlugin()))
+
+	groqSrv := newGroqServer(t)
+	groqClient, err := groq.NewGroqClientWithKey("test-key", groq.WithAPIURL(groqSrv.URL))
+	require.NoError(t, err)
+
+	projectRepo := persistence.NewProjectRepository(db)
+	taskRepo := persistence.NewTaskRepository(db)
+	chatService := chatSvc.NewChatService(
+		groqClient,
+		taskSvc.NewTaskService(taskRepo, projectRepo, nil),
+		projectSvc.NewProjectService(projectRepo, taskRepo, nil),
+	)
+	profileService := profileSvc.NewProfileService(persistence.NewProfileRepository(db), nil)
+	app := newChatTestApp(accountID, chatService, profileService, middlewares.TracingMiddleware())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/"+project.ID.String()+"/chat", strings.NewReader(`{"content":"hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	server, ok := spans["POST /api/:projectId/chat"]
+	require.True(t, ok, "no server span")
+
+	for _, name := range []string{"db.select projects", "db.select tasks", "db.select profiles", "chat " + groq.DefaultModel} {
+		span, ok := spans[name]
+		if assert.True(t, ok, "no span %q", name) {
+			assert.Equal(t, server.SpanContext().TraceID(), span.SpanContext().TraceID(), name)
+			assert.Equal(t, server.SpanContext().SpanID(), span.Parent().SpanID(), name)
+		}
+	}
+
+	// Groq receives the trace in traceparent
+	sc := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(groqSrv.header)))
+	assert.Equal(t, server.SpanContext().TraceID(), sc.TraceID())
+}
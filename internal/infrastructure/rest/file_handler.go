@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileHandler serves stored objects through signed, expiring URLs.
+// The URLs are created by Storage.URL, so no access token is needed to load e.g. an <img>.
+type FileHandler struct {
+	storage storage.Storage
+	signer  *storage.URLSigner
+	logger  logger.Logger
+}
+
+func NewFileHandler(store storage.Storage, signer *storage.URLSigner, l logger.Logger) *FileHandler {
+	return &FileHandler{
+		storage: store,
+		signer:  signer,
+		logger:  l,
+	}
+}
+
+func (h *FileHandler) ServeFile(c *fiber.Ctx) error {
+	key, err := url.PathUnescape(strings.TrimPrefix(c.Path(), strings.TrimRight(storage.ProxyPathPrefix, "/")+"/"))
+	if err != nil || storage.ValidateKey(key) != nil {
+		return responses.Error(c, apperror.NewNotFoundError("file not found", "FILE_NOT_FOUND", nil))
+	}
+
+	if !h.signer.Verify(key, c.Query("expires"), c.Query("signature"), time.Now()) {
+		return responses.Error(c, apperror.NewForbiddenError("link is invalid or has expired", "INVALID_FILE_SIGNATURE", nil))
+	}
+
+	body, info, err := h.storage.Open(c.UserContext(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return responses.Error(c, apperror.NewNotFoundError("file not found", "FILE_NOT_FOUND", nil))
+		}
+		h.logger.ErrorContext(c.UserContext(), "Failed to open stored file", map[string]interface{}{
+			"key":   key,
+			"error": err.Error(),
+		})
+		return responses.Error(c, apperror.NewInternalServerError("failed to read file", "READ_FILE_ERROR", err))
+	}
+
+	if info.ContentType != "" {
+		c.Set(fiber.HeaderContentType, info.ContentType)
+	}
+	c.Set(fiber.HeaderCacheControl, "private, max-age="+strconv.Itoa(int(time.Hour.Seconds())))
+	c.Set("X-Content-Type-Options", "nosniff")
+
+	// Fiber closes the body once it has been written
+	return c.SendStream(body, int(info.Size))
+}
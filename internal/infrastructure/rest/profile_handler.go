@@ -1,8 +1,11 @@
 package rest
 
 import (
+	"fmt"
+
 	"github.com/FrostBitzX/smart-task-ai/internal/application/profile"
 	"github.com/FrostBitzX/smart-task-ai/internal/application/profile/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/profiles"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
 	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
 	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
@@ -14,6 +17,8 @@ type ProfileHandler struct {
 	CreateProfileUC *usecase.CreateProfileUseCase
 	GetProfileUC    *usecase.GetProfileUseCase
 	UpdateProfileUC *usecase.UpdateProfileUseCase
+	UploadAvatarUC  *usecase.UploadAvatarUseCase
+	DeleteAvatarUC  *usecase.DeleteAvatarUseCase
 	logger          logger.Logger
 }
 
@@ -21,12 +26,16 @@ func NewProfileHandler(
 	create *usecase.CreateProfileUseCase,
 	get *usecase.GetProfileUseCase,
 	update *usecase.UpdateProfileUseCase,
+	uploadAvatar *usecase.UploadAvatarUseCase,
+	deleteAvatar *usecase.DeleteAvatarUseCase,
 	l logger.Logger,
 ) *ProfileHandler {
 	return &ProfileHandler{
 		CreateProfileUC: create,
 		GetProfileUC:    get,
 		UpdateProfileUC: update,
+		UploadAvatarUC:  uploadAvatar,
+		DeleteAvatarUC:  deleteAvatar,
 		logger:          l,
 	}
 
@@ -35,7 +44,7 @@ func NewProfileHandler(
 func (h *ProfileHandler) CreateProfile(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidate[profile.CreateProfileRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, apperror.ErrInvalidData)
@@ -44,20 +53,20 @@ func (h *ProfileHandler) CreateProfile(c *fiber.Ctx) error {
 	// Get AccountID from JWT claims
 	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
 	if !ok {
-		h.logger.Error("Invalid JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	accountID, ok := jwtClaims["AccountId"].(string)
 	if !ok || accountID == "" {
-		h.logger.Error("Missing AccountId in JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	// Set AccountID from JWT
 	req.AccountID = accountID
 
-	data, err := h.CreateProfileUC.Execute(c.Context(), req)
+	data, err := h.CreateProfileUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -69,13 +78,13 @@ func (h *ProfileHandler) GetProfile(c *fiber.Ctx) error {
 	// Get AccountID from JWT claims
 	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
 	if !ok {
-		h.logger.Error("Invalid JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	accountID, ok := jwtClaims["AccountId"].(string)
 	if !ok || accountID == "" {
-		h.logger.Error("Missing AccountId in JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
@@ -83,7 +92,7 @@ func (h *ProfileHandler) GetProfile(c *fiber.Ctx) error {
 		AccountID: accountID,
 	}
 
-	data, err := h.GetProfileUC.Execute(c.Context(), req)
+	data, err := h.GetProfileUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -94,7 +103,7 @@ func (h *ProfileHandler) GetProfile(c *fiber.Ctx) error {
 func (h *ProfileHandler) UpdateProfile(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidate[profile.UpdateProfileRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, apperror.ErrInvalidData)
@@ -103,23 +112,86 @@ func (h *ProfileHandler) UpdateProfile(c *fiber.Ctx) error {
 	// Get AccountID from JWT claims
 	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
 	if !ok {
-		h.logger.Error("Invalid JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	accountID, ok := jwtClaims["AccountId"].(string)
 	if !ok || accountID == "" {
-		h.logger.Error("Missing AccountId in JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	// Set AccountID from JWT
 	req.AccountID = accountID
 
-	data, err := h.UpdateProfileUC.Execute(c.Context(), req)
+	data, err := h.UpdateProfileUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
 	return responses.Success(c, data, "Profile updated successfully")
 }
+
+// UploadAvatar accepts a multipart form with the image in the "avatar" field
+func (h *ProfileHandler) UploadAvatar(c *fiber.Ctx) error {
+	// Get AccountID from JWT claims
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return responses.Error(c, apperror.NewBadRequestError("avatar file is required", "MISSING_AVATAR", nil))
+	}
+
+	if fileHeader.Size > profiles.MaxAvatarBytes {
+		return responses.Error(c, apperror.NewBadRequestError(
+			fmt.Sprintf("avatar must be at most %d MB", profiles.MaxAvatarBytes>>20),
+			"AVATAR_TOO_LARGE",
+			nil,
+		))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return responses.Error(c, apperror.NewBadRequestError("failed to read avatar file", "INVALID_AVATAR", nil))
+	}
+	defer file.Close()
+
+	data, err := h.UploadAvatarUC.Execute(c.UserContext(), accountID, file)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Avatar uploaded successfully")
+}
+
+func (h *ProfileHandler) DeleteAvatar(c *fiber.Ctx) error {
+	// Get AccountID from JWT claims
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	if err := h.DeleteAvatarUC.Execute(c.UserContext(), accountID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Avatar deleted successfully")
+}
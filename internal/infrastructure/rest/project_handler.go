@@ -16,6 +16,8 @@ type ProjectHandler struct {
 	GetProjectByIDUC       *usecase.GetProjectByIDUseCase
 	UpdateProjectUC        *usecase.UpdateProjectUseCase
 	DeleteProjectUC        *usecase.DeleteProjectUseCase
+	GetConfigSchemaUC      *usecase.GetProjectConfigSchemaUseCase
+	GetProjectStatsUC      *usecase.GetProjectStatsUseCase
 	logger                 logger.Logger
 }
 
@@ -25,6 +27,8 @@ func NewProjectHandler(
 	get *usecase.GetProjectByIDUseCase,
 	update *usecase.UpdateProjectUseCase,
 	delete *usecase.DeleteProjectUseCase,
+	configSchema *usecase.GetProjectConfigSchemaUseCase,
+	stats *usecase.GetProjectStatsUseCase,
 	l logger.Logger,
 ) *ProjectHandler {
 	return &ProjectHandler{
@@ -33,6 +37,8 @@ func NewProjectHandler(
 		GetProjectByIDUC:       get,
 		UpdateProjectUC:        update,
 		DeleteProjectUC:        delete,
+		GetConfigSchemaUC:      configSchema,
+		GetProjectStatsUC:      stats,
 		logger:                 l,
 	}
 }
@@ -40,7 +46,7 @@ func NewProjectHandler(
 func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidate[project.CreateProjectRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
@@ -49,20 +55,20 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 	// Get AccountID from JWT claims
 	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
 	if !ok {
-		h.logger.Error("Invalid JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	accountID, ok := jwtClaims["AccountId"].(string)
 	if !ok || accountID == "" {
-		h.logger.Error("Missing AccountId in JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	// Set AccountID from JWT
 	req.AccountID = accountID
 
-	data, err := h.CreateProjectUC.Execute(c.Context(), req)
+	data, err := h.CreateProjectUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -73,7 +79,7 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 func (h *ProjectHandler) ListProject(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidateQuery[project.ListProjectRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid query parameters", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid query parameters", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
@@ -82,19 +88,19 @@ func (h *ProjectHandler) ListProject(c *fiber.Ctx) error {
 	// Get AccountID from JWT claims
 	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
 	if !ok {
-		h.logger.Error("Invalid JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	accountID, ok := jwtClaims["AccountId"].(string)
 	if !ok || accountID == "" {
-		h.logger.Error("Missing AccountId in JWT claims", nil)
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
 		return responses.Error(c, apperror.ErrUnauthorized)
 	}
 
 	req.AccountID = accountID
 
-	data, err := h.ListProjectByAccountUC.Execute(c.Context(), req)
+	data, err := h.ListProjectByAccountUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -108,7 +114,7 @@ func (h *ProjectHandler) GetProject(c *fiber.Ctx) error {
 		return responses.Error(c, apperror.NewBadRequestError("missing projectId", "MISSING_PROJECT_ID", nil))
 	}
 
-	data, err := h.GetProjectByIDUC.Execute(c.Context(), projectID)
+	data, err := h.GetProjectByIDUC.Execute(c.UserContext(), projectID)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -124,7 +130,7 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 
 	req, err := requests.ParseAndValidate[project.UpdateProjectRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
@@ -132,7 +138,7 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 
 	req.ProjectID = projectID
 
-	data, err := h.UpdateProjectUC.Execute(c.Context(), req)
+	data, err := h.UpdateProjectUC.Execute(c.UserContext(), req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -146,10 +152,48 @@ func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
 		return responses.Error(c, apperror.NewBadRequestError("missing projectId", "MISSING_PROJECT_ID", nil))
 	}
 
-	data, err := h.DeleteProjectUC.Execute(c.Context(), projectID)
+	data, err := h.DeleteProjectUC.Execute(c.UserContext(), projectID)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
 	return responses.Success(c, data, "Project deleted successfully")
 }
+
+func (h *ProjectHandler) GetProjectConfigSchema(c *fiber.Ctx) error {
+	projectID := c.Params("projectId")
+	if projectID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("missing projectId", "MISSING_PROJECT_ID", nil))
+	}
+
+	data, err := h.GetConfigSchemaUC.Execute(c.UserContext(), projectID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Project config schema retrieved successfully")
+}
+
+func (h *ProjectHandler) GetProjectStats(c *fiber.Ctx) error {
+	projectID := c.Params("projectId")
+	if projectID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("missing projectId", "MISSING_PROJECT_ID", nil))
+	}
+
+	req, err := requests.ParseAndValidateQuery[project.GetProjectStatsRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid query parameters", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	req.ProjectID = projectID
+
+	data, err := h.GetProjectStatsUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Project stats retrieved successfully")
+}
@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/application/realtime/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/realtime"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RealtimeHandler streams the events of a project as server-sent events
+type RealtimeHandler struct {
+	hub                  *usecase.Hub
+	CreateStreamTicketUC *usecase.CreateStreamTicketUseCase
+	logger               logger.Logger
+}
+
+func NewRealtimeHandler(hub *usecase.Hub, createStreamTicketUC *usecase.CreateStreamTicketUseCase, l logger.Logger) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub:                  hub,
+		CreateStreamTicketUC: createStreamTicketUC,
+		logger:               l,
+	}
+}
+
+// CreateStreamTicket issues a ticket that opens the event stream of the
+// project from clients that cannot set the Authorization header
+func (h *RealtimeHandler) CreateStreamTicket(c *fiber.Ctx) error {
+	claims, err := h.getClaimsFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	projectID := c.Params("projectId")
+	if projectID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("project ID is required", "INVALID_PROJECT_ID", nil))
+	}
+
+	data, err := h.CreateStreamTicketUC.Execute(c.UserContext(), claims, projectID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Stream ticket created successfully")
+}
+
+// StreamProjectEvents keeps the response open and writes the messages of the
+// stream until the client goes away, the stream ends or the access token it
+// was opened with expires. Clients resume by sending the ID of the last
+// message in Last-Event-ID, or in ?cursor= when they cannot set headers.
+func (h *RealtimeHandler) StreamProjectEvents(c *fiber.Ctx) error {
+	claims, err := h.getClaimsFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	projectID := c.Params("projectId")
+	if projectID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("project ID is required", "INVALID_PROJECT_ID", nil))
+	}
+
+	cursor := c.Get("Last-Event-ID")
+	if cursor == "" {
+		cursor = c.Query("cursor")
+	}
+
+	stream, err := h.hub.Subscribe(c.UserContext(), claims, projectID, cursor)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// Keeps proxies such as nginx from buffering the stream
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after the handler returns, so it must not use the
+	// request context; a failed flush is how it learns the client went away
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.hub.Unsubscribe(stream)
+
+		ping := time.NewTicker(realtime.HeartbeatInterval)
+		defer ping.Stop()
+		expiry := time.NewTimer(time.Until(stream.ExpiresAt()))
+		defer expiry.Stop()
+
+		for {
+			select {
+			case <-stream.Done():
+				if final := stream.Final(); final != nil {
+					writeMessage(w, *final)
+					_ = w.Flush()
+				}
+				return
+			case <-expiry.C:
+				writeMessage(w, realtime.ClosedMessage(realtime.ClosedTokenExpired))
+				_ = w.Flush()
+				return
+			case msg := <-stream.Messages():
+				writeMessage(w, msg)
+			case <-ping.C:
+				_, _ = w.WriteString(": ping\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// getClaimsFromContext returns the claims of the access token, or of the
+// stream ticket, that authenticated the request
+func (h *RealtimeHandler) getClaimsFromContext(c *fiber.Ctx) (*accounts.AccessClaims, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return nil, apperror.ErrUnauthorized
+	}
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return nil, apperror.ErrUnauthorized
+	}
+	expiresAt, ok := jwtClaims["ExpiresAt"].(time.Time)
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Missing ExpiresAt in JWT claims", nil)
+		return nil, apperror.ErrUnauthorized
+	}
+
+	claims := &accounts.AccessClaims{AccountID: accountID}
+	claims.Email, _ = jwtClaims["Email"].(string)
+	claims.Username, _ = jwtClaims["Username"].(string)
+	claims.SessionID, _ = jwtClaims["SessionId"].(string)
+	claims.Role, _ = jwtClaims["Role"].(string)
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	return claims, nil
+}
+
+// writeMessage writes msg in the event stream format; the data is one line of JSON
+func writeMessage(w *bufio.Writer, msg realtime.Message) {
+	if msg.ID != "" {
+		_, _ = fmt.Fprintf(w, "id: %s\n", msg.ID)
+	}
+	_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Event, msg.Data)
+}
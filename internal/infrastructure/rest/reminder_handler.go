@@ -0,0 +1,206 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/reminder"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/reminder/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReminderHandler manages the task reminders of the authenticated account
+type ReminderHandler struct {
+	CreateReminderUC *usecase.CreateReminderUseCase
+	ListRemindersUC  *usecase.ListRemindersUseCase
+	DeleteReminderUC *usecase.DeleteReminderUseCase
+	logger           logger.Logger
+}
+
+func NewReminderHandler(
+	create *usecase.CreateReminderUseCase,
+	list *usecase.ListRemindersUseCase,
+	delete *usecase.DeleteReminderUseCase,
+	l logger.Logger,
+) *ReminderHandler {
+	return &ReminderHandler{
+		CreateReminderUC: create,
+		ListRemindersUC:  list,
+		DeleteReminderUC: delete,
+		logger:           l,
+	}
+}
+
+func (h *ReminderHandler) CreateReminder(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	taskID := c.Params("taskId")
+	if taskID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("task ID is required", "INVALID_TASK_ID", nil))
+	}
+
+	req, err := requests.ParseAndValidate[reminder.CreateReminderRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.CreateReminderUC.Execute(c.UserContext(), accountID, taskID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Reminder created successfully")
+}
+
+func (h *ReminderHandler) ListReminders(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	taskID := c.Params("taskId")
+	if taskID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("task ID is required", "INVALID_TASK_ID", nil))
+	}
+
+	data, err := h.ListRemindersUC.Execute(c.UserContext(), accountID, taskID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Reminders retrieved successfully")
+}
+
+func (h *ReminderHandler) DeleteReminder(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	taskID := c.Params("taskId")
+	reminderID := c.Params("reminderId")
+	if taskID == "" || reminderID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("task ID and reminder ID are required", "INVALID_REMINDER_ID", nil))
+	}
+
+	if err := h.DeleteReminderUC.Execute(c.UserContext(), accountID, taskID, reminderID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Reminder deleted successfully")
+}
+
+func (h *ReminderHandler) getAccountIDFromContext(c *fiber.Ctx) (string, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	return accountID, nil
+}
+
+// NotificationHandler serves the in-app notifications of the authenticated account
+type NotificationHandler struct {
+	ListNotificationsUC        *usecase.ListNotificationsUseCase
+	MarkNotificationReadUC     *usecase.MarkNotificationReadUseCase
+	MarkAllNotificationsReadUC *usecase.MarkAllNotificationsReadUseCase
+	logger                     logger.Logger
+}
+
+func NewNotificationHandler(
+	list *usecase.ListNotificationsUseCase,
+	markRead *usecase.MarkNotificationReadUseCase,
+	markAllRead *usecase.MarkAllNotificationsReadUseCase,
+	l logger.Logger,
+) *NotificationHandler {
+	return &NotificationHandler{
+		ListNotificationsUC:        list,
+		MarkNotificationReadUC:     markRead,
+		MarkAllNotificationsReadUC: markAllRead,
+		logger:                     l,
+	}
+}
+
+// ListNotifications lists notifications newest first (?unread=true, ?limit=, ?offset=)
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	req, err := requests.ParseAndValidateQuery[reminder.ListNotificationsRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid query parameters", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ListNotificationsUC.Execute(c.UserContext(), accountID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Notifications retrieved successfully")
+}
+
+func (h *NotificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	notificationID := c.Params("notificationId")
+	if notificationID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("notification ID is required", "INVALID_NOTIFICATION_ID", nil))
+	}
+
+	if err := h.MarkNotificationReadUC.Execute(c.UserContext(), accountID, notificationID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Notification marked as read")
+}
+
+func (h *NotificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.MarkAllNotificationsReadUC.Execute(c.UserContext(), accountID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Notifications marked as read")
+}
+
+func (h *NotificationHandler) getAccountIDFromContext(c *fiber.Ctx) (string, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	return accountID, nil
+}
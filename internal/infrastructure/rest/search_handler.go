@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/search"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/search/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SearchHandler searches the tasks and projects of the authenticated account
+type SearchHandler struct {
+	SearchUC *usecase.SearchUseCase
+	logger   logger.Logger
+}
+
+func NewSearchHandler(searchUC *usecase.SearchUseCase, l logger.Logger) *SearchHandler {
+	return &SearchHandler{
+		SearchUC: searchUC,
+		logger:   l,
+	}
+}
+
+// Search lists the best matches first (?q=, ?type=task|project, ?limit=, ?offset=)
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	req, err := requests.ParseAndValidateQuery[search.SearchRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid query parameters", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.SearchUC.Execute(c.UserContext(), accountID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Search results retrieved successfully")
+}
@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SessionHandler handles logout and session management of the authenticated account
+type SessionHandler struct {
+	ListSessionsUC      *usecase.ListSessionsUseCase
+	RevokeSessionUC     *usecase.RevokeSessionUseCase
+	RevokeAllSessionsUC *usecase.RevokeAllSessionsUseCase
+	logger              logger.Logger
+}
+
+func NewSessionHandler(
+	list *usecase.ListSessionsUseCase,
+	revoke *usecase.RevokeSessionUseCase,
+	revokeAll *usecase.RevokeAllSessionsUseCase,
+	l logger.Logger,
+) *SessionHandler {
+	return &SessionHandler{
+		ListSessionsUC:      list,
+		RevokeSessionUC:     revoke,
+		RevokeAllSessionsUC: revokeAll,
+		logger:              l,
+	}
+}
+
+// Logout revokes the session the current access token belongs to
+func (h *SessionHandler) Logout(c *fiber.Ctx) error {
+	accountID, sessionID, err := h.getSessionFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.RevokeSessionUC.Execute(c.UserContext(), accountID, sessionID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Logout successfully")
+}
+
+func (h *SessionHandler) ListSessions(c *fiber.Ctx) error {
+	accountID, sessionID, err := h.getSessionFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ListSessionsUC.Execute(c.UserContext(), accountID, sessionID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "List sessions successfully")
+}
+
+func (h *SessionHandler) RevokeSession(c *fiber.Ctx) error {
+	accountID, _, err := h.getSessionFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	sessionID := c.Params("sessionId")
+	if sessionID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("missing sessionId", "MISSING_SESSION_ID", nil))
+	}
+
+	data, err := h.RevokeSessionUC.Execute(c.UserContext(), accountID, sessionID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Session revoked successfully")
+}
+
+func (h *SessionHandler) RevokeAllSessions(c *fiber.Ctx) error {
+	accountID, _, err := h.getSessionFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.RevokeAllSessionsUC.Execute(c.UserContext(), accountID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "All sessions revoked successfully")
+}
+
+// getSessionFromContext extracts account and session IDs from JWT claims in context
+func (h *SessionHandler) getSessionFromContext(c *fiber.Ctx) (string, string, error) {
+	claims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		return "", "", apperror.NewUnauthorizedError("authentication required", "UNAUTHORIZED", nil)
+	}
+
+	accountID, ok := claims["AccountId"].(string)
+	if !ok || accountID == "" {
+		return "", "", apperror.NewUnauthorizedError("invalid token claims", "INVALID_TOKEN_CLAIMS", nil)
+	}
+
+	sessionID, ok := claims["SessionId"].(string)
+	if !ok || sessionID == "" {
+		return "", "", apperror.NewUnauthorizedError("invalid token claims", "INVALID_TOKEN_CLAIMS", nil)
+	}
+
+	return accountID, sessionID, nil
+}
@@ -40,7 +40,7 @@ func NewTaskHandler(
 func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidate[task.CreateTaskRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
@@ -52,7 +52,12 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 		return responses.Error(c, apperror.NewBadRequestError("project ID is required", "INVALID_PROJECT_ID", nil))
 	}
 
-	data, err := h.CreateTaskUC.Execute(c.Context(), projectID, req)
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.CreateTaskUC.Execute(c.UserContext(), accountID, projectID, req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -66,7 +71,12 @@ func (h *TaskHandler) GetTaskByID(c *fiber.Ctx) error {
 		return responses.Error(c, apperror.NewBadRequestError("task ID is required", "INVALID_TASK_ID", nil))
 	}
 
-	data, err := h.GetTaskByIDUC.Execute(c.Context(), taskID)
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.GetTaskByIDUC.Execute(c.UserContext(), accountID, taskID)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -80,7 +90,12 @@ func (h *TaskHandler) ListTasksByProject(c *fiber.Ctx) error {
 		return responses.Error(c, apperror.NewBadRequestError("project ID is required", "INVALID_PROJECT_ID", nil))
 	}
 
-	data, err := h.ListTasksByProjectUC.Execute(c.Context(), projectID)
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ListTasksByProjectUC.Execute(c.UserContext(), accountID, projectID)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -91,7 +106,7 @@ func (h *TaskHandler) ListTasksByProject(c *fiber.Ctx) error {
 func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 	req, err := requests.ParseAndValidate[task.UpdateTaskRequest](c)
 	if err != nil {
-		h.logger.Warn("Invalid request data", map[string]interface{}{
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
 			"error": err.Error(),
 		})
 		return responses.Error(c, err)
@@ -102,7 +117,12 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 		return responses.Error(c, apperror.NewBadRequestError("task ID is required", "INVALID_TASK_ID", nil))
 	}
 
-	data, err := h.UpdateTaskUC.Execute(c.Context(), taskID, req)
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.UpdateTaskUC.Execute(c.UserContext(), accountID, taskID, req)
 	if err != nil {
 		return responses.Error(c, err)
 	}
@@ -116,10 +136,26 @@ func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 		return responses.Error(c, apperror.NewBadRequestError("task ID is required", "INVALID_TASK_ID", nil))
 	}
 
-	deletedID, err := h.DeleteTaskUC.Execute(c.Context(), taskID)
+	deletedID, err := h.DeleteTaskUC.Execute(c.UserContext(), taskID)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
 	return responses.Success(c, fiber.Map{"task_id": deletedID}, "Task deleted successfully")
 }
+
+func (h *TaskHandler) getAccountIDFromContext(c *fiber.Ctx) (string, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	return accountID, nil
+}
@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/application/webhook"
+	"github.com/FrostBitzX/smart-task-ai/internal/application/webhook/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/requests"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookHandler manages the outgoing webhooks of the projects of the authenticated account
+type WebhookHandler struct {
+	CreateWebhookUC  *usecase.CreateWebhookUseCase
+	ListWebhooksUC   *usecase.ListWebhooksUseCase
+	GetWebhookUC     *usecase.GetWebhookUseCase
+	UpdateWebhookUC  *usecase.UpdateWebhookUseCase
+	DeleteWebhookUC  *usecase.DeleteWebhookUseCase
+	ListDeliveriesUC *usecase.ListDeliveriesUseCase
+	RedeliverUC      *usecase.RedeliverUseCase
+	logger           logger.Logger
+}
+
+func NewWebhookHandler(
+	create *usecase.CreateWebhookUseCase,
+	list *usecase.ListWebhooksUseCase,
+	get *usecase.GetWebhookUseCase,
+	update *usecase.UpdateWebhookUseCase,
+	delete *usecase.DeleteWebhookUseCase,
+	listDeliveries *usecase.ListDeliveriesUseCase,
+	redeliver *usecase.RedeliverUseCase,
+	l logger.Logger,
+) *WebhookHandler {
+	return &WebhookHandler{
+		CreateWebhookUC:  create,
+		ListWebhooksUC:   list,
+		GetWebhookUC:     get,
+		UpdateWebhookUC:  update,
+		DeleteWebhookUC:  delete,
+		ListDeliveriesUC: listDeliveries,
+		RedeliverUC:      redeliver,
+		logger:           l,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	projectID := c.Params("projectId")
+	if projectID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("project ID is required", "INVALID_PROJECT_ID", nil))
+	}
+
+	req, err := requests.ParseAndValidate[webhook.CreateWebhookRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.CreateWebhookUC.Execute(c.UserContext(), accountID, projectID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Webhook created successfully")
+}
+
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	projectID := c.Params("projectId")
+	if projectID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("project ID is required", "INVALID_PROJECT_ID", nil))
+	}
+
+	data, err := h.ListWebhooksUC.Execute(c.UserContext(), accountID, projectID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Webhooks retrieved successfully")
+}
+
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	accountID, projectID, webhookID, err := h.getWebhookParams(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	data, err := h.GetWebhookUC.Execute(c.UserContext(), accountID, projectID, webhookID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Webhook retrieved successfully")
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	accountID, projectID, webhookID, err := h.getWebhookParams(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	req, err := requests.ParseAndValidate[webhook.UpdateWebhookRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid request data", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.UpdateWebhookUC.Execute(c.UserContext(), accountID, projectID, webhookID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Webhook updated successfully")
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	accountID, projectID, webhookID, err := h.getWebhookParams(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.DeleteWebhookUC.Execute(c.UserContext(), accountID, projectID, webhookID); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, nil, "Webhook deleted successfully")
+}
+
+// ListDeliveries lists the delivery log of a webhook newest first (?limit=, ?offset=)
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	accountID, projectID, webhookID, err := h.getWebhookParams(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	req, err := requests.ParseAndValidateQuery[webhook.ListDeliveriesRequest](c)
+	if err != nil {
+		h.logger.WarnContext(c.UserContext(), "Invalid query parameters", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return responses.Error(c, err)
+	}
+
+	data, err := h.ListDeliveriesUC.Execute(c.UserContext(), accountID, projectID, webhookID, req)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Webhook deliveries retrieved successfully")
+}
+
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	accountID, projectID, webhookID, err := h.getWebhookParams(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	deliveryID := c.Params("deliveryId")
+	if deliveryID == "" {
+		return responses.Error(c, apperror.NewBadRequestError("delivery ID is required", "INVALID_DELIVERY_ID", nil))
+	}
+
+	data, err := h.RedeliverUC.Execute(c.UserContext(), accountID, projectID, webhookID, deliveryID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Success(c, data, "Webhook delivery queued successfully")
+}
+
+func (h *WebhookHandler) getWebhookParams(c *fiber.Ctx) (string, string, string, error) {
+	accountID, err := h.getAccountIDFromContext(c)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	projectID := c.Params("projectId")
+	webhookID := c.Params("webhookId")
+	if projectID == "" || webhookID == "" {
+		return "", "", "", apperror.NewBadRequestError("project ID and webhook ID are required", "INVALID_WEBHOOK_ID", nil)
+	}
+
+	return accountID, projectID, webhookID, nil
+}
+
+func (h *WebhookHandler) getAccountIDFromContext(c *fiber.Ctx) (string, error) {
+	jwtClaims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		h.logger.ErrorContext(c.UserContext(), "Invalid JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	accountID, ok := jwtClaims["AccountId"].(string)
+	if !ok || accountID == "" {
+		h.logger.ErrorContext(c.UserContext(), "Missing AccountId in JWT claims", nil)
+		return "", apperror.ErrUnauthorized
+	}
+
+	return accountID, nil
+}
@@ -0,0 +1,101 @@
+package safehttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidURL(t *testing.T) {
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://hooks.example.com/tasks", true},
+		{"http://93.184.216.34:8080/hook", true},
+		{"http://[2606:4700::1111]/hook", true},
+		{"/hook", false},
+		{"ftp://example.com/hook", false},
+		{"https://", false},
+		{"http://localhost:8080/hook", false},
+		{"http://LOCALHOST./hook", false},
+		{"http://db.localhost/hook", false},
+		{"http://127.0.0.1/hook", false},
+		{"http://10.0.0.5/hook", false},
+		{"http://192.168.1.1/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://0.0.0.0:5432", false},
+		{"http://[::1]/hook", false},
+		{"http://[::ffff:127.0.0.1]/hook", false},
+		{"http://[fd00:ec2::254]/hook", false},
+		{"https://example.com/" + string(make([]byte, maxURLLength)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.valid, ValidURL(tt.url))
+		})
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700::1111", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.0.1", false},
+		{"100.64.0.1", false},
+		{"169.254.169.254", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::1", false},
+		{"::", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, PublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	t.Run("refuses a loopback server", func(t *testing.T) {
+		_, err := NewClient(5 * time.Second).Get(server.URL)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrBlockedAddress), err.Error())
+		assert.False(t, called)
+	})
+
+	t.Run("refuses a host name resolving to loopback", func(t *testing.T) {
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		_, err = NewClient(5 * time.Second).Get("http://localhost:" + u.Port())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrBlockedAddress), err.Error())
+		assert.False(t, called)
+	})
+}
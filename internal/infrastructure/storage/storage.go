@@ -2,41 +2,156 @@ package storage
 
 import (
 	"context"
-	"mime/multipart"
-	"os"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
 )
 
-func UploadImage(ctx context.Context, file multipart.File, filename string) error {
-	cfg, err := config.LoadDefaultConfig(
-		ctx,
-		config.WithRegion(os.Getenv("S3_REGION")),
-		config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(
-				os.Getenv("S3_ACCESS_KEY"),
-				os.Getenv("S3_SECRET_KEY"),
-				"",
-			),
-		),
-	)
-	if err != nil {
-		return err
-	}
-
-	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.BaseEndpoint = aws.String(os.Getenv("S3_ENDPOINT"))
-		o.UsePathStyle = true
-	})
-
-	_, err = client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket: aws.String(os.Getenv("S3_BUCKET")),
-		Key:    aws.String("images/" + filename),
-		Body:   file,
-	})
-
-	return err
+const (
+	DriverS3    = "s3"
+	DriverLocal = "local"
+
+	URLModePresign = "presign"
+	URLModeProxy   = "proxy"
+
+	DefaultLocalDir = "tmp/storage"
+	// ProxyPathPrefix is where FileHandler serves objects for proxied URLs
+	ProxyPathPrefix = "/files/"
+)
+
+// ErrObjectNotFound is returned by Open when the key does not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+// Storage stores binary objects such as avatars under slash-separated keys
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns a time-limited URL the object can be fetched from without an access token
+	URL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// Pinger is implemented by backends that can check they are reachable
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping checks the backend when it supports it and succeeds otherwise
+func Ping(ctx context.Context, s Storage) error {
+	if p, ok := s.(Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// NewStorage builds the backend selected by STORAGE_DRIVER.
+// STORAGE_DRIVER=s3 | local (defaults to s3 when S3_BUCKET is set)
+// STORAGE_URL_MODE=presign | proxy (s3 only; local objects are always proxied)
+func NewStorage(cfg *config.Config) (Storage, error) {
+	signer := NewConfigURLSigner(cfg)
+
+	switch cfg.StorageDriver {
+	case DriverS3:
+		return NewS3Storage(context.Background(), S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			Bucket:    cfg.S3Bucket,
+			Proxy:     cfg.StorageURLMode == URLModeProxy,
+		}, signer)
+	case DriverLocal:
+		dir := cfg.StorageLocalDir
+		if dir == "" {
+			dir = DefaultLocalDir
+		}
+		return NewLocalStorage(dir, signer)
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", cfg.StorageDriver)
+	}
+}
+
+// URLSigner creates and verifies the expiring URLs served by the file proxy
+type URLSigner struct {
+	secret  []byte
+	baseURL string
+}
+
+// NewConfigURLSigner signs with JWT_SECRET and prefixes URLs with STORAGE_PUBLIC_URL
+func NewConfigURLSigner(cfg *config.Config) *URLSigner {
+	return NewURLSigner(cfg.JWTSecret, cfg.StoragePublicURL)
+}
+
+func NewURLSigner(secret, baseURL string) *URLSigner {
+	return &URLSigner{
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Sign returns the proxied URL of key, valid until expiresAt
+func (s *URLSigner) Sign(key string, expiresAt time.Time) (string, error) {
+	if len(s.secret) == 0 {
+		return "", errors.New("url signing secret is empty")
+	}
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", s.signature(key, expires))
+
+	return s.baseURL + ProxyPathPrefix + escapeKey(key) + "?" + q.Encode(), nil
+}
+
+// Verify checks the expires and signature query values of a proxied URL
+func (s *URLSigner) Verify(key, expires, signature string, now time.Time) bool {
+	if len(s.secret) == 0 {
+		return false
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > unix {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(s.signature(key, expires)))
+}
+
+func (s *URLSigner) signature(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateKey rejects keys that are empty or could escape the storage root
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	return nil
+}
+
+func escapeKey(key string) string {
+	parts := strings.Split(key, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
 }
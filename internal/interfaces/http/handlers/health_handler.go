@@ -2,23 +2,44 @@ package handlers
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/database"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/buildinfo"
 	"github.com/gofiber/fiber/v2"
 )
 
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+
+	// checkTimeout bounds every dependency check so a hanging one cannot stall the probe
+	checkTimeout = 3 * time.Second
+)
+
+// DependencyCheck probes one dependency for /readyz
+type DependencyCheck struct {
+	Name string
+	// Critical checks make the service not ready; the others only degrade it
+	Critical bool
+	// Check returns optional details for the response, or the reason the dependency is down
+	Check func(ctx context.Context) (map[string]interface{}, error)
+}
+
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
-	dbConnector *database.DBConnector
-	startTime   time.Time
+	checks       []DependencyCheck
+	startTime    time.Time
+	shuttingDown atomic.Bool
 }
 
 // NewHealthHandler creates a new HealthHandler
-func NewHealthHandler(dbConnector *database.DBConnector) *HealthHandler {
+func NewHealthHandler(checks ...DependencyCheck) *HealthHandler {
 	return &HealthHandler{
-		dbConnector: dbConnector,
-		startTime:   time.Now(),
+		checks:    checks,
+		startTime: time.Now(),
 	}
 }
 
@@ -28,17 +49,74 @@ type HealthResponse struct {
 	Timestamp string                 `json:"timestamp"`
 	Uptime    string                 `json:"uptime"`
 	Version   string                 `json:"version"`
+	Commit    string                 `json:"commit"`
 	Checks    map[string]HealthCheck `json:"checks"`
 }
 
 // HealthCheck represents individual health check result
 type HealthCheck struct {
-	Status  string                 `json:"status"`
-	Message string                 `json:"message,omitempty"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	Status   string                 `json:"status"`
+	Critical bool                   `json:"critical"`
+	Message  string                 `json:"message,omitempty"`
+	Latency  string                 `json:"latency,omitempty"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+// SetShuttingDown makes /readyz fail so load balancers stop routing before the server closes
+func (h *HealthHandler) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Livez reports that the process is running; it never checks dependencies
+// @Summary Liveness probe
+// @Description Returns 200 while the process is able to serve requests
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(h.newResponse(StatusHealthy))
+}
+
+// Readyz checks every dependency and fails while the server is shutting down
+// @Summary Readiness probe
+// @Description Returns 503 when a critical dependency is down or the server is shutting down
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Failure 503 {object} HealthResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *fiber.Ctx) error {
+	if h.shuttingDown.Load() {
+		response := h.newResponse(StatusUnhealthy)
+		response.Checks["shutdown"] = HealthCheck{
+			Status:   StatusUnhealthy,
+			Critical: true,
+			Message:  "Server is shutting down",
+		}
+		return c.Status(fiber.StatusServiceUnavailable).JSON(response)
+	}
+
+	response := h.newResponse(StatusHealthy)
+	for name, check := range h.runChecks(c.UserContext()) {
+		response.Checks[name] = check
+		if check.Status == StatusHealthy {
+			continue
+		}
+		if check.Critical {
+			response.Status = StatusUnhealthy
+		} else if response.Status == StatusHealthy {
+			response.Status = StatusDegraded
+		}
+	}
+
+	if response.Status == StatusUnhealthy {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(response)
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-// Health performs a basic health check
+// Health is kept for existing monitors and behaves like Readyz
 // @Summary Health check
 // @Description Returns the health status of the API
 // @Tags health
@@ -47,32 +125,74 @@ type HealthCheck struct {
 // @Success 200 {object} HealthResponse
 // @Router /health [get]
 func (h *HealthHandler) Health(c *fiber.Ctx) error {
-	response := HealthResponse{
-		Status:    "healthy",
+	return h.Readyz(c)
+}
+
+func (h *HealthHandler) newResponse(status string) HealthResponse {
+	info := buildinfo.Get()
+	return HealthResponse{
+		Status:    status,
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Uptime:    time.Since(h.startTime).String(),
-		Version:   "1.0.0",
+		Version:   info.Version,
+		Commit:    info.Commit,
 		Checks:    make(map[string]HealthCheck),
 	}
+}
 
-	// Check database connection
-	ctx, cancel := context.WithTimeout(c.Context(), 5*time.Second)
-	defer cancel()
+// runChecks probes the dependencies concurrently so the slowest one bounds the probe
+func (h *HealthHandler) runChecks(ctx context.Context) map[string]HealthCheck {
+	results := make(map[string]HealthCheck, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
 
-	if err := h.dbConnector.HealthCheck(ctx); err != nil {
-		response.Status = "unhealthy"
-		response.Checks["database"] = HealthCheck{
-			Status:  "unhealthy",
-			Message: err.Error(),
-		}
-		return c.Status(fiber.StatusServiceUnavailable).JSON(response)
-	}
+	for _, dep := range h.checks {
+		wg.Add(1)
+		go func(dep DependencyCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
 
-	response.Checks["database"] = HealthCheck{
-		Status:  "healthy",
-		Message: "Database connection is active",
-		Details: h.dbConnector.GetStats(),
+			start := time.Now()
+			details, err := dep.Check(checkCtx)
+			result := HealthCheck{
+				Status:   StatusHealthy,
+				Critical: dep.Critical,
+				Latency:  time.Since(start).String(),
+				Details:  details,
+			}
+			if err != nil {
+				result.Status = StatusUnhealthy
+				result.Message = err.Error()
+			}
+
+			mu.Lock()
+			results[dep.Name] = result
+			mu.Unlock()
+		}(dep)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response)
+	wg.Wait()
+	return results
+}
+
+// CachedCheck reuses a result for ttl, for checks that cost money or count against a quota
+func CachedCheck(ttl time.Duration, check func(ctx context.Context) (map[string]interface{}, error)) func(ctx context.Context) (map[string]interface{}, error) {
+	var (
+		mu        sync.Mutex
+		checkedAt time.Time
+		details   map[string]interface{}
+		lastErr   error
+	)
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if checkedAt.IsZero() || time.Since(checkedAt) >= ttl {
+			details, lastErr = check(ctx)
+			checkedAt = time.Now()
+		}
+		return details, lastErr
+	}
 }
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"crypto/subtle"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsHandler serves the Prometheus exposition format
+type MetricsHandler struct {
+	token   string
+	handler fiber.Handler
+}
+
+// NewMetricsHandler requires "Authorization: Bearer <token>" when token is not empty
+func NewMetricsHandler(token string) *MetricsHandler {
+	return &MetricsHandler{
+		token:   token,
+		handler: adaptor.HTTPHandler(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})),
+	}
+}
+
+// Metrics exposes the collected metrics
+// @Summary Prometheus metrics
+// @Description Returns the metrics in the Prometheus text format
+// @Tags health
+// @Produce plain
+// @Success 200 {string} string
+// @Router /metrics [get]
+func (h *MetricsHandler) Metrics(c *fiber.Ctx) error {
+	if h.token != "" {
+		expected := "Bearer " + h.token
+		if subtle.ConstantTimeCompare([]byte(c.Get(fiber.HeaderAuthorization)), []byte(expected)) != 1 {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+	}
+	return h.handler(c)
+}
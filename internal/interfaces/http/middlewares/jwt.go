@@ -1,17 +1,52 @@
 package middlewares
 
 import (
-	"os"
+	"context"
 	"strings"
 
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
 	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func JWTMiddleware() fiber.Handler {
+// JWTConfig holds configuration for the JWT middleware
+type JWTConfig struct {
+	// Secret is the HMAC key used to verify access tokens
+	Secret string
+
+	// SessionValidator rejects tokens whose session was revoked or whose account is inactive (optional)
+	SessionValidator func(ctx context.Context, claims *accounts.AccessClaims) error
+
+	// TicketQuery names the query parameter of a stream ticket for the requested
+	// path, accepted in place of the Authorization header (optional)
+	TicketQuery string
+}
+
+func JWTMiddleware(config ...JWTConfig) fiber.Handler {
+	var cfg JWTConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
 	return func(c *fiber.Ctx) error {
 		auth := c.Get("Authorization")
+		if auth == "" && cfg.TicketQuery != "" && c.Query(cfg.TicketQuery) != "" {
+			claims, err := accounts.ParseStreamTicket(c.Query(cfg.TicketQuery), cfg.Secret, c.Path())
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
+					Success: false,
+					Message: "invalid ticket",
+					Data:    nil,
+					Error: responses.ErrorDetail{
+						Code:    fiber.StatusBadRequest,
+						Message: "INVALID_TICKET",
+					},
+				})
+			}
+			return authenticate(c, cfg, claims)
+		}
 		if auth == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
 				Success: false,
@@ -40,10 +75,9 @@ func JWTMiddleware() fiber.Handler {
 
 		tokenStr := strings.TrimPrefix(auth, bearerPrefix)
 
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-		if err != nil || !token.Valid {
+		// Verifies signature, exp, nbf, iat and iss
+		claims, err := accounts.ParseAccessToken(tokenStr, cfg.Secret)
+		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
 				Success: false,
 				Message: "invalid token",
@@ -55,43 +89,46 @@ func JWTMiddleware() fiber.Handler {
 			})
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
-				Success: false,
-				Message: "invalid token claims",
-				Data:    nil,
-				Error: responses.ErrorDetail{
-					Code:    fiber.StatusBadRequest,
-					Message: "INVALID_TOKEN_CLAIMS",
-				},
-			})
-		}
+		return authenticate(c, cfg, claims)
+	}
+}
 
-		// Extract all claims
-		username, _ := claims["Username"].(string)
-		accountID, _ := claims["AccountId"].(string)
-		email, _ := claims["Email"].(string)
+// authenticate checks the session of verified claims and saves them for the handlers
+func authenticate(c *fiber.Ctx, cfg JWTConfig, claims *accounts.AccessClaims) error {
+	if claims.AccountID == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
+			Success: false,
+			Message: "missing account id in token",
+			Data:    nil,
+			Error: responses.ErrorDetail{
+				Code:    fiber.StatusBadRequest,
+				Message: "MISSING_ACCOUNT_ID",
+			},
+		})
+	}
 
-		if accountID == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(responses.ErrorResponse{
-				Success: false,
-				Message: "missing account id in token",
-				Data:    nil,
-				Error: responses.ErrorDetail{
-					Code:    fiber.StatusBadRequest,
-					Message: "MISSING_ACCOUNT_ID",
-				},
-			})
+	c.SetUserContext(logger.ContextWithFields(c.UserContext(), map[string]interface{}{
+		logger.AccountIDField: claims.AccountID,
+	}))
+
+	if cfg.SessionValidator != nil {
+		if err := cfg.SessionValidator(c.UserContext(), claims); err != nil {
+			if _, ok := apperror.IsAppError(err); !ok {
+				err = apperror.NewUnauthorizedError("invalid session", "INVALID_SESSION", err)
+			}
+			return responses.Error(c, err)
 		}
+	}
 
-		// Save all claims into context (Locals)
-		c.Locals("jwt_claims", map[string]interface{}{
-			"AccountId": accountID,
-			"Email":     email,
-			"Username":  username,
-		})
+	// Save all claims into context (Locals)
+	c.Locals("jwt_claims", map[string]interface{}{
+		"AccountId": claims.AccountID,
+		"Email":     claims.Email,
+		"Username":  claims.Username,
+		"SessionId": claims.SessionID,
+		"Role":      claims.Role,
+		"ExpiresAt": claims.ExpiresAt.Time,
+	})
 
-		return c.Next()
-	}
+	return c.Next()
 }
@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTMiddleware_StreamTicket(t *testing.T) {
+	const secret = "test-secret"
+	now := time.Now()
+	access := &accounts.AccessClaims{
+		AccountID: "0b0a5d1e-4a3c-4f5e-9d3b-2f1c6e7a8b9c",
+		SessionID: "5e6f7a8b-9c0d-4e1f-8a2b-3c4d5e6f7a8b",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    accounts.TokenIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accounts.AccessTokenTTL)),
+		},
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, access).SignedString([]byte(secret))
+	require.NoError(t, err)
+	ticket, _, err := accounts.SignStreamTicket(secret, access, "/streams/prj_1", now)
+	require.NoError(t, err)
+
+	newApp := func(ticketQuery string, revoked bool) *fiber.App {
+		app := fiber.New()
+		app.Get("/streams/:projectId", JWTMiddleware(JWTConfig{
+			Secret: secret,
+			SessionValidator: func(_ context.Context, claims *accounts.AccessClaims) error {
+				if revoked {
+					return apperror.NewUnauthorizedError("session has expired or was revoked", "SESSION_EXPIRED", nil)
+				}
+				return nil
+			},
+			TicketQuery: ticketQuery,
+		}), func(c *fiber.Ctx) error {
+			claims := c.Locals("jwt_claims").(map[string]interface{})
+			assert.Equal(t, access.AccountID, claims["AccountId"])
+			assert.Equal(t, access.ExpiresAt.Time, claims["ExpiresAt"])
+			return c.SendStatus(http.StatusOK)
+		})
+		return app
+	}
+
+	tests := []struct {
+		name        string
+		ticketQuery string
+		revoked     bool
+		path        string
+		ticket      string
+		token       string
+		status      int
+	}{
+		{name: "ticket for the path", ticketQuery: "ticket", path: "/streams/prj_1", ticket: ticket, status: http.StatusOK},
+		{name: "ticket for another path", ticketQuery: "ticket", path: "/streams/prj_2", ticket: ticket, status: http.StatusUnauthorized},
+		{name: "ticket of a revoked session", ticketQuery: "ticket", revoked: true, path: "/streams/prj_1", ticket: ticket, status: http.StatusUnauthorized},
+		{name: "ticket where tickets are not accepted", path: "/streams/prj_1", ticket: ticket, status: http.StatusUnauthorized},
+		{name: "access token as a ticket", ticketQuery: "ticket", path: "/streams/prj_1", ticket: accessToken, status: http.StatusUnauthorized},
+		{name: "header where tickets are accepted", ticketQuery: "ticket", path: "/streams/prj_1", token: accessToken, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.path
+			if tt.ticket != "" {
+				target += "?" + url.Values{"ticket": {tt.ticket}}.Encode()
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			resp, err := newApp(tt.ticketQuery, tt.revoked).Test(req, -1)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
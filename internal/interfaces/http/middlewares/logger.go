@@ -8,10 +8,18 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// quietPaths are polled by probes and scrapers and left out of the access log
+var quietPaths = map[string]bool{
+	"/healthz": true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
 func FiberLoggerMiddleware(log logger.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 
-		if c.Path() == "/healthz" || strings.HasPrefix(c.Path(), "/api/v2/healthz") {
+		if quietPaths[c.Path()] || strings.HasPrefix(c.Path(), "/api/v2/healthz") {
 			return c.Next()
 		}
 
@@ -19,7 +27,9 @@ func FiberLoggerMiddleware(log logger.Logger) fiber.Handler {
 		err := c.Next()
 		duration := time.Since(start)
 
-		log.Info("http_request", map[string]interface{}{
+		// Read the context after the handlers ran so fields added downstream, like the account ID, are included
+		ctx := c.UserContext()
+		log.InfoContext(ctx, "http_request", map[string]interface{}{
 			"method":  c.Method(),
 			"path":    c.Path(),
 			"status":  c.Response().StatusCode(),
@@ -28,7 +38,7 @@ func FiberLoggerMiddleware(log logger.Logger) fiber.Handler {
 		})
 
 		if err != nil {
-			log.Error("http_error", map[string]interface{}{
+			log.ErrorContext(ctx, "http_error", map[string]interface{}{
 				"error": err.Error(),
 				"path":  c.Path(),
 			})
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/metrics"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MetricsMiddleware records request counts and latency per route template and status
+func MetricsMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Path() == "/metrics" {
+			return c.Next()
+		}
+
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			status = fiberErr.Code
+		}
+
+		// The matched template, not the raw path, keeps IDs out of the label values;
+		// requests answered by a middleware (404s, CORS preflights) never reach a route
+		route := c.Route().Path
+		if c.Route().Method == "USE" {
+			route = "unmatched"
+		}
+
+		labels := []string{c.Method(), route, strconv.Itoa(status)}
+		metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+		metrics.HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
@@ -0,0 +1,152 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/ratelimit"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxBucketKeyLength = 255
+
+// RateLimitKeyFunc returns the bucket key of a request, or "" to skip the rule
+type RateLimitKeyFunc func(c *fiber.Ctx) string
+
+// RateLimitRule is one token bucket applied to a route group
+type RateLimitRule struct {
+	// Name scopes the buckets of the rule, e.g. "login-ip"
+	Name   string
+	Policy ratelimit.Policy
+	Key    RateLimitKeyFunc
+}
+
+// RateLimitConfig holds configuration for the rate limit middleware
+type RateLimitConfig struct {
+	Store  ratelimit.Store
+	Rules  []RateLimitRule
+	Logger logger.Logger
+}
+
+// RateLimitByIP keys buckets by client IP
+func RateLimitByIP(c *fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// RateLimitByAccount keys buckets by the authenticated account; it must run after JWTMiddleware
+func RateLimitByAccount(c *fiber.Ctx) string {
+	claims, ok := c.Locals("jwt_claims").(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	accountID, _ := claims["AccountId"].(string)
+	if accountID == "" {
+		return ""
+	}
+	return "account:" + accountID
+}
+
+// RateLimitByBodyField keys buckets by a string field of the JSON body, e.g. the
+// username of a login attempt, so one account cannot be brute-forced from many IPs
+func RateLimitByBodyField(field string) RateLimitKeyFunc {
+	return func(c *fiber.Ctx) string {
+		var body map[string]interface{}
+		if err := json.Unmarshal(c.Body(), &body); err != nil {
+			return ""
+		}
+		value, _ := body[field].(string)
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			return ""
+		}
+		return field + ":" + value
+	}
+}
+
+// RateLimitMiddleware takes one token from the bucket of every rule. The request is
+// rejected with 429 when any bucket is empty, and the RateLimit-* headers describe
+// the most restrictive rule. Store errors fail open so an outage does not block logins.
+func RateLimitMiddleware(cfg RateLimitConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		now := time.Now()
+
+		var (
+			tightest *ratelimit.Result
+			policy   ratelimit.Policy
+			denied   bool
+			retry    time.Duration
+		)
+
+		for _, rule := range cfg.Rules {
+			key := rule.Key(c)
+			if key == "" {
+				continue
+			}
+
+			res, err := cfg.Store.Take(c.UserContext(), bucketKey(rule.Name, key), rule.Policy, now)
+			if err != nil {
+				if cfg.Logger != nil {
+					cfg.Logger.WarnContext(c.UserContext(), "Rate limit store failed, allowing request", map[string]interface{}{
+						"rule":  rule.Name,
+						"error": err.Error(),
+					})
+				}
+				continue
+			}
+
+			if !res.Allowed {
+				denied = true
+				if res.RetryAfter > retry {
+					retry = res.RetryAfter
+				}
+			}
+
+			if tightest == nil || res.Remaining < tightest.Remaining || (!res.Allowed && tightest.Allowed) {
+				r := res
+				tightest = &r
+				policy = rule.Policy
+			}
+		}
+
+		if tightest != nil {
+			c.Set("RateLimit-Limit", strconv.Itoa(tightest.Limit))
+			c.Set("RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+			c.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(tightest.ResetAfter)))
+			c.Set("RateLimit-Policy", policy.String())
+		}
+
+		if denied {
+			seconds := ceilSeconds(retry)
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+			return responses.Error(c, apperror.NewTooManyRequestsError(
+				"too many requests, please retry later",
+				"RATE_LIMITED",
+				map[string]int{"retry_after": seconds},
+			))
+		}
+
+		return c.Next()
+	}
+}
+
+// bucketKey hashes keys that would not fit the storage column, e.g. very long usernames
+func bucketKey(rule, key string) string {
+	k := rule + "|" + key
+	if len(k) <= maxBucketKeyLength {
+		return k
+	}
+	sum := sha256.Sum256([]byte(key))
+	return rule + "|sha256:" + hex.EncodeToString(sum[:])
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
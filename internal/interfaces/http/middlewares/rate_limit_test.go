@@ -0,0 +1,174 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resultStore answers every rule with a fixed result, or fails it with err
+type resultStore map[string]struct {
+	res ratelimit.Result
+	err error
+}
+
+func (s resultStore) Take(_ context.Context, key string, _ ratelimit.Policy, _ time.Time) (ratelimit.Result, error) {
+	r, ok := s[key]
+	if !ok {
+		return ratelimit.Result{}, errors.New("unexpected bucket " + key)
+	}
+	return r.res, r.err
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	allowed := func(limit, remaining int, reset time.Duration) ratelimit.Result {
+		return ratelimit.Result{Allowed: true, Limit: limit, Remaining: remaining, ResetAfter: reset}
+	}
+	denied := func(limit int, reset, retry time.Duration) ratelimit.Result {
+		return ratelimit.Result{Limit: limit, ResetAfter: reset, RetryAfter: retry}
+	}
+	storeErr := errors.New("connection refused")
+
+	ip := RateLimitRule{Name: "ip", Policy: ratelimit.Policy{Limit: 10, Window: time.Minute}, Key: func(*fiber.Ctx) string { return "1" }}
+	account := RateLimitRule{Name: "account", Policy: ratelimit.Policy{Limit: 5, Window: 15 * time.Minute}, Key: func(*fiber.Ctx) string { return "1" }}
+	anonymous := RateLimitRule{Name: "anonymous", Policy: ratelimit.Policy{Limit: 1, Window: time.Second}, Key: func(*fiber.Ctx) string { return "" }}
+
+	tests := []struct {
+		name    string
+		rules   []RateLimitRule
+		store   resultStore
+		status  int
+		headers map[string]string
+	}{
+		{
+			name:  "rule with the fewest remaining requests sets the headers",
+			rules: []RateLimitRule{ip, account},
+			store: resultStore{
+				"ip|1":      {res: allowed(10, 8, 12*time.Second)},
+				"account|1": {res: allowed(5, 2, 540*time.Second)},
+			},
+			status: http.StatusOK,
+			headers: map[string]string{
+				"RateLimit-Limit":     "5",
+				"RateLimit-Remaining": "2",
+				"RateLimit-Reset":     "540",
+				"RateLimit-Policy":    "5;w=900",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:  "denying rule sets the headers over one with as few remaining",
+			rules: []RateLimitRule{ip, account},
+			store: resultStore{
+				"ip|1":      {res: allowed(10, 0, 60*time.Second)},
+				"account|1": {res: denied(5, 900*time.Second, 180*time.Second)},
+			},
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"RateLimit-Limit":     "5",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "900",
+				"RateLimit-Policy":    "5;w=900",
+				"Retry-After":         "180",
+			},
+		},
+		{
+			name:  "retry after is the longest wait of the denying rules",
+			rules: []RateLimitRule{ip, account},
+			store: resultStore{
+				"ip|1":      {res: denied(10, 60*time.Second, 1500*time.Millisecond)},
+				"account|1": {res: denied(5, 900*time.Second, 1200*time.Millisecond)},
+			},
+			status: http.StatusTooManyRequests,
+			headers: map[string]string{
+				"RateLimit-Limit": "10",
+				"Retry-After":     "2",
+			},
+		},
+		{
+			name:  "rule without a key is skipped",
+			rules: []RateLimitRule{anonymous, ip},
+			store: resultStore{
+				"ip|1": {res: allowed(10, 9, 6*time.Second)},
+			},
+			status: http.StatusOK,
+			headers: map[string]string{
+				"RateLimit-Limit":  "10",
+				"RateLimit-Policy": "10;w=60",
+			},
+		},
+		{
+			name:  "failing store lets the request through on the other rules",
+			rules: []RateLimitRule{ip, account},
+			store: resultStore{
+				"ip|1":      {res: allowed(10, 9, 6*time.Second)},
+				"account|1": {err: storeErr},
+			},
+			status: http.StatusOK,
+			headers: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "9",
+			},
+		},
+		{
+			name:  "failing store lets the request through",
+			rules: []RateLimitRule{ip, account},
+			store: resultStore{
+				"ip|1":      {err: storeErr},
+				"account|1": {err: storeErr},
+			},
+			status: http.StatusOK,
+			headers: map[string]string{
+				"RateLimit-Limit":     "",
+				"RateLimit-Remaining": "",
+				"Retry-After":         "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(RateLimitMiddleware(RateLimitConfig{
+				Store:  tt.store,
+				Rules:  tt.rules,
+				Logger: logger.NewZapLogger("test", "error"),
+			}))
+			app.Get("/", func(c *fiber.Ctx) error {
+				return c.SendStatus(http.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil), -1)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			for header, value := range tt.headers {
+				assert.Equal(t, value, resp.Header.Get(header), header)
+			}
+			if tt.status == http.StatusTooManyRequests {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Contains(t, string(body), `"details":"RATE_LIMITED"`)
+			}
+		})
+	}
+}
+
+func TestBucketKey(t *testing.T) {
+	assert.Equal(t, "login-account|username:alice", bucketKey("login-account", "username:alice"))
+
+	long := bucketKey("login-account", "username:"+string(make([]byte, 300)))
+	assert.LessOrEqual(t, len(long), maxBucketKeyLength)
+	assert.Contains(t, long, "login-account|sha256:")
+}
@@ -47,7 +47,7 @@ func RecoverMiddleware(log logger.Logger, config ...RecoverConfig) fiber.Handler
 					fields["stack"] = string(debug.Stack())
 				}
 
-				log.Error("Panic recovered", fields)
+				log.ErrorContext(c.UserContext(), "Panic recovered", fields)
 
 				// Return internal server error
 				_ = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
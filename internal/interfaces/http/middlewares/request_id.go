@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the correlation ID between clients, proxies and the API
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware reuses a well-formed incoming X-Request-ID or generates one,
+// echoes it in the response and stores it in the request context for logging
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(RequestIDHeader, id)
+		c.Locals("request_id", id)
+		c.SetUserContext(logger.ContextWithFields(c.UserContext(), map[string]interface{}{
+			logger.RequestIDField: id,
+		}))
+
+		return c.Next()
+	}
+}
+
+// validRequestID only accepts short printable ASCII so client input cannot forge log lines
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
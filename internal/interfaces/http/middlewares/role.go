@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/responses"
+	"github.com/FrostBitzX/smart-task-ai/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireRole only lets requests through whose access token carries role.
+// It must run after JWTMiddleware, whose session validator rejects tokens with an outdated role.
+func RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("jwt_claims").(map[string]interface{})
+		if !ok {
+			return responses.Error(c, apperror.NewUnauthorizedError("authentication required", "UNAUTHORIZED", nil))
+		}
+
+		if r, _ := claims["Role"].(string); r != role {
+			return responses.Error(c, apperror.NewForbiddenError("insufficient permissions", "FORBIDDEN", nil))
+		}
+
+		return c.Next()
+	}
+}
@@ -25,20 +25,23 @@ type SuccessResponse struct {
 }
 
 type ErrorDetail struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Details interface{} `json:"details,omitempty"`
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Details interface{}       `json:"details,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
 }
 
 func Error(c *fiber.Ctx, err error) error {
 	var status int
 	var code string
 	var message string
+	var fields map[string]string
 
 	if appErr, ok := apperror.IsAppError(err); ok {
 		status = appErr.Status
 		code = appErr.Code
 		message = appErr.Message
+		fields = appErr.Fields
 	} else {
 		status = apperror.StatusCode(err)
 		code = "INTERNAL_SERVER_ERROR"
@@ -53,6 +56,7 @@ func Error(c *fiber.Ctx, err error) error {
 			Code:    status,
 			Message: getStatusText(status),
 			Details: code,
+			Fields:  fields,
 		},
 	}
 
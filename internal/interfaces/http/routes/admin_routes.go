@@ -0,0 +1,38 @@
+package routes
+
+import (
+	accountUC "github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	accountDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	handler "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/rest"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// registerAdminRoutes mounts /api/admin on the authenticated api group
+func registerAdminRoutes(api fiber.Router, accountService *accountDomain.AccountService, log logger.Logger) {
+	admin := api.Group("/admin", middlewares.RequireRole(entity.AccountRoleAdmin))
+
+	listAccountUC := accountUC.NewListAccountUseCase(accountService, log)
+	getSupportViewUC := accountUC.NewGetAccountSupportViewUseCase(accountService, log)
+	suspendAccountUC := accountUC.NewSuspendAccountUseCase(accountService, log)
+	unsuspendAccountUC := accountUC.NewUnsuspendAccountUseCase(accountService, log)
+	setAccountRoleUC := accountUC.NewSetAccountRoleUseCase(accountService, log)
+	adminHandlerInstance := handler.NewAdminHandler(
+		listAccountUC,
+		getSupportViewUC,
+		suspendAccountUC,
+		unsuspendAccountUC,
+		setAccountRoleUC,
+		log,
+	)
+
+	// Admin account routes
+	admin.Get("/accounts", adminHandlerInstance.ListAccounts)
+	admin.Get("/accounts/:accountId", adminHandlerInstance.GetAccount)
+	admin.Post("/accounts/:accountId/suspend", adminHandlerInstance.SuspendAccount)
+	admin.Post("/accounts/:accountId/unsuspend", adminHandlerInstance.UnsuspendAccount)
+	admin.Patch("/accounts/:accountId/role", adminHandlerInstance.SetAccountRole)
+}
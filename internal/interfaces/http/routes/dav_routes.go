@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"net/http"
+
+	accountUC "github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	accountDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	calendarDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/service"
+	taskDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/dav"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"gorm.io/gorm"
+)
+
+// RegisterDAVRoutes mounts the CalDAV server, authenticated with app passwords.
+// The app must accept dav.RequestMethods.
+func RegisterDAVRoutes(app fiber.Router, cfg *config.Config, db *gorm.DB, log logger.Logger) {
+	appPasswordService := accountDomain.NewAppPasswordService(repo.NewAccountRepository(db), repo.NewAppPasswordRepository(db))
+	projectRepository := repo.NewProjectRepository(db)
+	taskRepository := repo.NewTaskRepository(db)
+	taskService := taskDomain.NewTaskService(taskRepository, projectRepository, repo.NewOutbox(db))
+	// Writes go through taskService, so the outbox handlers clean up and
+	// reschedule after them as after the task API
+	calendarService := calendarDomain.NewCalendarService(repo.NewCalendarFeedRepository(db), projectRepository, taskRepository, taskService)
+
+	davHandler := httpHandler(dav.NewHandler(calendarService, appPasswordService.Authenticate, accountUC.CalDAVPathPrefix, log))
+
+	app.Use(accountUC.CalDAVPathPrefix, davHandler)
+	// Service discovery (RFC 6764) redirects to the principal of the account
+	app.Use("/.well-known/caldav", davHandler)
+}
+
+// httpHandler adapts h like adaptor.HTTPHandler, but serves it the user
+// context of the request, which carries its request ID and trace, rather than
+// the bare fasthttp context
+func httpHandler(h http.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		return adaptor.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})(c)
+	}
+}
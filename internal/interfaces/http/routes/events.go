@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"time"
+
+	eventUC "github.com/FrostBitzX/smart-task-ai/internal/application/event/usecase"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	eventDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/events/service"
+	profileDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
+	taskDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
+
+	"gorm.io/gorm"
+)
+
+// outboxLease is how long a relay holds the outbox events it claimed
+const outboxLease = time.Minute
+
+// NewEventRelay builds the relay dispatching the events recorded by the task
+// and project services. Handlers are subscribed here; their names are stored
+// with the events, so they must not change.
+func NewEventRelay(cfg *config.Config, db *gorm.DB, log logger.Logger) *eventUC.RelayWorker {
+	relay := eventDomain.NewRelayService(repo.NewOutboxRepository(db))
+	relay.Subscribe("webhooks", events.HandlerFunc(newWebhookService(cfg, db).Publish))
+
+	// Deleted tasks leave attachments and reminders behind, and moved tasks
+	// reminders pointing at the old time, until these handlers succeed
+	attachmentService := taskDomain.NewAttachmentService(repo.NewAttachmentRepository(db), repo.NewTaskRepository(db), newStorage(cfg, log), cfg.AttachmentProjectQuota())
+	relay.Subscribe("attachments", events.HandlerFunc(attachmentService.HandleTaskEvent), events.TaskDeleted)
+	// Reminders only read time zones, so the profile service needs no storage
+	reminderService := newReminderService(cfg, db, log, profileDomain.NewProfileService(repo.NewProfileRepository(db), nil))
+	relay.Subscribe("reminders", events.HandlerFunc(reminderService.HandleTaskEvent), events.TaskUpdated, events.TaskDeleted)
+
+	return eventUC.NewRelayWorker(relay, eventUC.RelayConfig{
+		Interval:  cfg.OutboxPollInterval,
+		BatchSize: cfg.OutboxBatchSize,
+		Lease:     outboxLease,
+		Retention: cfg.OutboxRetention,
+	}, log)
+}
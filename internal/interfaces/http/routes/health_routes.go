@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"context"
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/database"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/groq"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/handlers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// llmCheckInterval keeps readiness probes from spending the Groq request quota
+const llmCheckInterval = 30 * time.Second
+
+// RegisterHealthRoutes mounts the probes and returns the handler so shutdown can flip readiness
+func RegisterHealthRoutes(app fiber.Router, cfg *config.Config, dbConnector *database.DBConnector, log logger.Logger) *handlers.HealthHandler {
+	store := newStorage(cfg, log)
+	groqClient, groqErr := groq.NewGroqClient(cfg)
+
+	healthHandler := handlers.NewHealthHandler(
+		handlers.DependencyCheck{
+			Name:     "database",
+			Critical: true,
+			Check: func(ctx context.Context) (map[string]interface{}, error) {
+				if err := dbConnector.HealthCheck(ctx); err != nil {
+					return nil, err
+				}
+				return dbConnector.GetStats(), nil
+			},
+		},
+		handlers.DependencyCheck{
+			Name: "storage",
+			Check: func(ctx context.Context) (map[string]interface{}, error) {
+				details := map[string]interface{}{"driver": cfg.StorageDriver}
+				return details, storage.Ping(ctx, store)
+			},
+		},
+		handlers.DependencyCheck{
+			Name: "llm",
+			Check: handlers.CachedCheck(llmCheckInterval, func(ctx context.Context) (map[string]interface{}, error) {
+				details := map[string]interface{}{"provider": "groq", "model": groq.DefaultModel}
+				if groqErr != nil {
+					return details, groqErr
+				}
+				return details, groqClient.Ping(ctx)
+			}),
+		},
+	)
+
+	app.Get("/livez", healthHandler.Livez)
+	app.Get("/readyz", healthHandler.Readyz)
+	app.Get("/health", healthHandler.Health)
+
+	return healthHandler
+}
@@ -1,17 +1,28 @@
 package routes
 
 import (
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/groq"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
 	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/middlewares"
 
+	accountUC "github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	calendarUC "github.com/FrostBitzX/smart-task-ai/internal/application/calendar/usecase"
 	chatUC "github.com/FrostBitzX/smart-task-ai/internal/application/chat/usecase"
 	profileUC "github.com/FrostBitzX/smart-task-ai/internal/application/profile/usecase"
 	projectUC "github.com/FrostBitzX/smart-task-ai/internal/application/project/usecase"
+	realtimeUC "github.com/FrostBitzX/smart-task-ai/internal/application/realtime/usecase"
+	reminderUC "github.com/FrostBitzX/smart-task-ai/internal/application/reminder/usecase"
+	searchUC "github.com/FrostBitzX/smart-task-ai/internal/application/search/usecase"
 	taskUC "github.com/FrostBitzX/smart-task-ai/internal/application/task/usecase"
+	webhookUC "github.com/FrostBitzX/smart-task-ai/internal/application/webhook/usecase"
+	accountDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	calendarDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/service"
 	chatDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/chats/service"
 	profileDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
 	projectDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/projects/service"
+	reminderDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/service"
+	searchDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/search/service"
 	taskDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
 	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
 	handler "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/rest"
@@ -20,37 +31,122 @@ import (
 	"gorm.io/gorm"
 )
 
-func RegisterPrivateRoutes(app fiber.Router, db *gorm.DB, log logger.Logger) {
-	api := app.Group("/api", middlewares.JWTMiddleware())
+func RegisterPrivateRoutes(app fiber.Router, cfg *config.Config, db *gorm.DB, hub *realtimeUC.Hub, log logger.Logger) {
+	// Account & session setup
+	accountRepository := repo.NewAccountRepository(db)
+	sessionRepository := repo.NewSessionRepository(db)
+	accountTokenRepository := repo.NewAccountTokenRepository(db)
+	accountService := accountDomain.NewAccountService(accountRepository, sessionRepository, accountTokenRepository, newMailer(cfg, log), accountSettings(cfg))
+
+	// Realtime routes; the hub is shared with the database listener, see NewRealtimeHub.
+	// The stream is registered ahead of the /api group so EventSource, which cannot
+	// set the Authorization header, can open it with a ticket instead.
+	createStreamTicketUC := realtimeUC.NewCreateStreamTicketUseCase(newRealtimeService(db), cfg.JWTSecret, log)
+	realtimeHandlerInstance := handler.NewRealtimeHandler(hub, createStreamTicketUC, log)
+	app.Get("/api/projects/:projectId/events", middlewares.JWTMiddleware(middlewares.JWTConfig{
+		Secret:           cfg.JWTSecret,
+		SessionValidator: accountService.ValidateSession,
+		TicketQuery:      realtimeUC.StreamTicketQuery,
+	}), realtimeHandlerInstance.StreamProjectEvents)
+
+	api := app.Group("/api", middlewares.JWTMiddleware(middlewares.JWTConfig{
+		Secret:           cfg.JWTSecret,
+		SessionValidator: accountService.ValidateSession,
+	}))
+
+	listSessionsUC := accountUC.NewListSessionsUseCase(accountService, log)
+	revokeSessionUC := accountUC.NewRevokeSessionUseCase(accountService, log)
+	revokeAllSessionsUC := accountUC.NewRevokeAllSessionsUseCase(accountService, log)
+	sessionHandlerInstance := handler.NewSessionHandler(listSessionsUC, revokeSessionUC, revokeAllSessionsUC, log)
+
+	// Session routes
+	api.Post("/logout", sessionHandlerInstance.Logout)
+	api.Get("/sessions", sessionHandlerInstance.ListSessions)
+	api.Delete("/sessions", sessionHandlerInstance.RevokeAllSessions)
+	api.Delete("/sessions/:sessionId", sessionHandlerInstance.RevokeSession)
+
+	getCurrentAccountUC := accountUC.NewGetCurrentAccountUseCase(accountService, log)
+	changePasswordUC := accountUC.NewChangePasswordUseCase(accountService, log)
+	changeEmailUC := accountUC.NewChangeEmailUseCase(accountService, log)
+	changeUsernameUC := accountUC.NewChangeUsernameUseCase(accountService, log)
+	deactivateAccountUC := accountUC.NewDeactivateAccountUseCase(accountService, log)
+	accountSettingsHandlerInstance := handler.NewAccountSettingsHandler(
+		getCurrentAccountUC,
+		changePasswordUC,
+		changeEmailUC,
+		changeUsernameUC,
+		deactivateAccountUC,
+		log,
+	)
+
+	// Account settings routes
+	api.Get("/account", accountSettingsHandlerInstance.GetCurrentAccount)
+	api.Patch("/account/password", accountSettingsHandlerInstance.ChangePassword)
+	api.Patch("/account/email", accountSettingsHandlerInstance.ChangeEmail)
+	api.Patch("/account/username", accountSettingsHandlerInstance.ChangeUsername)
+	api.Post("/account/deactivate", accountSettingsHandlerInstance.DeactivateAccount)
+
+	// App passwords for CalDAV clients, see RegisterDAVRoutes
+	appPasswordService := accountDomain.NewAppPasswordService(accountRepository, repo.NewAppPasswordRepository(db))
+	createAppPasswordUC := accountUC.NewCreateAppPasswordUseCase(appPasswordService, cfg.PublicAPIURL, log)
+	listAppPasswordsUC := accountUC.NewListAppPasswordsUseCase(appPasswordService, log)
+	revokeAppPasswordUC := accountUC.NewRevokeAppPasswordUseCase(appPasswordService, log)
+	appPasswordHandlerInstance := handler.NewAppPasswordHandler(createAppPasswordUC, listAppPasswordsUC, revokeAppPasswordUC, log)
+
+	api.Post("/account/app-passwords", appPasswordHandlerInstance.CreateAppPassword)
+	api.Get("/account/app-passwords", appPasswordHandlerInstance.ListAppPasswords)
+	api.Delete("/account/app-passwords/:appPasswordId", appPasswordHandlerInstance.RevokeAppPassword)
+
+	registerAdminRoutes(api, accountService, log)
+
+	store := newStorage(cfg, log)
 
 	// Profile setup
 	profileRepository := repo.NewProfileRepository(db)
-	profileService := profileDomain.NewProfileService(profileRepository)
+	profileService := profileDomain.NewProfileService(profileRepository, store)
 	createProfileUC := profileUC.NewCreateProfileUseCase(profileService, log)
 	getProfileUC := profileUC.NewGetProfileUseCase(profileService, log)
 	updateProfileUC := profileUC.NewUpdateProfileUseCase(profileService, log)
-	profileHandlerInstance := handler.NewProfileHandler(createProfileUC, getProfileUC, updateProfileUC, log)
+	uploadAvatarUC := profileUC.NewUploadAvatarUseCase(profileService, log)
+	deleteAvatarUC := profileUC.NewDeleteAvatarUseCase(profileService, log)
+	profileHandlerInstance := handler.NewProfileHandler(
+		createProfileUC,
+		getProfileUC,
+		updateProfileUC,
+		uploadAvatarUC,
+		deleteAvatarUC,
+		log,
+	)
 
 	// Profile routes
 	api.Post("/profiles", profileHandlerInstance.CreateProfile)
 	api.Get("/profiles", profileHandlerInstance.GetProfile)
 	api.Patch("/profiles", profileHandlerInstance.UpdateProfile)
+	api.Post("/profiles/avatar", profileHandlerInstance.UploadAvatar)
+	api.Delete("/profiles/avatar", profileHandlerInstance.DeleteAvatar)
+
+	// Project and task changes are recorded in the outbox and dispatched by the relay, see NewEventRelay
+	outbox := repo.NewOutbox(db)
 
 	// Project setup
 	projectRepository := repo.NewProjectRepository(db)
 	taskRepository := repo.NewTaskRepository(db)
-	projectService := projectDomain.NewProjectService(projectRepository, taskRepository)
+	projectService := projectDomain.NewProjectService(projectRepository, taskRepository, outbox)
 	createProjectUC := projectUC.NewCreateProjectUseCase(projectService, log)
 	listProjectByAccountUC := projectUC.NewListProjectByAccountUseCase(projectService, log)
 	getProjectByIDUC := projectUC.NewGetProjectByIDUseCase(projectService, log)
 	updateProjectUC := projectUC.NewUpdateProjectUseCase(projectService, log)
 	deleteProjectUC := projectUC.NewDeleteProjectUseCase(projectService, log)
+	getProjectConfigSchemaUC := projectUC.NewGetProjectConfigSchemaUseCase(projectService, log)
+	getProjectStatsUC := projectUC.NewGetProjectStatsUseCase(projectService, log)
 	projectHandlerInstance := handler.NewProjectHandler(
 		createProjectUC,
 		listProjectByAccountUC,
 		getProjectByIDUC,
 		updateProjectUC,
 		deleteProjectUC,
+		getProjectConfigSchemaUC,
+		getProjectStatsUC,
 		log,
 	)
 
@@ -60,13 +156,57 @@ func RegisterPrivateRoutes(app fiber.Router, db *gorm.DB, log logger.Logger) {
 	api.Get("/projects/:projectId", projectHandlerInstance.GetProject)
 	api.Patch("/projects/:projectId", projectHandlerInstance.UpdateProject)
 	api.Delete("/projects/:projectId", projectHandlerInstance.DeleteProject)
+	api.Get("/projects/:projectId/config/schema", projectHandlerInstance.GetProjectConfigSchema)
+	api.Get("/projects/:projectId/stats", projectHandlerInstance.GetProjectStats)
+
+	api.Post("/projects/:projectId/events/ticket", realtimeHandlerInstance.CreateStreamTicket)
+
+	// Search setup
+	searchService := searchDomain.NewSearchService(repo.NewSearchRepository(db))
+	searchUseCase := searchUC.NewSearchUseCase(searchService, log)
+	searchHandlerInstance := handler.NewSearchHandler(searchUseCase, log)
+
+	// Search routes
+	api.Get("/search", searchHandlerInstance.Search)
+
+	// Webhook setup; deliveries are queued by the relay and posted by NewWebhookWorker
+	webhookService := newWebhookService(cfg, db)
+	createWebhookUC := webhookUC.NewCreateWebhookUseCase(webhookService, log)
+	listWebhooksUC := webhookUC.NewListWebhooksUseCase(webhookService, log)
+	getWebhookUC := webhookUC.NewGetWebhookUseCase(webhookService, log)
+	updateWebhookUC := webhookUC.NewUpdateWebhookUseCase(webhookService, log)
+	deleteWebhookUC := webhookUC.NewDeleteWebhookUseCase(webhookService, log)
+	listDeliveriesUC := webhookUC.NewListDeliveriesUseCase(webhookService, log)
+	redeliverUC := webhookUC.NewRedeliverUseCase(webhookService, log)
+	webhookHandlerInstance := handler.NewWebhookHandler(
+		createWebhookUC,
+		listWebhooksUC,
+		getWebhookUC,
+		updateWebhookUC,
+		deleteWebhookUC,
+		listDeliveriesUC,
+		redeliverUC,
+		log,
+	)
+
+	// Webhook routes
+	api.Post("/projects/:projectId/webhooks", webhookHandlerInstance.CreateWebhook)
+	api.Get("/projects/:projectId/webhooks", webhookHandlerInstance.ListWebhooks)
+	api.Get("/projects/:projectId/webhooks/:webhookId", webhookHandlerInstance.GetWebhook)
+	api.Patch("/projects/:projectId/webhooks/:webhookId", webhookHandlerInstance.UpdateWebhook)
+	api.Delete("/projects/:projectId/webhooks/:webhookId", webhookHandlerInstance.DeleteWebhook)
+	api.Get("/projects/:projectId/webhooks/:webhookId/deliveries", webhookHandlerInstance.ListDeliveries)
+	api.Post("/projects/:projectId/webhooks/:webhookId/deliveries/:deliveryId/redeliver", webhookHandlerInstance.Redeliver)
 
 	// Task setup
-	taskService := taskDomain.NewTaskService(taskRepository, projectRepository)
-	createTaskUC := taskUC.NewCreateTaskUseCase(taskService, log)
-	getTaskByIDUC := taskUC.NewGetTaskByIDUseCase(taskService, log)
-	listTasksByProjectUC := taskUC.NewListTasksByProjectUseCase(taskService, log)
-	updateTaskUC := taskUC.NewUpdateTaskUseCase(taskService, log)
+	taskService := taskDomain.NewTaskService(taskRepository, projectRepository, outbox)
+	reminderService := newReminderService(cfg, db, log, profileService)
+	createTaskUC := taskUC.NewCreateTaskUseCase(taskService, profileService, log)
+	getTaskByIDUC := taskUC.NewGetTaskByIDUseCase(taskService, profileService, log)
+	listTasksByProjectUC := taskUC.NewListTasksByProjectUseCase(taskService, profileService, log)
+	updateTaskUC := taskUC.NewUpdateTaskUseCase(taskService, profileService, log)
+	attachmentRepository := repo.NewAttachmentRepository(db)
+	attachmentService := taskDomain.NewAttachmentService(attachmentRepository, taskRepository, store, cfg.AttachmentProjectQuota())
 	deleteTaskUC := taskUC.NewDeleteTaskUseCase(taskService, log)
 	taskHandlerInstance := handler.NewTaskHandler(createTaskUC, getTaskByIDUC, listTasksByProjectUC, updateTaskUC, deleteTaskUC, log)
 
@@ -77,18 +217,76 @@ func RegisterPrivateRoutes(app fiber.Router, db *gorm.DB, log logger.Logger) {
 	api.Patch("/tasks/:taskId", taskHandlerInstance.UpdateTask)
 	api.Delete("/tasks/:taskId", taskHandlerInstance.DeleteTask)
 
+	// Attachment setup
+	uploadAttachmentUC := taskUC.NewUploadAttachmentUseCase(attachmentService, log)
+	listAttachmentsUC := taskUC.NewListAttachmentsUseCase(attachmentService, log)
+	downloadAttachmentUC := taskUC.NewDownloadAttachmentUseCase(attachmentService, log)
+	deleteAttachmentUC := taskUC.NewDeleteAttachmentUseCase(attachmentService, log)
+	attachmentHandlerInstance := handler.NewAttachmentHandler(
+		uploadAttachmentUC,
+		listAttachmentsUC,
+		downloadAttachmentUC,
+		deleteAttachmentUC,
+		log,
+	)
+
+	// Attachment routes
+	api.Post("/tasks/:taskId/attachments", attachmentHandlerInstance.UploadAttachment)
+	api.Get("/tasks/:taskId/attachments", attachmentHandlerInstance.ListAttachments)
+	api.Get("/tasks/:taskId/attachments/:attachmentId", attachmentHandlerInstance.DownloadAttachment)
+	api.Delete("/tasks/:taskId/attachments/:attachmentId", attachmentHandlerInstance.DeleteAttachment)
+
+	// Reminder setup; due reminders are delivered by the worker, see NewReminderWorker
+	createReminderUC := reminderUC.NewCreateReminderUseCase(reminderService, profileService, log)
+	listRemindersUC := reminderUC.NewListRemindersUseCase(reminderService, profileService, log)
+	deleteReminderUC := reminderUC.NewDeleteReminderUseCase(reminderService, log)
+	reminderHandlerInstance := handler.NewReminderHandler(createReminderUC, listRemindersUC, deleteReminderUC, log)
+
+	// Reminder routes
+	api.Post("/tasks/:taskId/reminders", reminderHandlerInstance.CreateReminder)
+	api.Get("/tasks/:taskId/reminders", reminderHandlerInstance.ListReminders)
+	api.Delete("/tasks/:taskId/reminders/:reminderId", reminderHandlerInstance.DeleteReminder)
+
+	// Notification setup
+	notificationService := reminderDomain.NewNotificationService(repo.NewNotificationRepository(db))
+	listNotificationsUC := reminderUC.NewListNotificationsUseCase(notificationService, log)
+	markNotificationReadUC := reminderUC.NewMarkNotificationReadUseCase(notificationService, log)
+	markAllNotificationsReadUC := reminderUC.NewMarkAllNotificationsReadUseCase(notificationService, log)
+	notificationHandlerInstance := handler.NewNotificationHandler(listNotificationsUC, markNotificationReadUC, markAllNotificationsReadUC, log)
+
+	// Notification routes
+	api.Get("/notifications", notificationHandlerInstance.ListNotifications)
+	api.Post("/notifications/read", notificationHandlerInstance.MarkAllNotificationsRead)
+	api.Post("/notifications/:notificationId/read", notificationHandlerInstance.MarkNotificationRead)
+
+	// Calendar setup
+	calendarService := calendarDomain.NewCalendarService(repo.NewCalendarFeedRepository(db), projectRepository, taskRepository, taskService)
+	createFeedUC := calendarUC.NewCreateFeedUseCase(calendarService, cfg.PublicAPIURL, log)
+	listFeedsUC := calendarUC.NewListFeedsUseCase(calendarService, log)
+	revokeFeedUC := calendarUC.NewRevokeFeedUseCase(calendarService, log)
+	importTasksUC := calendarUC.NewImportTasksUseCase(calendarService, profileService, log)
+	calendarHandlerInstance := handler.NewCalendarHandler(createFeedUC, listFeedsUC, revokeFeedUC, importTasksUC, log)
+
+	// Calendar feed routes; the feeds themselves are public, see RegisterPublicRoutes
+	api.Post("/calendar/feeds", calendarHandlerInstance.CreateFeed)
+	api.Get("/calendar/feeds", calendarHandlerInstance.ListFeeds)
+	api.Delete("/calendar/feeds/:feedId", calendarHandlerInstance.RevokeFeed)
+
+	// iCalendar import into a project
+	api.Post("/:projectId/tasks/import", calendarHandlerInstance.ImportTasks)
+
 	// Chat setup
-	groqClient, err := groq.NewGroqClient()
+	groqClient, err := groq.NewGroqClient(cfg, groq.WithLogger(log))
 	if err != nil {
 		log.Warn("Failed to initialize Groq client, chat endpoints will not be available", map[string]interface{}{
 			"error": err.Error(),
 		})
 	} else {
 		chatService := chatDomain.NewChatService(groqClient, taskService, projectService)
-		sendMessageUC := chatUC.NewSendMessageUseCase(chatService, log)
+		sendMessageUC := chatUC.NewSendMessageUseCase(chatService, profileService, log)
 		chatHandlerInstance := handler.NewChatHandler(sendMessageUC, log)
 
 		// Chat routes (protected by JWT middleware via /api group)
-		api.Post("/:projectId/chat", chatHandlerInstance.SendMessage)
+		api.Post("/:projectId/chat", newRateLimiters(cfg, db, log).chat(), chatHandlerInstance.SendMessage)
 	}
 }
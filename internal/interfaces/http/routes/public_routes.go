@@ -1,27 +1,107 @@
 package routes
 
 import (
+	"strings"
+
 	accUC "github.com/FrostBitzX/smart-task-ai/internal/application/account/usecase"
+	calendarUC "github.com/FrostBitzX/smart-task-ai/internal/application/calendar/usecase"
 	accDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	calendarDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/service"
+	taskDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
 	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/mailer"
 	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
 	accHandler "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/rest"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func RegisterPublicRoutes(app fiber.Router, db *gorm.DB, log logger.Logger) {
+func RegisterPublicRoutes(app fiber.Router, cfg *config.Config, db *gorm.DB, log logger.Logger) {
 	api := app.Group("/api")
 
 	accountRepository := repo.NewAccountRepository(db)
-	accountService := accDomain.NewAccountService(accountRepository)
+	sessionRepository := repo.NewSessionRepository(db)
+	accountTokenRepository := repo.NewAccountTokenRepository(db)
+	accountService := accDomain.NewAccountService(accountRepository, sessionRepository, accountTokenRepository, newMailer(cfg, log), accountSettings(cfg))
 	accountSignUpUC := accUC.NewCreateAccountUseCase(accountService, log)
 	accountLoginUC := accUC.NewLoginUseCase(accountService, log)
-	listAccountUC := accUC.NewListAccountUseCase(accountService, log)
-	accountHandler := accHandler.NewAccountHandler(accountSignUpUC, listAccountUC, accountLoginUC, log)
+	refreshTokenUC := accUC.NewRefreshTokenUseCase(accountService, log)
+	reactivateAccountUC := accUC.NewReactivateAccountUseCase(accountService, log)
+	accountHandler := accHandler.NewAccountHandler(accountSignUpUC, accountLoginUC, refreshTokenUC, reactivateAccountUC, log)
+
+	limiters := newRateLimiters(cfg, db, log)
+
+	api.Post("/signup", limiters.signup(), accountHandler.CreateAccount)
+	api.Post("/login", limiters.login(), accountHandler.Login)
+	api.Post("/token/refresh", limiters.refresh(), accountHandler.RefreshToken)
+	api.Post("/account/reactivate", limiters.login(), accountHandler.ReactivateAccount)
+
+	forgotPasswordUC := accUC.NewForgotPasswordUseCase(accountService, log)
+	resetPasswordUC := accUC.NewResetPasswordUseCase(accountService, log)
+	verifyEmailUC := accUC.NewVerifyEmailUseCase(accountService, log)
+	resendVerificationUC := accUC.NewResendVerificationUseCase(accountService, log)
+	recoveryHandler := accHandler.NewAccountRecoveryHandler(forgotPasswordUC, resetPasswordUC, verifyEmailUC, resendVerificationUC, log)
+
+	recoveryLimiter := limiters.recovery()
+	api.Post("/password/forgot", recoveryLimiter, recoveryHandler.ForgotPassword)
+	api.Post("/password/reset", recoveryLimiter, recoveryHandler.ResetPassword)
+	api.Post("/email/verify", recoveryLimiter, recoveryHandler.VerifyEmail)
+	api.Post("/email/verify/resend", recoveryLimiter, recoveryHandler.ResendVerification)
+
+	// Signed file proxy, used by the local backend and S3 in proxy mode
+	fileHandler := accHandler.NewFileHandler(newStorage(cfg, log), storage.NewConfigURLSigner(cfg), log)
+	app.Get(strings.TrimRight(storage.ProxyPathPrefix, "/")+"/*", fileHandler.ServeFile)
+
+	// iCalendar feeds, authenticated by the secret token in the URL
+	projectRepository := repo.NewProjectRepository(db)
+	taskRepository := repo.NewTaskRepository(db)
+	taskService := taskDomain.NewTaskService(taskRepository, projectRepository, repo.NewOutbox(db))
+	calendarService := calendarDomain.NewCalendarService(repo.NewCalendarFeedRepository(db), projectRepository, taskRepository, taskService)
+	getFeedUC := calendarUC.NewGetFeedUseCase(calendarService, log)
+	calendarFeedHandler := accHandler.NewCalendarFeedHandler(getFeedUC, log)
+	app.Get(calendarUC.FeedPathPrefix+":token.ics", calendarFeedHandler.GetFeed)
+}
+
+// accountSettings picks the values the account service needs out of the app config
+func accountSettings(cfg *config.Config) accDomain.AccountSettings {
+	return accDomain.AccountSettings{
+		JWTSecret:                    cfg.JWTSecret,
+		FrontendURL:                  cfg.FrontendURL,
+		EmailVerificationGracePeriod: cfg.EmailVerificationGracePeriod,
+	}
+}
+
+// newMailer falls back to the outbox mailer when the mail settings are unusable,
+// so a broken mail setup never takes the API down
+func newMailer(cfg *config.Config, log logger.Logger) mailer.Mailer {
+	m, err := mailer.NewMailer(cfg, log)
+	if err != nil {
+		log.Warn("Failed to initialize mailer, emails will only be written to the outbox", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return mailer.NewOutboxMailer(mailer.DefaultOutboxDir, mailer.DefaultFrom, log)
+	}
+	return m
+}
+
+// newStorage falls back to local disk when the configured backend cannot be built
+func newStorage(cfg *config.Config, log logger.Logger) storage.Storage {
+	s, err := storage.NewStorage(cfg)
+	if err == nil {
+		return s
+	}
 
-	api.Post("/signup", accountHandler.CreateAccount)
-	api.Post("/login", accountHandler.Login)
-	api.Get("/accounts", accountHandler.ListAccounts)
+	log.Warn("Failed to initialize storage, falling back to local disk", map[string]interface{}{
+		"error": err.Error(),
+	})
+	s, err = storage.NewLocalStorage(storage.DefaultLocalDir, storage.NewConfigURLSigner(cfg))
+	if err != nil {
+		log.Error("Failed to initialize local storage", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return s
 }
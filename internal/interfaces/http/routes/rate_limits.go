@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/ratelimit"
+	"github.com/FrostBitzX/smart-task-ai/internal/interfaces/http/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Rate limit policies per route group
+var (
+	// loginIPPolicy and loginAccountPolicy slow down password guessing from one
+	// address and against one account from many addresses
+	loginIPPolicy      = ratelimit.Policy{Limit: 10, Window: time.Minute}
+	loginAccountPolicy = ratelimit.Policy{Limit: 5, Window: 15 * time.Minute}
+
+	signupIPPolicy   = ratelimit.Policy{Limit: 5, Window: time.Hour}
+	recoveryIPPolicy = ratelimit.Policy{Limit: 5, Window: 15 * time.Minute}
+	refreshIPPolicy  = ratelimit.Policy{Limit: 30, Window: time.Minute}
+
+	// chat calls Groq on every request, so it is limited per account and per address
+	chatAccountPolicy = ratelimit.Policy{Limit: 20, Window: time.Minute}
+	chatIPPolicy      = ratelimit.Policy{Limit: 60, Window: time.Minute}
+)
+
+// rateLimiters builds the middleware of every rate-limited route group on one store
+type rateLimiters struct {
+	store ratelimit.Store
+	log   logger.Logger
+}
+
+// newRateLimiters falls back to the in-memory store when RATE_LIMIT_STORE is misconfigured
+func newRateLimiters(cfg *config.Config, db *gorm.DB, log logger.Logger) *rateLimiters {
+	store, err := ratelimit.NewStore(cfg.RateLimitStore, db, log)
+	if err != nil {
+		log.Warn("Failed to initialize rate limit store, using in-memory buckets", map[string]interface{}{
+			"error": err.Error(),
+		})
+		store = ratelimit.NewMemoryStore()
+	}
+	return &rateLimiters{store: store, log: log}
+}
+
+func (r *rateLimiters) with(rules ...middlewares.RateLimitRule) fiber.Handler {
+	return middlewares.RateLimitMiddleware(middlewares.RateLimitConfig{
+		Store:  r.store,
+		Rules:  rules,
+		Logger: r.log,
+	})
+}
+
+func (r *rateLimiters) login() fiber.Handler {
+	return r.with(
+		middlewares.RateLimitRule{Name: "login-ip", Policy: loginIPPolicy, Key: middlewares.RateLimitByIP},
+		middlewares.RateLimitRule{Name: "login-account", Policy: loginAccountPolicy, Key: middlewares.RateLimitByBodyField("username")},
+	)
+}
+
+func (r *rateLimiters) signup() fiber.Handler {
+	return r.with(middlewares.RateLimitRule{Name: "signup-ip", Policy: signupIPPolicy, Key: middlewares.RateLimitByIP})
+}
+
+func (r *rateLimiters) recovery() fiber.Handler {
+	return r.with(middlewares.RateLimitRule{Name: "recovery-ip", Policy: recoveryIPPolicy, Key: middlewares.RateLimitByIP})
+}
+
+func (r *rateLimiters) refresh() fiber.Handler {
+	return r.with(middlewares.RateLimitRule{Name: "refresh-ip", Policy: refreshIPPolicy, Key: middlewares.RateLimitByIP})
+}
+
+func (r *rateLimiters) chat() fiber.Handler {
+	return r.with(
+		middlewares.RateLimitRule{Name: "chat-account", Policy: chatAccountPolicy, Key: middlewares.RateLimitByAccount},
+		middlewares.RateLimitRule{Name: "chat-ip", Policy: chatIPPolicy, Key: middlewares.RateLimitByIP},
+	)
+}
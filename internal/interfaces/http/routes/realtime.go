@@ -0,0 +1,24 @@
+package routes
+
+import (
+	realtimeUC "github.com/FrostBitzX/smart-task-ai/internal/application/realtime/usecase"
+	accountDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/service"
+	realtimeDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
+
+	"gorm.io/gorm"
+)
+
+// NewRealtimeHub builds the hub of the project event streams. It is fed by a
+// database listener on realtime.ChannelEvents and realtime.ChannelPresence,
+// started along with the background workers.
+func NewRealtimeHub(cfg *config.Config, db *gorm.DB, log logger.Logger) *realtimeUC.Hub {
+	accountService := accountDomain.NewAccountService(repo.NewAccountRepository(db), repo.NewSessionRepository(db), repo.NewAccountTokenRepository(db), newMailer(cfg, log), accountSettings(cfg))
+	return realtimeUC.NewHub(newRealtimeService(db), accountService.ValidateSession, log)
+}
+
+func newRealtimeService(db *gorm.DB) *realtimeDomain.RealtimeService {
+	return realtimeDomain.NewRealtimeService(repo.NewProjectRepository(db), repo.NewEventLog(db), repo.NewViewerRepository(db))
+}
@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"time"
+
+	reminderUC "github.com/FrostBitzX/smart-task-ai/internal/application/reminder/usecase"
+	profileDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/profiles/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/domain/reminders"
+	reminderDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/safehttp"
+
+	"gorm.io/gorm"
+)
+
+// reminderLease is how long a worker holds the reminders it claimed
+const reminderLease = 5 * time.Minute
+
+// NewReminderWorker builds the background worker delivering due reminders
+func NewReminderWorker(cfg *config.Config, db *gorm.DB, log logger.Logger) *reminderUC.ReminderWorker {
+	// The worker only reads time zones, so the profile service needs no storage
+	profileService := profileDomain.NewProfileService(repo.NewProfileRepository(db), nil)
+	return reminderUC.NewReminderWorker(newReminderService(cfg, db, log, profileService), reminderUC.WorkerConfig{
+		Interval:  cfg.ReminderPollInterval,
+		BatchSize: cfg.ReminderBatchSize,
+		Lease:     reminderLease,
+	}, log)
+}
+
+// newReminderService wires the in-app, email and webhook channels
+func newReminderService(cfg *config.Config, db *gorm.DB, log logger.Logger, profileService *profileDomain.ProfileService) *reminderDomain.ReminderService {
+	notificationRepository := repo.NewNotificationRepository(db)
+	reminderRepository := repo.NewReminderRepository(db)
+	channels := map[string]reminders.Channel{
+		reminders.ChannelInApp:   reminderDomain.NewInAppChannel(notificationRepository),
+		reminders.ChannelEmail:   reminderDomain.NewEmailChannel(newMailer(cfg, log), repo.NewAccountRepository(db), reminderRepository),
+		reminders.ChannelWebhook: reminderDomain.NewWebhookChannel(safehttp.NewClient(cfg.ReminderWebhookTimeout)),
+	}
+
+	return reminderDomain.NewReminderService(
+		reminderRepository,
+		notificationRepository,
+		repo.NewTaskRepository(db),
+		repo.NewProjectRepository(db),
+		profileService,
+		channels,
+	)
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"time"
+
+	webhookUC "github.com/FrostBitzX/smart-task-ai/internal/application/webhook/usecase"
+	webhookDomain "github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/service"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/config"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/logger"
+	repo "github.com/FrostBitzX/smart-task-ai/internal/infrastructure/persistence"
+	"github.com/FrostBitzX/smart-task-ai/internal/infrastructure/safehttp"
+
+	"gorm.io/gorm"
+)
+
+// webhookLease is how long a worker holds the webhook deliveries it claimed
+const webhookLease = 5 * time.Minute
+
+// NewWebhookWorker builds the background worker posting project webhooks
+func NewWebhookWorker(cfg *config.Config, db *gorm.DB, log logger.Logger) *webhookUC.WebhookWorker {
+	return webhookUC.NewWebhookWorker(newWebhookService(cfg, db), webhookUC.WorkerConfig{
+		Interval:  cfg.WebhookPollInterval,
+		BatchSize: cfg.WebhookBatchSize,
+		Lease:     webhookLease,
+	}, log)
+}
+
+func newWebhookService(cfg *config.Config, db *gorm.DB) *webhookDomain.WebhookService {
+	return webhookDomain.NewWebhookService(
+		repo.NewWebhookSubscriptionRepository(db),
+		repo.NewWebhookDeliveryRepository(db),
+		repo.NewProjectRepository(db),
+		safehttp.NewClient(cfg.WebhookTimeout),
+	)
+}
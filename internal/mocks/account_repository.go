@@ -12,8 +12,11 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	accounts "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts"
 	entity "github.com/FrostBitzX/smart-task-ai/internal/domain/accounts/entity"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -70,6 +73,66 @@ func (mr *MockAccountRepositoryMockRecorder) ExistsAccount(ctx, username, email
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsAccount", reflect.TypeOf((*MockAccountRepository)(nil).ExistsAccount), ctx, username, email)
 }
 
+// ExistsEmail mocks base method.
+func (m *MockAccountRepository) ExistsEmail(ctx context.Context, email string, excludeID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsEmail", ctx, email, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsEmail indicates an expected call of ExistsEmail.
+func (mr *MockAccountRepositoryMockRecorder) ExistsEmail(ctx, email, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsEmail", reflect.TypeOf((*MockAccountRepository)(nil).ExistsEmail), ctx, email, excludeID)
+}
+
+// ExistsUsername mocks base method.
+func (m *MockAccountRepository) ExistsUsername(ctx context.Context, username string, excludeID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsUsername", ctx, username, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsUsername indicates an expected call of ExistsUsername.
+func (mr *MockAccountRepositoryMockRecorder) ExistsUsername(ctx, username, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsUsername", reflect.TypeOf((*MockAccountRepository)(nil).ExistsUsername), ctx, username, excludeID)
+}
+
+// GetByEmail mocks base method.
+func (m *MockAccountRepository) GetByEmail(ctx context.Context, email string) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockAccountRepositoryMockRecorder) GetByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockAccountRepository)(nil).GetByEmail), ctx, email)
+}
+
+// GetByID mocks base method.
+func (m *MockAccountRepository) GetByID(ctx context.Context, accountID uuid.UUID) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, accountID)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAccountRepositoryMockRecorder) GetByID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAccountRepository)(nil).GetByID), ctx, accountID)
+}
+
 // GetByUsername mocks base method.
 func (m *MockAccountRepository) GetByUsername(ctx context.Context, username string) (*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -86,9 +149,9 @@ func (mr *MockAccountRepositoryMockRecorder) GetByUsername(ctx, username any) *g
 }
 
 // ListAccounts mocks base method.
-func (m *MockAccountRepository) ListAccounts(ctx context.Context, limit, offset int) ([]*entity.Account, int, error) {
+func (m *MockAccountRepository) ListAccounts(ctx context.Context, filter accounts.AccountFilter, limit, offset int) ([]*entity.Account, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, filter, limit, offset)
 	ret0, _ := ret[0].([]*entity.Account)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -96,7 +159,384 @@ func (m *MockAccountRepository) ListAccounts(ctx context.Context, limit, offset
 }
 
 // ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockAccountRepositoryMockRecorder) ListAccounts(ctx, limit, offset any) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) ListAccounts(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountRepository)(nil).ListAccounts), ctx, filter, limit, offset)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockAccountRepository) MarkEmailVerified(ctx context.Context, accountID uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, accountID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockAccountRepositoryMockRecorder) MarkEmailVerified(ctx, accountID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockAccountRepository)(nil).MarkEmailVerified), ctx, accountID, at)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockAccountRepository) UpdateAccount(ctx context.Context, acc *entity.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", ctx, acc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockAccountRepositoryMockRecorder) UpdateAccount(ctx, acc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAccountRepository)(nil).UpdateAccount), ctx, acc)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockAccountRepository) UpdatePassword(ctx context.Context, accountID uuid.UUID, passwordHash string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, accountID, passwordHash, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockAccountRepositoryMockRecorder) UpdatePassword(ctx, accountID, passwordHash, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockAccountRepository)(nil).UpdatePassword), ctx, accountID, passwordHash, at)
+}
+
+// UpdateRole mocks base method.
+func (m *MockAccountRepository) UpdateRole(ctx context.Context, accountID uuid.UUID, role string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, accountID, role, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockAccountRepositoryMockRecorder) UpdateRole(ctx, accountID, role, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockAccountRepository)(nil).UpdateRole), ctx, accountID, role, at)
+}
+
+// UpdateState mocks base method.
+func (m *MockAccountRepository) UpdateState(ctx context.Context, accountID uuid.UUID, state string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", ctx, accountID, state, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockAccountRepositoryMockRecorder) UpdateState(ctx, accountID, state, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockAccountRepository)(nil).UpdateState), ctx, accountID, state, at)
+}
+
+// MockSessionRepository is a mock of SessionRepository interface.
+type MockSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionRepositoryMockRecorder is the mock recorder for MockSessionRepository.
+type MockSessionRepositoryMockRecorder struct {
+	mock *MockSessionRepository
+}
+
+// NewMockSessionRepository creates a new mock instance.
+func NewMockSessionRepository(ctrl *gomock.Controller) *MockSessionRepository {
+	mock := &MockSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepository) EXPECT() *MockSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockSessionRepository) CreateSession(ctx context.Context, session *entity.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockSessionRepositoryMockRecorder) CreateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionRepository)(nil).CreateSession), ctx, session)
+}
+
+// FindSessionByTokenHash mocks base method.
+func (m *MockSessionRepository) FindSessionByTokenHash(ctx context.Context, tokenHash string) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSessionByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSessionByTokenHash indicates an expected call of FindSessionByTokenHash.
+func (mr *MockSessionRepositoryMockRecorder) FindSessionByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSessionByTokenHash", reflect.TypeOf((*MockSessionRepository)(nil).FindSessionByTokenHash), ctx, tokenHash)
+}
+
+// GetSessionByID mocks base method.
+func (m *MockSessionRepository) GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByID", ctx, sessionID)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByID indicates an expected call of GetSessionByID.
+func (mr *MockSessionRepositoryMockRecorder) GetSessionByID(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByID", reflect.TypeOf((*MockSessionRepository)(nil).GetSessionByID), ctx, sessionID)
+}
+
+// ListActiveSessions mocks base method.
+func (m *MockSessionRepository) ListActiveSessions(ctx context.Context, accountID uuid.UUID, now time.Time) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSessions", ctx, accountID, now)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSessions indicates an expected call of ListActiveSessions.
+func (mr *MockSessionRepositoryMockRecorder) ListActiveSessions(ctx, accountID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSessions", reflect.TypeOf((*MockSessionRepository)(nil).ListActiveSessions), ctx, accountID, now)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockSessionRepository) RevokeAllSessions(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", ctx, accountID, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockSessionRepositoryMockRecorder) RevokeAllSessions(ctx, accountID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockSessionRepository)(nil).RevokeAllSessions), ctx, accountID, at)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockSessionRepository) RevokeOtherSessions(ctx context.Context, accountID, keepSessionID uuid.UUID, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, accountID, keepSessionID, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockSessionRepositoryMockRecorder) RevokeOtherSessions(ctx, accountID, keepSessionID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockSessionRepository)(nil).RevokeOtherSessions), ctx, accountID, keepSessionID, at)
+}
+
+// RevokeSession mocks base method.
+func (m *MockSessionRepository) RevokeSession(ctx context.Context, accountID, sessionID uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, accountID, sessionID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockSessionRepositoryMockRecorder) RevokeSession(ctx, accountID, sessionID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockSessionRepository)(nil).RevokeSession), ctx, accountID, sessionID, at)
+}
+
+// RotateSession mocks base method.
+func (m *MockSessionRepository) RotateSession(ctx context.Context, sessionID uuid.UUID, oldHash, newHash string, expiresAt, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", ctx, sessionID, oldHash, newHash, expiresAt, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockSessionRepositoryMockRecorder) RotateSession(ctx, sessionID, oldHash, newHash, expiresAt, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockSessionRepository)(nil).RotateSession), ctx, sessionID, oldHash, newHash, expiresAt, usedAt)
+}
+
+// MockAccountTokenRepository is a mock of AccountTokenRepository interface.
+type MockAccountTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountTokenRepositoryMockRecorder is the mock recorder for MockAccountTokenRepository.
+type MockAccountTokenRepositoryMockRecorder struct {
+	mock *MockAccountTokenRepository
+}
+
+// NewMockAccountTokenRepository creates a new mock instance.
+func NewMockAccountTokenRepository(ctrl *gomock.Controller) *MockAccountTokenRepository {
+	mock := &MockAccountTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountTokenRepository) EXPECT() *MockAccountTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeAccountToken mocks base method.
+func (m *MockAccountTokenRepository) ConsumeAccountToken(ctx context.Context, tokenID uuid.UUID, purpose string, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeAccountToken", ctx, tokenID, purpose, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeAccountToken indicates an expected call of ConsumeAccountToken.
+func (mr *MockAccountTokenRepositoryMockRecorder) ConsumeAccountToken(ctx, tokenID, purpose, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeAccountToken", reflect.TypeOf((*MockAccountTokenRepository)(nil).ConsumeAccountToken), ctx, tokenID, purpose, now)
+}
+
+// CreateAccountToken mocks base method.
+func (m *MockAccountTokenRepository) CreateAccountToken(ctx context.Context, token *entity.AccountToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAccountToken indicates an expected call of CreateAccountToken.
+func (mr *MockAccountTokenRepositoryMockRecorder) CreateAccountToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountToken", reflect.TypeOf((*MockAccountTokenRepository)(nil).CreateAccountToken), ctx, token)
+}
+
+// InvalidateAccountTokens mocks base method.
+func (m *MockAccountTokenRepository) InvalidateAccountTokens(ctx context.Context, accountID uuid.UUID, purpose string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateAccountTokens", ctx, accountID, purpose, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateAccountTokens indicates an expected call of InvalidateAccountTokens.
+func (mr *MockAccountTokenRepositoryMockRecorder) InvalidateAccountTokens(ctx, accountID, purpose, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAccountTokens", reflect.TypeOf((*MockAccountTokenRepository)(nil).InvalidateAccountTokens), ctx, accountID, purpose, at)
+}
+
+// MockAppPasswordRepository is a mock of AppPasswordRepository interface.
+type MockAppPasswordRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAppPasswordRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAppPasswordRepositoryMockRecorder is the mock recorder for MockAppPasswordRepository.
+type MockAppPasswordRepositoryMockRecorder struct {
+	mock *MockAppPasswordRepository
+}
+
+// NewMockAppPasswordRepository creates a new mock instance.
+func NewMockAppPasswordRepository(ctrl *gomock.Controller) *MockAppPasswordRepository {
+	mock := &MockAppPasswordRepository{ctrl: ctrl}
+	mock.recorder = &MockAppPasswordRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAppPasswordRepository) EXPECT() *MockAppPasswordRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAppPassword mocks base method.
+func (m *MockAppPasswordRepository) CreateAppPassword(ctx context.Context, appPassword *entity.AppPassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAppPassword", ctx, appPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAppPassword indicates an expected call of CreateAppPassword.
+func (mr *MockAppPasswordRepositoryMockRecorder) CreateAppPassword(ctx, appPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppPassword", reflect.TypeOf((*MockAppPasswordRepository)(nil).CreateAppPassword), ctx, appPassword)
+}
+
+// GetAppPasswordByHash mocks base method.
+func (m *MockAppPasswordRepository) GetAppPasswordByHash(ctx context.Context, passwordHash string) (*entity.AppPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppPasswordByHash", ctx, passwordHash)
+	ret0, _ := ret[0].(*entity.AppPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppPasswordByHash indicates an expected call of GetAppPasswordByHash.
+func (mr *MockAppPasswordRepositoryMockRecorder) GetAppPasswordByHash(ctx, passwordHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppPasswordByHash", reflect.TypeOf((*MockAppPasswordRepository)(nil).GetAppPasswordByHash), ctx, passwordHash)
+}
+
+// ListActiveAppPasswords mocks base method.
+func (m *MockAppPasswordRepository) ListActiveAppPasswords(ctx context.Context, accountID uuid.UUID) ([]*entity.AppPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveAppPasswords", ctx, accountID)
+	ret0, _ := ret[0].([]*entity.AppPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveAppPasswords indicates an expected call of ListActiveAppPasswords.
+func (mr *MockAppPasswordRepositoryMockRecorder) ListActiveAppPasswords(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveAppPasswords", reflect.TypeOf((*MockAppPasswordRepository)(nil).ListActiveAppPasswords), ctx, accountID)
+}
+
+// RevokeAppPassword mocks base method.
+func (m *MockAppPasswordRepository) RevokeAppPassword(ctx context.Context, accountID, appPasswordID uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAppPassword", ctx, accountID, appPasswordID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAppPassword indicates an expected call of RevokeAppPassword.
+func (mr *MockAppPasswordRepositoryMockRecorder) RevokeAppPassword(ctx, accountID, appPasswordID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAppPassword", reflect.TypeOf((*MockAppPasswordRepository)(nil).RevokeAppPassword), ctx, accountID, appPasswordID, at)
+}
+
+// TouchAppPassword mocks base method.
+func (m *MockAppPasswordRepository) TouchAppPassword(ctx context.Context, appPasswordID uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAppPassword", ctx, appPasswordID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAppPassword indicates an expected call of TouchAppPassword.
+func (mr *MockAppPasswordRepositoryMockRecorder) TouchAppPassword(ctx, appPasswordID, at any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountRepository)(nil).ListAccounts), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAppPassword", reflect.TypeOf((*MockAppPasswordRepository)(nil).TouchAppPassword), ctx, appPasswordID, at)
 }
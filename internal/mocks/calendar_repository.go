@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../../mocks/calendar_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/FrostBitzX/smart-task-ai/internal/domain/calendars/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedRepository is a mock of FeedRepository interface.
+type MockFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedRepositoryMockRecorder is the mock recorder for MockFeedRepository.
+type MockFeedRepositoryMockRecorder struct {
+	mock *MockFeedRepository
+}
+
+// NewMockFeedRepository creates a new mock instance.
+func NewMockFeedRepository(ctrl *gomock.Controller) *MockFeedRepository {
+	mock := &MockFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedRepository) EXPECT() *MockFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateFeed mocks base method.
+func (m *MockFeedRepository) CreateFeed(ctx context.Context, feed *entity.Feed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeed", ctx, feed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFeed indicates an expected call of CreateFeed.
+func (mr *MockFeedRepositoryMockRecorder) CreateFeed(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeed", reflect.TypeOf((*MockFeedRepository)(nil).CreateFeed), ctx, feed)
+}
+
+// GetFeedByTokenHash mocks base method.
+func (m *MockFeedRepository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*entity.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedByTokenHash indicates an expected call of GetFeedByTokenHash.
+func (mr *MockFeedRepositoryMockRecorder) GetFeedByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedByTokenHash", reflect.TypeOf((*MockFeedRepository)(nil).GetFeedByTokenHash), ctx, tokenHash)
+}
+
+// ListActiveFeeds mocks base method.
+func (m *MockFeedRepository) ListActiveFeeds(ctx context.Context, accountID uuid.UUID) ([]*entity.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveFeeds", ctx, accountID)
+	ret0, _ := ret[0].([]*entity.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveFeeds indicates an expected call of ListActiveFeeds.
+func (mr *MockFeedRepositoryMockRecorder) ListActiveFeeds(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveFeeds", reflect.TypeOf((*MockFeedRepository)(nil).ListActiveFeeds), ctx, accountID)
+}
+
+// RevokeFeed mocks base method.
+func (m *MockFeedRepository) RevokeFeed(ctx context.Context, accountID, feedID uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFeed", ctx, accountID, feedID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeFeed indicates an expected call of RevokeFeed.
+func (mr *MockFeedRepositoryMockRecorder) RevokeFeed(ctx, accountID, feedID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFeed", reflect.TypeOf((*MockFeedRepository)(nil).RevokeFeed), ctx, accountID, feedID, at)
+}
@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../../mocks/outbox_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	events "github.com/FrostBitzX/smart-task-ai/internal/domain/events"
+	entity "github.com/FrostBitzX/smart-task-ai/internal/domain/events/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockOutbox) Record(ctx context.Context, arg1 ...events.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Record", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockOutboxMockRecorder) Record(ctx any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockOutbox)(nil).Record), varargs...)
+}
+
+// WithinTransaction mocks base method.
+func (m *MockOutbox) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockOutboxMockRecorder) WithinTransaction(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockOutbox)(nil).WithinTransaction), ctx, fn)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueEvents mocks base method.
+func (m *MockOutboxRepository) ClaimDueEvents(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueEvents", ctx, now, lockedUntil, limit)
+	ret0, _ := ret[0].([]*entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueEvents indicates an expected call of ClaimDueEvents.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimDueEvents(ctx, now, lockedUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueEvents", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimDueEvents), ctx, now, lockedUntil, limit)
+}
+
+// DeleteDispatchedEvents mocks base method.
+func (m *MockOutboxRepository) DeleteDispatchedEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDispatchedEvents", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDispatchedEvents indicates an expected call of DeleteDispatchedEvents.
+func (mr *MockOutboxRepositoryMockRecorder) DeleteDispatchedEvents(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDispatchedEvents", reflect.TypeOf((*MockOutboxRepository)(nil).DeleteDispatchedEvents), ctx, before, limit)
+}
+
+// FinishClaim mocks base method.
+func (m *MockOutboxRepository) FinishClaim(ctx context.Context, event *entity.OutboxEvent, claimedUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishClaim", ctx, event, claimedUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishClaim indicates an expected call of FinishClaim.
+func (mr *MockOutboxRepositoryMockRecorder) FinishClaim(ctx, event, claimedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishClaim", reflect.TypeOf((*MockOutboxRepository)(nil).FinishClaim), ctx, event, claimedUntil)
+}
+
+// MockEventLog is a mock of EventLog interface.
+type MockEventLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogMockRecorder is the mock recorder for MockEventLog.
+type MockEventLogMockRecorder struct {
+	mock *MockEventLog
+}
+
+// NewMockEventLog creates a new mock instance.
+func NewMockEventLog(ctrl *gomock.Controller) *MockEventLog {
+	mock := &MockEventLog{ctrl: ctrl}
+	mock.recorder = &MockEventLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLog) EXPECT() *MockEventLogMockRecorder {
+	return m.recorder
+}
+
+// GetEventsBySeq mocks base method.
+func (m *MockEventLog) GetEventsBySeq(ctx context.Context, seqs []int64) ([]*entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsBySeq", ctx, seqs)
+	ret0, _ := ret[0].([]*entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsBySeq indicates an expected call of GetEventsBySeq.
+func (mr *MockEventLogMockRecorder) GetEventsBySeq(ctx, seqs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsBySeq", reflect.TypeOf((*MockEventLog)(nil).GetEventsBySeq), ctx, seqs)
+}
+
+// GetSeqRange mocks base method.
+func (m *MockEventLog) GetSeqRange(ctx context.Context) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeqRange", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSeqRange indicates an expected call of GetSeqRange.
+func (mr *MockEventLogMockRecorder) GetSeqRange(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeqRange", reflect.TypeOf((*MockEventLog)(nil).GetSeqRange), ctx)
+}
+
+// ListProjectEvents mocks base method.
+func (m *MockEventLog) ListProjectEvents(ctx context.Context, projectID uuid.UUID, afterSeq int64, types []string, limit int) ([]*entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectEvents", ctx, projectID, afterSeq, types, limit)
+	ret0, _ := ret[0].([]*entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjectEvents indicates an expected call of ListProjectEvents.
+func (mr *MockEventLogMockRecorder) ListProjectEvents(ctx, projectID, afterSeq, types, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectEvents", reflect.TypeOf((*MockEventLog)(nil).ListProjectEvents), ctx, projectID, afterSeq, types, limit)
+}
@@ -85,6 +85,21 @@ func (mr *MockProjectRepositoryMockRecorder) GetProjectByID(ctx, projectID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByID", reflect.TypeOf((*MockProjectRepository)(nil).GetProjectByID), ctx, projectID)
 }
 
+// ListAllProjectsByAccountID mocks base method.
+func (m *MockProjectRepository) ListAllProjectsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllProjectsByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]*entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllProjectsByAccountID indicates an expected call of ListAllProjectsByAccountID.
+func (mr *MockProjectRepositoryMockRecorder) ListAllProjectsByAccountID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllProjectsByAccountID", reflect.TypeOf((*MockProjectRepository)(nil).ListAllProjectsByAccountID), ctx, accountID)
+}
+
 // ListProjectByAccountID mocks base method.
 func (m *MockProjectRepository) ListProjectByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int) ([]*entity.Project, int, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,20 @@ func (mr *MockProjectRepositoryMockRecorder) ListProjectByAccountID(ctx, account
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectByAccountID", reflect.TypeOf((*MockProjectRepository)(nil).ListProjectByAccountID), ctx, accountID, limit, offset)
 }
 
+// LockProject mocks base method.
+func (m *MockProjectRepository) LockProject(ctx context.Context, projectID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockProject", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockProject indicates an expected call of LockProject.
+func (mr *MockProjectRepositoryMockRecorder) LockProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockProject", reflect.TypeOf((*MockProjectRepository)(nil).LockProject), ctx, projectID)
+}
+
 // UpdateProject mocks base method.
 func (m *MockProjectRepository) UpdateProject(ctx context.Context, proj *entity.Project) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,288 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../../mocks/reminder_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/FrostBitzX/smart-task-ai/internal/domain/reminders/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReminderRepository is a mock of ReminderRepository interface.
+type MockReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReminderRepositoryMockRecorder is the mock recorder for MockReminderRepository.
+type MockReminderRepositoryMockRecorder struct {
+	mock *MockReminderRepository
+}
+
+// NewMockReminderRepository creates a new mock instance.
+func NewMockReminderRepository(ctrl *gomock.Controller) *MockReminderRepository {
+	mock := &MockReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderRepository) EXPECT() *MockReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueReminders mocks base method.
+func (m *MockReminderRepository) ClaimDueReminders(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueReminders", ctx, now, lockedUntil, limit)
+	ret0, _ := ret[0].([]*entity.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueReminders indicates an expected call of ClaimDueReminders.
+func (mr *MockReminderRepositoryMockRecorder) ClaimDueReminders(ctx, now, lockedUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueReminders", reflect.TypeOf((*MockReminderRepository)(nil).ClaimDueReminders), ctx, now, lockedUntil, limit)
+}
+
+// ClaimSend mocks base method.
+func (m *MockReminderRepository) ClaimSend(ctx context.Context, send *entity.Send) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimSend", ctx, send)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimSend indicates an expected call of ClaimSend.
+func (mr *MockReminderRepositoryMockRecorder) ClaimSend(ctx, send any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimSend", reflect.TypeOf((*MockReminderRepository)(nil).ClaimSend), ctx, send)
+}
+
+// CreateReminder mocks base method.
+func (m *MockReminderRepository) CreateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReminder indicates an expected call of CreateReminder.
+func (mr *MockReminderRepositoryMockRecorder) CreateReminder(ctx, reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReminder", reflect.TypeOf((*MockReminderRepository)(nil).CreateReminder), ctx, reminder)
+}
+
+// DeleteReminder mocks base method.
+func (m *MockReminderRepository) DeleteReminder(ctx context.Context, reminderID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReminder", ctx, reminderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReminder indicates an expected call of DeleteReminder.
+func (mr *MockReminderRepositoryMockRecorder) DeleteReminder(ctx, reminderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReminder", reflect.TypeOf((*MockReminderRepository)(nil).DeleteReminder), ctx, reminderID)
+}
+
+// DeleteRemindersByTask mocks base method.
+func (m *MockReminderRepository) DeleteRemindersByTask(ctx context.Context, taskID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRemindersByTask", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRemindersByTask indicates an expected call of DeleteRemindersByTask.
+func (mr *MockReminderRepositoryMockRecorder) DeleteRemindersByTask(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemindersByTask", reflect.TypeOf((*MockReminderRepository)(nil).DeleteRemindersByTask), ctx, taskID)
+}
+
+// FinishClaim mocks base method.
+func (m *MockReminderRepository) FinishClaim(ctx context.Context, reminder *entity.Reminder, claimedUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishClaim", ctx, reminder, claimedUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishClaim indicates an expected call of FinishClaim.
+func (mr *MockReminderRepositoryMockRecorder) FinishClaim(ctx, reminder, claimedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishClaim", reflect.TypeOf((*MockReminderRepository)(nil).FinishClaim), ctx, reminder, claimedUntil)
+}
+
+// GetReminderByID mocks base method.
+func (m *MockReminderRepository) GetReminderByID(ctx context.Context, reminderID uuid.UUID) (*entity.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminderByID", ctx, reminderID)
+	ret0, _ := ret[0].(*entity.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReminderByID indicates an expected call of GetReminderByID.
+func (mr *MockReminderRepositoryMockRecorder) GetReminderByID(ctx, reminderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminderByID", reflect.TypeOf((*MockReminderRepository)(nil).GetReminderByID), ctx, reminderID)
+}
+
+// ListRemindersByTask mocks base method.
+func (m *MockReminderRepository) ListRemindersByTask(ctx context.Context, taskID uuid.UUID) ([]*entity.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRemindersByTask", ctx, taskID)
+	ret0, _ := ret[0].([]*entity.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRemindersByTask indicates an expected call of ListRemindersByTask.
+func (mr *MockReminderRepositoryMockRecorder) ListRemindersByTask(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRemindersByTask", reflect.TypeOf((*MockReminderRepository)(nil).ListRemindersByTask), ctx, taskID)
+}
+
+// ReleaseSend mocks base method.
+func (m *MockReminderRepository) ReleaseSend(ctx context.Context, reminderID uuid.UUID, occurrenceStart time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseSend", ctx, reminderID, occurrenceStart)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseSend indicates an expected call of ReleaseSend.
+func (mr *MockReminderRepositoryMockRecorder) ReleaseSend(ctx, reminderID, occurrenceStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseSend", reflect.TypeOf((*MockReminderRepository)(nil).ReleaseSend), ctx, reminderID, occurrenceStart)
+}
+
+// UpdateReminder mocks base method.
+func (m *MockReminderRepository) UpdateReminder(ctx context.Context, reminder *entity.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReminder indicates an expected call of UpdateReminder.
+func (mr *MockReminderRepositoryMockRecorder) UpdateReminder(ctx, reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReminder", reflect.TypeOf((*MockReminderRepository)(nil).UpdateReminder), ctx, reminder)
+}
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockNotificationRepository) CountUnreadNotifications(ctx context.Context, accountID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", ctx, accountID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockNotificationRepositoryMockRecorder) CountUnreadNotifications(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockNotificationRepository)(nil).CountUnreadNotifications), ctx, accountID)
+}
+
+// CreateNotification mocks base method.
+func (m *MockNotificationRepository) CreateNotification(ctx context.Context, notification *entity.Notification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, notification)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockNotificationRepositoryMockRecorder) CreateNotification(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockNotificationRepository)(nil).CreateNotification), ctx, notification)
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotificationRepository) ListNotifications(ctx context.Context, accountID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entity.Notification, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, accountID, unreadOnly, limit, offset)
+	ret0, _ := ret[0].([]*entity.Notification)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotificationRepositoryMockRecorder) ListNotifications(ctx, accountID, unreadOnly, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotificationRepository)(nil).ListNotifications), ctx, accountID, unreadOnly, limit, offset)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *MockNotificationRepository) MarkAllNotificationsRead(ctx context.Context, accountID uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", ctx, accountID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkAllNotificationsRead(ctx, accountID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkAllNotificationsRead), ctx, accountID, at)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockNotificationRepository) MarkNotificationRead(ctx context.Context, accountID, notificationID uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, accountID, notificationID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkNotificationRead(ctx, accountID, notificationID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkNotificationRead), ctx, accountID, notificationID, at)
+}
@@ -12,7 +12,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	tasks "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks"
 	entity "github.com/FrostBitzX/smart-task-ai/internal/domain/tasks/entity"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -71,6 +73,20 @@ func (mr *MockTaskRepositoryMockRecorder) CreateTask(ctx, task any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), ctx, task)
 }
 
+// CreateTasks mocks base method.
+func (m *MockTaskRepository) CreateTasks(ctx context.Context, arg1 []*entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTasks", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTasks indicates an expected call of CreateTasks.
+func (mr *MockTaskRepositoryMockRecorder) CreateTasks(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTasks", reflect.TypeOf((*MockTaskRepository)(nil).CreateTasks), ctx, arg1)
+}
+
 // DeleteTask mocks base method.
 func (m *MockTaskRepository) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -85,6 +101,36 @@ func (mr *MockTaskRepositoryMockRecorder) DeleteTask(ctx, taskID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), ctx, taskID)
 }
 
+// GetTaskBurndown mocks base method.
+func (m *MockTaskRepository) GetTaskBurndown(ctx context.Context, projectID uuid.UUID, from, to time.Time) ([]tasks.BurndownPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskBurndown", ctx, projectID, from, to)
+	ret0, _ := ret[0].([]tasks.BurndownPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskBurndown indicates an expected call of GetTaskBurndown.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskBurndown(ctx, projectID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskBurndown", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskBurndown), ctx, projectID, from, to)
+}
+
+// GetTaskByCalDAVName mocks base method.
+func (m *MockTaskRepository) GetTaskByCalDAVName(ctx context.Context, projectID uuid.UUID, name string) (*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByCalDAVName", ctx, projectID, name)
+	ret0, _ := ret[0].(*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByCalDAVName indicates an expected call of GetTaskByCalDAVName.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskByCalDAVName(ctx, projectID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByCalDAVName", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskByCalDAVName), ctx, projectID, name)
+}
+
 // GetTaskByID mocks base method.
 func (m *MockTaskRepository) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*entity.Task, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +146,51 @@ func (mr *MockTaskRepositoryMockRecorder) GetTaskByID(ctx, taskID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskByID), ctx, taskID)
 }
 
+// GetTaskStats mocks base method.
+func (m *MockTaskRepository) GetTaskStats(ctx context.Context, projectID uuid.UUID, now, dueSoonUntil time.Time) (*tasks.TaskStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStats", ctx, projectID, now, dueSoonUntil)
+	ret0, _ := ret[0].(*tasks.TaskStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStats indicates an expected call of GetTaskStats.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskStats(ctx, projectID, now, dueSoonUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStats", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskStats), ctx, projectID, now, dueSoonUntil)
+}
+
+// ListExternalUIDs mocks base method.
+func (m *MockTaskRepository) ListExternalUIDs(ctx context.Context, projectID uuid.UUID, uids []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExternalUIDs", ctx, projectID, uids)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExternalUIDs indicates an expected call of ListExternalUIDs.
+func (mr *MockTaskRepositoryMockRecorder) ListExternalUIDs(ctx, projectID, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExternalUIDs", reflect.TypeOf((*MockTaskRepository)(nil).ListExternalUIDs), ctx, projectID, uids)
+}
+
+// ListTasksByAccount mocks base method.
+func (m *MockTaskRepository) ListTasksByAccount(ctx context.Context, accountID uuid.UUID) ([]*entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasksByAccount", ctx, accountID)
+	ret0, _ := ret[0].([]*entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasksByAccount indicates an expected call of ListTasksByAccount.
+func (mr *MockTaskRepositoryMockRecorder) ListTasksByAccount(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasksByAccount", reflect.TypeOf((*MockTaskRepository)(nil).ListTasksByAccount), ctx, accountID)
+}
+
 // ListTasksByProject mocks base method.
 func (m *MockTaskRepository) ListTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.Task, error) {
 	m.ctrl.T.Helper()
@@ -128,3 +219,101 @@ func (mr *MockTaskRepositoryMockRecorder) UpdateTask(ctx, task any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), ctx, task)
 }
+
+// MockAttachmentRepository is a mock of AttachmentRepository interface.
+type MockAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentRepositoryMockRecorder is the mock recorder for MockAttachmentRepository.
+type MockAttachmentRepositoryMockRecorder struct {
+	mock *MockAttachmentRepository
+}
+
+// NewMockAttachmentRepository creates a new mock instance.
+func NewMockAttachmentRepository(ctrl *gomock.Controller) *MockAttachmentRepository {
+	mock := &MockAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepository) EXPECT() *MockAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachmentWithinQuota mocks base method.
+func (m *MockAttachmentRepository) CreateAttachmentWithinQuota(ctx context.Context, attachment *entity.Attachment, quota int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachmentWithinQuota", ctx, attachment, quota)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachmentWithinQuota indicates an expected call of CreateAttachmentWithinQuota.
+func (mr *MockAttachmentRepositoryMockRecorder) CreateAttachmentWithinQuota(ctx, attachment, quota any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachmentWithinQuota", reflect.TypeOf((*MockAttachmentRepository)(nil).CreateAttachmentWithinQuota), ctx, attachment, quota)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, attachmentID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) DeleteAttachment(ctx, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).DeleteAttachment), ctx, attachmentID)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockAttachmentRepository) GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", ctx, attachmentID)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockAttachmentRepositoryMockRecorder) GetAttachmentByID(ctx, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockAttachmentRepository)(nil).GetAttachmentByID), ctx, attachmentID)
+}
+
+// ListAttachmentsByTask mocks base method.
+func (m *MockAttachmentRepository) ListAttachmentsByTask(ctx context.Context, taskID uuid.UUID) ([]*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachmentsByTask", ctx, taskID)
+	ret0, _ := ret[0].([]*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachmentsByTask indicates an expected call of ListAttachmentsByTask.
+func (mr *MockAttachmentRepositoryMockRecorder) ListAttachmentsByTask(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachmentsByTask", reflect.TypeOf((*MockAttachmentRepository)(nil).ListAttachmentsByTask), ctx, taskID)
+}
+
+// SumAttachmentSizeByProject mocks base method.
+func (m *MockAttachmentRepository) SumAttachmentSizeByProject(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAttachmentSizeByProject", ctx, projectID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAttachmentSizeByProject indicates an expected call of SumAttachmentSizeByProject.
+func (mr *MockAttachmentRepositoryMockRecorder) SumAttachmentSizeByProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAttachmentSizeByProject", reflect.TypeOf((*MockAttachmentRepository)(nil).SumAttachmentSizeByProject), ctx, projectID)
+}
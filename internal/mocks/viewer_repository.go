@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../../mocks/viewer_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/FrostBitzX/smart-task-ai/internal/domain/realtime/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockViewerRepository is a mock of ViewerRepository interface.
+type MockViewerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockViewerRepositoryMockRecorder is the mock recorder for MockViewerRepository.
+type MockViewerRepositoryMockRecorder struct {
+	mock *MockViewerRepository
+}
+
+// NewMockViewerRepository creates a new mock instance.
+func NewMockViewerRepository(ctrl *gomock.Controller) *MockViewerRepository {
+	mock := &MockViewerRepository{ctrl: ctrl}
+	mock.recorder = &MockViewerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockViewerRepository) EXPECT() *MockViewerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteStaleViewers mocks base method.
+func (m *MockViewerRepository) DeleteStaleViewers(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleViewers", ctx, before)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleViewers indicates an expected call of DeleteStaleViewers.
+func (mr *MockViewerRepositoryMockRecorder) DeleteStaleViewers(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleViewers", reflect.TypeOf((*MockViewerRepository)(nil).DeleteStaleViewers), ctx, before)
+}
+
+// DeleteViewer mocks base method.
+func (m *MockViewerRepository) DeleteViewer(ctx context.Context, connectionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteViewer", ctx, connectionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteViewer indicates an expected call of DeleteViewer.
+func (mr *MockViewerRepositoryMockRecorder) DeleteViewer(ctx, connectionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteViewer", reflect.TypeOf((*MockViewerRepository)(nil).DeleteViewer), ctx, connectionID)
+}
+
+// ListViewersByProject mocks base method.
+func (m *MockViewerRepository) ListViewersByProject(ctx context.Context, projectID uuid.UUID, seenAfter time.Time) ([]*entity.Viewer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViewersByProject", ctx, projectID, seenAfter)
+	ret0, _ := ret[0].([]*entity.Viewer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViewersByProject indicates an expected call of ListViewersByProject.
+func (mr *MockViewerRepositoryMockRecorder) ListViewersByProject(ctx, projectID, seenAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewersByProject", reflect.TypeOf((*MockViewerRepository)(nil).ListViewersByProject), ctx, projectID, seenAfter)
+}
+
+// NotifyPresence mocks base method.
+func (m *MockViewerRepository) NotifyPresence(ctx context.Context, projectID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPresence", ctx, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPresence indicates an expected call of NotifyPresence.
+func (mr *MockViewerRepositoryMockRecorder) NotifyPresence(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPresence", reflect.TypeOf((*MockViewerRepository)(nil).NotifyPresence), ctx, projectID)
+}
+
+// TouchViewers mocks base method.
+func (m *MockViewerRepository) TouchViewers(ctx context.Context, viewers []*entity.Viewer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchViewers", ctx, viewers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchViewers indicates an expected call of TouchViewers.
+func (mr *MockViewerRepositoryMockRecorder) TouchViewers(ctx, viewers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchViewers", reflect.TypeOf((*MockViewerRepository)(nil).TouchViewers), ctx, viewers)
+}
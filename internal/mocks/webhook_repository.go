@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=../../mocks/webhook_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/FrostBitzX/smart-task-ai/internal/domain/webhooks/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionRepository is a mock of SubscriptionRepository interface.
+type MockSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionRepositoryMockRecorder is the mock recorder for MockSubscriptionRepository.
+type MockSubscriptionRepositoryMockRecorder struct {
+	mock *MockSubscriptionRepository
+}
+
+// NewMockSubscriptionRepository creates a new mock instance.
+func NewMockSubscriptionRepository(ctrl *gomock.Controller) *MockSubscriptionRepository {
+	mock := &MockSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionRepository) CreateSubscription(ctx context.Context, subscription *entity.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionRepositoryMockRecorder) CreateSubscription(ctx, subscription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionRepository)(nil).CreateSubscription), ctx, subscription)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockSubscriptionRepository) DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockSubscriptionRepositoryMockRecorder) DeleteSubscription(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionRepository)(nil).DeleteSubscription), ctx, subscriptionID)
+}
+
+// GetSubscriptionByID mocks base method.
+func (m *MockSubscriptionRepository) GetSubscriptionByID(ctx context.Context, subscriptionID uuid.UUID) (*entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionByID", ctx, subscriptionID)
+	ret0, _ := ret[0].(*entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionByID indicates an expected call of GetSubscriptionByID.
+func (mr *MockSubscriptionRepositoryMockRecorder) GetSubscriptionByID(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionByID", reflect.TypeOf((*MockSubscriptionRepository)(nil).GetSubscriptionByID), ctx, subscriptionID)
+}
+
+// ListSubscriptionsByProject mocks base method.
+func (m *MockSubscriptionRepository) ListSubscriptionsByProject(ctx context.Context, projectID uuid.UUID) ([]*entity.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionsByProject", ctx, projectID)
+	ret0, _ := ret[0].([]*entity.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionsByProject indicates an expected call of ListSubscriptionsByProject.
+func (mr *MockSubscriptionRepositoryMockRecorder) ListSubscriptionsByProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionsByProject", reflect.TypeOf((*MockSubscriptionRepository)(nil).ListSubscriptionsByProject), ctx, projectID)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionRepository) UpdateSubscription(ctx context.Context, subscription *entity.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionRepositoryMockRecorder) UpdateSubscription(ctx, subscription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionRepository)(nil).UpdateSubscription), ctx, subscription)
+}
+
+// MockDeliveryRepository is a mock of DeliveryRepository interface.
+type MockDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDeliveryRepositoryMockRecorder is the mock recorder for MockDeliveryRepository.
+type MockDeliveryRepositoryMockRecorder struct {
+	mock *MockDeliveryRepository
+}
+
+// NewMockDeliveryRepository creates a new mock instance.
+func NewMockDeliveryRepository(ctrl *gomock.Controller) *MockDeliveryRepository {
+	mock := &MockDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryRepository) EXPECT() *MockDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueDeliveries mocks base method.
+func (m *MockDeliveryRepository) ClaimDueDeliveries(ctx context.Context, now, lockedUntil time.Time, limit int) ([]*entity.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDeliveries", ctx, now, lockedUntil, limit)
+	ret0, _ := ret[0].([]*entity.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDeliveries indicates an expected call of ClaimDueDeliveries.
+func (mr *MockDeliveryRepositoryMockRecorder) ClaimDueDeliveries(ctx, now, lockedUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDeliveries", reflect.TypeOf((*MockDeliveryRepository)(nil).ClaimDueDeliveries), ctx, now, lockedUntil, limit)
+}
+
+// CreateDeliveries mocks base method.
+func (m *MockDeliveryRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveries indicates an expected call of CreateDeliveries.
+func (mr *MockDeliveryRepositoryMockRecorder) CreateDeliveries(ctx, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveries", reflect.TypeOf((*MockDeliveryRepository)(nil).CreateDeliveries), ctx, deliveries)
+}
+
+// FinishClaim mocks base method.
+func (m *MockDeliveryRepository) FinishClaim(ctx context.Context, delivery *entity.Delivery, claimedUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishClaim", ctx, delivery, claimedUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishClaim indicates an expected call of FinishClaim.
+func (mr *MockDeliveryRepositoryMockRecorder) FinishClaim(ctx, delivery, claimedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishClaim", reflect.TypeOf((*MockDeliveryRepository)(nil).FinishClaim), ctx, delivery, claimedUntil)
+}
+
+// GetDeliveryByID mocks base method.
+func (m *MockDeliveryRepository) GetDeliveryByID(ctx context.Context, deliveryID uuid.UUID) (*entity.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryByID", ctx, deliveryID)
+	ret0, _ := ret[0].(*entity.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryByID indicates an expected call of GetDeliveryByID.
+func (mr *MockDeliveryRepositoryMockRecorder) GetDeliveryByID(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryByID", reflect.TypeOf((*MockDeliveryRepository)(nil).GetDeliveryByID), ctx, deliveryID)
+}
+
+// ListDeliveriesBySubscription mocks base method.
+func (m *MockDeliveryRepository) ListDeliveriesBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*entity.Delivery, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveriesBySubscription", ctx, subscriptionID, limit, offset)
+	ret0, _ := ret[0].([]*entity.Delivery)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeliveriesBySubscription indicates an expected call of ListDeliveriesBySubscription.
+func (mr *MockDeliveryRepositoryMockRecorder) ListDeliveriesBySubscription(ctx, subscriptionID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveriesBySubscription", reflect.TypeOf((*MockDeliveryRepository)(nil).ListDeliveriesBySubscription), ctx, subscriptionID, limit, offset)
+}
@@ -10,11 +10,12 @@ import (
 
 // AppError represents a domain/application-level error that can be mapped to HTTP responses.
 type AppError struct {
-	Status   int         `json:"-"`                 // HTTP status code
-	Code     string      `json:"code"`              // Machine-readable error code (e.g. "INTERNAL_SERVER_ERROR")
-	Message  string      `json:"message"`           // Human-readable message
-	Details  interface{} `json:"details,omitempty"` // Optional additional details for response
-	RawError error       `json:"-"`                 // Underlying error for logging
+	Status   int               `json:"-"`                 // HTTP status code
+	Code     string            `json:"code"`              // Machine-readable error code (e.g. "INTERNAL_SERVER_ERROR")
+	Message  string            `json:"message"`           // Human-readable message
+	Details  interface{}       `json:"details,omitempty"` // Optional additional details for response
+	Fields   map[string]string `json:"fields,omitempty"`  // Field-level validation errors keyed by field path
+	RawError error             `json:"-"`                 // Underlying error for logging
 }
 
 func (e *AppError) Error() string {
@@ -51,6 +52,16 @@ func NewBadRequestError(message, code string, details interface{}) *AppError {
 	}
 }
 
+// NewValidationError creates a 400 error carrying field-level validation messages.
+func NewValidationError(message, code string, fields map[string]string) *AppError {
+	return &AppError{
+		Status:  http.StatusBadRequest,
+		Code:    code,
+		Message: message,
+		Fields:  fields,
+	}
+}
+
 func NewUnauthorizedError(message, code string, details interface{}) *AppError {
 	return &AppError{
 		Status:  http.StatusUnauthorized,
@@ -105,6 +116,15 @@ func NewTimeoutError(message, code string, details interface{}) *AppError {
 	}
 }
 
+func NewPayloadTooLargeError(message, code string, details interface{}) *AppError {
+	return &AppError{
+		Status:  http.StatusRequestEntityTooLarge,
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+}
+
 func NewTooManyRequestsError(message, code string, details interface{}) *AppError {
 	return &AppError{
 		Status:  http.StatusTooManyRequests,